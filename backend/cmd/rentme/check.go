@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"rentme/internal/infra/broker/kafka"
+	"rentme/internal/infra/config"
+	mongodb "rentme/internal/infra/db/mongo"
+	mlpricing "rentme/internal/infra/pricing"
+	storages3 "rentme/internal/infra/storage/s3"
+)
+
+// checkTimeout bounds a single integration check run by `rentme check` or at
+// startup; /readyz keeps its own, shorter budget for the whole set.
+const checkTimeout = 5 * time.Second
+
+// integrationCheck exercises one external integration with a real but
+// harmless call. The same checks back /readyz, `rentme check` and the strict
+// startup mode.
+type integrationCheck struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// checkResult is the outcome of one integration check.
+type checkResult struct {
+	name    string
+	err     error
+	elapsed time.Duration
+}
+
+// integrationChecks builds a check for every integration the configuration
+// enables; integrations left unconfigured are not checked. The returned
+// cleanup releases the clients the checks hold on to.
+func integrationChecks(cfg config.Config, logger *slog.Logger) ([]integrationCheck, func()) {
+	var (
+		checks   []integrationCheck
+		cleanups []func()
+	)
+	fail := func(name string, err error) {
+		checks = append(checks, integrationCheck{name: name, run: func(context.Context) error { return err }})
+	}
+
+	if strings.TrimSpace(cfg.MongoURI) != "" {
+		client, err := mongodb.New(cfg.MongoURI, cfg.MongoDB)
+		if err != nil {
+			fail("mongo", err)
+		} else {
+			cleanups = append(cleanups, func() { _ = client.Close(context.Background()) })
+			checks = append(checks, integrationCheck{name: "mongo", run: func(ctx context.Context) error {
+				if err := client.Ping(ctx); err != nil {
+					return err
+				}
+				// Every index the repositories rely on is created by a migration.
+				pending, err := mongodb.NewMigrator(client.DB, logger).Pending(ctx)
+				if err != nil {
+					return err
+				}
+				if len(pending) > 0 {
+					return fmt.Errorf("%d pending migrations, indexes may be missing; run `rentme migrate`", len(pending))
+				}
+				return nil
+			}})
+		}
+	}
+
+	if strings.TrimSpace(cfg.S3Endpoint) != "" {
+		// The uploader would log every probe put; the check reports on its own.
+		client, err := storages3.NewClient(cfg.S3Endpoint, cfg.S3UseSSL, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3Bucket, cfg.S3PublicEndpoint, nil)
+		if err != nil {
+			fail("s3", err)
+		} else {
+			checks = append(checks, integrationCheck{name: "s3", run: client.Probe})
+		}
+	}
+
+	if strings.ToLower(strings.TrimSpace(cfg.PricingMode)) == "ml" {
+		engine := &mlpricing.MLPricingEngine{
+			Client:   &http.Client{Timeout: checkTimeout},
+			Endpoint: cfg.MLPricingURL,
+		}
+		checks = append(checks, integrationCheck{name: "ml-pricing", run: engine.Probe})
+	}
+
+	if len(cfg.KafkaBrokers) > 0 {
+		brokers := cfg.KafkaBrokers
+		checks = append(checks, integrationCheck{name: "kafka", run: func(ctx context.Context) error {
+			return kafka.CheckBrokers(ctx, brokers)
+		}})
+	}
+
+	if strings.TrimSpace(cfg.MessagingGRPCAddr) != "" {
+		client, cleanup := resolveMessagingClient(cfg, logger)
+		if client == nil {
+			fail("messaging", errors.New("messaging grpc client could not be created"))
+		} else {
+			cleanups = append(cleanups, cleanup)
+			checks = append(checks, integrationCheck{name: "messaging", run: client.Health})
+		}
+	}
+
+	return checks, func() {
+		for _, fn := range cleanups {
+			fn()
+		}
+	}
+}
+
+// runIntegrationChecks runs every check with its own timeout, so one hung
+// integration does not hide the state of the others.
+func runIntegrationChecks(ctx context.Context, checks []integrationCheck) []checkResult {
+	results := make([]checkResult, 0, len(checks))
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		start := time.Now()
+		err := check.run(checkCtx)
+		cancel()
+		results = append(results, checkResult{name: check.name, err: err, elapsed: time.Since(start)})
+	}
+	return results
+}
+
+// printCheckResults writes the pass/fail table and reports whether any check failed.
+func printCheckResults(w io.Writer, results []checkResult) bool {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tRESULT\tDURATION\tDETAIL")
+	failed := false
+	for _, r := range results {
+		result, detail := "pass", ""
+		if r.err != nil {
+			result, detail, failed = "FAIL", r.err.Error(), true
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.name, result, r.elapsed.Round(time.Millisecond), detail)
+	}
+	if len(results) == 0 {
+		fmt.Fprintln(tw, "(no integrations configured)\t\t\t")
+	}
+	_ = tw.Flush()
+	return failed
+}
+
+// runChecks backs the `check` subcommand: it prints the table and returns an
+// error when any integration fails.
+func runChecks(ctx context.Context, cfg config.Config, logger *slog.Logger, out io.Writer) error {
+	checks, cleanup := integrationChecks(cfg, logger)
+	defer cleanup()
+	if printCheckResults(out, runIntegrationChecks(ctx, checks)) {
+		return errors.New("integration checks failed")
+	}
+	return nil
+}
+
+// verifyIntegrations runs the checks at startup. STARTUP_CHECKS selects the
+// behaviour: "off" (default) skips, "warn" logs failures, "strict" refuses to start.
+func verifyIntegrations(ctx context.Context, cfg config.Config, logger *slog.Logger) error {
+	mode := strings.ToLower(strings.TrimSpace(cfg.StartupChecks))
+	if mode != "warn" && mode != "strict" {
+		return nil
+	}
+	checks, cleanup := integrationChecks(cfg, logger)
+	defer cleanup()
+	var failed []string
+	for _, r := range runIntegrationChecks(ctx, checks) {
+		if r.err == nil {
+			logger.Info("integration check passed", "check", r.name, "duration", r.elapsed)
+			continue
+		}
+		logger.Warn("integration check failed", "check", r.name, "duration", r.elapsed, "error", r.err)
+		failed = append(failed, r.name)
+	}
+	if len(failed) > 0 && mode == "strict" {
+		return fmt.Errorf("integration checks failed: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
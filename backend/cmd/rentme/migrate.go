@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/infra/config"
+	mongodb "rentme/internal/infra/db/mongo"
+)
+
+// runMigrations applies pending Mongo migrations; used by the `migrate` subcommand.
+func runMigrations(ctx context.Context, cfg config.Config, logger *slog.Logger) error {
+	client, err := mongodb.New(cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close(context.Background())
+	}()
+	applied, err := mongodb.NewMigrator(client.DB, logger).Up(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Info("mongo migrations applied", "count", applied, "db", cfg.MongoDB)
+	return nil
+}
+
+// verifyMigrations checks for pending Mongo migrations at startup. MONGO_MIGRATIONS
+// selects the behaviour: "warn" (default) logs, "strict" refuses to start, "off" skips.
+func verifyMigrations(ctx context.Context, cfg config.Config, logger *slog.Logger) error {
+	mode := strings.ToLower(strings.TrimSpace(cfg.MongoMigrations))
+	if mode == "off" || strings.TrimSpace(cfg.MongoURI) == "" {
+		return nil
+	}
+	strict := mode == "strict"
+
+	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	pending, err := pendingMigrations(checkCtx, cfg, logger)
+	if err != nil {
+		if strict {
+			return fmt.Errorf("mongo migrations check failed: %w", err)
+		}
+		logger.Warn("mongo migrations check skipped", "error", err)
+		return nil
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	versions := make([]int, 0, len(pending))
+	for _, migration := range pending {
+		versions = append(versions, migration.Version)
+	}
+	if strict {
+		return fmt.Errorf("mongo has %d pending migrations %v; run `rentme migrate`", len(pending), versions)
+	}
+	logger.Warn("mongo has pending migrations; run `rentme migrate`", "count", len(pending), "versions", versions)
+	return nil
+}
+
+func pendingMigrations(ctx context.Context, cfg config.Config, logger *slog.Logger) ([]mongodb.Migration, error) {
+	client, err := mongodb.New(cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = client.Close(context.Background())
+	}()
+	return mongodb.NewMigrator(client.DB, logger).Pending(ctx)
+}
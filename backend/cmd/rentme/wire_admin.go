@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	adminapp "rentme/internal/app/handlers/admin"
+	bookingapp "rentme/internal/app/handlers/booking"
+	reviewsapp "rentme/internal/app/handlers/reviews"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/services/identity"
+	"rentme/internal/infra/config"
+	ginserver "rentme/internal/infra/http/gin"
+	mlpricing "rentme/internal/infra/pricing"
+	"rentme/internal/infra/storage/memory"
+)
+
+// adminModule wires moderation, market settings, ML price clamps, booking
+// integrity checks and the operational views (jobs, ML metrics, configuration).
+type adminModule struct {
+	admin ginserver.AdminHandler
+	lifecycle
+}
+
+func newAdminModule(in *infra) adminModule {
+	var m adminModule
+	cfg, logger := in.cfg, in.logger
+
+	suspendListingHandler := &adminapp.AdminSuspendListingHandler{
+		Outbox:  in.outbox,
+		Encoder: in.encoder,
+		Logger:  logger,
+	}
+	commands.RegisterHandler(in.commandBus, adminapp.AdminSuspendListingCommand{}.Key(), suspendListingHandler)
+	reactivateListingHandler := &adminapp.AdminReactivateListingHandler{
+		Outbox:  in.outbox,
+		Encoder: in.encoder,
+		Logger:  logger,
+	}
+	commands.RegisterHandler(in.commandBus, adminapp.AdminReactivateListingCommand{}.Key(), reactivateListingHandler)
+	updateMarketsHandler := &adminapp.AdminUpdateMarketsHandler{
+		Markets: in.markets,
+		Outbox:  in.outbox,
+		Encoder: in.encoder,
+		Logger:  logger,
+	}
+	commands.RegisterHandler(in.commandBus, adminapp.AdminUpdateMarketsCommand{}.Key(), updateMarketsHandler)
+	commands.RegisterHandler(in.commandBus, adminapp.AdminUpdateCityClampsCommand{}.Key(), &adminapp.AdminUpdateCityClampsHandler{
+		Store:      in.clamps,
+		Configured: in.clampConfig,
+		Logger:     logger,
+	})
+	commands.RegisterHandler(in.commandBus, adminapp.AdminImportPriceClampsCommand{}.Key(), &adminapp.AdminImportPriceClampsHandler{
+		Store:      in.clamps,
+		Configured: in.clampConfig,
+		Logger:     logger,
+	})
+	commands.RegisterHandler(in.commandBus, bookingapp.AdminCancelBookingCommand{}.Key(), &bookingapp.AdminCancelBookingHandler{
+		Outbox:   in.outbox,
+		Encoder:  in.encoder,
+		Notifier: in.notifier,
+		Logger:   logger,
+	})
+	commands.RegisterHandler(in.commandBus, bookingapp.AdminForceConfirmBookingCommand{}.Key(), &bookingapp.AdminForceConfirmBookingHandler{Logger: logger})
+	commands.RegisterHandler(in.commandBus, reviewsapp.ModerateReviewCommand{}.Key(), &reviewsapp.ModerateReviewHandler{Logger: logger})
+	integrityReports := memory.NewIntegrityReportStore()
+	integrityChecker := &adminapp.BookingIntegrityChecker{
+		UoWFactory: in.uowFactory,
+		Reports:    integrityReports,
+		Notifier:   in.notifier,
+		Interval:   cfg.IntegrityCheck,
+		Logger:     logger,
+	}
+	commands.RegisterHandler(in.commandBus, adminapp.RunBookingIntegrityCheckCommand{}.Key(), &adminapp.RunBookingIntegrityCheckHandler{Checker: integrityChecker})
+
+	queries.RegisterHandler(in.queryBus, adminapp.AdminListListingsQuery{}.Key(), &adminapp.AdminListListingsHandler{UoWFactory: in.uowFactory, Logger: logger})
+	queries.RegisterHandler(in.queryBus, adminapp.AdminGetListingQuery{}.Key(), &adminapp.AdminGetListingHandler{UoWFactory: in.uowFactory})
+	queries.RegisterHandler(in.queryBus, bookingapp.AdminListBookingsQuery{}.Key(), &bookingapp.AdminListBookingsHandler{UoWFactory: in.uowFactory, Logger: logger})
+	queries.RegisterHandler(in.queryBus, reviewsapp.AdminListListingReviewsQuery{}.Key(), &reviewsapp.AdminListListingReviewsHandler{UoWFactory: in.uowFactory, Logger: logger})
+	queries.RegisterHandler(in.queryBus, adminapp.ListJobRunsQuery{}.Key(), &adminapp.ListJobRunsHandler{Store: in.jobs.Store})
+	queries.RegisterHandler(in.queryBus, adminapp.ListBookingIntegrityReportsQuery{}.Key(), &adminapp.ListBookingIntegrityReportsHandler{Reports: integrityReports})
+	queries.RegisterHandler(in.queryBus, adminapp.ExportPriceClampsQuery{}.Key(), &adminapp.ExportPriceClampsHandler{Store: in.clamps, Configured: in.clampConfig})
+	queries.RegisterHandler(in.queryBus, adminapp.CityPriceClampsQuery{}.Key(), &adminapp.CityPriceClampsHandler{Store: in.clamps, Configured: in.clampConfig})
+
+	if integrityChecker.Interval > 0 {
+		m.onStart(func(ctx context.Context) {
+			go func() {
+				if err := integrityChecker.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+					logger.Error("booking integrity checker stopped", "error", err)
+				}
+			}()
+		})
+	}
+
+	m.admin = ginserver.AdminHandler{
+		Commands:    in.commands,
+		Queries:     in.queries,
+		Users:       in.users,
+		Sessions:    in.sessions,
+		Metrics:     buildMLMetrics(cfg, in.httpClient, logger),
+		MLBreaker:   in.mlBreaker,
+		Velocity:    in.velocity,
+		Identity:    &identity.Service{Users: in.users, Logger: logger},
+		GuestBlocks: in.guestBlocks,
+		Settings:    cfg.Describe(),
+		RateLimits:  in.rateLimits,
+		Logger:      logger,
+	}
+	return m
+}
+
+func buildMLMetrics(cfg config.Config, httpClient *http.Client, logger *slog.Logger) *mlpricing.MetricsCache {
+	endpoint := deriveMLMetricsEndpoint(cfg.MLPricingURL)
+	if endpoint == "" {
+		return nil
+	}
+	const (
+		metricsTimeout  = 15 * time.Second
+		metricsCacheTTL = 45 * time.Second
+	)
+	if httpClient == nil || httpClient.Timeout < metricsTimeout {
+		httpClient = &http.Client{Timeout: metricsTimeout}
+	}
+	client := &mlpricing.MetricsClient{
+		Endpoint: endpoint,
+		Client:   httpClient,
+		Logger:   logger,
+	}
+	return &mlpricing.MetricsCache{
+		Source:       client,
+		TTL:          metricsCacheTTL,
+		FetchTimeout: metricsTimeout,
+		Logger:       logger,
+	}
+}
+
+func deriveMLMetricsEndpoint(predictURL string) string {
+	raw := strings.TrimSpace(predictURL)
+	if raw == "" {
+		return ""
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return ""
+	}
+	parsed.Path = "/metrics"
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	authsvc "rentme/internal/app/services/auth"
+	ginserver "rentme/internal/infra/http/gin"
+	"rentme/internal/infra/security"
+)
+
+// authModule wires registration, sessions and the authentication middleware,
+// and seeds the dev admin and demo accounts.
+type authModule struct {
+	service    *authsvc.Service
+	auth       ginserver.AuthHandler
+	middleware ginserver.AuthMiddleware
+	lifecycle
+}
+
+func newAuthModule(in *infra) authModule {
+	var m authModule
+	cfg, logger := in.cfg, in.logger
+
+	passwordHasher := security.BcryptHasher{}
+	service := &authsvc.Service{
+		Users:      in.users,
+		Sessions:   in.sessions,
+		Passwords:  passwordHasher,
+		Tokens:     security.RandomTokenGenerator{Size: 48},
+		SessionTTL: 24 * time.Hour,
+		RefreshTTL: 30 * 24 * time.Hour,
+		Logger:     logger,
+	}
+	seedDevAdmin(cfg.Env, in.users, passwordHasher, logger)
+	seedDemoUsers(cfg.Env, in.users, passwordHasher, logger)
+
+	sessions := in.sessions
+	m.onStart(func(ctx context.Context) {
+		go runMemoryCleanup(ctx, "sessions", logger, func(ctx context.Context) int {
+			return sessions.Cleanup(ctx, time.Now())
+		})
+	})
+
+	m.service = service
+	m.auth = ginserver.AuthHandler{
+		Service:  service,
+		Velocity: in.velocity,
+		Logger:   logger,
+	}
+	m.middleware = ginserver.AuthMiddleware{
+		Service: service,
+		Logger:  logger,
+	}
+	return m
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"rentme/internal/app/commands"
+	bookingapp "rentme/internal/app/handlers/booking"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/workers"
+	ginserver "rentme/internal/infra/http/gin"
+)
+
+// bookingModule wires the guest booking flow, host decisions on requests and
+// expiry of stale pending bookings.
+type bookingModule struct {
+	booking     ginserver.BookingHandler
+	hostBooking ginserver.HostBookingHandler
+	lifecycle
+}
+
+func newBookingModule(in *infra) bookingModule {
+	var m bookingModule
+	cfg, logger := in.cfg, in.logger
+
+	requestBookingHandler := &bookingapp.RequestBookingHandler{
+		UoWFactory: in.uowFactory,
+		Pricing:    in.pricingPort,
+		Outbox:     in.outbox,
+		Encoder:    in.encoder,
+		Rounding:   in.rounding,
+		PendingTTL: cfg.BookingPendingTTL,
+		Users:      in.users,
+		Blocks:     in.guestBlocks,
+	}
+	commands.RegisterHandler(in.commandBus, bookingapp.RequestBookingCommand{}.Key(), requestBookingHandler)
+	commands.RegisterHandler(in.commandBus, bookingapp.ConfirmHostBookingCommand{}.Key(), &bookingapp.ConfirmHostBookingHandler{Logger: logger})
+	declineBookingHandler := &bookingapp.DeclineHostBookingHandler{
+		Outbox:  in.outbox,
+		Encoder: in.encoder,
+		Logger:  logger,
+	}
+	commands.RegisterHandler(in.commandBus, bookingapp.DeclineHostBookingCommand{}.Key(), declineBookingHandler)
+	noShowHandler := &bookingapp.MarkNoShowHandler{
+		Outbox:  in.outbox,
+		Encoder: in.encoder,
+		Logger:  logger,
+	}
+	commands.RegisterHandler(in.commandBus, bookingapp.MarkNoShowCommand{}.Key(), noShowHandler)
+	commands.RegisterHandler(in.commandBus, bookingapp.CheckInBookingCommand{}.Key(), &bookingapp.CheckInBookingHandler{
+		Outbox:  in.outbox,
+		Encoder: in.encoder,
+		Logger:  logger,
+	})
+	commands.RegisterHandler(in.commandBus, bookingapp.CheckOutBookingCommand{}.Key(), &bookingapp.CheckOutBookingHandler{
+		Outbox:  in.outbox,
+		Encoder: in.encoder,
+		Logger:  logger,
+	})
+	modifyBookingHandler := &bookingapp.ModifyBookingHandler{
+		Rounding: in.rounding,
+		Outbox:   in.outbox,
+		Encoder:  in.encoder,
+		Logger:   logger,
+	}
+	commands.RegisterHandler(in.commandBus, bookingapp.ModifyBookingCommand{}.Key(), modifyBookingHandler)
+	commands.RegisterHandler(in.commandBus, bookingapp.BlockGuestCommand{}.Key(), &bookingapp.BlockGuestHandler{
+		Blocks: in.guestBlocks,
+		Users:  in.users,
+		Logger: logger,
+	})
+	commands.RegisterHandler(in.commandBus, bookingapp.UnblockGuestCommand{}.Key(), &bookingapp.UnblockGuestHandler{Blocks: in.guestBlocks, Logger: logger})
+	expirePendingHandler := &bookingapp.ExpirePendingBookingsHandler{
+		TTL:     cfg.BookingPendingTTL,
+		Outbox:  in.outbox,
+		Encoder: in.encoder,
+		Logger:  logger,
+	}
+	commands.RegisterHandler(in.commandBus, bookingapp.ExpirePendingBookingsCommand{}.Key(), expirePendingHandler)
+
+	hostBookingsHandler := &bookingapp.ListHostBookingsHandler{
+		UoWFactory: in.uowFactory,
+		Users:      in.users,
+		Logger:     logger,
+	}
+	queries.RegisterHandler(in.queryBus, bookingapp.ListHostBookingsQuery{}.Key(), hostBookingsHandler)
+	queries.RegisterHandler(in.queryBus, bookingapp.GetBookingQuery{}.Key(), &bookingapp.GetBookingHandler{UoWFactory: in.uowFactory, Logger: logger})
+	quoteBookingHandler := &bookingapp.QuoteBookingHandler{
+		UoWFactory: in.uowFactory,
+		Pricing:    in.pricingPort,
+		Users:      in.users,
+	}
+	queries.RegisterHandler(in.queryBus, bookingapp.QuoteBookingQuery{}.Key(), quoteBookingHandler)
+	queries.RegisterHandler(in.queryBus, bookingapp.ListBlockedGuestsQuery{}.Key(), &bookingapp.ListBlockedGuestsHandler{Blocks: in.guestBlocks})
+
+	if !isTestEnv(cfg.Env) {
+		expiryWorker := &workers.ExpiryWorker{
+			Commands: in.commands,
+			Interval: cfg.BookingExpiryTick,
+			Logger:   logger,
+		}
+		in.registerJob(expiryWorker.Job())
+	}
+
+	m.booking = ginserver.BookingHandler{
+		Commands: in.commands,
+		Queries:  in.queries,
+		Velocity: in.velocity,
+		Logger:   logger,
+	}
+	m.hostBooking = ginserver.HostBookingHandler{
+		Commands: in.commands,
+		Queries:  in.queries,
+		Logger:   logger,
+	}
+	return m
+}
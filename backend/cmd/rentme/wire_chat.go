@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"rentme/internal/app/services/digest"
+	"rentme/internal/app/workers"
+	"rentme/internal/infra/config"
+	mongodb "rentme/internal/infra/db/mongo"
+	ginserver "rentme/internal/infra/http/gin"
+	infraMessaging "rentme/internal/infra/messaging"
+	"rentme/internal/infra/storage/memory"
+)
+
+// chatModule wires the messaging-service client, the host team inbox and the
+// weekly host digest that summarises unread conversations.
+type chatModule struct {
+	chat ginserver.ChatHandler
+	lifecycle
+}
+
+func newChatModule(in *infra) chatModule {
+	var m chatModule
+	cfg, logger := in.cfg, in.logger
+
+	messagingClient, msgCleanup := resolveMessagingClient(cfg, logger)
+	m.onClose(msgCleanup)
+	digestLog := resolveDigestLog(in.mongo)
+	digestService := &digest.Service{
+		UoWFactory: in.uowFactory,
+		Users:      in.users,
+		Queries:    in.queries,
+		Notifier:   in.notifier,
+		SendLog:    digestLog,
+		Logger:     logger,
+	}
+	if messagingClient != nil {
+		digestService.Chats = messagingClient
+	}
+	if !isTestEnv(cfg.Env) {
+		digestWorker := &workers.DigestWorker{Service: digestService}
+		in.registerJob(digestWorker.Job())
+	}
+
+	m.chat = ginserver.ChatHandler{
+		Messaging:   messagingClient,
+		UoWFactory:  in.uowFactory,
+		Assignments: memory.NewInboxAssignmentRepository(),
+		Notes:       memory.NewInboxNoteRepository(),
+		GuestBlocks: in.guestBlocks,
+		Notifier:    in.notifier,
+		Users:       in.users,
+		Logger:      logger,
+	}
+	return m
+}
+
+// resolveMessagingClient builds the client whether or not messaging-service is
+// up yet: it reconnects on its own, and chat endpoints answer 503 meanwhile.
+// Only a missing or malformed address leaves chat without a client.
+func resolveMessagingClient(cfg config.Config, logger *slog.Logger) (*infraMessaging.Client, func()) {
+	addr := strings.TrimSpace(cfg.MessagingGRPCAddr)
+	if addr == "" {
+		return nil, nil
+	}
+	client, err := infraMessaging.NewClient(context.Background(), infraMessaging.Config{
+		Addr:        addr,
+		DialTimeout: cfg.MessagingGRPCDial,
+		CallTimeout: cfg.MessagingGRPCTime,
+	}, logger)
+	if err != nil {
+		if logger != nil {
+			logger.Warn("messaging grpc client init failed", "error", err, "addr", addr)
+		}
+		return nil, nil
+	}
+	return client, func() {
+		_ = client.Close()
+	}
+}
+
+// resolveDigestLog keeps the host digest send log in Mongo when available so
+// a restart in the middle of a week does not send the digest again.
+func resolveDigestLog(client *mongodb.Client) digest.SendLog {
+	if client == nil {
+		return memory.NewDigestLog()
+	}
+	return mongodb.NewDigestLog(client.DB)
+}
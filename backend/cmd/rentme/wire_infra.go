@@ -0,0 +1,397 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/jobs"
+	"rentme/internal/app/middleware"
+	"rentme/internal/app/outbox"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/services/trust"
+	domainmarkets "rentme/internal/domain/markets"
+	domainpricing "rentme/internal/domain/pricing"
+	domainwishlist "rentme/internal/domain/wishlist"
+	"rentme/internal/infra/broker/kafka"
+	"rentme/internal/infra/config"
+	mongodb "rentme/internal/infra/db/mongo"
+	ginserver "rentme/internal/infra/http/gin"
+	"rentme/internal/infra/notify"
+	"rentme/internal/infra/obs"
+	mlpricing "rentme/internal/infra/pricing"
+	"rentme/internal/infra/storage/memory"
+	storages3 "rentme/internal/infra/storage/s3"
+)
+
+// lifecycle collects what a wiring module needs once the server is assembled:
+// background loops to start, dependencies /readyz checks and resources to
+// release on shutdown.
+type lifecycle struct {
+	starts   []func(ctx context.Context)
+	checks   []func(ctx context.Context) error
+	cleanups []func()
+}
+
+func (l *lifecycle) onStart(fn func(ctx context.Context)) {
+	l.starts = append(l.starts, fn)
+}
+
+// onReady registers a dependency check; the service reports not ready while
+// any check fails.
+func (l *lifecycle) onReady(fn func(ctx context.Context) error) {
+	l.checks = append(l.checks, fn)
+}
+
+func (l *lifecycle) onClose(fn func()) {
+	if fn != nil {
+		l.cleanups = append(l.cleanups, fn)
+	}
+}
+
+// merge appends the hooks of a module so the application runs them in wiring order.
+func (l *lifecycle) merge(other lifecycle) {
+	l.starts = append(l.starts, other.starts...)
+	l.checks = append(l.checks, other.checks...)
+	l.cleanups = append(l.cleanups, other.cleanups...)
+}
+
+// infra is the shared infrastructure every wiring module builds on. Handlers
+// register on commandBus/queryBus; HTTP handlers and workers dispatch through
+// commands/queries, which carry the middleware chain. The chain only wraps the
+// in-memory buses, so registrations made after newInfra are still visible.
+type infra struct {
+	cfg        config.Config
+	logger     *slog.Logger
+	metrics    *obs.Metrics
+	tracing    *obs.Tracing
+	httpClient *http.Client
+	// mongo is shared by every Mongo-backed store; nil means they keep their
+	// data in memory.
+	mongo *mongodb.Client
+
+	listings     *memory.ListingRepository
+	availability *memory.AvailabilityRepository
+	bookings     *memory.BookingRepository
+	reviews      *memory.ReviewsRepository
+	wishlists    domainwishlist.Repository
+	guestBlocks  *memory.GuestBlockRepository
+	markets      *memory.MarketRepository
+	users        *memory.UserRepository
+	sessions     *memory.SessionStore
+	uowFactory   memory.Factory
+
+	pricing     domainpricing.Calculator
+	mlBreaker   *mlpricing.CircuitBreaker
+	pricingPort memory.PricingPortAdapter
+	clamps      domainpricing.ClampStore
+	clampConfig domainpricing.ClampTable
+	media       storages3.MediaURLs
+	rounding    domainpricing.RoundingPolicy
+	velocity    *trust.Service
+	rateLimits  *middleware.RateLimitRules
+	notifier    notify.LogNotifier
+
+	outbox  *memory.Outbox
+	encoder outbox.JSONEventEncoder
+	jobs    *jobs.Runner
+
+	commandBus *commands.InMemoryBus
+	queryBus   *queries.InMemoryBus
+	commands   commands.Bus
+	queries    queries.Bus
+
+	lifecycle
+}
+
+func newInfra(ctx context.Context, logger *slog.Logger, cfg config.Config) *infra {
+	in := &infra{
+		cfg:        cfg,
+		logger:     logger,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		notifier:   notify.LogNotifier{Logger: logger},
+	}
+	if cfg.MetricsEnabled {
+		in.metrics = obs.NewMetrics(nil)
+	}
+	tracing, err := obs.SetupTracing(ctx, cfg.OTelEndpoint, "rentme-backend")
+	if err != nil {
+		logger.Warn("tracing disabled", "error", err)
+		tracing = &obs.Tracing{}
+	}
+	if tracing.Enabled() {
+		logger.Info("tracing enabled", "endpoint", cfg.OTelEndpoint)
+		in.onClose(func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := tracing.Shutdown(shutdownCtx); err != nil {
+				logger.Warn("tracing shutdown failed", "error", err)
+			}
+		})
+	}
+	in.tracing = tracing
+
+	// /readyz runs the same integration checks as `rentme check`.
+	checks, checksCleanup := integrationChecks(cfg, logger)
+	in.onClose(checksCleanup)
+	for _, check := range checks {
+		in.onReady(func(ctx context.Context) error {
+			if err := check.run(ctx); err != nil {
+				return fmt.Errorf("%s: %w", check.name, err)
+			}
+			return nil
+		})
+	}
+
+	mongoClient, mongoCleanup := resolveMongo(cfg, logger)
+	in.onClose(mongoCleanup)
+	in.mongo = mongoClient
+
+	in.listings = memory.NewListingRepository()
+	in.availability = memory.NewAvailabilityRepository()
+	in.bookings = memory.NewBookingRepository()
+	in.reviews = memory.NewReviewsRepository()
+	in.wishlists = resolveWishlistRepository(in.mongo)
+	in.guestBlocks = memory.NewGuestBlockRepository()
+	in.markets = memory.NewMarketRepository(domainmarkets.NewSettings(cfg.AllowedCities, cfg.MarketGrandfather))
+	in.users = memory.NewUserRepository()
+	in.sessions = memory.NewSessionStore()
+	in.clamps = resolveClampStore(in.mongo)
+	in.clampConfig = mlpricing.LoadClampConfig(cfg.MLPriceClamps, logger)
+	// Every DTO mapper rewrites stored photo URLs through dto.MediaURL.
+	in.media = storages3.NewMediaURLs(cfg.MediaBaseURL, storages3.ObjectBase(cfg.S3PublicEndpoint, cfg.S3Bucket))
+	dto.MediaURL = in.media.Public
+	in.pricing, in.mlBreaker = resolvePricingCalculator(cfg, in.httpClient, in.listings, in.clamps, in.clampConfig, logger)
+	in.rounding = mlpricing.LoadRoundingPolicy(cfg.PriceRounding, logger)
+	in.pricingPort = memory.PricingPortAdapter{Calculator: in.pricing, Rounding: in.rounding}
+	in.uowFactory = memory.Factory{
+		ListingsRepo:     in.listings,
+		AvailabilityRepo: in.availability,
+		BookingRepo:      in.bookings,
+		PricingSvc:       in.pricing,
+		ReviewsRepo:      in.reviews,
+		WishlistsRepo:    in.wishlists,
+	}
+	in.velocity = &trust.Service{
+		Store: memory.NewVelocityStore(),
+		Users: in.users,
+		Rules: map[trust.Action]trust.Thresholds{
+			trust.ActionRegister: velocityThresholds(cfg.RegisterVelocity),
+			trust.ActionBooking:  velocityThresholds(cfg.BookingVelocity),
+		},
+		Logger: logger,
+	}
+	in.rateLimits = ginserver.NewRateLimitRules(cfg, logger)
+	in.outbox = memory.NewOutbox()
+
+	idStore := resolveIdempotencyStore(in.mongo, cfg.IdempotencyTTL)
+	jobStore := resolveJobStore(in.mongo)
+	in.jobs = &jobs.Runner{
+		Store:  jobStore,
+		Poll:   cfg.OutboxPollInterval,
+		Logger: logger,
+	}
+	if publisher := resolveOutboxPublisher(cfg); publisher != nil {
+		in.outbox.EnableDispatch()
+		relay := &outbox.Relay{
+			Store:       in.outbox,
+			Publisher:   publisher,
+			Interval:    cfg.OutboxPollInterval,
+			TopicPrefix: cfg.KafkaTopicPrefix,
+			Backoff:     cfg.RetryBackoff,
+			Logger:      logger,
+		}
+		in.registerJob(relay.Job())
+		in.onClose(func() {
+			if err := publisher.Close(); err != nil {
+				logger.Warn("kafka publisher close failed", "error", err)
+			}
+		})
+	}
+
+	in.commandBus = commands.NewInMemoryBus()
+	in.queryBus = queries.NewInMemoryBus()
+	var (
+		baseCommands commands.Bus  = in.commandBus
+		baseQueries  queries.Bus   = in.queryBus
+		flushOutbox  outbox.Outbox = in.outbox
+	)
+	if in.metrics != nil || tracing.Enabled() {
+		instrumented := obs.InstrumentedBus{Commands: in.commandBus, Queries: in.queryBus, Metrics: in.metrics}
+		baseCommands, baseQueries = instrumented, instrumented
+	}
+	var flushObserver middleware.OutboxFlushObserver
+	if in.metrics != nil {
+		flushOutbox = obs.InstrumentedOutbox{Outbox: in.outbox, Metrics: in.metrics}
+		flushObserver = in.metrics
+	}
+	in.commands = middleware.ChainCommands(
+		baseCommands,
+		middleware.Idempotency(idStore, nil),
+		middleware.OutboxFlush(flushOutbox, logger, flushObserver),
+		middleware.Transaction(in.uowFactory, nil),
+	)
+	in.queries = middleware.ChainQueries(baseQueries)
+
+	if store, ok := idStore.(*memory.IdempotencyStore); ok {
+		in.onStart(func(ctx context.Context) {
+			go runMemoryCleanup(ctx, "idempotency", logger, func(ctx context.Context) int {
+				return store.Cleanup(ctx, cfg.IdempotencyTTL)
+			})
+		})
+	}
+	return in
+}
+
+// registerJob adds a recurring job to the shared runner; the runner itself is
+// started by the application after every module has registered its jobs.
+func (in *infra) registerJob(def jobs.Definition) {
+	if err := in.jobs.Register(def); err != nil {
+		in.logger.Error("job registration failed", "job", def.Name, "error", err)
+	}
+}
+
+// resolvePricingCalculator picks the pricing engine. In ML mode the engine
+// sits behind a circuit breaker that quotes with the memory engine while the
+// service is failing, unless ML_PRICING_CB_FAILURES is zero.
+func resolvePricingCalculator(cfg config.Config, httpClient *http.Client, listingsRepo *memory.ListingRepository, clamps domainpricing.ClampStore, clampConfig domainpricing.ClampTable, logger *slog.Logger) (domainpricing.Calculator, *mlpricing.CircuitBreaker) {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	mode := strings.ToLower(strings.TrimSpace(cfg.PricingMode))
+	switch mode {
+	case "ml":
+		endpoint := cfg.MLPricingURL
+		if endpoint == "" {
+			endpoint = "http://localhost:8000/predict"
+		}
+		engine := &mlpricing.MLPricingEngine{
+			Client:     httpClient,
+			Endpoint:   endpoint,
+			Listings:   listingsRepo,
+			Logger:     logger,
+			Clamps:     clampConfig,
+			ClampStore: clamps,
+		}
+		if cfg.MLBreakerFailures <= 0 {
+			return engine, nil
+		}
+		breaker := &mlpricing.CircuitBreaker{
+			Failures: cfg.MLBreakerFailures,
+			Cooldown: cfg.MLBreakerCooldown,
+		}
+		return &mlpricing.BreakerCalculator{
+			Primary:  engine,
+			Fallback: memory.NewPricingEngine(),
+			Breaker:  breaker,
+			Logger:   logger,
+		}, breaker
+	default:
+		return memory.NewPricingEngine(), nil
+	}
+}
+
+// resolveClampStore keeps admin-edited price clamps in Mongo when it is
+// reachable; in memory they last until restart and ML_PRICE_CLAMPS applies
+// again.
+func resolveClampStore(client *mongodb.Client) domainpricing.ClampStore {
+	if client == nil {
+		return memory.NewClampStore()
+	}
+	return mongodb.NewClampStore(client.DB)
+}
+
+// resolveWishlistRepository keeps wishlists in Mongo so saved listings
+// survive restarts; without Mongo they live in process memory.
+func resolveWishlistRepository(client *mongodb.Client) domainwishlist.Repository {
+	if client == nil {
+		return memory.NewWishlistRepository()
+	}
+	return mongodb.NewWishlistRepository(client.DB)
+}
+
+// resolveIdempotencyStore prefers Mongo so keys survive restarts and falls
+// back to process memory without it.
+func resolveIdempotencyStore(client *mongodb.Client, ttl time.Duration) middleware.IdempotencyStore {
+	if client == nil {
+		return memory.NewIdempotencyStore(ttl, nil)
+	}
+	return mongodb.NewIdempotencyStore(client.DB, ttl)
+}
+
+// resolveJobStore prefers Mongo so several instances share one queue; memory
+// keeps single-node setups working.
+func resolveJobStore(client *mongodb.Client) jobs.Store {
+	if client == nil {
+		return memory.NewJobStore()
+	}
+	return mongodb.NewJobStore(client.DB)
+}
+
+// resolveMongo connects the client every Mongo-backed store shares. It
+// returns nil when MONGO_URI is unset or Mongo does not answer at startup; the
+// stores then keep their data in process memory.
+func resolveMongo(cfg config.Config, logger *slog.Logger) (*mongodb.Client, func()) {
+	if strings.TrimSpace(cfg.MongoURI) == "" {
+		return nil, nil
+	}
+	client, err := mongodb.New(cfg.MongoURI, cfg.MongoDB)
+	if err != nil {
+		if logger != nil {
+			logger.Warn("mongo stores disabled; falling back to memory", "error", err)
+		}
+		return nil, nil
+	}
+	pingCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Ping(pingCtx); err != nil {
+		_ = client.Close(context.Background())
+		if logger != nil {
+			logger.Warn("mongo stores disabled; falling back to memory", "error", err)
+		}
+		return nil, nil
+	}
+	return client, func() {
+		_ = client.Close(context.Background())
+	}
+}
+
+// resolveOutboxPublisher returns nil when Kafka is not configured; outbox
+// events are then dropped on flush as before.
+func resolveOutboxPublisher(cfg config.Config) *kafka.Publisher {
+	if len(cfg.KafkaBrokers) == 0 {
+		return nil
+	}
+	return kafka.NewPublisher(cfg.KafkaBrokers)
+}
+
+func velocityThresholds(limits config.VelocityLimits) trust.Thresholds {
+	return trust.Thresholds{
+		Verify: trust.Limits{PerHour: limits.VerifyHourly, PerDay: limits.VerifyDaily},
+		Reject: trust.Limits{PerHour: limits.RejectHourly, PerDay: limits.RejectDaily},
+	}
+}
+
+// memoryCleanupInterval is how often in-memory stores evict expired entries.
+const memoryCleanupInterval = 10 * time.Minute
+
+// runMemoryCleanup evicts expired entries of a process-local store until ctx
+// is cancelled. It stays off the job runner: a shared job store would lease
+// the task to a single instance while every instance holds its own entries.
+func runMemoryCleanup(ctx context.Context, store string, logger *slog.Logger, cleanup func(ctx context.Context) int) {
+	ticker := time.NewTicker(memoryCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			logger.Debug("memory store cleanup", "store", store, "removed", cleanup(ctx))
+		}
+	}
+}
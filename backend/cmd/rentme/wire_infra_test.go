@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"rentme/internal/infra/config"
+	"rentme/internal/infra/storage/memory"
+)
+
+func TestStoresFallBackToMemoryWithoutMongo(t *testing.T) {
+	client, cleanup := resolveMongo(config.Config{}, nil)
+	if client != nil || cleanup != nil {
+		t.Fatal("resolveMongo connected without MONGO_URI")
+	}
+	if _, ok := resolveClampStore(client).(*memory.ClampStore); !ok {
+		t.Error("clamp store is not in memory")
+	}
+	if _, ok := resolveIdempotencyStore(client, time.Hour).(*memory.IdempotencyStore); !ok {
+		t.Error("idempotency store is not in memory")
+	}
+	if _, ok := resolveWishlistRepository(client).(*memory.WishlistRepository); !ok {
+		t.Error("wishlist repository is not in memory")
+	}
+	if _, ok := resolveJobStore(client).(*memory.JobStore); !ok {
+		t.Error("job store is not in memory")
+	}
+	if _, ok := resolveDigestLog(client).(*memory.DigestLog); !ok {
+		t.Error("digest log is not in memory")
+	}
+	if _, ok := resolvePhotoUploadStore(client).(*memory.PhotoUploadStore); !ok {
+		t.Error("photo upload store is not in memory")
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"log/slog"
+	"strings"
+
+	"rentme/internal/app/commands"
+	availabilityapp "rentme/internal/app/handlers/availability"
+	listingapp "rentme/internal/app/handlers/listings"
+	marketsapp "rentme/internal/app/handlers/markets"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/workers"
+	"rentme/internal/infra/config"
+	mongodb "rentme/internal/infra/db/mongo"
+	ginserver "rentme/internal/infra/http/gin"
+	"rentme/internal/infra/security"
+	"rentme/internal/infra/storage/memory"
+	storages3 "rentme/internal/infra/storage/s3"
+)
+
+// listingsModule wires the listing catalog, host listing management, photos,
+// availability and markets.
+type listingsModule struct {
+	listing      ginserver.ListingHandler
+	hostListing  ginserver.HostListingHandler
+	availability ginserver.AvailabilityHandler
+	markets      ginserver.MarketsHandler
+	lifecycle
+}
+
+func newListingsModule(in *infra) listingsModule {
+	var m listingsModule
+	cfg, logger := in.cfg, in.logger
+	uploader := resolveUploader(cfg, logger)
+	photoUploads := resolvePhotoUploadStore(in.mongo)
+
+	commands.RegisterHandler(in.commandBus, listingapp.CreateHostListingCommand{}.Key(), &listingapp.CreateHostListingHandler{Logger: logger})
+	commands.RegisterHandler(in.commandBus, listingapp.UpdateHostListingCommand{}.Key(), &listingapp.UpdateHostListingHandler{Logger: logger})
+	commands.RegisterHandler(in.commandBus, listingapp.UpdateHostListingFieldsCommand{}.Key(), &listingapp.UpdateHostListingFieldsHandler{Logger: logger})
+	commands.RegisterHandler(in.commandBus, listingapp.DuplicateHostListingCommand{}.Key(), &listingapp.DuplicateHostListingHandler{Logger: logger})
+	bulkBlockHandler := &listingapp.BulkBlockCalendarHandler{
+		Outbox:  in.outbox,
+		Encoder: in.encoder,
+		Logger:  logger,
+	}
+	commands.RegisterHandler(in.commandBus, listingapp.BulkBlockCalendarCommand{}.Key(), bulkBlockHandler)
+	publishListingHandler := &listingapp.PublishHostListingHandler{
+		Markets: in.markets,
+		Relaxed: cfg.PublishRelaxed,
+		Logger:  logger,
+	}
+	commands.RegisterHandler(in.commandBus, listingapp.PublishHostListingCommand{}.Key(), publishListingHandler)
+	unpublishListingHandler := &listingapp.UnpublishHostListingHandler{
+		Scheduler: in.jobs,
+		Logger:    logger,
+	}
+	commands.RegisterHandler(in.commandBus, listingapp.UnpublishHostListingCommand{}.Key(), unpublishListingHandler)
+	commands.RegisterHandler(in.commandBus, listingapp.DeleteHostListingCommand{}.Key(), &listingapp.DeleteHostListingHandler{Logger: logger})
+	commands.RegisterHandler(in.commandBus, listingapp.RestoreHostListingCommand{}.Key(), &listingapp.RestoreHostListingHandler{Logger: logger})
+	commands.RegisterHandler(in.commandBus, listingapp.CancelRepublishHostListingCommand{}.Key(), &listingapp.CancelRepublishHostListingHandler{Logger: logger})
+	republishListingHandler := &listingapp.RepublishScheduledListingHandler{
+		Markets:  in.markets,
+		Relaxed:  cfg.PublishRelaxed,
+		Notifier: in.notifier,
+		Logger:   logger,
+	}
+	commands.RegisterHandler(in.commandBus, listingapp.RepublishScheduledListingCommand{}.Key(), republishListingHandler)
+	uploadPhotoHandler := &listingapp.UploadHostListingPhotoHandler{
+		Logger:   logger,
+		Uploader: uploader,
+	}
+	commands.RegisterHandler(in.commandBus, listingapp.UploadHostListingPhotoCommand{}.Key(), uploadPhotoHandler)
+	deletePhotoHandler := &listingapp.DeleteHostListingPhotoHandler{
+		Logger:   logger,
+		Uploader: uploader,
+	}
+	commands.RegisterHandler(in.commandBus, listingapp.DeleteHostListingPhotoCommand{}.Key(), deletePhotoHandler)
+	registerPhotoHandler := &listingapp.RegisterHostListingPhotoHandler{
+		Logger:   logger,
+		Uploader: uploader,
+	}
+	commands.RegisterHandler(in.commandBus, listingapp.RegisterHostListingPhotoCommand{}.Key(), registerPhotoHandler)
+	createPhotoUploadHandler := &listingapp.CreateHostListingPhotoUploadHandler{Uploads: photoUploads, Logger: logger}
+	commands.RegisterHandler(in.commandBus, listingapp.CreateHostListingPhotoUploadCommand{}.Key(), createPhotoUploadHandler)
+	putPhotoUploadPartHandler := &listingapp.PutHostListingPhotoUploadPartHandler{Uploads: photoUploads, Uploader: uploader}
+	commands.RegisterHandler(in.commandBus, listingapp.PutHostListingPhotoUploadPartCommand{}.Key(), putPhotoUploadPartHandler)
+	completePhotoUploadHandler := &listingapp.CompleteHostListingPhotoUploadHandler{
+		Uploads:  photoUploads,
+		Uploader: uploader,
+		Logger:   logger,
+	}
+	commands.RegisterHandler(in.commandBus, listingapp.CompleteHostListingPhotoUploadCommand{}.Key(), completePhotoUploadHandler)
+	expirePhotoUploadsHandler := &listingapp.ExpireHostListingPhotoUploadsHandler{
+		Uploads:  photoUploads,
+		Uploader: uploader,
+		Logger:   logger,
+	}
+	commands.RegisterHandler(in.commandBus, listingapp.ExpireHostListingPhotoUploadsCommand{}.Key(), expirePhotoUploadsHandler)
+	commands.RegisterHandler(in.commandBus, listingapp.SetHostListingCoHostsCommand{}.Key(), &listingapp.SetHostListingCoHostsHandler{Users: in.users, Logger: logger})
+	commands.RegisterHandler(in.commandBus, listingapp.SetHostListingUnitGroupCommand{}.Key(), &listingapp.SetHostListingUnitGroupHandler{Logger: logger})
+	commands.RegisterHandler(in.commandBus, listingapp.ReorderHostListingPhotosCommand{}.Key(), &listingapp.ReorderHostListingPhotosHandler{Logger: logger})
+	commands.RegisterHandler(in.commandBus, listingapp.TagHostListingPhotoCommand{}.Key(), &listingapp.TagHostListingPhotoHandler{Logger: logger})
+
+	queries.RegisterHandler(in.queryBus, availabilityapp.GetCalendarQuery{}.Key(), &availabilityapp.GetCalendarHandler{UoWFactory: in.uowFactory})
+	queries.RegisterHandler(in.queryBus, listingapp.GetOverviewQuery{}.Key(), &listingapp.GetOverviewHandler{UoWFactory: in.uowFactory})
+	priceCalendarHandler := &listingapp.GetPriceCalendarHandler{
+		Logger:     logger,
+		Pricing:    in.pricingPort,
+		UoWFactory: in.uowFactory,
+	}
+	queries.RegisterHandler(in.queryBus, listingapp.GetPriceCalendarQuery{}.Key(), priceCalendarHandler)
+	catalogHandler := &listingapp.SearchCatalogHandler{
+		UoWFactory:      in.uowFactory,
+		Markets:         in.markets,
+		HideOutOfMarket: cfg.MarketHideOutside,
+		Rounding:        in.rounding,
+	}
+	if cfg.CatalogDedupe {
+		catalogHandler.Dedupe = &listingapp.CatalogDedupe{CacheTTL: cfg.CatalogCacheTTL}
+		if in.metrics != nil {
+			catalogHandler.Dedupe.Metrics = in.metrics
+		}
+	}
+	queries.RegisterHandler(in.queryBus, listingapp.SearchCatalogQuery{}.Key(), catalogHandler)
+	mapClustersHandler := &listingapp.GetMapClustersHandler{
+		UoWFactory:      in.uowFactory,
+		Markets:         in.markets,
+		HideOutOfMarket: cfg.MarketHideOutside,
+		Rounding:        in.rounding,
+	}
+	queries.RegisterHandler(in.queryBus, listingapp.GetMapClustersQuery{}.Key(), mapClustersHandler)
+	queries.RegisterHandler(in.queryBus, marketsapp.ListMarketsQuery{}.Key(), &marketsapp.ListMarketsHandler{Markets: in.markets})
+	hostCatalogHandler := &listingapp.ListHostListingsHandler{
+		UoWFactory: in.uowFactory,
+		Logger:     logger,
+	}
+	queries.RegisterHandler(in.queryBus, listingapp.ListHostListingsQuery{}.Key(), hostCatalogHandler)
+	hostDetailHandler := &listingapp.GetHostListingHandler{
+		UoWFactory: in.uowFactory,
+		Logger:     logger,
+	}
+	queries.RegisterHandler(in.queryBus, listingapp.GetHostListingQuery{}.Key(), hostDetailHandler)
+	hostHistoryHandler := &listingapp.HostListingHistoryHandler{
+		UoWFactory: in.uowFactory,
+		Logger:     logger,
+	}
+	queries.RegisterHandler(in.queryBus, listingapp.HostListingHistoryQuery{}.Key(), hostHistoryHandler)
+	queries.RegisterHandler(in.queryBus, listingapp.HostListingPriceHistoryQuery{}.Key(), &listingapp.HostListingPriceHistoryHandler{UoWFactory: in.uowFactory})
+	queries.RegisterHandler(in.queryBus, listingapp.ValidateHostListingAddressQuery{}.Key(), &listingapp.ValidateHostListingAddressHandler{Markets: in.markets})
+	photoUploadURLHandler := &listingapp.HostListingPhotoUploadURLHandler{
+		UoWFactory: in.uowFactory,
+		Uploader:   uploader,
+	}
+	queries.RegisterHandler(in.queryBus, listingapp.HostListingPhotoUploadURLQuery{}.Key(), photoUploadURLHandler)
+	queries.RegisterHandler(in.queryBus, listingapp.HostListingPhotoUploadQuery{}.Key(), &listingapp.HostListingPhotoUploadHandler{Uploads: photoUploads})
+	priceSuggestionHandler := &listingapp.HostListingPriceSuggestionHandler{
+		UoWFactory: in.uowFactory,
+		Pricing:    in.pricingPort,
+		Rounding:   in.rounding,
+		Logger:     logger,
+	}
+	queries.RegisterHandler(in.queryBus, listingapp.HostListingPriceSuggestionQuery{}.Key(), priceSuggestionHandler)
+	commands.RegisterHandler(in.commandBus, listingapp.ApplyPriceSuggestionCommand{}.Key(), &listingapp.ApplyPriceSuggestionHandler{
+		Pricing:  in.pricingPort,
+		Rounding: in.rounding,
+		Logger:   logger,
+	})
+
+	republishWorker := &workers.RepublishWorker{Commands: in.commands, Logger: logger}
+	in.registerJob(republishWorker.Job())
+	photoUploadJanitor := &workers.PhotoUploadJanitor{Commands: in.commands}
+	in.registerJob(photoUploadJanitor.Job())
+
+	m.listing = ginserver.ListingHandler{Queries: in.queries}
+	m.hostListing = ginserver.HostListingHandler{
+		Commands:     in.commands,
+		Queries:      in.queries,
+		CalendarFeed: resolveCalendarFeedSigner(cfg, logger),
+		Media:        in.media,
+		Logger:       logger,
+	}
+	m.availability = ginserver.AvailabilityHandler{Queries: in.queries}
+	m.markets = ginserver.MarketsHandler{
+		Queries: in.queries,
+		Logger:  logger,
+	}
+	return m
+}
+
+func resolveUploader(cfg config.Config, logger *slog.Logger) storages3.Uploader {
+	uploader, err := storages3.NewClient(cfg.S3Endpoint, cfg.S3UseSSL, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3Bucket, cfg.S3PublicEndpoint, logger)
+	if err != nil {
+		if logger != nil {
+			logger.Warn("s3 uploader disabled; falling back to noop", "error", err)
+		}
+		return storages3.NoopUploader{}
+	}
+	return uploader
+}
+
+// resolvePhotoUploadStore keeps upload sessions in Mongo when available, so a
+// part may reach any instance.
+func resolvePhotoUploadStore(client *mongodb.Client) listingapp.PhotoUploadStore {
+	if client == nil {
+		return memory.NewPhotoUploadStore()
+	}
+	return mongodb.NewPhotoUploadStore(client.DB)
+}
+
+// resolveCalendarFeedSigner falls back to a per-process key, which keeps feeds
+// working but invalidates every issued URL on restart.
+func resolveCalendarFeedSigner(cfg config.Config, logger *slog.Logger) security.URLSigner {
+	if key := strings.TrimSpace(cfg.CalendarFeedKey); key != "" {
+		return security.URLSigner{Key: []byte(key)}
+	}
+	key, err := security.RandomTokenGenerator{Size: 32}.NewToken()
+	if err != nil {
+		logger.Error("calendar feed key generation failed", "error", err)
+		return security.URLSigner{}
+	}
+	logger.Warn("CALENDAR_FEED_KEY not set; calendar feed URLs change on restart")
+	return security.URLSigner{Key: []byte(key)}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"rentme/internal/app/commands"
+	meapp "rentme/internal/app/handlers/me"
+	"rentme/internal/app/queries"
+	authsvc "rentme/internal/app/services/auth"
+	ginserver "rentme/internal/infra/http/gin"
+)
+
+// meModule wires the guest's own account pages: trips, wishlist, profile,
+// password and notification preferences.
+type meModule struct {
+	me ginserver.MeHandler
+	lifecycle
+}
+
+func newMeModule(in *infra, auth *authsvc.Service) meModule {
+	var m meModule
+	logger := in.logger
+
+	commands.RegisterHandler(in.commandBus, meapp.AddToWishlistCommand{}.Key(), &meapp.AddToWishlistHandler{Logger: logger})
+	commands.RegisterHandler(in.commandBus, meapp.RemoveFromWishlistCommand{}.Key(), &meapp.RemoveFromWishlistHandler{Logger: logger})
+	commands.RegisterHandler(in.commandBus, meapp.UpdateNotificationsCommand{}.Key(), &meapp.UpdateNotificationsHandler{Users: in.users, Logger: logger})
+
+	bookingsHandler := &meapp.ListGuestBookingsHandler{
+		UoWFactory: in.uowFactory,
+		Logger:     logger,
+	}
+	queries.RegisterHandler(in.queryBus, meapp.ListGuestBookingsQuery{}.Key(), bookingsHandler)
+	wishlistHandler := &meapp.ListWishlistHandler{
+		UoWFactory: in.uowFactory,
+		Logger:     logger,
+	}
+	queries.RegisterHandler(in.queryBus, meapp.ListWishlistQuery{}.Key(), wishlistHandler)
+
+	m.me = ginserver.MeHandler{
+		Commands: in.commands,
+		Queries:  in.queries,
+		Auth:     auth,
+		Logger:   logger,
+	}
+	return m
+}
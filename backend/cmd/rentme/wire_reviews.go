@@ -0,0 +1,45 @@
+package main
+
+import (
+	"rentme/internal/app/commands"
+	reviewsapp "rentme/internal/app/handlers/reviews"
+	"rentme/internal/app/queries"
+	ginserver "rentme/internal/infra/http/gin"
+)
+
+// reviewsModule wires guest reviews and host replies.
+type reviewsModule struct {
+	reviews ginserver.ReviewsHandler
+	lifecycle
+}
+
+func newReviewsModule(in *infra) reviewsModule {
+	var m reviewsModule
+	logger := in.logger
+
+	submitHandler := &reviewsapp.SubmitReviewHandler{
+		UoWFactory: in.uowFactory,
+		Logger:     logger,
+	}
+	commands.RegisterHandler(in.commandBus, reviewsapp.SubmitReviewCommand{}.Key(), submitHandler)
+	updateHandler := &reviewsapp.UpdateReviewHandler{
+		UoWFactory: in.uowFactory,
+		Logger:     logger,
+	}
+	commands.RegisterHandler(in.commandBus, reviewsapp.UpdateReviewCommand{}.Key(), updateHandler)
+	commands.RegisterHandler(in.commandBus, reviewsapp.ReplyToReviewCommand{}.Key(), &reviewsapp.ReplyToReviewHandler{Logger: logger})
+
+	listingReviewsHandler := &reviewsapp.ListListingReviewsHandler{
+		UoWFactory: in.uowFactory,
+		Users:      in.users,
+		Logger:     logger,
+	}
+	queries.RegisterHandler(in.queryBus, reviewsapp.ListListingReviewsQuery{}.Key(), listingReviewsHandler)
+
+	m.reviews = ginserver.ReviewsHandler{
+		Commands: in.commands,
+		Queries:  in.queries,
+		Logger:   logger,
+	}
+	return m
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type UserList struct {
 	Items []UserProfile `json:"items"`
 	Total int           `json:"total"`
@@ -16,3 +18,23 @@ type MLMetrics struct {
 	ShortTerm ModelMetrics `json:"short_term"`
 	LongTerm  ModelMetrics `json:"long_term"`
 }
+
+// VelocityDecision is a trust & safety decision kept for admin review.
+type VelocityDecision struct {
+	Action   string    `json:"action"`
+	Decision string    `json:"decision"`
+	Reason   string    `json:"reason"`
+	IP       string    `json:"ip,omitempty"`
+	Device   string    `json:"device,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+type AdminUserDetail struct {
+	User               UserProfile        `json:"user"`
+	VerificationReason string             `json:"verification_reason,omitempty"`
+	VelocityDecisions  []VelocityDecision `json:"velocity_decisions"`
+	// BlockedGuests are the guests this user blocked as a host; BlockedBy are
+	// the hosts that blocked this user as a guest.
+	BlockedGuests []GuestBlock `json:"blocked_guests"`
+	BlockedBy     []GuestBlock `json:"blocked_by"`
+}
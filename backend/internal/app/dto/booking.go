@@ -1,11 +1,14 @@
 package dto
 
 import (
+	"strings"
 	"time"
 
 	domainbooking "rentme/internal/domain/booking"
 	domainlistings "rentme/internal/domain/listings"
+	domainpricing "rentme/internal/domain/pricing"
 	domainreviews "rentme/internal/domain/reviews"
+	domainrange "rentme/internal/domain/shared/daterange"
 	"rentme/internal/domain/shared/money"
 )
 
@@ -33,6 +36,8 @@ type GuestBookingSummary struct {
 	Months          int                    `json:"months,omitempty"`
 	PriceUnit       string                 `json:"price_unit"`
 	Status          string                 `json:"status"`
+	CleaningFee     MoneyDTO               `json:"cleaning_fee"`
+	ServiceFee      MoneyDTO               `json:"service_fee"`
 	Total           MoneyDTO               `json:"total"`
 	CreatedAt       time.Time              `json:"created_at"`
 	ReviewSubmitted bool                   `json:"review_submitted"`
@@ -43,26 +48,119 @@ type GuestBookingSummary struct {
 	ReviewCreatedAt *time.Time             `json:"review_created_at,omitempty"`
 }
 
+// PriceLine is one named fee, tax, discount or adjustment of a price breakdown.
+type PriceLine struct {
+	Name   string   `json:"name"`
+	Amount MoneyDTO `json:"amount"`
+}
+
+// BookingPriceBreakdown is the price quoted when the booking was requested.
+// Units counts nights or months depending on the booking price unit.
+type BookingPriceBreakdown struct {
+	Unit             string      `json:"unit"`
+	Units            int         `json:"units"`
+	UnitPrice        MoneyDTO    `json:"unit_price"`
+	WeekendSurcharge MoneyDTO    `json:"weekend_surcharge"`
+	Fees             []PriceLine `json:"fees"`
+	Taxes            []PriceLine `json:"taxes"`
+	Discounts        []PriceLine `json:"discounts"`
+	Adjustments      []PriceLine `json:"adjustments"`
+	Total            MoneyDTO    `json:"total"`
+}
+
+// BookingQuote previews the price of a stay before it is requested. Unit and
+// Units say whether NightlyRate is charged per night or per month; fee and
+// discount amounts are sums of the matching breakdown lines. A stay that
+// cannot be booked as asked is still priced, with the reason it is unavailable.
+type BookingQuote struct {
+	ListingID         string    `json:"listing_id"`
+	CheckIn           time.Time `json:"check_in"`
+	CheckOut          time.Time `json:"check_out"`
+	Nights            int       `json:"nights"`
+	Guests            int       `json:"guests"`
+	Unit              string    `json:"unit"`
+	Units             int       `json:"units"`
+	NightlyRate       MoneyDTO  `json:"nightly_rate"`
+	WeekendSurcharge  MoneyDTO  `json:"weekend_surcharge"`
+	CleaningFee       MoneyDTO  `json:"cleaning_fee"`
+	ServiceFee        MoneyDTO  `json:"service_fee"`
+	Discount          MoneyDTO  `json:"discount"`
+	Total             MoneyDTO  `json:"total"`
+	IsAvailable       bool      `json:"is_available"`
+	UnavailableReason string    `json:"unavailable_reason,omitempty"`
+}
+
+// CancellationPolicyPreview shows the guest what cancelling would cost.
+type CancellationPolicyPreview struct {
+	PolicyID                  string     `json:"policy_id"`
+	FreeCancellationUntil     *time.Time `json:"free_cancellation_until,omitempty"`
+	PreCheckInPenaltyPercent  int        `json:"pre_check_in_penalty_percent"`
+	PostCheckInPenaltyPercent int        `json:"post_check_in_penalty_percent"`
+	RefundIfCancelledNow      MoneyDTO   `json:"refund_if_cancelled_now"`
+}
+
+// BookingDetail is the single-booking view shared by the guest, the host and
+// admins.
+type BookingDetail struct {
+	ID          string                    `json:"id"`
+	Listing     BookingListingSnapshot    `json:"listing"`
+	GuestID     string                    `json:"guest_id"`
+	CheckIn     time.Time                 `json:"check_in"`
+	CheckOut    time.Time                 `json:"check_out"`
+	Guests      int                       `json:"guests"`
+	Months      int                       `json:"months,omitempty"`
+	PriceUnit   string                    `json:"price_unit"`
+	Status      string                    `json:"status"`
+	Price       BookingPriceBreakdown     `json:"price"`
+	Policy      CancellationPolicyPreview `json:"policy"`
+	PaymentHold string                    `json:"payment_hold,omitempty"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+}
+
+// AdminBookingCancellation reports how support split the refund of a
+// cancelled booking.
+type AdminBookingCancellation struct {
+	BookingID      string   `json:"booking_id"`
+	Status         string   `json:"status"`
+	Initiator      string   `json:"initiator"`
+	Refund         MoneyDTO `json:"refund"`
+	Penalty        MoneyDTO `json:"penalty"`
+	RefundOverride bool     `json:"refund_override"`
+	Reason         string   `json:"reason"`
+}
+
 type GuestBookingCollection struct {
-	Items []GuestBookingSummary `json:"items"`
+	Items      []GuestBookingSummary `json:"items"`
+	NextCursor string                `json:"next_cursor,omitempty"`
 }
 
 type HostBookingSummary struct {
-	ID        string                 `json:"id"`
-	Listing   BookingListingSnapshot `json:"listing"`
-	GuestID   string                 `json:"guest_id"`
-	CheckIn   time.Time              `json:"check_in"`
-	CheckOut  time.Time              `json:"check_out"`
-	Guests    int                    `json:"guests"`
-	Months    int                    `json:"months,omitempty"`
-	PriceUnit string                 `json:"price_unit"`
-	Status    string                 `json:"status"`
-	Total     MoneyDTO               `json:"total"`
-	CreatedAt time.Time              `json:"created_at"`
+	ID            string                 `json:"id"`
+	Listing       BookingListingSnapshot `json:"listing"`
+	GuestID       string                 `json:"guest_id"`
+	GuestVerified bool                   `json:"guest_verified"`
+	CheckIn       time.Time              `json:"check_in"`
+	CheckOut      time.Time              `json:"check_out"`
+	Guests        int                    `json:"guests"`
+	Months        int                    `json:"months,omitempty"`
+	PriceUnit     string                 `json:"price_unit"`
+	Status        string                 `json:"status"`
+	CleaningFee   MoneyDTO               `json:"cleaning_fee"`
+	ServiceFee    MoneyDTO               `json:"service_fee"`
+	Total         MoneyDTO               `json:"total"`
+	CreatedAt     time.Time              `json:"created_at"`
 }
 
 type HostBookingCollection struct {
-	Items []HostBookingSummary `json:"items"`
+	Items []HostBookingSummary      `json:"items"`
+	Meta  HostBookingCollectionMeta `json:"meta"`
+}
+
+type HostBookingCollectionMeta struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
 }
 
 func MapMoney(value money.Money) MoneyDTO {
@@ -78,26 +176,17 @@ func MapGuestBookingSummary(
 	review *domainreviews.Review,
 	canReview bool,
 ) GuestBookingSummary {
-	snapshot := BookingListingSnapshot{
-		ID: string(booking.ListingID),
-	}
-	if listing != nil {
-		snapshot.Title = listing.Title
-		snapshot.AddressLine1 = listing.Address.Line1
-		snapshot.City = listing.Address.City
-		snapshot.Region = listing.Address.Region
-		snapshot.Country = listing.Address.Country
-		snapshot.ThumbnailURL = listing.ThumbnailURL
-	}
 	summary := GuestBookingSummary{
 		ID:              string(booking.ID),
-		Listing:         snapshot,
+		Listing:         mapBookingListingSnapshot(booking, listing),
 		CheckIn:         booking.Range.CheckIn,
 		CheckOut:        booking.Range.CheckOut,
 		Guests:          booking.Guests,
 		Months:          booking.Months,
 		PriceUnit:       resolvePriceUnit(booking.PriceUnit),
 		Status:          string(booking.State),
+		CleaningFee:     MapMoney(booking.Price.Fee(domainpricing.FeeCleaning)),
+		ServiceFee:      MapMoney(booking.Price.Fee(domainpricing.FeeService)),
 		Total:           MapMoney(booking.Price.Total),
 		CreatedAt:       booking.CreatedAt,
 		ReviewSubmitted: review != nil,
@@ -113,7 +202,131 @@ func MapGuestBookingSummary(
 	return summary
 }
 
+func MapBookingDetail(booking *domainbooking.Booking, listing *domainlistings.Listing, now time.Time) BookingDetail {
+	return BookingDetail{
+		ID:          string(booking.ID),
+		Listing:     mapBookingListingSnapshot(booking, listing),
+		GuestID:     booking.GuestID,
+		CheckIn:     booking.Range.CheckIn,
+		CheckOut:    booking.Range.CheckOut,
+		Guests:      booking.Guests,
+		Months:      booking.Months,
+		PriceUnit:   resolvePriceUnit(booking.PriceUnit),
+		Status:      string(booking.State),
+		Price:       MapBookingPriceBreakdown(booking.Price),
+		Policy:      MapCancellationPolicyPreview(booking, now),
+		PaymentHold: maskPaymentHold(booking.PaymentHold),
+		CreatedAt:   booking.CreatedAt,
+		UpdatedAt:   booking.UpdatedAt,
+	}
+}
+
 func MapHostBookingSummary(booking *domainbooking.Booking, listing *domainlistings.Listing) HostBookingSummary {
+	return HostBookingSummary{
+		ID:          string(booking.ID),
+		Listing:     mapBookingListingSnapshot(booking, listing),
+		GuestID:     booking.GuestID,
+		CheckIn:     booking.Range.CheckIn,
+		CheckOut:    booking.Range.CheckOut,
+		Guests:      booking.Guests,
+		Months:      booking.Months,
+		PriceUnit:   resolvePriceUnit(booking.PriceUnit),
+		Status:      string(booking.State),
+		CleaningFee: MapMoney(booking.Price.Fee(domainpricing.FeeCleaning)),
+		ServiceFee:  MapMoney(booking.Price.Fee(domainpricing.FeeService)),
+		Total:       MapMoney(booking.Price.Total),
+		CreatedAt:   booking.CreatedAt,
+	}
+}
+
+func MapBookingPriceBreakdown(price domainpricing.PriceBreakdown) BookingPriceBreakdown {
+	return BookingPriceBreakdown{
+		Unit:             price.PriceUnit(),
+		Units:            price.Nights,
+		UnitPrice:        MapMoney(price.Nightly),
+		WeekendSurcharge: MapMoney(money.Money{Amount: price.WeekendSurcharge.Amount, Currency: price.Nightly.Currency}),
+		Fees:             mapFees(price.Fees),
+		Taxes:            mapTaxes(price.Taxes),
+		Discounts:        mapDiscounts(price.Discounts),
+		Adjustments:      mapAdjustments(price.Adjustments),
+		Total:            MapMoney(price.Total),
+	}
+}
+
+// MapBookingQuote describes the priced stay as available; the caller clears
+// IsAvailable and sets the reason when it is not.
+func MapBookingQuote(listingID string, dr domainrange.DateRange, guests int, price domainpricing.PriceBreakdown) BookingQuote {
+	currency := price.Nightly.Currency
+	return BookingQuote{
+		ListingID:        listingID,
+		CheckIn:          dr.CheckIn,
+		CheckOut:         dr.CheckOut,
+		Nights:           dr.Nights(),
+		Guests:           guests,
+		Unit:             price.PriceUnit(),
+		Units:            price.Nights,
+		NightlyRate:      MapMoney(price.Nightly),
+		WeekendSurcharge: MapMoney(money.Money{Amount: price.WeekendSurcharge.Amount, Currency: currency}),
+		CleaningFee:      MapMoney(price.Fee(domainpricing.FeeCleaning)),
+		ServiceFee:       MapMoney(price.Fee(domainpricing.FeeService)),
+		Discount:         MapMoney(price.DiscountTotal()),
+		Total:            MapMoney(price.Total),
+		IsAvailable:      true,
+	}
+}
+
+// MapCancellationPolicyPreview evaluates the booking policy as of now.
+func MapCancellationPolicyPreview(booking *domainbooking.Booking, now time.Time) CancellationPolicyPreview {
+	policy := booking.Policy
+	preview := CancellationPolicyPreview{
+		PolicyID:                  policy.PolicyID,
+		PreCheckInPenaltyPercent:  policy.PreCheckInPenaltyPercent,
+		PostCheckInPenaltyPercent: policy.PostCheckInPenaltyPercent,
+		RefundIfCancelledNow:      MapMoney(booking.Price.Total),
+	}
+	if !policy.FreeCancellationUntil.IsZero() {
+		until := policy.FreeCancellationUntil
+		preview.FreeCancellationUntil = &until
+	}
+	if refund, _, err := policy.CalculateRefund(booking.Price.Total, now, booking.Range.CheckIn); err == nil {
+		preview.RefundIfCancelledNow = MapMoney(refund)
+	}
+	return preview
+}
+
+func mapFees(items []domainpricing.Fee) []PriceLine {
+	lines := make([]PriceLine, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, PriceLine{Name: item.Name, Amount: MapMoney(item.Amount)})
+	}
+	return lines
+}
+
+func mapTaxes(items []domainpricing.Tax) []PriceLine {
+	lines := make([]PriceLine, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, PriceLine{Name: item.Name, Amount: MapMoney(item.Amount)})
+	}
+	return lines
+}
+
+func mapDiscounts(items []domainpricing.Discount) []PriceLine {
+	lines := make([]PriceLine, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, PriceLine{Name: item.Name, Amount: MapMoney(item.Amount)})
+	}
+	return lines
+}
+
+func mapAdjustments(items []domainpricing.Adjustment) []PriceLine {
+	lines := make([]PriceLine, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, PriceLine{Name: item.Name, Amount: MapMoney(item.Amount)})
+	}
+	return lines
+}
+
+func mapBookingListingSnapshot(booking *domainbooking.Booking, listing *domainlistings.Listing) BookingListingSnapshot {
 	snapshot := BookingListingSnapshot{
 		ID: string(booking.ListingID),
 	}
@@ -123,21 +336,22 @@ func MapHostBookingSummary(booking *domainbooking.Booking, listing *domainlistin
 		snapshot.City = listing.Address.City
 		snapshot.Region = listing.Address.Region
 		snapshot.Country = listing.Address.Country
-		snapshot.ThumbnailURL = listing.ThumbnailURL
+		snapshot.ThumbnailURL = MediaURL(listing.ThumbnailURL)
 	}
-	return HostBookingSummary{
-		ID:        string(booking.ID),
-		Listing:   snapshot,
-		GuestID:   booking.GuestID,
-		CheckIn:   booking.Range.CheckIn,
-		CheckOut:  booking.Range.CheckOut,
-		Guests:    booking.Guests,
-		Months:    booking.Months,
-		PriceUnit: resolvePriceUnit(booking.PriceUnit),
-		Status:    string(booking.State),
-		Total:     MapMoney(booking.Price.Total),
-		CreatedAt: booking.CreatedAt,
+	return snapshot
+}
+
+// maskPaymentHold keeps the last four characters so support can match a hold
+// without exposing the full provider id.
+func maskPaymentHold(id string) string {
+	const visible = 4
+	if id == "" {
+		return ""
+	}
+	if len(id) <= visible {
+		return strings.Repeat("*", len(id))
 	}
+	return strings.Repeat("*", len(id)-visible) + id[len(id)-visible:]
 }
 
 func resolvePriceUnit(value string) string {
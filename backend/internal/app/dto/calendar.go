@@ -4,17 +4,41 @@ import (
 	"time"
 
 	"rentme/internal/domain/availability"
+	"rentme/internal/domain/shared/daterange"
 )
 
 type CalendarBlock struct {
 	From   time.Time `json:"from"`
 	To     time.Time `json:"to"`
 	Reason string    `json:"reason"`
+	// Reference identifies the booking or host block; it stays out of the
+	// public JSON and only feeds stable iCal UIDs.
+	Reference string `json:"-"`
+}
+
+// HostCalendarBlock is a block the host created; Reference identifies it for
+// later removal.
+type HostCalendarBlock struct {
+	Reference string    `json:"reference"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Reason    string    `json:"reason"`
+	Note      string    `json:"note,omitempty"`
+}
+
+// CalendarDay is the occupancy of the night starting at Date (UTC midnight).
+type CalendarDay struct {
+	Date      time.Time `json:"date"`
+	Available bool      `json:"available"`
+	Reason    string    `json:"reason,omitempty"`
 }
 
 type Calendar struct {
 	ListingID string          `json:"listing_id"`
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
 	Blocks    []CalendarBlock `json:"blocks"`
+	Days      []CalendarDay   `json:"days,omitempty"`
 }
 
 func MapCalendar(cal *availability.AvailabilityCalendar) Calendar {
@@ -50,6 +74,27 @@ func MapCalendarWithin(cal *availability.AvailabilityCalendar, from, to time.Tim
 	return Calendar{ListingID: string(cal.ListingID), Blocks: mapCalendarBlocks(filtered)}
 }
 
+// MapCalendarDays expands the calendar into one entry per night in
+// [from, to). Both bounds are expected at UTC midnight.
+func MapCalendarDays(cal *availability.AvailabilityCalendar, from, to time.Time) []CalendarDay {
+	if from.IsZero() || !to.After(from) {
+		return nil
+	}
+	days := make([]CalendarDay, 0, int(to.Sub(from).Hours()/24))
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		entry := CalendarDay{Date: day, Available: true}
+		if cal != nil {
+			night := daterange.DateRange{CheckIn: day, CheckOut: day.AddDate(0, 0, 1)}
+			if reason, blocked := cal.BlockingReason(night); blocked {
+				entry.Available = false
+				entry.Reason = string(reason)
+			}
+		}
+		days = append(days, entry)
+	}
+	return days
+}
+
 func mapCalendarBlocks(blocks []availability.Block) []CalendarBlock {
 	if len(blocks) == 0 {
 		return nil
@@ -57,9 +102,10 @@ func mapCalendarBlocks(blocks []availability.Block) []CalendarBlock {
 	result := make([]CalendarBlock, 0, len(blocks))
 	for _, b := range blocks {
 		result = append(result, CalendarBlock{
-			From:   b.Range.CheckIn,
-			To:     b.Range.CheckOut,
-			Reason: string(b.Reason),
+			From:      b.Range.CheckIn,
+			To:        b.Range.CheckOut,
+			Reason:    string(b.Reason),
+			Reference: b.Reference,
 		})
 	}
 	return result
@@ -71,3 +117,26 @@ func normalizeUTC(t time.Time) time.Time {
 	}
 	return t.UTC()
 }
+
+// PriceCalendarEntry is the single-night price for Date: the nightly rate plus
+// the weekend surcharge when Date is a Friday or Saturday. Long-term listings
+// have no nightly price and leave PriceRub out.
+type PriceCalendarEntry struct {
+	Date        time.Time `json:"date"`
+	PriceRub    int64     `json:"price_rub,omitempty"`
+	IsAvailable bool      `json:"is_available"`
+}
+
+// PriceCalendar carries the listing's stay bounds along with the prices so the
+// UI can render the nights picker or the month selector without another call.
+type PriceCalendar struct {
+	ListingID  string               `json:"listing_id"`
+	Currency   string               `json:"currency"`
+	Guests     int                  `json:"guests"`
+	RentalTerm string               `json:"rental_term"`
+	MinNights  int                  `json:"min_nights,omitempty"`
+	MaxNights  int                  `json:"max_nights,omitempty"`
+	MinMonths  int                  `json:"min_months,omitempty"`
+	MaxMonths  int                  `json:"max_months,omitempty"`
+	Days       []PriceCalendarEntry `json:"days"`
+}
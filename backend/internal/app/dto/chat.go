@@ -13,6 +13,24 @@ type Conversation struct {
 	LastMessageSender  string    `json:"last_message_sender_id,omitempty"`
 	LastMessageText    string    `json:"last_message_text,omitempty"`
 	HasUnread          bool      `json:"has_unread,omitempty"`
+	UnreadCount        int       `json:"unread_count"`
+	Peers              []ConversationPeer `json:"peers,omitempty"`
+	Listing            *ListingSnapshot   `json:"listing,omitempty"`
+}
+
+// ListingSnapshot is the listing a conversation is about, as shown in the
+// chat list.
+type ListingSnapshot struct {
+	Title        string `json:"title"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	City         string `json:"city,omitempty"`
+}
+
+// ConversationPeer describes another participant of a conversation.
+type ConversationPeer struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Verified bool   `json:"verified"`
 }
 
 // ConversationList is a paginated collection.
@@ -23,11 +41,12 @@ type ConversationList struct {
 
 // ChatMessage contains a single message payload.
 type ChatMessage struct {
-	ID             string    `json:"id"`
-	ConversationID string    `json:"conversation_id"`
-	SenderID       string    `json:"sender_id"`
-	Text           string    `json:"text"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID              string    `json:"id"`
+	ConversationID  string    `json:"conversation_id"`
+	SenderID        string    `json:"sender_id"`
+	Text            string    `json:"text"`
+	ClientMessageID string    `json:"client_message_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 // ChatMessageList is a paginated message list.
@@ -35,3 +54,26 @@ type ChatMessageList struct {
 	Items      []ChatMessage `json:"items"`
 	NextCursor string        `json:"next_cursor,omitempty"`
 }
+
+// ConversationAssignment tells which host team member handles a conversation.
+type ConversationAssignment struct {
+	ConversationID string    `json:"conversation_id"`
+	ListingID      string    `json:"listing_id,omitempty"`
+	AssigneeID     string    `json:"assignee_id,omitempty"`
+	AssignedBy     string    `json:"assigned_by,omitempty"`
+	AssignedAt     time.Time `json:"assigned_at,omitempty"`
+}
+
+// InboxNote is an internal note visible to the host team only.
+type InboxNote struct {
+	ID             string    `json:"id"`
+	ConversationID string    `json:"conversation_id"`
+	AuthorID       string    `json:"author_id"`
+	Text           string    `json:"text"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// InboxNoteList holds the internal notes of a conversation.
+type InboxNoteList struct {
+	Items []InboxNote `json:"items"`
+}
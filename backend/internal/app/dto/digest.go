@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// HostDigest is the template data of the weekly host digest.
+type HostDigest struct {
+	HostID            string                       `json:"host_id"`
+	HostName          string                       `json:"host_name"`
+	Period            string                       `json:"period"`
+	From              time.Time                    `json:"from"`
+	To                time.Time                    `json:"to"`
+	PendingRequests   []HostBookingSummary         `json:"pending_requests"`
+	UpcomingCheckIns  []HostBookingSummary         `json:"upcoming_check_ins"`
+	UpcomingCheckOuts []HostBookingSummary         `json:"upcoming_check_outs"`
+	NewReviews        []Review                     `json:"new_reviews"`
+	UnreadMessages    int                          `json:"unread_messages"`
+	PriceAlerts       []HostListingPriceSuggestion `json:"price_alerts"`
+}
+
+// Empty reports whether the digest has nothing worth sending.
+func (d HostDigest) Empty() bool {
+	return len(d.PendingRequests) == 0 &&
+		len(d.UpcomingCheckIns) == 0 &&
+		len(d.UpcomingCheckOuts) == 0 &&
+		len(d.NewReviews) == 0 &&
+		d.UnreadMessages == 0 &&
+		len(d.PriceAlerts) == 0
+}
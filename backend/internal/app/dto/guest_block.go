@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	domainguestblock "rentme/internal/domain/guestblock"
+)
+
+// GuestBlock is a guest a host refuses to host again. Only the host and
+// admins ever see it.
+type GuestBlock struct {
+	HostID    string    `json:"host_id"`
+	GuestID   string    `json:"guest_id"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GuestBlockCollection struct {
+	Items []GuestBlock `json:"items"`
+}
+
+func MapGuestBlock(block *domainguestblock.Block) GuestBlock {
+	return GuestBlock{
+		HostID:    string(block.HostID),
+		GuestID:   block.GuestID,
+		Reason:    block.Reason,
+		CreatedAt: block.CreatedAt,
+	}
+}
+
+func MapGuestBlocks(blocks []*domainguestblock.Block) []GuestBlock {
+	items := make([]GuestBlock, 0, len(blocks))
+	for _, block := range blocks {
+		items = append(items, MapGuestBlock(block))
+	}
+	return items
+}
@@ -43,48 +43,136 @@ type HostListingSummary struct {
 	Photos           []string  `json:"photos"`
 	UpdatedAt        time.Time `json:"updated_at"`
 	State            string    `json:"state"`
+	// PublishIssues flags content rules the listing misses; for a published
+	// listing they do not take it down but should be fixed.
+	PublishIssues []string `json:"publish_issues,omitempty"`
 }
 
 type HostListingDetail struct {
-	ID                   string         `json:"id"`
-	Title                string         `json:"title"`
-	Description          string         `json:"description"`
-	PropertyType         string         `json:"property_type"`
-	Address              ListingAddress `json:"address"`
-	Amenities            []string       `json:"amenities"`
-	GuestsLimit          int            `json:"guests_limit"`
-	MinNights            int            `json:"min_nights"`
-	MaxNights            int            `json:"max_nights"`
-	HouseRules           []string       `json:"house_rules"`
-	Host                 ListingHost    `json:"host"`
-	State                string         `json:"state"`
-	Tags                 []string       `json:"tags"`
-	Highlights           []string       `json:"highlights"`
-	RateRub              int64          `json:"rate_rub"`
-	PriceUnit            string         `json:"price_unit"`
-	Bedrooms             int            `json:"bedrooms"`
-	Bathrooms            int            `json:"bathrooms"`
-	Floor                int            `json:"floor"`
-	FloorsTotal          int            `json:"floors_total"`
-	RenovationScore      int            `json:"renovation_score"`
-	BuildingAgeYears     int            `json:"building_age_years"`
-	AreaSquareMeters     float64        `json:"area_sq_m"`
-	TravelMinutes        float64        `json:"travel_minutes"`
-	TravelMode           string         `json:"travel_mode"`
-	RentalTerm           string         `json:"rental_term"`
-	ThumbnailURL         string         `json:"thumbnail_url"`
-	Photos               []string       `json:"photos"`
-	CancellationPolicyID string         `json:"cancellation_policy_id"`
-	AvailableFrom        time.Time      `json:"available_from"`
-	CreatedAt            time.Time      `json:"created_at"`
-	UpdatedAt            time.Time      `json:"updated_at"`
-	StateLabel           string         `json:"status"`
+	ID                   string            `json:"id"`
+	Title                string            `json:"title"`
+	Description          string            `json:"description"`
+	PropertyType         string            `json:"property_type"`
+	Address              ListingAddress    `json:"address"`
+	Amenities            []string          `json:"amenities"`
+	GuestsLimit          int               `json:"guests_limit"`
+	MinNights            int               `json:"min_nights"`
+	MaxNights            int               `json:"max_nights"`
+	MinMonths            int               `json:"min_months"`
+	MaxMonths            int               `json:"max_months"`
+	HouseRules           []string          `json:"house_rules"`
+	Host                 ListingHost       `json:"host"`
+	CoHosts              []string          `json:"co_hosts"`
+	UnitGroupID          string            `json:"unit_group_id,omitempty"`
+	State                string            `json:"state"`
+	Tags                 []string          `json:"tags"`
+	Highlights           []string          `json:"highlights"`
+	RateRub              int64             `json:"rate_rub"`
+	CleaningFeeRub       int64             `json:"cleaning_fee_rub"`
+	ServiceFeePct        float64           `json:"service_fee_pct"`
+	WeekendPremiumPct    float64           `json:"weekend_premium_pct"`
+	PriceUnit            string            `json:"price_unit"`
+	Bedrooms             int               `json:"bedrooms"`
+	Bathrooms            int               `json:"bathrooms"`
+	Floor                int               `json:"floor"`
+	FloorsTotal          int               `json:"floors_total"`
+	RenovationScore      int               `json:"renovation_score"`
+	BuildingAgeYears     int               `json:"building_age_years"`
+	AreaSquareMeters     float64           `json:"area_sq_m"`
+	TravelMinutes        float64           `json:"travel_minutes"`
+	TravelMode           string            `json:"travel_mode"`
+	RentalTerm           string            `json:"rental_term"`
+	VerifiedGuestsOnly   bool              `json:"verified_guests_only"`
+	ThumbnailURL         string            `json:"thumbnail_url"`
+	Photos               []string          `json:"photos"`
+	PhotoTags            map[string]string `json:"photo_tags"`
+	CancellationPolicyID string            `json:"cancellation_policy_id"`
+	AvailableFrom        time.Time         `json:"available_from"`
+	RepublishAt          *time.Time        `json:"republish_at,omitempty"`
+	// RestoreUntil is set on deleted listings: the last moment they can be restored.
+	RestoreUntil    *time.Time `json:"restore_until,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	StateLabel      string     `json:"status"`
+	Recommendations []string   `json:"recommendations,omitempty"`
+	PublishIssues   []string   `json:"publish_issues,omitempty"`
+
+	LongStayDiscountPct     float64 `json:"long_stay_discount_pct"`
+	LongStayThresholdNights int     `json:"long_stay_threshold_nights"`
 }
 
 type HostListingPhotoUploadResult struct {
-	ListingID    string   `json:"listing_id"`
-	Photos       []string `json:"photos"`
-	ThumbnailURL string   `json:"thumbnail_url"`
+	ListingID    string            `json:"listing_id"`
+	Photos       []string          `json:"photos"`
+	PhotoTags    map[string]string `json:"photo_tags"`
+	ThumbnailURL string            `json:"thumbnail_url"`
+}
+
+// HostListingPhotoUploadURL is a presigned URL for a direct photo upload.
+type HostListingPhotoUploadURL struct {
+	URL       string `json:"url"`
+	Key       string `json:"key"`
+	ExpiresIn int    `json:"expires_in"`
+}
+
+// HostListingPhotoUpload is the state of a resumable photo upload; clients
+// resume by sending the parts that are not listed yet.
+type HostListingPhotoUpload struct {
+	UploadID      string                       `json:"upload_id"`
+	ListingID     string                       `json:"listing_id"`
+	Parts         []HostListingPhotoUploadPart `json:"parts"`
+	ReceivedBytes int64                        `json:"received_bytes"`
+	MaxBytes      int64                        `json:"max_bytes"`
+	ExpiresAt     time.Time                    `json:"expires_at"`
+}
+
+type HostListingPhotoUploadPart struct {
+	Number int   `json:"number"`
+	Size   int64 `json:"size"`
+}
+
+// ListingChangelogEntry names the listing attributes one update changed.
+type ListingChangelogEntry struct {
+	Version   int64     `json:"version"`
+	ChangedBy string    `json:"changed_by"`
+	At        time.Time `json:"at"`
+	Fields    []string  `json:"fields"`
+}
+
+func MapListingChangelog(entries []domainlistings.ChangelogEntry) []ListingChangelogEntry {
+	result := make([]ListingChangelogEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, ListingChangelogEntry{
+			Version:   entry.Version,
+			ChangedBy: entry.ChangedBy,
+			At:        entry.At,
+			Fields:    append([]string{}, entry.Fields...),
+		})
+	}
+	return result
+}
+
+// ListingPriceChange is one rate change. Source is "manual" or "ml_applied".
+type ListingPriceChange struct {
+	OldRateRub int64     `json:"old_rate_rub"`
+	NewRateRub int64     `json:"new_rate_rub"`
+	Source     string    `json:"source"`
+	ChangedBy  string    `json:"changed_by,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+func MapListingPriceHistory(entries []domainlistings.PriceChange) []ListingPriceChange {
+	result := make([]ListingPriceChange, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, ListingPriceChange{
+			OldRateRub: entry.OldRateRub,
+			NewRateRub: entry.NewRateRub,
+			Source:     string(entry.Source),
+			ChangedBy:  entry.ChangedBy,
+			At:         entry.At,
+		})
+	}
+	return result
 }
 
 func MapHostListingSummary(listing *domainlistings.Listing) HostListingSummary {
@@ -112,10 +200,11 @@ func MapHostListingSummary(listing *domainlistings.Listing) HostListingSummary {
 		TravelMode:       listing.TravelMode,
 		RentalTerm:       string(listing.RentalTermType),
 		AvailableFrom:    listing.AvailableFrom,
-		ThumbnailURL:     listing.ThumbnailURL,
-		Photos:           append([]string(nil), listing.Photos...),
+		ThumbnailURL:     MediaURL(listing.ThumbnailURL),
+		Photos:           MediaURLs(listing.Photos),
 		UpdatedAt:        listing.UpdatedAt,
 		State:            string(listing.State),
+		PublishIssues:    MapPublishIssues(listing),
 	}
 }
 
@@ -123,16 +212,8 @@ func MapHostListingDetail(listing *domainlistings.Listing) HostListingDetail {
 	if listing == nil {
 		return HostListingDetail{}
 	}
-	address := ListingAddress{
-		Line1:   listing.Address.Line1,
-		Line2:   listing.Address.Line2,
-		City:    listing.Address.City,
-		Region:  listing.Address.Region,
-		Country: listing.Address.Country,
-		Lat:     listing.Address.Lat,
-		Lon:     listing.Address.Lon,
-	}
-	return HostListingDetail{
+	address := MapListingAddress(listing.Address)
+	result := HostListingDetail{
 		ID:                   string(listing.ID),
 		Title:                listing.Title,
 		Description:          listing.Description,
@@ -142,12 +223,19 @@ func MapHostListingDetail(listing *domainlistings.Listing) HostListingDetail {
 		GuestsLimit:          listing.GuestsLimit,
 		MinNights:            listing.MinNights,
 		MaxNights:            listing.MaxNights,
+		MinMonths:            listing.MinMonths,
+		MaxMonths:            listing.MaxMonths,
 		HouseRules:           append([]string(nil), listing.HouseRules...),
 		Host:                 ListingHost{ID: string(listing.Host)},
+		CoHosts:              mapCoHosts(listing.CoHosts),
+		UnitGroupID:          listing.UnitGroupID,
 		State:                string(listing.State),
 		Tags:                 append([]string(nil), listing.Tags...),
 		Highlights:           append([]string(nil), listing.Highlights...),
 		RateRub:              listing.RateRub,
+		CleaningFeeRub:       listing.CleaningFeeRub,
+		ServiceFeePct:        listing.ServiceFeePct,
+		WeekendPremiumPct:    listing.WeekendPremiumPct,
 		PriceUnit:            hostPriceUnit(listing.RentalTermType),
 		Bedrooms:             listing.Bedrooms,
 		Bathrooms:            listing.Bathrooms,
@@ -159,14 +247,64 @@ func MapHostListingDetail(listing *domainlistings.Listing) HostListingDetail {
 		TravelMinutes:        listing.TravelMinutes,
 		TravelMode:           listing.TravelMode,
 		RentalTerm:           string(listing.RentalTermType),
-		ThumbnailURL:         listing.ThumbnailURL,
-		Photos:               append([]string(nil), listing.Photos...),
+		VerifiedGuestsOnly:   listing.VerifiedGuestsOnly,
+		ThumbnailURL:         MediaURL(listing.ThumbnailURL),
+		Photos:               MediaURLs(listing.Photos),
+		PhotoTags:            MapPhotoTags(listing),
 		CancellationPolicyID: listing.CancellationPolicyID,
 		AvailableFrom:        listing.AvailableFrom,
 		CreatedAt:            listing.CreatedAt,
 		UpdatedAt:            listing.UpdatedAt,
 		StateLabel:           toStatus(listing.State),
+		PublishIssues:        MapPublishIssues(listing),
+
+		LongStayDiscountPct:     listing.LongStayDiscountPct,
+		LongStayThresholdNights: listing.LongStayThresholdNights,
+	}
+	if !listing.RepublishAt.IsZero() {
+		republishAt := listing.RepublishAt
+		result.RepublishAt = &republishAt
 	}
+	if !listing.DeletedAt.IsZero() {
+		restoreUntil := listing.DeletedAt.Add(domainlistings.DeletedRetention)
+		result.RestoreUntil = &restoreUntil
+	}
+	return result
+}
+
+// MapPublishIssues names the publishing rules the listing misses.
+func MapPublishIssues(listing *domainlistings.Listing) []string {
+	var codes []string
+	for _, issue := range listing.PublishIssues() {
+		switch issue {
+		case domainlistings.ErrPhotoRequired:
+			codes = append(codes, "photo_required")
+		case domainlistings.ErrDescriptionLen:
+			codes = append(codes, "description_too_short")
+		case domainlistings.ErrRateRequired:
+			codes = append(codes, "rate_required")
+		}
+	}
+	return codes
+}
+
+// MapPhotoTags returns the room tag of every tagged gallery photo keyed by URL.
+func MapPhotoTags(listing *domainlistings.Listing) map[string]string {
+	tags := make(map[string]string, len(listing.PhotoTags))
+	for _, url := range listing.Photos {
+		if tag := listing.PhotoTags[url]; tag != "" {
+			tags[MediaURL(url)] = string(tag)
+		}
+	}
+	return tags
+}
+
+func mapCoHosts(ids []domainlistings.HostID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, string(id))
+	}
+	return result
 }
 
 func toStatus(state domainlistings.ListingState) string {
@@ -177,14 +315,53 @@ func toStatus(state domainlistings.ListingState) string {
 		return "published"
 	case domainlistings.ListingSuspended:
 		return "archived"
+	case domainlistings.ListingDeleted:
+		return "deleted"
 	default:
 		return strings.ToLower(string(state))
 	}
 }
 
+// ListingStatesForStatus maps a status label back to listing states; an
+// unknown or empty label matches every state but deleted.
+func ListingStatesForStatus(raw string) []domainlistings.ListingState {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "draft":
+		return []domainlistings.ListingState{domainlistings.ListingDraft}
+	case "published":
+		return []domainlistings.ListingState{domainlistings.ListingActive}
+	case "archived":
+		return []domainlistings.ListingState{domainlistings.ListingSuspended}
+	case "deleted":
+		return []domainlistings.ListingState{domainlistings.ListingDeleted}
+	default:
+		return nil
+	}
+}
+
 func hostPriceUnit(term domainlistings.RentalTermType) string {
 	if term == domainlistings.RentalTermLong {
 		return "month"
 	}
 	return "night"
 }
+
+// HostListingAddressValidation previews how an address would be stored.
+// Warnings do not block saving a draft; they list what publishing needs.
+type HostListingAddressValidation struct {
+	Address         ListingAddress `json:"address"`
+	MarketSupported bool           `json:"market_supported"`
+	Warnings        []string       `json:"warnings"`
+}
+
+func MapListingAddress(address domainlistings.Address) ListingAddress {
+	return ListingAddress{
+		Line1:   address.Line1,
+		Line2:   address.Line2,
+		City:    address.City,
+		Region:  address.Region,
+		Country: address.Country,
+		Lat:     address.Lat,
+		Lon:     address.Lon,
+	}
+}
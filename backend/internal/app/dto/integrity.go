@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+const (
+	IntegrityFindingOverlap      = "overlap"
+	IntegrityFindingMissingBlock = "missing_block"
+)
+
+// BookingIntegrityFinding describes a single inconsistency between bookings and calendars.
+type BookingIntegrityFinding struct {
+	Kind       string    `json:"kind"`
+	ListingID  string    `json:"listing_id"`
+	BookingIDs []string  `json:"booking_ids"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	Detail     string    `json:"detail"`
+}
+
+// BookingIntegrityReport is the outcome of one integrity check run.
+type BookingIntegrityReport struct {
+	ID              string                    `json:"id"`
+	ListingID       string                    `json:"listing_id,omitempty"`
+	GeneratedAt     time.Time                 `json:"generated_at"`
+	ListingsScanned int                       `json:"listings_scanned"`
+	BookingsScanned int                       `json:"bookings_scanned"`
+	Findings        []BookingIntegrityFinding `json:"findings"`
+}
+
+type BookingIntegrityReportList struct {
+	Items []BookingIntegrityReport `json:"items"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"rentme/internal/app/jobs"
+)
+
+// JobRun is one finished attempt of a background job.
+type JobRun struct {
+	ID         string    `json:"id"`
+	Job        string    `json:"job"`
+	TaskID     string    `json:"task_id"`
+	Owner      string    `json:"owner"`
+	Attempt    int       `json:"attempt"`
+	Status     string    `json:"status"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	DurationMs int64     `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+type JobRunList struct {
+	Items []JobRun `json:"items"`
+}
+
+func MapJobRuns(runs []jobs.Run) JobRunList {
+	items := make([]JobRun, 0, len(runs))
+	for _, run := range runs {
+		items = append(items, JobRun{
+			ID:         run.ID,
+			Job:        run.Job,
+			TaskID:     run.TaskID,
+			Owner:      run.Owner,
+			Attempt:    run.Attempt,
+			Status:     string(run.Status),
+			StartedAt:  run.StartedAt,
+			FinishedAt: run.FinishedAt,
+			DurationMs: run.Duration().Milliseconds(),
+			Error:      run.Error,
+		})
+	}
+	return JobRunList{Items: items}
+}
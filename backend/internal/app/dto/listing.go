@@ -1,10 +1,12 @@
 package dto
 
 import (
+	"strconv"
 	"time"
 
 	domainavailability "rentme/internal/domain/availability"
 	domainlistings "rentme/internal/domain/listings"
+	domainreviews "rentme/internal/domain/reviews"
 )
 
 // ListingAddress represents the public location snapshot.
@@ -29,6 +31,12 @@ type AvailabilityWindow struct {
 	To   time.Time `json:"to"`
 }
 
+// ListingPhoto is a gallery entry with its optional room tag.
+type ListingPhoto struct {
+	URL string `json:"url"`
+	Tag string `json:"tag,omitempty"`
+}
+
 // ListingOverview aggregates listing details and calendar information.
 type ListingOverview struct {
 	ID                 string             `json:"id"`
@@ -39,13 +47,33 @@ type ListingOverview struct {
 	GuestsLimit        int                `json:"guests_limit"`
 	MinNights          int                `json:"min_nights"`
 	MaxNights          int                `json:"max_nights"`
+	MinMonths          int                `json:"min_months"`
+	MaxMonths          int                `json:"max_months"`
 	RentalTerm         string             `json:"rental_term"`
+	VerifiedGuestsOnly bool               `json:"verified_guests_only,omitempty"`
 	HouseRules         []string           `json:"house_rules"`
 	Host               ListingHost        `json:"host"`
 	State              string             `json:"state"`
 	Rating             float64            `json:"rating"`
+	ReviewCount        int                `json:"review_count"`
+	RatingBreakdown    map[string]int     `json:"rating_breakdown"`
+	ThumbnailURL       string             `json:"thumbnail_url"`
+	Photos             []ListingPhoto     `json:"photos"`
 	Calendar           Calendar           `json:"calendar"`
 	AvailabilityWindow AvailabilityWindow `json:"availability_window"`
+
+	LongStayDiscountPct     float64 `json:"long_stay_discount_pct,omitempty"`
+	LongStayThresholdNights int     `json:"long_stay_threshold_nights,omitempty"`
+}
+
+// ApplyReviewStats fills the review counters; the breakdown is keyed by star
+// ("1".."5") and always lists every star.
+func ApplyReviewStats(overview *ListingOverview, stats domainreviews.ListingStats) {
+	overview.ReviewCount = stats.Count
+	overview.RatingBreakdown = make(map[string]int, len(stats.Stars))
+	for i, count := range stats.Stars {
+		overview.RatingBreakdown[strconv.Itoa(i+1)] = count
+	}
 }
 
 // MapListingOverview builds a DTO that is convenient for the frontend.
@@ -76,13 +104,30 @@ func MapListingOverview(
 		GuestsLimit:        listing.GuestsLimit,
 		MinNights:          listing.MinNights,
 		MaxNights:          listing.MaxNights,
+		MinMonths:          listing.MinMonths,
+		MaxMonths:          listing.MaxMonths,
 		RentalTerm:         string(listing.RentalTermType),
+		VerifiedGuestsOnly: listing.VerifiedGuestsOnly,
 		HouseRules:         append([]string(nil), listing.HouseRules...),
 		Host:               host,
 		State:              string(listing.State),
 		Rating:             listing.Rating,
+		ThumbnailURL:       MediaURL(listing.ThumbnailURL),
+		Photos:             MapListingPhotos(listing),
 		AvailabilityWindow: AvailabilityWindow{From: windowFrom, To: windowTo},
+
+		LongStayDiscountPct:     listing.LongStayDiscountPct,
+		LongStayThresholdNights: listing.LongStayThresholdNights,
 	}
 	overview.Calendar = MapCalendarWithin(calendar, windowFrom, windowTo)
 	return overview
 }
+
+// MapListingPhotos keeps gallery order and attaches room tags.
+func MapListingPhotos(listing *domainlistings.Listing) []ListingPhoto {
+	photos := make([]ListingPhoto, 0, len(listing.Photos))
+	for _, url := range listing.Photos {
+		photos = append(photos, ListingPhoto{URL: MediaURL(url), Tag: string(listing.PhotoTags[url])})
+	}
+	return photos
+}
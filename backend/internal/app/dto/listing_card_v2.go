@@ -0,0 +1,186 @@
+package dto
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	domainpricing "rentme/internal/domain/pricing"
+)
+
+// API v2 shapes. v1 DTOs stay frozen; new card fields land here and are
+// mapped from the same ListingCard projection, so both versions always
+// describe the same listing.
+
+// PhotoVariantWidths are the widths advertised for every gallery photo.
+var PhotoVariantWidths = map[string]int{
+	"thumb":  320,
+	"medium": 960,
+}
+
+// MoneyV2 carries an amount in minor units (kopecks for RUB).
+type MoneyV2 struct {
+	AmountMinor int64  `json:"amount_minor"`
+	Currency    string `json:"currency"`
+	Formatted   string `json:"formatted"`
+}
+
+// PhotoV2 is a gallery photo with resized variants keyed by name.
+type PhotoV2 struct {
+	URL      string            `json:"url"`
+	Variants map[string]string `json:"variants"`
+}
+
+// ListingCardV2 is the catalog card of API v2.
+type ListingCardV2 struct {
+	ID           string              `json:"id"`
+	Slug         string              `json:"slug"`
+	HostID       string              `json:"host_id"`
+	Title        string              `json:"title"`
+	City         string              `json:"city"`
+	Region       string              `json:"region"`
+	Country      string              `json:"country"`
+	PropertyType string              `json:"property_type"`
+	GuestsLimit  int                 `json:"guests_limit"`
+	Bedrooms     int                 `json:"bedrooms"`
+	Bathrooms    int                 `json:"bathrooms"`
+	AreaSqM      float64             `json:"area_sq_m"`
+	RentalTerm   string              `json:"rental_term"`
+	Price        MoneyV2             `json:"price"`
+	DisplayPrice MoneyV2             `json:"display_price"`
+	PriceUnit    string              `json:"price_unit"`
+	Badges       []string            `json:"badges"`
+	Tags         []string            `json:"tags"`
+	Amenities    []string            `json:"amenities"`
+	Photos       []PhotoV2           `json:"photos"`
+	Rating       float64             `json:"rating"`
+	Travel       *TravelV2           `json:"travel,omitempty"`
+	Availability ListingAvailability `json:"availability"`
+	IsFavorite   *bool               `json:"is_favorite,omitempty"`
+}
+
+// TravelV2 groups the commute fields that v1 flattens onto the card.
+type TravelV2 struct {
+	Minutes float64 `json:"minutes"`
+	Mode    string  `json:"mode"`
+	Source  string  `json:"source"`
+}
+
+// ListingCatalogV2 is the v2 catalog page.
+type ListingCatalogV2 struct {
+	Items   []ListingCardV2 `json:"items"`
+	Filters CatalogFilters  `json:"filters"`
+	Meta    CatalogMetadata `json:"meta"`
+}
+
+// MapListingCatalogV2 adapts a catalog page to the v2 shape.
+func MapListingCatalogV2(catalog ListingCatalog) ListingCatalogV2 {
+	items := make([]ListingCardV2, 0, len(catalog.Items))
+	for _, card := range catalog.Items {
+		items = append(items, MapListingCardV2(card))
+	}
+	return ListingCatalogV2{Items: items, Filters: catalog.Filters, Meta: catalog.Meta}
+}
+
+// MapListingCardV2 builds the v2 card from the catalog projection.
+func MapListingCardV2(card ListingCard) ListingCardV2 {
+	out := ListingCardV2{
+		ID:           card.ID,
+		Slug:         ListingSlug(card.Title, card.ID),
+		HostID:       card.HostID,
+		Title:        card.Title,
+		City:         card.City,
+		Region:       card.Region,
+		Country:      card.Country,
+		PropertyType: card.PropertyType,
+		GuestsLimit:  card.GuestsLimit,
+		Bedrooms:     card.Bedrooms,
+		Bathrooms:    card.Bathrooms,
+		AreaSqM:      card.AreaSquareMeters,
+		RentalTerm:   card.RentalTerm,
+		Price:        rubMoney(card.RateRub, domainpricing.DefaultRoundingPolicy().Rule(card.City, "RUB").Format(card.RateRub)),
+		DisplayPrice: rubMoney(card.DisplayPriceRub, card.DisplayPrice),
+		PriceUnit:    card.PriceUnit,
+		Badges:       listingBadges(card),
+		Tags:         append([]string{}, card.Tags...),
+		Amenities:    append([]string{}, card.Amenities...),
+		Photos:       photoVariants(card.Photos, card.ThumbnailURL),
+		Rating:       card.Rating,
+		Availability: card.Availability,
+		IsFavorite:   card.IsFavorite,
+	}
+	if card.TravelMinutes > 0 {
+		out.Travel = &TravelV2{Minutes: card.TravelMinutes, Mode: card.TravelMode, Source: card.TravelSource}
+	}
+	return out
+}
+
+// ListingSlug builds a URL slug from the title; the id suffix keeps it unique.
+func ListingSlug(title, id string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.Trim(b.String(), "-")
+	if slug == "" {
+		return id
+	}
+	return slug + "--" + id
+}
+
+func rubMoney(amountRub int64, formatted string) MoneyV2 {
+	return MoneyV2{AmountMinor: amountRub * 100, Currency: "RUB", Formatted: formatted}
+}
+
+func listingBadges(card ListingCard) []string {
+	badges := make([]string, 0, 3)
+	if card.Rating >= 4.8 {
+		badges = append(badges, "top_rated")
+	}
+	if card.RentalTerm == "long_term" {
+		badges = append(badges, "long_term")
+	}
+	if card.Availability.IsAvailable {
+		badges = append(badges, "available")
+	}
+	return badges
+}
+
+// photoVariants advertises resized copies through the w query parameter;
+// storage that ignores it serves the original. Listings without a gallery
+// fall back to the thumbnail.
+func photoVariants(photos []string, thumbnail string) []PhotoV2 {
+	if len(photos) == 0 && thumbnail != "" {
+		photos = []string{thumbnail}
+	}
+	out := make([]PhotoV2, 0, len(photos))
+	for _, raw := range photos {
+		variants := make(map[string]string, len(PhotoVariantWidths))
+		for name, width := range PhotoVariantWidths {
+			variants[name] = withWidth(raw, width)
+		}
+		out = append(out, PhotoV2{URL: raw, Variants: variants})
+	}
+	return out
+}
+
+func withWidth(raw string, width int) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	query := parsed.Query()
+	query.Set("w", strconv.Itoa(width))
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	domainlistings "rentme/internal/domain/listings"
+	domainpricing "rentme/internal/domain/pricing"
 )
 
 // ListingCatalog is a paginated collection of listings.
@@ -28,19 +29,28 @@ type ListingCard struct {
 	MinNights        int                 `json:"min_nights"`
 	MaxNights        int                 `json:"max_nights"`
 	RateRub          int64               `json:"rate_rub"`
+	DisplayPriceRub  int64               `json:"display_price_rub"`
+	DisplayPrice     string              `json:"display_price"`
 	PriceUnit        string              `json:"price_unit"`
 	Bedrooms         int                 `json:"bedrooms"`
 	Bathrooms        int                 `json:"bathrooms"`
 	AreaSquareMeters float64             `json:"area_sq_m"`
+	PricePerSqM      float64             `json:"price_per_sq_m,omitempty"`
+	GoodValue        bool                `json:"good_value,omitempty"`
 	RentalTerm       string              `json:"rental_term"`
 	Tags             []string            `json:"tags"`
 	Amenities        []string            `json:"amenities"`
 	Highlights       []string            `json:"highlights"`
 	ThumbnailURL     string              `json:"thumbnail_url"`
+	Photos           []string            `json:"-"`
 	Rating           float64             `json:"rating"`
 	AvailableFrom    time.Time           `json:"available_from"`
 	State            string              `json:"state"`
+	TravelMinutes    float64             `json:"travel_minutes,omitempty"`
+	TravelMode       string              `json:"travel_mode,omitempty"`
+	TravelSource     string              `json:"travel_source,omitempty"`
 	Availability     ListingAvailability `json:"availability"`
+	IsFavorite       *bool               `json:"is_favorite,omitempty"`
 }
 
 // ListingAvailability describes availability for selected filters.
@@ -55,19 +65,31 @@ type ListingAvailability struct {
 
 // CatalogFilters echoes back the applied filters.
 type CatalogFilters struct {
-	City          string   `json:"city"`
-	Region        string   `json:"region"`
-	Country       string   `json:"country"`
-	Location      string   `json:"location"`
-	Tags          []string `json:"tags"`
-	Amenities     []string `json:"amenities"`
-	MinGuests     int      `json:"min_guests"`
-	PriceMinRub   int64    `json:"price_min_rub"`
-	PriceMaxRub   int64    `json:"price_max_rub"`
-	PropertyTypes []string `json:"property_types"`
-	CheckIn       string   `json:"check_in"`
-	CheckOut      string   `json:"check_out"`
-	RentalTerms   []string `json:"rental_terms"`
+	City             string     `json:"city"`
+	Region           string     `json:"region"`
+	Country          string     `json:"country"`
+	Location         string     `json:"location"`
+	Tags             []string   `json:"tags"`
+	Amenities        []string   `json:"amenities"`
+	MinGuests        int        `json:"min_guests"`
+	PriceMinRub      int64      `json:"price_min_rub"`
+	PriceMaxRub      int64      `json:"price_max_rub"`
+	MaxPricePerSqM   float64    `json:"max_price_per_sq_m,omitempty"`
+	PropertyTypes    []string   `json:"property_types"`
+	CheckIn          string     `json:"check_in"`
+	CheckOut         string     `json:"check_out"`
+	RentalTerms      []string   `json:"rental_terms"`
+	MaxTravelMinutes float64    `json:"max_travel_minutes,omitempty"`
+	TravelMode       string     `json:"travel_mode,omitempty"`
+	Geo              *GeoFilter `json:"geo,omitempty"`
+	AvailableOnly    bool       `json:"available_only,omitempty"`
+}
+
+// GeoFilter echoes the radius search around a point.
+type GeoFilter struct {
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	RadiusKm float64 `json:"radius_km"`
 }
 
 // CatalogMetadata describes pagination.
@@ -87,6 +109,7 @@ func MapCatalog(result domainlistings.SearchResult, params domainlistings.Search
 	items := make([]ListingCard, 0, len(result.Items))
 	for _, listing := range result.Items {
 		card := MapListingCard(listing)
+		applyCommute(&card, listing, normalized.TravelMode, normalized.CommuteFrom)
 		if availability != nil {
 			if report, ok := availability[listing.ID]; ok {
 				card.Availability = report
@@ -95,6 +118,10 @@ func MapCatalog(result domainlistings.SearchResult, params domainlistings.Search
 		items = append(items, card)
 	}
 	page, totalPages := resolvePaging(normalized.Limit, normalized.Offset, result.Total)
+	var geo *GeoFilter
+	if center, radius, ok := normalized.GeoFilter(); ok {
+		geo = &GeoFilter{Lat: center.Lat, Lon: center.Lon, RadiusKm: radius}
+	}
 	rentalTerms := make([]string, 0, len(normalized.RentalTerms))
 	for _, term := range normalized.RentalTerms {
 		rentalTerms = append(rentalTerms, string(term))
@@ -102,19 +129,23 @@ func MapCatalog(result domainlistings.SearchResult, params domainlistings.Search
 	return ListingCatalog{
 		Items: items,
 		Filters: CatalogFilters{
-			City:          normalized.City,
-			Region:        normalized.Region,
-			Country:       normalized.Country,
-			Location:      normalized.LocationQuery,
-			Tags:          append([]string(nil), normalized.Tags...),
-			Amenities:     append([]string(nil), normalized.Amenities...),
-			MinGuests:     normalized.MinGuests,
-			PriceMinRub:   normalized.PriceMinRub,
-			PriceMaxRub:   normalized.PriceMaxRub,
-			PropertyTypes: append([]string(nil), normalized.PropertyTypes...),
-			CheckIn:       formatDate(normalized.CheckIn),
-			CheckOut:      formatDate(normalized.CheckOut),
-			RentalTerms:   rentalTerms,
+			City:             normalized.City,
+			Region:           normalized.Region,
+			Country:          normalized.Country,
+			Location:         normalized.LocationQuery,
+			Tags:             append([]string(nil), normalized.Tags...),
+			Amenities:        append([]string(nil), normalized.Amenities...),
+			MinGuests:        normalized.MinGuests,
+			PriceMinRub:      normalized.PriceMinRub,
+			PriceMaxRub:      normalized.PriceMaxRub,
+			MaxPricePerSqM:   normalized.MaxPricePerSqM,
+			PropertyTypes:    append([]string(nil), normalized.PropertyTypes...),
+			CheckIn:          formatDate(normalized.CheckIn),
+			CheckOut:         formatDate(normalized.CheckOut),
+			RentalTerms:      rentalTerms,
+			MaxTravelMinutes: normalized.MaxTravelMinutes,
+			TravelMode:       normalized.TravelMode,
+			Geo:              geo,
 		},
 		Meta: CatalogMetadata{
 			Total:      result.Total,
@@ -133,7 +164,7 @@ func MapListingCard(listing *domainlistings.Listing) ListingCard {
 	if listing == nil {
 		return ListingCard{}
 	}
-	return ListingCard{
+	card := ListingCard{
 		ID:               string(listing.ID),
 		HostID:           string(listing.Host),
 		Title:            listing.Title,
@@ -154,11 +185,37 @@ func MapListingCard(listing *domainlistings.Listing) ListingCard {
 		Tags:             append([]string(nil), listing.Tags...),
 		Amenities:        append([]string(nil), listing.Amenities...),
 		Highlights:       append([]string(nil), listing.Highlights...),
-		ThumbnailURL:     listing.ThumbnailURL,
+		ThumbnailURL:     MediaURL(listing.ThumbnailURL),
+		Photos:           MediaURLs(listing.Photos),
 		Rating:           listing.Rating,
 		AvailableFrom:    listing.AvailableFrom,
 		State:            string(listing.State),
 	}
+	if price, ok := listing.PricePerSqM(); ok {
+		card.PricePerSqM = math.Round(price*100) / 100
+	}
+	applyCommute(&card, listing, "", nil)
+	ApplyDisplayPrice(&card, domainpricing.DefaultRoundingPolicy().Rule(card.City, "RUB"))
+	return card
+}
+
+// ApplyDisplayPrice fills the rounded marketing price; monthly rents use the
+// coarser display step.
+func ApplyDisplayPrice(card *ListingCard, rule domainpricing.RoundingRule) {
+	card.DisplayPriceRub = rule.Display(card.RateRub, card.PriceUnit == "month")
+	card.DisplayPrice = rule.Format(card.DisplayPriceRub)
+}
+
+// applyCommute fills travel fields with the declared or estimated commute.
+func applyCommute(card *ListingCard, listing *domainlistings.Listing, mode string, from *domainlistings.GeoPoint) {
+	commute, ok := listing.Commute(mode, from)
+	if !ok {
+		card.TravelMinutes, card.TravelMode, card.TravelSource = 0, "", ""
+		return
+	}
+	card.TravelMinutes = commute.Minutes
+	card.TravelMode = commute.Mode
+	card.TravelSource = string(commute.Source)
 }
 
 func formatDate(t time.Time) string {
@@ -0,0 +1,37 @@
+package dto
+
+// ListingMapClusters is the map view of a catalog search: dense grid cells
+// collapse into clusters, sparse ones list their listings as pins.
+type ListingMapClusters struct {
+	Zoom      int                 `json:"zoom"`
+	BBox      MapBBox             `json:"bbox"`
+	Clusters  []ListingMapCluster `json:"clusters"`
+	Pins      []ListingMapPin     `json:"pins"`
+	Total     int                 `json:"total"`
+	Truncated bool                `json:"truncated"`
+}
+
+// MapBBox echoes the viewport in degrees.
+type MapBBox struct {
+	South float64 `json:"south"`
+	West  float64 `json:"west"`
+	North float64 `json:"north"`
+	East  float64 `json:"east"`
+}
+
+// ListingMapCluster summarizes the listings of one grid cell.
+type ListingMapCluster struct {
+	Lat         float64 `json:"lat"`
+	Lon         float64 `json:"lon"`
+	Count       int     `json:"count"`
+	PriceMinRub int64   `json:"price_min_rub"`
+	PriceMaxRub int64   `json:"price_max_rub"`
+	Bounds      MapBBox `json:"bounds"`
+}
+
+// ListingMapPin places a single listing card on the map.
+type ListingMapPin struct {
+	Lat     float64     `json:"lat"`
+	Lon     float64     `json:"lon"`
+	Listing ListingCard `json:"listing"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	domainmarkets "rentme/internal/domain/markets"
+)
+
+// MarketSettings describes the cities open for publishing.
+type MarketSettings struct {
+	Cities            []string   `json:"cities"`
+	Restricted        bool       `json:"restricted"`
+	GrandfatherActive bool       `json:"grandfather_active"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+func MapMarketSettings(settings domainmarkets.Settings) MarketSettings {
+	result := MarketSettings{
+		Cities:            append([]string{}, settings.Cities...),
+		Restricted:        settings.Restricted(),
+		GrandfatherActive: settings.GrandfatherActive,
+	}
+	if !settings.UpdatedAt.IsZero() {
+		updated := settings.UpdatedAt
+		result.UpdatedAt = &updated
+	}
+	return result
+}
@@ -0,0 +1,15 @@
+package dto
+
+// MediaURL turns a stored photo URL into the one clients load it from. The
+// wiring points it at the blob layer when MEDIA_BASE_URL is set; by default
+// URLs are returned as stored.
+var MediaURL = func(raw string) string { return raw }
+
+// MediaURLs applies MediaURL to every URL, keeping order.
+func MediaURLs(raw []string) []string {
+	out := make([]string, 0, len(raw))
+	for _, url := range raw {
+		out = append(out, MediaURL(url))
+	}
+	return out
+}
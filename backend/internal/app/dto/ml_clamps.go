@@ -0,0 +1,53 @@
+package dto
+
+import (
+	domainpricing "rentme/internal/domain/pricing"
+)
+
+// PriceClamp bounds ML price recommendations in whole rubles.
+type PriceClamp struct {
+	MinRub int64 `json:"min_rub"`
+	MaxRub int64 `json:"max_rub"`
+}
+
+// PriceClampTable has the shape of ML_PRICE_CLAMPS, so an export can be
+// imported back or pasted into the configuration.
+type PriceClampTable struct {
+	Defaults map[string]PriceClamp            `json:"defaults"`
+	Cities   map[string]map[string]PriceClamp `json:"cities"`
+}
+
+// CityPriceClamps lists the clamps that apply in one city. Overridden is set
+// when an admin has stored ranges for the city.
+type CityPriceClamps struct {
+	City       string                `json:"city"`
+	Terms      map[string]PriceClamp `json:"terms"`
+	Overridden bool                  `json:"overridden"`
+}
+
+func MapPriceClampTable(table domainpricing.ClampTable) PriceClampTable {
+	result := PriceClampTable{
+		Defaults: mapPriceClampTerms(table.Defaults),
+		Cities:   make(map[string]map[string]PriceClamp, len(table.Cities)),
+	}
+	for city, terms := range table.Cities {
+		result.Cities[city] = mapPriceClampTerms(terms)
+	}
+	return result
+}
+
+func MapCityPriceClamps(city string, terms domainpricing.ClampTerms, overridden bool) CityPriceClamps {
+	return CityPriceClamps{
+		City:       city,
+		Terms:      mapPriceClampTerms(terms),
+		Overridden: overridden,
+	}
+}
+
+func mapPriceClampTerms(terms domainpricing.ClampTerms) map[string]PriceClamp {
+	result := make(map[string]PriceClamp, len(terms))
+	for term, rng := range terms {
+		result[string(term)] = PriceClamp{MinRub: rng.MinRub, MaxRub: rng.MaxRub}
+	}
+	return result
+}
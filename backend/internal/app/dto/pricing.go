@@ -14,11 +14,16 @@ type ListingDateRange struct {
 }
 
 type HostListingPriceSuggestion struct {
-	ListingID             string           `json:"listing_id"`
-	RecommendedPriceRub   int64            `json:"recommended_price_rub"`
-	CurrentPriceRub       int64            `json:"current_price_rub"`
-	PriceLevel            string           `json:"price_level"`
-	PriceGapPercent       float64          `json:"price_gap_percent"`
-	Message               string           `json:"message"`
-	Range                 ListingDateRange `json:"range"`
+	ListingID           string           `json:"listing_id"`
+	RecommendedPriceRub int64            `json:"recommended_price_rub"`
+	RecommendedPrice    string           `json:"recommended_price"`
+	CurrentPriceRub     int64            `json:"current_price_rub"`
+	PriceLevel          string           `json:"price_level"`
+	PriceGapPercent     float64          `json:"price_gap_percent"`
+	Message             string           `json:"message"`
+	PriceUnit           string           `json:"price_unit"`
+	Range               ListingDateRange `json:"range"`
+	// Source is "ml", or "fallback" when the ML service was unavailable and
+	// the suggestion is the rules engine's estimate.
+	Source string `json:"source,omitempty"`
 }
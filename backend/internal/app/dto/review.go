@@ -1,12 +1,14 @@
 package dto
 
 import (
+	"strings"
 	"time"
 
 	domainreviews "rentme/internal/domain/reviews"
 )
 
-// Review represents a public review payload.
+// Review is the full review payload returned to its author, the host and
+// admins.
 type Review struct {
 	ID        string    `json:"id"`
 	BookingID string    `json:"booking_id"`
@@ -15,11 +17,9 @@ type Review struct {
 	Rating    int       `json:"rating"`
 	Text      string    `json:"text,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
-}
 
-type ReviewCollection struct {
-	Items []Review `json:"items"`
-	Total int      `json:"total"`
+	HostReply   string     `json:"host_reply,omitempty"`
+	HostReplyAt *time.Time `json:"host_reply_at,omitempty"`
 }
 
 // MapReview builds a DTO from a domain review.
@@ -35,5 +35,92 @@ func MapReview(review *domainreviews.Review) Review {
 		Rating:    review.Rating,
 		Text:      review.Text,
 		CreatedAt: review.CreatedAt,
+
+		HostReply:   review.HostReplyText,
+		HostReplyAt: review.HostReplyAt,
+	}
+}
+
+// PublicReview is a review as shown on listing pages. The author appears only
+// by display name and stay month, never by user id or contact data.
+type PublicReview struct {
+	ID         string    `json:"id"`
+	ListingID  string    `json:"listing_id"`
+	AuthorName string    `json:"author_name"`
+	StayMonth  string    `json:"stay_month,omitempty"`
+	Rating     int       `json:"rating"`
+	Text       string    `json:"text,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	HostReply   string     `json:"host_reply,omitempty"`
+	HostReplyAt *time.Time `json:"host_reply_at,omitempty"`
+}
+
+type PublicReviewCollection struct {
+	Items []PublicReview `json:"items"`
+	Total int            `json:"total"`
+}
+
+// MapPublicReview builds the public DTO. authorName is the author's full
+// name and is shortened here; stay is the check-in date of the reviewed
+// booking, if known.
+func MapPublicReview(review *domainreviews.Review, authorName string, stay *time.Time) PublicReview {
+	if review == nil {
+		return PublicReview{}
+	}
+	result := PublicReview{
+		ID:         string(review.ID),
+		ListingID:  string(review.ListingID),
+		AuthorName: ReviewerDisplayName(authorName),
+		Rating:     review.Rating,
+		Text:       review.Text,
+		CreatedAt:  review.CreatedAt,
+
+		HostReply:   review.HostReplyText,
+		HostReplyAt: review.HostReplyAt,
+	}
+	if stay != nil && !stay.IsZero() {
+		result.StayMonth = stay.UTC().Format("2006-01")
+	}
+	return result
+}
+
+// ReviewerDisplayName shortens a full name to the first name and the initial
+// of the last one, e.g. "Anna K.". An empty name becomes "Guest".
+func ReviewerDisplayName(name string) string {
+	parts := strings.Fields(name)
+	if len(parts) == 0 {
+		return "Guest"
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	initial := []rune(parts[len(parts)-1])[0]
+	return parts[0] + " " + strings.ToUpper(string(initial)) + "."
+}
+
+// AdminReview is the moderation view of a review, including hidden ones.
+type AdminReview struct {
+	Review
+	Moderated        bool       `json:"moderated"`
+	ModerationReason string     `json:"moderation_reason,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+}
+
+type AdminReviewCollection struct {
+	Items []AdminReview `json:"items"`
+	Total int           `json:"total"`
+}
+
+// MapAdminReview builds the moderation DTO from a domain review.
+func MapAdminReview(review *domainreviews.Review) AdminReview {
+	if review == nil {
+		return AdminReview{}
+	}
+	return AdminReview{
+		Review:           MapReview(review),
+		Moderated:        review.Moderated,
+		ModerationReason: review.ModerationReason,
+		ModeratedAt:      review.ModeratedAt,
 	}
 }
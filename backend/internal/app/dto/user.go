@@ -7,18 +7,34 @@ import (
 )
 
 type UserProfile struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Name      string    `json:"name"`
-	Roles     []string  `json:"roles"`
-	Blocked   bool      `json:"blocked"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                   string            `json:"id"`
+	Email                string            `json:"email"`
+	Name                 string            `json:"name"`
+	Roles                []string          `json:"roles"`
+	Blocked              bool              `json:"blocked"`
+	VerificationRequired bool              `json:"verification_required,omitempty"`
+	Verification         UserVerification  `json:"verification"`
+	Notifications        UserNotifications `json:"notifications"`
+	CreatedAt            time.Time         `json:"created_at"`
+	UpdatedAt            time.Time         `json:"updated_at"`
+}
+
+// UserVerification is the identity verification sub-resource of a user.
+type UserVerification struct {
+	Status     string     `json:"status"`
+	Method     string     `json:"method,omitempty"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
+// UserNotifications are the notification preferences of a user.
+type UserNotifications struct {
+	WeeklyDigest bool `json:"weekly_digest"`
 }
 
 type AuthResponse struct {
-	User  UserProfile `json:"user"`
-	Token string      `json:"token"`
+	User         UserProfile `json:"user"`
+	Token        string      `json:"token"`
+	RefreshToken string      `json:"refresh_token,omitempty"`
 }
 
 func MapUserProfile(user *domainuser.User) UserProfile {
@@ -30,19 +46,38 @@ func MapUserProfile(user *domainuser.User) UserProfile {
 		roles = append(roles, string(role))
 	}
 	return UserProfile{
-		ID:        string(user.ID),
-		Email:     user.Email,
-		Name:      user.Name,
-		Roles:     roles,
-		Blocked:   user.Blocked,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:                   string(user.ID),
+		Email:                user.Email,
+		Name:                 user.Name,
+		Roles:                roles,
+		Blocked:              user.Blocked,
+		VerificationRequired: user.VerificationRequired,
+		Verification:         MapUserVerification(user),
+		Notifications:        UserNotifications{WeeklyDigest: !user.Notifications.WeeklyDigestDisabled},
+		CreatedAt:            user.CreatedAt,
+		UpdatedAt:            user.UpdatedAt,
+	}
+}
+
+func MapUserVerification(user *domainuser.User) UserVerification {
+	result := UserVerification{
+		Status: string(user.VerificationState()),
+	}
+	if user == nil {
+		return result
+	}
+	result.Method = user.Verification.Method
+	if !user.Verification.VerifiedAt.IsZero() {
+		verifiedAt := user.Verification.VerifiedAt
+		result.VerifiedAt = &verifiedAt
 	}
+	return result
 }
 
-func NewAuthResponse(user *domainuser.User, token string) AuthResponse {
+func NewAuthResponse(user *domainuser.User, token, refreshToken string) AuthResponse {
 	return AuthResponse{
-		User:  MapUserProfile(user),
-		Token: token,
+		User:         MapUserProfile(user),
+		Token:        token,
+		RefreshToken: refreshToken,
 	}
 }
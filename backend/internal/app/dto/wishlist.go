@@ -0,0 +1,13 @@
+package dto
+
+// WishlistEntry reports whether a listing is saved for the guest.
+type WishlistEntry struct {
+	ListingID string `json:"listing_id"`
+	Saved     bool   `json:"saved"`
+}
+
+// WishlistCollection lists saved listings as catalog cards.
+type WishlistCollection struct {
+	Items []ListingCard `json:"items"`
+	Total int           `json:"total"`
+}
@@ -0,0 +1,283 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/policies"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainavailability "rentme/internal/domain/availability"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+const (
+	runBookingIntegrityCheckKey    = "admin.integrity.bookings.run"
+	listBookingIntegrityReportsKey = "admin.integrity.bookings.list"
+
+	integrityNotificationRecipient = "admins"
+	integrityNotificationTemplate  = "booking_integrity_findings"
+	integrityScanPageSize          = 60
+	defaultIntegrityReportsLimit   = 10
+)
+
+// IntegrityReportStore keeps the results of booking integrity checks.
+type IntegrityReportStore interface {
+	Save(ctx context.Context, report dto.BookingIntegrityReport) error
+	List(ctx context.Context, listingID string, limit int) ([]dto.BookingIntegrityReport, error)
+}
+
+// BookingIntegrityChecker scans bookings and availability calendars for
+// CONFIRMED/CHECKED_IN bookings that overlap or have no calendar block.
+type BookingIntegrityChecker struct {
+	UoWFactory uow.UoWFactory
+	Reports    IntegrityReportStore
+	Notifier   policies.Notifier
+	Interval   time.Duration
+	Logger     *slog.Logger
+}
+
+// Run checks every listing once per Interval until ctx is cancelled.
+func (c *BookingIntegrityChecker) Run(ctx context.Context) error {
+	if c.Interval <= 0 {
+		return errors.New("admin: integrity check interval must be positive")
+	}
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := c.Check(ctx, ""); err != nil && c.Logger != nil {
+				c.Logger.Error("booking integrity check failed", "error", err)
+			}
+		}
+	}
+}
+
+// Check scans a single listing when listingID is set, otherwise every listing.
+func (c *BookingIntegrityChecker) Check(ctx context.Context, listingID string) (dto.BookingIntegrityReport, error) {
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, c.UoWFactory)
+	if err != nil {
+		return dto.BookingIntegrityReport{}, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	listingID = strings.TrimSpace(listingID)
+	var ids []domainlistings.ListingID
+	if listingID != "" {
+		listing, err := unit.Listings().ByID(execCtx, domainlistings.ListingID(listingID))
+		if err != nil {
+			return dto.BookingIntegrityReport{}, fmt.Errorf("%w: %v", ErrListingNotFound, err)
+		}
+		ids = append(ids, listing.ID)
+	} else {
+		ids, err = allListingIDs(execCtx, unit.Listings())
+		if err != nil {
+			return dto.BookingIntegrityReport{}, err
+		}
+	}
+
+	report := dto.BookingIntegrityReport{
+		ID:          uuid.NewString(),
+		ListingID:   listingID,
+		GeneratedAt: time.Now().UTC(),
+		Findings:    make([]dto.BookingIntegrityFinding, 0),
+	}
+	for _, id := range ids {
+		findings, scanned, err := checkListingBookings(execCtx, unit, id)
+		if err != nil {
+			return dto.BookingIntegrityReport{}, err
+		}
+		report.ListingsScanned++
+		report.BookingsScanned += scanned
+		report.Findings = append(report.Findings, findings...)
+	}
+
+	if c.Reports != nil {
+		if err := c.Reports.Save(ctx, report); err != nil {
+			return dto.BookingIntegrityReport{}, err
+		}
+	}
+	if len(report.Findings) > 0 {
+		if c.Logger != nil {
+			c.Logger.Warn("booking integrity findings detected", "report_id", report.ID, "listing_id", listingID, "findings", len(report.Findings))
+		}
+		if c.Notifier != nil {
+			if err := c.Notifier.Send(ctx, integrityNotificationRecipient, integrityNotificationTemplate, report); err != nil && c.Logger != nil {
+				c.Logger.Warn("booking integrity notification failed", "report_id", report.ID, "error", err)
+			}
+		}
+	} else if c.Logger != nil {
+		c.Logger.Info("booking integrity check passed", "report_id", report.ID, "listing_id", listingID, "listings", report.ListingsScanned, "bookings", report.BookingsScanned)
+	}
+	return report, nil
+}
+
+func allListingIDs(ctx context.Context, repo domainlistings.ListingRepository) ([]domainlistings.ListingID, error) {
+	ids := make([]domainlistings.ListingID, 0)
+	for offset := 0; ; offset += integrityScanPageSize {
+		result, err := repo.Search(ctx, domainlistings.SearchParams{
+			Limit:  integrityScanPageSize,
+			Offset: offset,
+			Sort:   domainlistings.SortByNewest,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, listing := range result.Items {
+			ids = append(ids, listing.ID)
+		}
+		if len(result.Items) == 0 || offset+len(result.Items) >= result.Total {
+			return ids, nil
+		}
+	}
+}
+
+func checkListingBookings(ctx context.Context, unit uow.UnitOfWork, listingID domainlistings.ListingID) ([]dto.BookingIntegrityFinding, int, error) {
+	bookings, err := unit.Booking().ListByListing(ctx, listingID)
+	if err != nil {
+		return nil, 0, err
+	}
+	active := make([]*domainbooking.Booking, 0, len(bookings))
+	for _, booking := range bookings {
+		if booking.State == domainbooking.StateConfirmed || booking.State == domainbooking.StateCheckedIn {
+			active = append(active, booking)
+		}
+	}
+	if len(active) == 0 {
+		return nil, 0, nil
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].Range.CheckIn.Before(active[j].Range.CheckIn)
+	})
+
+	findings := make([]dto.BookingIntegrityFinding, 0)
+	for i := range active {
+		for j := i + 1; j < len(active) && active[j].Range.CheckIn.Before(active[i].Range.CheckOut); j++ {
+			if !active[i].Range.Overlaps(active[j].Range) {
+				continue
+			}
+			from := active[j].Range.CheckIn
+			to := active[i].Range.CheckOut
+			if active[j].Range.CheckOut.Before(to) {
+				to = active[j].Range.CheckOut
+			}
+			findings = append(findings, dto.BookingIntegrityFinding{
+				Kind:       dto.IntegrityFindingOverlap,
+				ListingID:  string(listingID),
+				BookingIDs: []string{string(active[i].ID), string(active[j].ID)},
+				From:       from,
+				To:         to,
+				Detail:     "bookings overlap",
+			})
+		}
+	}
+
+	calendar, err := unit.Availability().Calendar(ctx, listingID)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, booking := range active {
+		if hasBookingBlock(calendar, booking) {
+			continue
+		}
+		findings = append(findings, dto.BookingIntegrityFinding{
+			Kind:       dto.IntegrityFindingMissingBlock,
+			ListingID:  string(listingID),
+			BookingIDs: []string{string(booking.ID)},
+			From:       booking.Range.CheckIn,
+			To:         booking.Range.CheckOut,
+			Detail:     "calendar has no booking block for " + string(booking.State) + " booking",
+		})
+	}
+	return findings, len(active), nil
+}
+
+func hasBookingBlock(calendar *domainavailability.AvailabilityCalendar, booking *domainbooking.Booking) bool {
+	if calendar == nil {
+		return false
+	}
+	for _, block := range calendar.Blocks {
+		if block.Reason != domainavailability.ReasonBooking || block.Reference != string(booking.ID) {
+			continue
+		}
+		if block.Range.Contains(booking.Range) {
+			return true
+		}
+	}
+	return false
+}
+
+type RunBookingIntegrityCheckCommand struct {
+	AdminID   string
+	ListingID string
+}
+
+func (c RunBookingIntegrityCheckCommand) Key() string { return runBookingIntegrityCheckKey }
+
+type RunBookingIntegrityCheckHandler struct {
+	Checker *BookingIntegrityChecker
+}
+
+func (h *RunBookingIntegrityCheckHandler) Handle(ctx context.Context, cmd RunBookingIntegrityCheckCommand) (*dto.BookingIntegrityReport, error) {
+	if strings.TrimSpace(cmd.AdminID) == "" {
+		return nil, errors.New("admin id is required")
+	}
+	if strings.TrimSpace(cmd.ListingID) == "" {
+		return nil, errors.New("listing id is required")
+	}
+	if h.Checker == nil {
+		return nil, errors.New("admin: integrity checker not configured")
+	}
+	report, err := h.Checker.Check(ctx, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+type ListBookingIntegrityReportsQuery struct {
+	ListingID string
+	Limit     int
+}
+
+func (q ListBookingIntegrityReportsQuery) Key() string { return listBookingIntegrityReportsKey }
+
+type ListBookingIntegrityReportsHandler struct {
+	Reports IntegrityReportStore
+}
+
+func (h *ListBookingIntegrityReportsHandler) Handle(ctx context.Context, q ListBookingIntegrityReportsQuery) (dto.BookingIntegrityReportList, error) {
+	if h.Reports == nil {
+		return dto.BookingIntegrityReportList{}, errors.New("admin: integrity report store not configured")
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultIntegrityReportsLimit
+	}
+	reports, err := h.Reports.List(ctx, strings.TrimSpace(q.ListingID), limit)
+	if err != nil {
+		return dto.BookingIntegrityReportList{}, err
+	}
+	return dto.BookingIntegrityReportList{Items: reports}, nil
+}
+
+var (
+	_ commands.Handler[RunBookingIntegrityCheckCommand, *dto.BookingIntegrityReport]    = (*RunBookingIntegrityCheckHandler)(nil)
+	_ queries.Handler[ListBookingIntegrityReportsQuery, dto.BookingIntegrityReportList] = (*ListBookingIntegrityReportsHandler)(nil)
+)
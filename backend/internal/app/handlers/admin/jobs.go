@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"rentme/internal/app/dto"
+	"rentme/internal/app/jobs"
+	"rentme/internal/app/queries"
+)
+
+const (
+	listJobRunsKey      = "admin.jobs.runs.list"
+	defaultJobRunsLimit = 50
+	maxJobRunsLimit     = 200
+)
+
+// ListJobRunsQuery returns recent background job runs, newest first.
+type ListJobRunsQuery struct {
+	Job   string
+	Limit int
+}
+
+func (q ListJobRunsQuery) Key() string { return listJobRunsKey }
+
+type ListJobRunsHandler struct {
+	Store jobs.Store
+}
+
+func (h *ListJobRunsHandler) Handle(ctx context.Context, q ListJobRunsQuery) (dto.JobRunList, error) {
+	if h.Store == nil {
+		return dto.JobRunList{}, errors.New("admin: job store not configured")
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultJobRunsLimit
+	}
+	if limit > maxJobRunsLimit {
+		limit = maxJobRunsLimit
+	}
+	runs, err := h.Store.ListRuns(ctx, strings.TrimSpace(q.Job), limit)
+	if err != nil {
+		return dto.JobRunList{}, err
+	}
+	return dto.MapJobRuns(runs), nil
+}
+
+var _ queries.Handler[ListJobRunsQuery, dto.JobRunList] = (*ListJobRunsHandler)(nil)
@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/outbox"
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+const (
+	adminSuspendListingKey    = "admin.listings.suspend"
+	adminReactivateListingKey = "admin.listings.reactivate"
+
+	defaultSuspendReason = "policy-violation"
+)
+
+var (
+	ErrListingNotFound     = errors.New("admin: listing not found")
+	ErrListingNotSuspended = errors.New("admin: listing is not suspended")
+)
+
+type AdminSuspendListingCommand struct {
+	AdminID   string
+	ListingID string
+	Reason    string
+}
+
+func (c AdminSuspendListingCommand) Key() string { return adminSuspendListingKey }
+
+type AdminSuspendListingHandler struct {
+	Outbox  outbox.Outbox
+	Encoder outbox.EventEncoder
+	Logger  *slog.Logger
+}
+
+func (h *AdminSuspendListingHandler) Handle(ctx context.Context, cmd AdminSuspendListingCommand) (*dto.HostListingDetail, error) {
+	unit, listing, err := loadListing(ctx, cmd.AdminID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(cmd.Reason)
+	if reason == "" {
+		reason = defaultSuspendReason
+	}
+	if err := listing.Suspend(time.Now(), "admin:"+reason); err != nil {
+		return nil, err
+	}
+	if err := saveListing(ctx, unit, listing, h.Outbox, h.Encoder); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("listing suspended by admin", "listing_id", listing.ID, "host_id", listing.Host, "admin_id", cmd.AdminID, "reason", reason)
+	}
+	result := dto.MapHostListingDetail(listing)
+	return &result, nil
+}
+
+type AdminReactivateListingCommand struct {
+	AdminID   string
+	ListingID string
+}
+
+func (c AdminReactivateListingCommand) Key() string { return adminReactivateListingKey }
+
+type AdminReactivateListingHandler struct {
+	Outbox  outbox.Outbox
+	Encoder outbox.EventEncoder
+	Logger  *slog.Logger
+}
+
+func (h *AdminReactivateListingHandler) Handle(ctx context.Context, cmd AdminReactivateListingCommand) (*dto.HostListingDetail, error) {
+	unit, listing, err := loadListing(ctx, cmd.AdminID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.State != domainlistings.ListingSuspended {
+		return nil, ErrListingNotSuspended
+	}
+	if err := listing.Activate(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := saveListing(ctx, unit, listing, h.Outbox, h.Encoder); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("listing reactivated by admin", "listing_id", listing.ID, "host_id", listing.Host, "admin_id", cmd.AdminID)
+	}
+	result := dto.MapHostListingDetail(listing)
+	return &result, nil
+}
+
+func loadListing(ctx context.Context, adminID, listingID string) (uow.UnitOfWork, *domainlistings.Listing, error) {
+	if strings.TrimSpace(adminID) == "" {
+		return nil, nil, errors.New("admin id is required")
+	}
+	if strings.TrimSpace(listingID) == "" {
+		return nil, nil, errors.New("listing id is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, nil, uow.ErrUnitOfWorkMissing
+	}
+	listing, err := unit.Listings().ByID(ctx, domainlistings.ListingID(strings.TrimSpace(listingID)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrListingNotFound, err)
+	}
+	return unit, listing, nil
+}
+
+func saveListing(ctx context.Context, unit uow.UnitOfWork, listing *domainlistings.Listing, box outbox.Outbox, encoder outbox.EventEncoder) error {
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return err
+	}
+	pending := listing.PendingEvents()
+	listing.ClearEvents()
+	return outbox.RecordDomainEvents(ctx, box, encoder, pending)
+}
+
+var (
+	_ commands.Handler[AdminSuspendListingCommand, *dto.HostListingDetail]    = (*AdminSuspendListingHandler)(nil)
+	_ commands.Handler[AdminReactivateListingCommand, *dto.HostListingDetail] = (*AdminReactivateListingHandler)(nil)
+)
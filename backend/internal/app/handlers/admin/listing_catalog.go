@@ -0,0 +1,123 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+const (
+	adminListListingsKey = "admin.listings.list"
+	adminGetListingKey   = "admin.listings.get"
+
+	defaultAdminListingsLimit = 20
+)
+
+// AdminListListingsQuery browses listings of every host in any state. Status
+// takes the host dashboard labels (draft, published, archived); empty
+// filters match everything.
+type AdminListListingsQuery struct {
+	Status string
+	City   string
+	HostID string
+	Limit  int
+	Offset int
+}
+
+func (q AdminListListingsQuery) Key() string { return adminListListingsKey }
+
+type AdminListListingsHandler struct {
+	UoWFactory uow.UoWFactory
+	Logger     *slog.Logger
+}
+
+func (h *AdminListListingsHandler) Handle(ctx context.Context, q AdminListListingsQuery) (dto.HostListingCatalog, error) {
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return dto.HostListingCatalog{}, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultAdminListingsLimit
+	}
+	offset := q.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	params := domainlistings.SearchParams{
+		Host:       domainlistings.HostID(strings.TrimSpace(q.HostID)),
+		City:       strings.TrimSpace(q.City),
+		States:     dto.ListingStatesForStatus(q.Status),
+		Sort:       domainlistings.SortByUpdated,
+		Limit:      limit,
+		Offset:     offset,
+		OnlyActive: false,
+	}
+	result, err := unit.Listings().Search(execCtx, params)
+	if err != nil {
+		return dto.HostListingCatalog{}, err
+	}
+
+	items := make([]dto.HostListingSummary, 0, len(result.Items))
+	for _, listing := range result.Items {
+		items = append(items, dto.MapHostListingSummary(listing))
+	}
+	if h.Logger != nil {
+		h.Logger.Debug("admin listings queried", "host_id", params.Host, "city", params.City, "status", q.Status, "count", len(items))
+	}
+	return dto.HostListingCatalog{
+		Items: items,
+		Meta: dto.HostListingCatalogMeta{
+			Total:  result.Total,
+			Limit:  limit,
+			Offset: offset,
+		},
+	}, nil
+}
+
+// AdminGetListingQuery loads any listing the way its host sees it.
+type AdminGetListingQuery struct {
+	ListingID string
+}
+
+func (q AdminGetListingQuery) Key() string { return adminGetListingKey }
+
+type AdminGetListingHandler struct {
+	UoWFactory uow.UoWFactory
+}
+
+func (h *AdminGetListingHandler) Handle(ctx context.Context, q AdminGetListingQuery) (dto.HostListingDetail, error) {
+	listingID := strings.TrimSpace(q.ListingID)
+	if listingID == "" {
+		return dto.HostListingDetail{}, errors.New("listing id is required")
+	}
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return dto.HostListingDetail{}, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	listing, err := unit.Listings().ByID(execCtx, domainlistings.ListingID(listingID))
+	if err != nil {
+		return dto.HostListingDetail{}, fmt.Errorf("%w: %v", ErrListingNotFound, err)
+	}
+	return dto.MapHostListingDetail(listing), nil
+}
+
+var (
+	_ queries.Handler[AdminListListingsQuery, dto.HostListingCatalog] = (*AdminListListingsHandler)(nil)
+	_ queries.Handler[AdminGetListingQuery, dto.HostListingDetail]    = (*AdminGetListingHandler)(nil)
+)
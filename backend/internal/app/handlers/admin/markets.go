@@ -0,0 +1,110 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/outbox"
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+	domainmarkets "rentme/internal/domain/markets"
+)
+
+const (
+	adminUpdateMarketsKey = "admin.markets.update"
+
+	marketClosedReason = "admin:market-closed"
+	marketScanPageSize = 60
+)
+
+type AdminUpdateMarketsCommand struct {
+	AdminID           string
+	Cities            []string
+	GrandfatherActive bool
+}
+
+func (c AdminUpdateMarketsCommand) Key() string { return adminUpdateMarketsKey }
+
+// AdminUpdateMarketsHandler replaces the supported cities. Without the
+// grandfather flag, active listings outside the new markets are suspended.
+type AdminUpdateMarketsHandler struct {
+	Markets domainmarkets.Repository
+	Outbox  outbox.Outbox
+	Encoder outbox.EventEncoder
+	Logger  *slog.Logger
+}
+
+func (h *AdminUpdateMarketsHandler) Handle(ctx context.Context, cmd AdminUpdateMarketsCommand) (*dto.MarketSettings, error) {
+	adminID := strings.TrimSpace(cmd.AdminID)
+	if adminID == "" {
+		return nil, errors.New("admin id is required")
+	}
+	if h.Markets == nil {
+		return nil, errors.New("admin: markets store not configured")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, uow.ErrUnitOfWorkMissing
+	}
+
+	now := time.Now().UTC()
+	settings := domainmarkets.NewSettings(cmd.Cities, cmd.GrandfatherActive)
+	settings.UpdatedBy = adminID
+	settings.UpdatedAt = now
+
+	suspended := 0
+	if settings.Restricted() && !settings.GrandfatherActive {
+		listings, err := activeListingsOutside(ctx, unit.Listings(), settings)
+		if err != nil {
+			return nil, err
+		}
+		for _, listing := range listings {
+			if err := listing.Suspend(now, marketClosedReason); err != nil {
+				return nil, err
+			}
+			if err := saveListing(ctx, unit, listing, h.Outbox, h.Encoder); err != nil {
+				return nil, err
+			}
+			suspended++
+		}
+	}
+	if err := h.Markets.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("markets updated", "admin_id", adminID, "cities", settings.Cities, "grandfather_active", settings.GrandfatherActive, "suspended", suspended)
+	}
+	result := dto.MapMarketSettings(settings)
+	return &result, nil
+}
+
+func activeListingsOutside(ctx context.Context, repo domainlistings.ListingRepository, settings domainmarkets.Settings) ([]*domainlistings.Listing, error) {
+	outside := make([]*domainlistings.Listing, 0)
+	for offset := 0; ; offset += marketScanPageSize {
+		result, err := repo.Search(ctx, domainlistings.SearchParams{
+			OnlyActive: true,
+			Limit:      marketScanPageSize,
+			Offset:     offset,
+			Sort:       domainlistings.SortByNewest,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, listing := range result.Items {
+			if !settings.Allows(listing.Address.City) {
+				outside = append(outside, listing)
+			}
+		}
+		if len(result.Items) == 0 || offset+len(result.Items) >= result.Total {
+			return outside, nil
+		}
+	}
+}
+
+var _ commands.Handler[AdminUpdateMarketsCommand, *dto.MarketSettings] = (*AdminUpdateMarketsHandler)(nil)
@@ -0,0 +1,165 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/queries"
+	domainpricing "rentme/internal/domain/pricing"
+)
+
+const (
+	exportPriceClampsKey      = "admin.ml.clamps.export"
+	cityPriceClampsKey        = "admin.ml.clamps.city"
+	adminUpdateCityClampsKey  = "admin.ml.clamps.city.update"
+	adminImportPriceClampsKey = "admin.ml.clamps.import"
+)
+
+var errClampStoreMissing = errors.New("admin: price clamp store not configured")
+
+// ExportPriceClampsQuery returns the full clamp table in effect: stored
+// ranges laid over the configured ones.
+type ExportPriceClampsQuery struct{}
+
+func (q ExportPriceClampsQuery) Key() string { return exportPriceClampsKey }
+
+// CityPriceClampsQuery returns the clamps that apply in one city.
+type CityPriceClampsQuery struct {
+	City string
+}
+
+func (q CityPriceClampsQuery) Key() string { return cityPriceClampsKey }
+
+// ExportPriceClampsHandler lays the store over Configured, the table from
+// ML_PRICE_CLAMPS or the built-in defaults.
+type ExportPriceClampsHandler struct {
+	Store      domainpricing.ClampStore
+	Configured domainpricing.ClampTable
+}
+
+func (h *ExportPriceClampsHandler) Handle(ctx context.Context, q ExportPriceClampsQuery) (dto.PriceClampTable, error) {
+	if h.Store == nil {
+		return dto.PriceClampTable{}, errClampStoreMissing
+	}
+	stored, err := h.Store.Table(ctx)
+	if err != nil {
+		return dto.PriceClampTable{}, err
+	}
+	return dto.MapPriceClampTable(stored.Over(h.Configured)), nil
+}
+
+type CityPriceClampsHandler struct {
+	Store      domainpricing.ClampStore
+	Configured domainpricing.ClampTable
+}
+
+func (h *CityPriceClampsHandler) Handle(ctx context.Context, q CityPriceClampsQuery) (dto.CityPriceClamps, error) {
+	city := domainpricing.NormalizeCity(q.City)
+	if city == "" {
+		return dto.CityPriceClamps{}, domainpricing.ErrClampCityRequired
+	}
+	if h.Store == nil {
+		return dto.CityPriceClamps{}, errClampStoreMissing
+	}
+	stored, err := h.Store.Table(ctx)
+	if err != nil {
+		return dto.CityPriceClamps{}, err
+	}
+	_, overridden := stored.Cities[city]
+	return dto.MapCityPriceClamps(city, stored.Over(h.Configured).City(city), overridden), nil
+}
+
+// AdminUpdateCityClampsCommand replaces the stored ranges of one city. Terms
+// left out fall back to the configured ranges; no terms clears the override.
+type AdminUpdateCityClampsCommand struct {
+	AdminID string
+	City    string
+	Terms   domainpricing.ClampTerms
+}
+
+func (c AdminUpdateCityClampsCommand) Key() string { return adminUpdateCityClampsKey }
+
+type AdminUpdateCityClampsHandler struct {
+	Store      domainpricing.ClampStore
+	Configured domainpricing.ClampTable
+	Logger     *slog.Logger
+}
+
+func (h *AdminUpdateCityClampsHandler) Handle(ctx context.Context, cmd AdminUpdateCityClampsCommand) (*dto.CityPriceClamps, error) {
+	adminID := strings.TrimSpace(cmd.AdminID)
+	if adminID == "" {
+		return nil, errors.New("admin id is required")
+	}
+	city := domainpricing.NormalizeCity(cmd.City)
+	if city == "" {
+		return nil, domainpricing.ErrClampCityRequired
+	}
+	if err := cmd.Terms.Validate(); err != nil {
+		return nil, err
+	}
+	if h.Store == nil {
+		return nil, errClampStoreMissing
+	}
+	if err := h.Store.SaveCity(ctx, city, cmd.Terms); err != nil {
+		return nil, err
+	}
+	if h.Logger != nil {
+		h.Logger.Info("ml price clamps updated", "admin_id", adminID, "city", city, "terms", len(cmd.Terms))
+	}
+	result, err := (&CityPriceClampsHandler{Store: h.Store, Configured: h.Configured}).Handle(ctx, CityPriceClampsQuery{City: city})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AdminImportPriceClampsCommand replaces every stored range with the table.
+// An empty table drops all overrides.
+type AdminImportPriceClampsCommand struct {
+	AdminID string
+	Table   domainpricing.ClampTable
+}
+
+func (c AdminImportPriceClampsCommand) Key() string { return adminImportPriceClampsKey }
+
+type AdminImportPriceClampsHandler struct {
+	Store      domainpricing.ClampStore
+	Configured domainpricing.ClampTable
+	Logger     *slog.Logger
+}
+
+func (h *AdminImportPriceClampsHandler) Handle(ctx context.Context, cmd AdminImportPriceClampsCommand) (*dto.PriceClampTable, error) {
+	adminID := strings.TrimSpace(cmd.AdminID)
+	if adminID == "" {
+		return nil, errors.New("admin id is required")
+	}
+	if err := cmd.Table.Validate(); err != nil {
+		return nil, err
+	}
+	if h.Store == nil {
+		return nil, errClampStoreMissing
+	}
+	table := cmd.Table.Normalized()
+	if err := h.Store.Replace(ctx, table); err != nil {
+		return nil, err
+	}
+	if h.Logger != nil {
+		h.Logger.Info("ml price clamps imported", "admin_id", adminID, "defaults", len(table.Defaults), "cities", len(table.Cities))
+	}
+	result, err := (&ExportPriceClampsHandler{Store: h.Store, Configured: h.Configured}).Handle(ctx, ExportPriceClampsQuery{})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+var (
+	_ queries.Handler[ExportPriceClampsQuery, dto.PriceClampTable]          = (*ExportPriceClampsHandler)(nil)
+	_ queries.Handler[CityPriceClampsQuery, dto.CityPriceClamps]            = (*CityPriceClampsHandler)(nil)
+	_ commands.Handler[AdminUpdateCityClampsCommand, *dto.CityPriceClamps]  = (*AdminUpdateCityClampsHandler)(nil)
+	_ commands.Handler[AdminImportPriceClampsCommand, *dto.PriceClampTable] = (*AdminImportPriceClampsHandler)(nil)
+)
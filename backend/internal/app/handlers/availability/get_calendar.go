@@ -8,10 +8,18 @@ import (
 	"rentme/internal/app/queries"
 	"rentme/internal/app/uow"
 	domainlistings "rentme/internal/domain/listings"
+	domainrange "rentme/internal/domain/shared/daterange"
 )
 
 const getCalendarKey = "availability.calendar"
 
+const (
+	// DefaultCalendarWindowDays is used when the query has no upper bound.
+	DefaultCalendarWindowDays = 45
+	// MaxCalendarWindowDays caps the day-level response.
+	MaxCalendarWindowDays = 365
+)
+
 type GetCalendarQuery struct {
 	ListingID string
 	From      time.Time
@@ -22,6 +30,7 @@ func (q GetCalendarQuery) Key() string { return getCalendarKey }
 
 type GetCalendarHandler struct {
 	UoWFactory uow.UoWFactory
+	Now        func() time.Time
 }
 
 func (h *GetCalendarHandler) Handle(ctx context.Context, q GetCalendarQuery) (dto.Calendar, error) {
@@ -44,7 +53,45 @@ func (h *GetCalendarHandler) Handle(ctx context.Context, q GetCalendarQuery) (dt
 		return dto.Calendar{}, err
 	}
 
-	return dto.MapCalendar(calendar), nil
+	from, to := h.window(q.From, q.To)
+	// Without a requested range v1 clients expect every block, as before the
+	// day view existed; only the days follow the default window.
+	result := dto.MapCalendar(calendar)
+	if !q.From.IsZero() || !q.To.IsZero() {
+		result = dto.MapCalendarWithin(calendar, from, to)
+	}
+	result.ListingID = q.ListingID
+	result.From = from
+	result.To = to
+	result.Days = dto.MapCalendarDays(calendar, from, to)
+	return result, nil
+}
+
+// window snaps the bounds to their calendar day, matching the half-open daterange
+// nights, and clamps the span to MaxCalendarWindowDays.
+func (h *GetCalendarHandler) window(from, to time.Time) (time.Time, time.Time) {
+	if from.IsZero() {
+		from = h.now()
+	}
+	from = domainrange.DateOf(from)
+	if to.IsZero() {
+		to = from.AddDate(0, 0, DefaultCalendarWindowDays)
+	}
+	to = domainrange.DateOf(to)
+	if !to.After(from) {
+		to = from.AddDate(0, 0, DefaultCalendarWindowDays)
+	}
+	if limit := from.AddDate(0, 0, MaxCalendarWindowDays); to.After(limit) {
+		to = limit
+	}
+	return from, to
+}
+
+func (h *GetCalendarHandler) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
 }
 
 var _ queries.Handler[GetCalendarQuery, dto.Calendar] = (*GetCalendarHandler)(nil)
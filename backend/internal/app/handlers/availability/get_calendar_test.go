@@ -0,0 +1,111 @@
+package availability
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"rentme/internal/app/dto"
+	"rentme/internal/app/uow"
+	domainavailability "rentme/internal/domain/availability"
+	domainlistings "rentme/internal/domain/listings"
+	domainrange "rentme/internal/domain/shared/daterange"
+)
+
+type stubUnit struct {
+	uow.UnitOfWork
+	availability domainavailability.Repository
+}
+
+func (u stubUnit) Availability() domainavailability.Repository { return u.availability }
+func (u stubUnit) Rollback(context.Context) error              { return nil }
+
+type stubAvailability struct {
+	domainavailability.Repository
+	calendar *domainavailability.AvailabilityCalendar
+}
+
+func (r stubAvailability) Calendar(context.Context, domainlistings.ListingID) (*domainavailability.AvailabilityCalendar, error) {
+	return r.calendar, nil
+}
+
+func day(d int) time.Time { return domainrange.Date(2026, time.March, d) }
+
+// edgeCalendar has a booking over the window start, a host block over the
+// window end and a booking well outside the window.
+func edgeCalendar() *domainavailability.AvailabilityCalendar {
+	cal := domainavailability.NewCalendar("listing-1", 0)
+	cal.Blocks = []domainavailability.Block{
+		{Range: domainrange.DateRange{CheckIn: day(8), CheckOut: day(12)}, Reason: domainavailability.ReasonBooking, Reference: "booking-1"},
+		{Range: domainrange.DateRange{CheckIn: day(18), CheckOut: day(23)}, Reason: domainavailability.ReasonHostBlock, Reference: "block-1"},
+		{Range: domainrange.DateRange{CheckIn: day(1), CheckOut: day(3)}, Reason: domainavailability.ReasonBooking, Reference: "booking-0"},
+	}
+	return cal
+}
+
+func handleCalendar(t *testing.T, q GetCalendarQuery, now time.Time) dto.Calendar {
+	t.Helper()
+	ctx := uow.ContextWithUnitOfWork(context.Background(), stubUnit{availability: stubAvailability{calendar: edgeCalendar()}})
+	handler := &GetCalendarHandler{Now: func() time.Time { return now }}
+	result, err := handler.Handle(ctx, q)
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	return result
+}
+
+func TestCalendarBlocksSpanningWindowEdges(t *testing.T) {
+	result := handleCalendar(t, GetCalendarQuery{ListingID: "listing-1", From: day(10), To: day(20)}, day(1))
+
+	if len(result.Days) != 10 {
+		t.Fatalf("days = %d, want 10", len(result.Days))
+	}
+	want := map[int]string{10: "BOOKING", 11: "BOOKING", 18: "HOST_BLOCK", 19: "HOST_BLOCK"}
+	for _, entry := range result.Days {
+		d := entry.Date.Day()
+		reason, blocked := want[d]
+		if entry.Available == blocked || entry.Reason != reason {
+			t.Errorf("March %d: available=%t reason=%q, want available=%t reason=%q", d, entry.Available, entry.Reason, !blocked, reason)
+		}
+	}
+	if len(result.Blocks) != 2 {
+		t.Fatalf("blocks = %+v, want the two overlapping the window", result.Blocks)
+	}
+	for _, block := range result.Blocks {
+		if block.Reference == "booking-0" {
+			t.Fatalf("block outside the window returned: %+v", block)
+		}
+	}
+}
+
+func TestCalendarWithoutRangeKeepsEveryBlock(t *testing.T) {
+	result := handleCalendar(t, GetCalendarQuery{ListingID: "listing-1"}, day(10).Add(15*time.Hour))
+
+	if len(result.Blocks) != 3 {
+		t.Fatalf("blocks = %d, want all 3 without a requested range", len(result.Blocks))
+	}
+	if !result.From.Equal(day(10)) || !result.To.Equal(day(10).AddDate(0, 0, DefaultCalendarWindowDays)) {
+		t.Fatalf("window = [%s, %s), want the default window from March 10", result.From, result.To)
+	}
+	if len(result.Days) != DefaultCalendarWindowDays {
+		t.Fatalf("days = %d, want %d", len(result.Days), DefaultCalendarWindowDays)
+	}
+}
+
+func TestCalendarWindowIsClamped(t *testing.T) {
+	result := handleCalendar(t, GetCalendarQuery{ListingID: "listing-1", From: day(1), To: day(1).AddDate(2, 0, 0)}, day(1))
+
+	if len(result.Days) != MaxCalendarWindowDays {
+		t.Fatalf("days = %d, want %d", len(result.Days), MaxCalendarWindowDays)
+	}
+}
+
+func TestCalendarWindowKeepsTheCalendarDay(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*3600)
+	from := time.Date(2026, time.March, 10, 0, 0, 0, 0, moscow)
+	result := handleCalendar(t, GetCalendarQuery{ListingID: "listing-1", From: from, To: from.AddDate(0, 0, 2)}, day(1))
+
+	if !result.From.Equal(day(10)) || !result.To.Equal(day(12)) {
+		t.Fatalf("window = [%s, %s), want [March 10, March 12) UTC", result.From, result.To)
+	}
+}
@@ -0,0 +1,162 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+const (
+	adminListBookingsKey        = "admin.bookings.list"
+	adminForceConfirmBookingKey = "admin.bookings.force_confirm"
+)
+
+// AdminListBookingsQuery searches bookings of every guest and host. Empty
+// filters match everything; Status takes a booking state or ALL.
+type AdminListBookingsQuery struct {
+	GuestID   string
+	HostID    string
+	ListingID string
+	Status    string
+	Limit     int
+	Offset    int
+}
+
+func (q AdminListBookingsQuery) Key() string { return adminListBookingsKey }
+
+type AdminListBookingsHandler struct {
+	UoWFactory uow.UoWFactory
+	Logger     *slog.Logger
+}
+
+func (h *AdminListBookingsHandler) Handle(ctx context.Context, q AdminListBookingsQuery) (dto.HostBookingCollection, error) {
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return dto.HostBookingCollection{}, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultHostBookingsLimit
+	}
+	if limit > maxHostBookingsLimit {
+		limit = maxHostBookingsLimit
+	}
+	offset := max(q.Offset, 0)
+	filter := domainbooking.BookingFilter{
+		GuestID: strings.TrimSpace(q.GuestID),
+		Limit:   limit,
+		Offset:  offset,
+	}
+	if status := strings.ToUpper(strings.TrimSpace(q.Status)); status != "" && status != allStatusesFilterValue {
+		filter.State = domainbooking.BookingState(status)
+	}
+	listingID := domainlistings.ListingID(strings.TrimSpace(q.ListingID))
+	if hostID := strings.TrimSpace(q.HostID); hostID != "" {
+		owned, err := hostListingsByID(execCtx, unit, domainlistings.HostID(hostID))
+		if err != nil {
+			return dto.HostBookingCollection{}, err
+		}
+		filter.ListingIDs = make([]domainlistings.ListingID, 0, len(owned))
+		for id := range owned {
+			if listingID == "" || id == listingID {
+				filter.ListingIDs = append(filter.ListingIDs, id)
+			}
+		}
+	} else if listingID != "" {
+		filter.ListingIDs = []domainlistings.ListingID{listingID}
+	}
+
+	bookings, total, err := unit.Booking().List(execCtx, filter)
+	if err != nil {
+		return dto.HostBookingCollection{}, err
+	}
+	listings := make(map[domainlistings.ListingID]*domainlistings.Listing)
+	items := make([]dto.HostBookingSummary, 0, len(bookings))
+	for _, booking := range bookings {
+		listing, seen := listings[booking.ListingID]
+		if !seen {
+			listing, err = unit.Listings().ByID(execCtx, booking.ListingID)
+			if err != nil && h.Logger != nil {
+				h.Logger.Warn("load listing for admin bookings failed", "booking_id", booking.ID, "listing_id", booking.ListingID, "error", err)
+			}
+			listings[booking.ListingID] = listing
+		}
+		items = append(items, dto.MapHostBookingSummary(booking, listing))
+	}
+
+	if h.Logger != nil {
+		h.Logger.Debug("admin bookings listed", "guest_id", filter.GuestID, "host_id", q.HostID, "listing_id", listingID, "status", filter.State, "count", len(items), "total", total)
+	}
+	return dto.HostBookingCollection{
+		Items: items,
+		Meta: dto.HostBookingCollectionMeta{
+			Total:  total,
+			Limit:  limit,
+			Offset: offset,
+		},
+	}, nil
+}
+
+// AdminForceConfirmBookingCommand confirms a booking for support without the
+// host ownership check.
+type AdminForceConfirmBookingCommand struct {
+	AdminID   string
+	BookingID string
+}
+
+func (c AdminForceConfirmBookingCommand) Key() string { return adminForceConfirmBookingKey }
+
+type AdminForceConfirmBookingHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *AdminForceConfirmBookingHandler) Handle(ctx context.Context, cmd AdminForceConfirmBookingCommand) (*HostBookingActionResult, error) {
+	adminID := strings.TrimSpace(cmd.AdminID)
+	if adminID == "" {
+		return nil, errors.New("admin id is required")
+	}
+	bookingID := strings.TrimSpace(cmd.BookingID)
+	if bookingID == "" {
+		return nil, errors.New("booking id is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, uow.ErrUnitOfWorkMissing
+	}
+
+	booking, err := unit.Booking().ByID(ctx, domainbooking.BookingID(bookingID))
+	if err != nil {
+		return nil, err
+	}
+	if err := booking.Confirm(demoPaymentHoldID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := unit.Booking().Save(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("booking force-confirmed by admin", "booking_id", booking.ID, "admin_id", adminID, "listing_id", booking.ListingID)
+	}
+
+	return &HostBookingActionResult{BookingID: string(booking.ID), Status: string(booking.State)}, nil
+}
+
+var (
+	_ queries.Handler[AdminListBookingsQuery, dto.HostBookingCollection]          = (*AdminListBookingsHandler)(nil)
+	_ commands.Handler[AdminForceConfirmBookingCommand, *HostBookingActionResult] = (*AdminForceConfirmBookingHandler)(nil)
+)
@@ -0,0 +1,140 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/outbox"
+	"rentme/internal/app/policies"
+	"rentme/internal/app/uow"
+	domainbooking "rentme/internal/domain/booking"
+	"rentme/internal/domain/shared/money"
+)
+
+const (
+	adminCancelBookingKey = "admin.bookings.cancel"
+
+	adminCancelledTemplate = "booking_cancelled_by_support"
+)
+
+// AdminCancelBookingCommand cancels a booking on behalf of the guest, the host
+// or the platform. RefundRub, when set, overrides the policy refund.
+type AdminCancelBookingCommand struct {
+	AdminID   string
+	BookingID string
+	Initiator string
+	RefundRub *int64
+	Reason    string
+}
+
+func (c AdminCancelBookingCommand) Key() string { return adminCancelBookingKey }
+
+type AdminCancelBookingHandler struct {
+	Outbox   outbox.Outbox
+	Encoder  outbox.EventEncoder
+	Notifier policies.Notifier
+	Logger   *slog.Logger
+}
+
+func (h *AdminCancelBookingHandler) Handle(ctx context.Context, cmd AdminCancelBookingCommand) (*dto.AdminBookingCancellation, error) {
+	adminID := strings.TrimSpace(cmd.AdminID)
+	if adminID == "" {
+		return nil, errors.New("admin id is required")
+	}
+	bookingID := strings.TrimSpace(cmd.BookingID)
+	if bookingID == "" {
+		return nil, errors.New("booking id is required")
+	}
+	reason := strings.TrimSpace(cmd.Reason)
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+	initiator := domainbooking.CancellationInitiator(strings.ToLower(strings.TrimSpace(cmd.Initiator)))
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, uow.ErrUnitOfWorkMissing
+	}
+
+	booking, err := unit.Booking().ByID(ctx, domainbooking.BookingID(bookingID))
+	if err != nil {
+		return nil, err
+	}
+	listing, err := unit.Listings().ByID(ctx, booking.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	var override *money.Money
+	if cmd.RefundRub != nil {
+		override = &money.Money{Amount: *cmd.RefundRub, Currency: booking.Price.Total.Currency}
+	}
+
+	now := time.Now().UTC()
+	refund, penalty, err := booking.CancelOnBehalf(initiator, override, "admin:"+reason, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := unit.Booking().Save(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	pending := booking.PendingEvents()
+	booking.ClearEvents()
+	released, err := releaseNights(ctx, unit, booking, now)
+	if err != nil {
+		return nil, err
+	}
+	pending = append(pending, released...)
+	if err := outbox.RecordDomainEvents(ctx, h.Outbox, h.encoder(), pending); err != nil {
+		return nil, err
+	}
+
+	result := &dto.AdminBookingCancellation{
+		BookingID:      string(booking.ID),
+		Status:         string(booking.State),
+		Initiator:      string(initiator),
+		Refund:         dto.MapMoney(refund),
+		Penalty:        dto.MapMoney(penalty),
+		RefundOverride: override != nil,
+		Reason:         reason,
+	}
+	if h.Logger != nil {
+		h.Logger.Info("booking cancelled by admin",
+			"booking_id", booking.ID,
+			"listing_id", booking.ListingID,
+			"admin_id", adminID,
+			"initiator", initiator,
+			"refund", refund.Amount,
+			"penalty", penalty.Amount,
+			"refund_override", override != nil,
+			"reason", reason,
+		)
+	}
+	h.notify(ctx, booking.GuestID, result)
+	h.notify(ctx, string(listing.Host), result)
+	return result, nil
+}
+
+// notify tells one party about the cancellation; a failed notification does
+// not undo it.
+func (h *AdminCancelBookingHandler) notify(ctx context.Context, to string, result *dto.AdminBookingCancellation) {
+	if h.Notifier == nil || to == "" {
+		return
+	}
+	if err := h.Notifier.Send(ctx, to, adminCancelledTemplate, result); err != nil && h.Logger != nil {
+		h.Logger.Warn("notify booking cancellation failed", "booking_id", result.BookingID, "to", to, "error", err)
+	}
+}
+
+func (h *AdminCancelBookingHandler) encoder() outbox.EventEncoder {
+	if h.Encoder != nil {
+		return h.Encoder
+	}
+	return outbox.JSONEventEncoder{}
+}
+
+var _ commands.Handler[AdminCancelBookingCommand, *dto.AdminBookingCancellation] = (*AdminCancelBookingHandler)(nil)
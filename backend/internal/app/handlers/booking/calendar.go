@@ -0,0 +1,161 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"rentme/internal/app/uow"
+	domainavailability "rentme/internal/domain/availability"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/domain/shared/events"
+)
+
+const unitGroupPageSize = 100
+
+// reserveNights holds the booking range on its listing and mirrors it as a
+// host block on the other listings of the unit group, all in the caller's
+// unit of work. A sibling that is already taken fails the whole booking;
+// siblings are checked first so a rejected request leaves no calendar changed.
+func reserveNights(ctx context.Context, unit uow.UnitOfWork, listing *domainlistings.Listing, booking *domainbooking.Booking, now time.Time) ([]events.DomainEvent, error) {
+	mirrors, err := unitGroupCalendars(ctx, unit, listing)
+	if err != nil {
+		return nil, err
+	}
+	for _, mirror := range mirrors {
+		if err := checkDatesAvailable(mirror, booking.Range); err != nil {
+			return nil, err
+		}
+	}
+	calendar, err := unit.Availability().Calendar(ctx, listing.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := calendar.Reserve(booking.Range, string(booking.ID), now); err != nil {
+		return nil, err
+	}
+	if err := unit.Availability().Save(ctx, calendar); err != nil {
+		return nil, err
+	}
+	pending := calendar.PendingEvents()
+	calendar.ClearEvents()
+
+	reference := domainavailability.MirrorReference(string(booking.ID))
+	for _, mirror := range mirrors {
+		if err := mirror.BlockRange(booking.Range, domainavailability.ReasonHostBlock, reference, now); err != nil {
+			return nil, err
+		}
+		if err := unit.Availability().Save(ctx, mirror); err != nil {
+			return nil, err
+		}
+		pending = append(pending, mirror.PendingEvents()...)
+		mirror.ClearEvents()
+	}
+	return pending, nil
+}
+
+// unitGroupCalendars loads the calendars of the other listings in the unit
+// group of listing.
+func unitGroupCalendars(ctx context.Context, unit uow.UnitOfWork, listing *domainlistings.Listing) ([]*domainavailability.AvailabilityCalendar, error) {
+	if listing.UnitGroupID == "" {
+		return nil, nil
+	}
+	siblings, err := hostListings(ctx, unit, listing.Host, listing.UnitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	calendars := make([]*domainavailability.AvailabilityCalendar, 0, len(siblings))
+	for _, sibling := range siblings {
+		if sibling.ID == listing.ID {
+			continue
+		}
+		calendar, err := unit.Availability().Calendar(ctx, sibling.ID)
+		if err != nil {
+			return nil, err
+		}
+		calendars = append(calendars, calendar)
+	}
+	return calendars, nil
+}
+
+// releaseNights frees the calendar range held for the booking, its cleaning
+// buffers and the blocks it mirrored onto the unit group. Mirrors are looked up across
+// all listings of the host so unlinking a listing does not strand them.
+func releaseNights(ctx context.Context, unit uow.UnitOfWork, booking *domainbooking.Booking, now time.Time) ([]events.DomainEvent, error) {
+	var released []events.DomainEvent
+	// The cleaning buffers Reserve added around the stay go with it.
+	for _, reference := range []string{string(booking.ID), string(booking.ID) + "-before", string(booking.ID) + "-after"} {
+		freed, err := releaseBlock(ctx, unit, booking.ListingID, reference, now)
+		if err != nil {
+			return nil, err
+		}
+		released = append(released, freed...)
+	}
+	listing, err := unit.Listings().ByID(ctx, booking.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.UnitGroupID == "" {
+		return released, nil
+	}
+	siblings, err := hostListings(ctx, unit, listing.Host, "")
+	if err != nil {
+		return nil, err
+	}
+	reference := domainavailability.MirrorReference(string(booking.ID))
+	for _, sibling := range siblings {
+		if sibling.ID == listing.ID {
+			continue
+		}
+		mirrored, err := releaseBlock(ctx, unit, sibling.ID, reference, now)
+		if err != nil {
+			return nil, err
+		}
+		released = append(released, mirrored...)
+	}
+	return released, nil
+}
+
+// releaseBlock drops the block with reference from the listing calendar; a
+// missing block is not an error.
+func releaseBlock(ctx context.Context, unit uow.UnitOfWork, listingID domainlistings.ListingID, reference string, now time.Time) ([]events.DomainEvent, error) {
+	calendar, err := unit.Availability().Calendar(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := calendar.Release(reference, now); err != nil {
+		if errors.Is(err, domainavailability.ErrRangeNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := unit.Availability().Save(ctx, calendar); err != nil {
+		return nil, err
+	}
+	released := calendar.PendingEvents()
+	calendar.ClearEvents()
+	return released, nil
+}
+
+// hostListings lists the host's listings in unitGroupID, or all of them when
+// it is empty.
+func hostListings(ctx context.Context, unit uow.UnitOfWork, host domainlistings.HostID, unitGroupID string) ([]*domainlistings.Listing, error) {
+	var result []*domainlistings.Listing
+	for offset := 0; ; offset += unitGroupPageSize {
+		page, err := unit.Listings().Search(ctx, domainlistings.SearchParams{
+			Host:        host,
+			UnitGroupID: unitGroupID,
+			Limit:       unitGroupPageSize,
+			Offset:      offset,
+			MaxLimit:    unitGroupPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page.Items...)
+		if len(page.Items) < unitGroupPageSize || offset+len(page.Items) >= page.Total {
+			return result, nil
+		}
+	}
+}
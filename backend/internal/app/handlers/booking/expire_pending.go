@@ -0,0 +1,97 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/outbox"
+	"rentme/internal/app/uow"
+	domainbooking "rentme/internal/domain/booking"
+)
+
+const (
+	expirePendingBookingsKey = "bookings.expire_pending"
+	defaultPendingTTL        = 24 * time.Hour
+)
+
+// ExpirePendingBookingsCommand expires PENDING bookings the host did not
+// answer within the handler TTL as of Now.
+type ExpirePendingBookingsCommand struct {
+	Now time.Time
+}
+
+func (c ExpirePendingBookingsCommand) Key() string { return expirePendingBookingsKey }
+
+type ExpirePendingBookingsResult struct {
+	Expired int `json:"expired"`
+}
+
+type ExpirePendingBookingsHandler struct {
+	TTL     time.Duration
+	Outbox  outbox.Outbox
+	Encoder outbox.EventEncoder
+	Logger  *slog.Logger
+}
+
+func (h *ExpirePendingBookingsHandler) Handle(ctx context.Context, cmd ExpirePendingBookingsCommand) (*ExpirePendingBookingsResult, error) {
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, uow.ErrUnitOfWorkMissing
+	}
+	now := cmd.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.UTC()
+
+	bookings, err := unit.Booking().ListByState(ctx, domainbooking.StatePending, now.Add(-h.ttl()))
+	if err != nil {
+		return nil, err
+	}
+	expired := 0
+	for _, booking := range bookings {
+		if err := booking.Expire(now); err != nil {
+			if errors.Is(err, domainbooking.ErrInvalidState) {
+				continue
+			}
+			return nil, err
+		}
+		if err := unit.Booking().Save(ctx, booking); err != nil {
+			return nil, err
+		}
+		pending := booking.PendingEvents()
+		booking.ClearEvents()
+		released, err := releaseNights(ctx, unit, booking, now)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, released...)
+		if err := outbox.RecordDomainEvents(ctx, h.Outbox, h.encoder(), pending); err != nil {
+			return nil, err
+		}
+		expired++
+	}
+	if expired > 0 && h.Logger != nil {
+		h.Logger.Info("pending bookings expired", "count", expired)
+	}
+	return &ExpirePendingBookingsResult{Expired: expired}, nil
+}
+
+func (h *ExpirePendingBookingsHandler) ttl() time.Duration {
+	if h.TTL <= 0 {
+		return defaultPendingTTL
+	}
+	return h.TTL
+}
+
+func (h *ExpirePendingBookingsHandler) encoder() outbox.EventEncoder {
+	if h.Encoder != nil {
+		return h.Encoder
+	}
+	return outbox.JSONEventEncoder{}
+}
+
+var _ commands.Handler[ExpirePendingBookingsCommand, *ExpirePendingBookingsResult] = (*ExpirePendingBookingsHandler)(nil)
@@ -0,0 +1,76 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+const getBookingKey = "bookings.get"
+
+// GetBookingQuery loads one booking for ViewerID. Only the guest, the listing
+// owner and admins may see it; anyone else gets ErrBookingNotFound so the id
+// does not leak.
+type GetBookingQuery struct {
+	BookingID string
+	ViewerID  string
+	Admin     bool
+}
+
+func (q GetBookingQuery) Key() string { return getBookingKey }
+
+type GetBookingHandler struct {
+	UoWFactory uow.UoWFactory
+	Logger     *slog.Logger
+}
+
+func (h *GetBookingHandler) Handle(ctx context.Context, q GetBookingQuery) (dto.BookingDetail, error) {
+	bookingID := strings.TrimSpace(q.BookingID)
+	if bookingID == "" {
+		return dto.BookingDetail{}, errors.New("booking id is required")
+	}
+	viewerID := strings.TrimSpace(q.ViewerID)
+	if viewerID == "" {
+		return dto.BookingDetail{}, errors.New("viewer id is required")
+	}
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return dto.BookingDetail{}, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	booking, err := unit.Booking().ByID(execCtx, domainbooking.BookingID(bookingID))
+	if err != nil {
+		return dto.BookingDetail{}, err
+	}
+	listing, err := unit.Listings().ByID(execCtx, booking.ListingID)
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Warn("load listing for booking detail failed", "booking_id", booking.ID, "listing_id", booking.ListingID, "error", err)
+		}
+	}
+	if !canViewBooking(booking, listing, viewerID, q.Admin) {
+		return dto.BookingDetail{}, domainbooking.ErrBookingNotFound
+	}
+	return dto.MapBookingDetail(booking, listing, time.Now().UTC()), nil
+}
+
+func canViewBooking(booking *domainbooking.Booking, listing *domainlistings.Listing, viewerID string, admin bool) bool {
+	if admin || booking.GuestID == viewerID {
+		return true
+	}
+	return listing != nil && listing.Host == domainlistings.HostID(viewerID)
+}
+
+var _ queries.Handler[GetBookingQuery, dto.BookingDetail] = (*GetBookingHandler)(nil)
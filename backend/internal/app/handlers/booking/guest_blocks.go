@@ -0,0 +1,156 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/queries"
+	domainguestblock "rentme/internal/domain/guestblock"
+	domainlistings "rentme/internal/domain/listings"
+	domainuser "rentme/internal/domain/user"
+)
+
+const (
+	blockGuestKey        = "host.guest_blocks.add"
+	unblockGuestKey      = "host.guest_blocks.remove"
+	listBlockedGuestsKey = "host.guest_blocks.list"
+)
+
+var (
+	ErrGuestBlocksUnavailable = errors.New("booking: guest blocks unavailable")
+	ErrBlockedGuestNotFound   = errors.New("booking: guest not found")
+)
+
+// checkGuestNotBlocked refuses a booking from a guest the listing host
+// blocked. The guest gets the same answer as for taken dates so the block
+// itself stays private.
+func checkGuestNotBlocked(ctx context.Context, blocks domainguestblock.Repository, listing *domainlistings.Listing, guestID string) error {
+	blocked, err := domainguestblock.IsBlocked(ctx, blocks, listing.Host, guestID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrDatesUnavailable
+	}
+	return nil
+}
+
+type BlockGuestCommand struct {
+	HostID  string
+	GuestID string
+	Reason  string
+}
+
+func (c BlockGuestCommand) Key() string { return blockGuestKey }
+
+type BlockGuestHandler struct {
+	Blocks domainguestblock.Repository
+	Users  domainuser.Repository
+	Logger *slog.Logger
+}
+
+// Handle blocks the guest for every listing of the host. Blocking again only
+// updates the reason.
+func (h *BlockGuestHandler) Handle(ctx context.Context, cmd BlockGuestCommand) (*dto.GuestBlock, error) {
+	if h.Blocks == nil {
+		return nil, ErrGuestBlocksUnavailable
+	}
+	block, err := domainguestblock.New(domainlistings.HostID(cmd.HostID), cmd.GuestID, cmd.Reason, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if h.Users != nil {
+		if _, err := h.Users.ByID(ctx, domainuser.ID(block.GuestID)); err != nil {
+			if errors.Is(err, domainuser.ErrNotFound) {
+				return nil, ErrBlockedGuestNotFound
+			}
+			return nil, err
+		}
+	}
+	if err := h.Blocks.Save(ctx, block); err != nil {
+		return nil, err
+	}
+	if h.Logger != nil {
+		h.Logger.Info("guest blocked by host", "host_id", block.HostID, "guest_id", block.GuestID)
+	}
+	result := dto.MapGuestBlock(block)
+	return &result, nil
+}
+
+type UnblockGuestCommand struct {
+	HostID  string
+	GuestID string
+	// RemovedBy is the admin lifting the block on behalf of the host, empty
+	// when the host does it.
+	RemovedBy string
+}
+
+func (c UnblockGuestCommand) Key() string { return unblockGuestKey }
+
+type UnblockGuestHandler struct {
+	Blocks domainguestblock.Repository
+	Logger *slog.Logger
+}
+
+// Handle lifts the block and returns it as it was.
+func (h *UnblockGuestHandler) Handle(ctx context.Context, cmd UnblockGuestCommand) (*dto.GuestBlock, error) {
+	if h.Blocks == nil {
+		return nil, ErrGuestBlocksUnavailable
+	}
+	hostID := domainlistings.HostID(strings.TrimSpace(cmd.HostID))
+	if hostID == "" {
+		return nil, domainguestblock.ErrHostRequired
+	}
+	guestID := strings.TrimSpace(cmd.GuestID)
+	if guestID == "" {
+		return nil, domainguestblock.ErrGuestRequired
+	}
+	block, err := h.Blocks.Get(ctx, hostID, guestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.Blocks.Delete(ctx, hostID, guestID); err != nil {
+		return nil, err
+	}
+	if h.Logger != nil {
+		h.Logger.Info("guest block removed", "host_id", hostID, "guest_id", guestID, "removed_by", cmd.RemovedBy)
+	}
+	result := dto.MapGuestBlock(block)
+	return &result, nil
+}
+
+type ListBlockedGuestsQuery struct {
+	HostID string
+}
+
+func (q ListBlockedGuestsQuery) Key() string { return listBlockedGuestsKey }
+
+type ListBlockedGuestsHandler struct {
+	Blocks domainguestblock.Repository
+}
+
+func (h *ListBlockedGuestsHandler) Handle(ctx context.Context, q ListBlockedGuestsQuery) (dto.GuestBlockCollection, error) {
+	if h.Blocks == nil {
+		return dto.GuestBlockCollection{}, ErrGuestBlocksUnavailable
+	}
+	hostID := domainlistings.HostID(strings.TrimSpace(q.HostID))
+	if hostID == "" {
+		return dto.GuestBlockCollection{}, domainguestblock.ErrHostRequired
+	}
+	blocks, err := h.Blocks.ByHost(ctx, hostID)
+	if err != nil {
+		return dto.GuestBlockCollection{}, err
+	}
+	return dto.GuestBlockCollection{Items: dto.MapGuestBlocks(blocks)}, nil
+}
+
+var (
+	_ commands.Handler[BlockGuestCommand, *dto.GuestBlock]              = (*BlockGuestHandler)(nil)
+	_ commands.Handler[UnblockGuestCommand, *dto.GuestBlock]            = (*UnblockGuestHandler)(nil)
+	_ queries.Handler[ListBlockedGuestsQuery, dto.GuestBlockCollection] = (*ListBlockedGuestsHandler)(nil)
+)
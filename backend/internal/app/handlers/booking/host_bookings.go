@@ -4,26 +4,29 @@ import (
 	"context"
 	"errors"
 	"log/slog"
-	"sort"
 	"strings"
 	"time"
 
 	"rentme/internal/app/commands"
 	"rentme/internal/app/dto"
 	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/outbox"
 	"rentme/internal/app/queries"
 	"rentme/internal/app/uow"
 	domainbooking "rentme/internal/domain/booking"
 	domainlistings "rentme/internal/domain/listings"
+	domainuser "rentme/internal/domain/user"
 )
 
 const (
-	listHostBookingsKey    = "host.bookings.list"
-	confirmHostBookingKey  = "host.bookings.confirm"
-	declineHostBookingKey  = "host.bookings.decline"
-	demoPaymentHoldID      = "demo-hold"
-	defaultHostListLimit   = 60
-	allStatusesFilterValue = "ALL"
+	listHostBookingsKey      = "host.bookings.list"
+	confirmHostBookingKey    = "host.bookings.confirm"
+	declineHostBookingKey    = "host.bookings.decline"
+	demoPaymentHoldID        = "demo-hold"
+	hostListingsPageSize     = 60
+	defaultHostBookingsLimit = 20
+	maxHostBookingsLimit     = 100
+	allStatusesFilterValue   = "ALL"
 )
 
 var ErrBookingNotOwned = errors.New("booking: not owned by host")
@@ -31,12 +34,15 @@ var ErrBookingNotOwned = errors.New("booking: not owned by host")
 type ListHostBookingsQuery struct {
 	HostID string
 	Status string
+	Limit  int
+	Offset int
 }
 
 func (q ListHostBookingsQuery) Key() string { return listHostBookingsKey }
 
 type ListHostBookingsHandler struct {
 	UoWFactory uow.UoWFactory
+	Users      domainuser.Repository
 	Logger     *slog.Logger
 }
 
@@ -53,43 +59,106 @@ func (h *ListHostBookingsHandler) Handle(ctx context.Context, q ListHostBookings
 		defer cleanup()
 	}
 
-	listingsResult, err := unit.Listings().Search(execCtx, domainlistings.SearchParams{
-		Host:   domainlistings.HostID(hostID),
-		Limit: defaultHostListLimit,
-	})
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultHostBookingsLimit
+	}
+	if limit > maxHostBookingsLimit {
+		limit = maxHostBookingsLimit
+	}
+	offset := q.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	hostListings, err := hostListingsByID(execCtx, unit, domainlistings.HostID(hostID))
 	if err != nil {
 		return dto.HostBookingCollection{}, err
 	}
+	listingIDs := make([]domainlistings.ListingID, 0, len(hostListings))
+	for id := range hostListings {
+		listingIDs = append(listingIDs, id)
+	}
 
 	statusFilter := strings.ToUpper(strings.TrimSpace(q.Status))
 	if statusFilter == "" {
 		statusFilter = string(domainbooking.StatePending)
 	}
-	allStatuses := statusFilter == allStatusesFilterValue
+	var state domainbooking.BookingState
+	if statusFilter != allStatusesFilterValue {
+		state = domainbooking.BookingState(statusFilter)
+	}
 
-	items := make([]dto.HostBookingSummary, 0)
-	for _, listing := range listingsResult.Items {
-		bookings, err := unit.Booking().ListByListing(execCtx, listing.ID)
-		if err != nil {
-			return dto.HostBookingCollection{}, err
+	bookings, total, err := unit.Booking().ListByListingIDs(execCtx, listingIDs, state, limit, offset)
+	if err != nil {
+		return dto.HostBookingCollection{}, err
+	}
+	verified := h.verifiedGuests(execCtx, bookings)
+	items := make([]dto.HostBookingSummary, 0, len(bookings))
+	for _, booking := range bookings {
+		summary := dto.MapHostBookingSummary(booking, hostListings[booking.ListingID])
+		summary.GuestVerified = verified[booking.GuestID]
+		items = append(items, summary)
+	}
+
+	if h.Logger != nil {
+		h.Logger.Debug("host bookings listed", "host_id", hostID, "count", len(items), "total", total, "status", statusFilter)
+	}
+
+	return dto.HostBookingCollection{
+		Items: items,
+		Meta: dto.HostBookingCollectionMeta{
+			Total:  total,
+			Limit:  limit,
+			Offset: offset,
+		},
+	}, nil
+}
+
+// verifiedGuests looks up the identity badge of every guest on the page. A
+// failed lookup only hides the badge.
+func (h *ListHostBookingsHandler) verifiedGuests(ctx context.Context, bookings []*domainbooking.Booking) map[string]bool {
+	result := make(map[string]bool)
+	if h.Users == nil {
+		return result
+	}
+	for _, booking := range bookings {
+		if _, seen := result[booking.GuestID]; seen {
+			continue
 		}
-		for _, booking := range bookings {
-			if !allStatuses && string(booking.State) != statusFilter {
-				continue
+		guest, err := h.Users.ByID(ctx, domainuser.ID(booking.GuestID))
+		if err != nil {
+			if h.Logger != nil && !errors.Is(err, domainuser.ErrNotFound) {
+				h.Logger.Warn("load guest for host bookings failed", "guest_id", booking.GuestID, "error", err)
 			}
-			items = append(items, dto.MapHostBookingSummary(booking, listing))
+			result[booking.GuestID] = false
+			continue
 		}
+		result[booking.GuestID] = guest.IdentityVerified()
 	}
+	return result
+}
 
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].CreatedAt.After(items[j].CreatedAt)
-	})
-
-	if h.Logger != nil {
-		h.Logger.Debug("host bookings listed", "host_id", hostID, "count", len(items), "status", statusFilter)
+// hostListingsByID pages through every listing of the host; search caps a
+// single page at hostListingsPageSize.
+func hostListingsByID(ctx context.Context, unit uow.UnitOfWork, hostID domainlistings.HostID) (map[domainlistings.ListingID]*domainlistings.Listing, error) {
+	result := make(map[domainlistings.ListingID]*domainlistings.Listing)
+	for offset := 0; ; offset += hostListingsPageSize {
+		page, err := unit.Listings().Search(ctx, domainlistings.SearchParams{
+			Host:   hostID,
+			Limit:  hostListingsPageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, listing := range page.Items {
+			result[listing.ID] = listing
+		}
+		if len(page.Items) < hostListingsPageSize || offset+len(page.Items) >= page.Total {
+			return result, nil
+		}
 	}
-
-	return dto.HostBookingCollection{Items: items}, nil
 }
 
 type ConfirmHostBookingCommand struct {
@@ -158,7 +227,9 @@ func (h *ConfirmHostBookingHandler) Handle(ctx context.Context, cmd ConfirmHostB
 }
 
 type DeclineHostBookingHandler struct {
-	Logger *slog.Logger
+	Outbox  outbox.Outbox
+	Encoder outbox.EventEncoder
+	Logger  *slog.Logger
 }
 
 func (h *DeclineHostBookingHandler) Handle(ctx context.Context, cmd DeclineHostBookingCommand) (*HostBookingActionResult, error) {
@@ -199,6 +270,13 @@ func (h *DeclineHostBookingHandler) Handle(ctx context.Context, cmd DeclineHostB
 	if err := unit.Booking().Save(ctx, booking); err != nil {
 		return nil, err
 	}
+	released, err := releaseNights(ctx, unit, booking, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := outbox.RecordDomainEvents(ctx, h.Outbox, h.Encoder, released); err != nil {
+		return nil, err
+	}
 
 	if h.Logger != nil {
 		h.Logger.Info("host booking declined", "booking_id", booking.ID, "host_id", hostID, "listing_id", booking.ListingID, "reason", reason)
@@ -0,0 +1,67 @@
+package booking
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/outbox"
+)
+
+const markNoShowKey = "host.bookings.no_show"
+
+type MarkNoShowCommand struct {
+	HostID    string
+	BookingID string
+}
+
+func (c MarkNoShowCommand) Key() string { return markNoShowKey }
+
+type MarkNoShowHandler struct {
+	Outbox  outbox.Outbox
+	Encoder outbox.EventEncoder
+	Logger  *slog.Logger
+}
+
+func (h *MarkNoShowHandler) Handle(ctx context.Context, cmd MarkNoShowCommand) (*HostBookingActionResult, error) {
+	unit, booking, err := loadHostStay(ctx, cmd.HostID, cmd.BookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := booking.MarkNoShow(now); err != nil {
+		return nil, err
+	}
+	if err := unit.Booking().Save(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	// The guest never arrived, so the nights held for this booking go back on sale.
+	pending := booking.PendingEvents()
+	booking.ClearEvents()
+	released, err := releaseNights(ctx, unit, booking, now)
+	if err != nil {
+		return nil, err
+	}
+	pending = append(pending, released...)
+	if err := outbox.RecordDomainEvents(ctx, h.Outbox, h.encoder(), pending); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("host booking marked no-show", "booking_id", booking.ID, "host_id", cmd.HostID, "listing_id", booking.ListingID)
+	}
+
+	return &HostBookingActionResult{BookingID: string(booking.ID), Status: string(booking.State)}, nil
+}
+
+func (h *MarkNoShowHandler) encoder() outbox.EventEncoder {
+	if h.Encoder != nil {
+		return h.Encoder
+	}
+	return outbox.JSONEventEncoder{}
+}
+
+var _ commands.Handler[MarkNoShowCommand, *HostBookingActionResult] = (*MarkNoShowHandler)(nil)
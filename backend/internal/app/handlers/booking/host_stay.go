@@ -0,0 +1,130 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/outbox"
+	"rentme/internal/app/uow"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+const (
+	checkInBookingKey  = "host.bookings.check_in"
+	checkOutBookingKey = "host.bookings.check_out"
+)
+
+// loadHostStay loads the booking from the current unit of work and makes sure
+// it belongs to one of the host's listings.
+func loadHostStay(ctx context.Context, hostID, bookingID string) (uow.UnitOfWork, *domainbooking.Booking, error) {
+	hostID = strings.TrimSpace(hostID)
+	if hostID == "" {
+		return nil, nil, errors.New("host id is required")
+	}
+	bookingID = strings.TrimSpace(bookingID)
+	if bookingID == "" {
+		return nil, nil, errors.New("booking id is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, nil, uow.ErrUnitOfWorkMissing
+	}
+	booking, err := unit.Booking().ByID(ctx, domainbooking.BookingID(bookingID))
+	if err != nil {
+		return nil, nil, err
+	}
+	listing, err := unit.Listings().ByID(ctx, booking.ListingID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if listing.Host != domainlistings.HostID(hostID) {
+		return nil, nil, ErrBookingNotOwned
+	}
+	return unit, booking, nil
+}
+
+// saveStayTransition persists the booking and queues the transition events.
+func saveStayTransition(ctx context.Context, unit uow.UnitOfWork, booking *domainbooking.Booking, box outbox.Outbox, encoder outbox.EventEncoder) error {
+	if err := unit.Booking().Save(ctx, booking); err != nil {
+		return err
+	}
+	pending := booking.PendingEvents()
+	booking.ClearEvents()
+	if encoder == nil {
+		encoder = outbox.JSONEventEncoder{}
+	}
+	return outbox.RecordDomainEvents(ctx, box, encoder, pending)
+}
+
+type CheckInBookingCommand struct {
+	HostID    string
+	BookingID string
+}
+
+func (c CheckInBookingCommand) Key() string { return checkInBookingKey }
+
+type CheckInBookingHandler struct {
+	Outbox  outbox.Outbox
+	Encoder outbox.EventEncoder
+	Logger  *slog.Logger
+}
+
+// Handle checks the guest in. It opens CheckInGrace before the check-in date.
+func (h *CheckInBookingHandler) Handle(ctx context.Context, cmd CheckInBookingCommand) (*HostBookingActionResult, error) {
+	unit, booking, err := loadHostStay(ctx, cmd.HostID, cmd.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := booking.CheckIn(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := saveStayTransition(ctx, unit, booking, h.Outbox, h.Encoder); err != nil {
+		return nil, err
+	}
+	if h.Logger != nil {
+		h.Logger.Info("host booking checked in", "booking_id", booking.ID, "host_id", cmd.HostID, "listing_id", booking.ListingID)
+	}
+	return &HostBookingActionResult{BookingID: string(booking.ID), Status: string(booking.State)}, nil
+}
+
+type CheckOutBookingCommand struct {
+	HostID    string
+	BookingID string
+}
+
+func (c CheckOutBookingCommand) Key() string { return checkOutBookingKey }
+
+type CheckOutBookingHandler struct {
+	Outbox  outbox.Outbox
+	Encoder outbox.EventEncoder
+	Logger  *slog.Logger
+}
+
+// Handle checks the guest out, which makes the stay reviewable. An early
+// departure is allowed; the booked nights are not released.
+func (h *CheckOutBookingHandler) Handle(ctx context.Context, cmd CheckOutBookingCommand) (*HostBookingActionResult, error) {
+	unit, booking, err := loadHostStay(ctx, cmd.HostID, cmd.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := booking.CheckOut(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := saveStayTransition(ctx, unit, booking, h.Outbox, h.Encoder); err != nil {
+		return nil, err
+	}
+	if h.Logger != nil {
+		h.Logger.Info("host booking checked out", "booking_id", booking.ID, "host_id", cmd.HostID, "listing_id", booking.ListingID)
+	}
+	return &HostBookingActionResult{BookingID: string(booking.ID), Status: string(booking.State)}, nil
+}
+
+var (
+	_ commands.Handler[CheckInBookingCommand, *HostBookingActionResult]  = (*CheckInBookingHandler)(nil)
+	_ commands.Handler[CheckOutBookingCommand, *HostBookingActionResult] = (*CheckOutBookingHandler)(nil)
+)
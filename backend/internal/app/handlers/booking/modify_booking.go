@@ -0,0 +1,211 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/outbox"
+	"rentme/internal/app/uow"
+	domainavailability "rentme/internal/domain/availability"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+	domainpricing "rentme/internal/domain/pricing"
+	domainrange "rentme/internal/domain/shared/daterange"
+)
+
+const modifyBookingKey = "me.bookings.modify"
+
+var (
+	ErrBookingNotGuests = errors.New("booking: not owned by guest")
+	ErrWholeMonths      = errors.New("booking: check_out must be a whole number of months after check_in")
+)
+
+// ModifyBookingCommand moves the check-out of the guest's booking; the
+// check-in stays put.
+type ModifyBookingCommand struct {
+	GuestID     string
+	BookingID   string
+	NewCheckOut time.Time
+}
+
+func (c ModifyBookingCommand) Key() string { return modifyBookingKey }
+
+// ModifyBookingResult is the booking after the change, repriced at the
+// listing's current rate.
+type ModifyBookingResult struct {
+	dto.GuestBookingSummary
+	Price dto.BookingPriceBreakdown `json:"price"`
+}
+
+type ModifyBookingHandler struct {
+	Rounding domainpricing.RoundingPolicy
+	Outbox   outbox.Outbox
+	Encoder  outbox.EventEncoder
+	Logger   *slog.Logger
+}
+
+func (h *ModifyBookingHandler) Handle(ctx context.Context, cmd ModifyBookingCommand) (*ModifyBookingResult, error) {
+	guestID := strings.TrimSpace(cmd.GuestID)
+	if guestID == "" {
+		return nil, errors.New("guest id is required")
+	}
+	bookingID := strings.TrimSpace(cmd.BookingID)
+	if bookingID == "" {
+		return nil, errors.New("booking id is required")
+	}
+	if cmd.NewCheckOut.IsZero() {
+		return nil, errors.New("check_out is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, uow.ErrUnitOfWorkMissing
+	}
+
+	booking, err := unit.Booking().ByID(ctx, domainbooking.BookingID(bookingID))
+	if err != nil {
+		return nil, err
+	}
+	if booking.GuestID != guestID {
+		return nil, ErrBookingNotGuests
+	}
+	if booking.State != domainbooking.StateAccepted && booking.State != domainbooking.StateConfirmed {
+		return nil, domainbooking.ErrInvalidState
+	}
+	listing, err := unit.Listings().ByID(ctx, booking.ListingID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bookings made before ranges were date-aligned may carry a time of day;
+	// the stay keeps its check-in date either way.
+	dr, err := domainrange.NewNightly(domainrange.DateOf(booking.Range.CheckIn), cmd.NewCheckOut)
+	if err != nil {
+		return nil, err
+	}
+	units, months := dr.Nights(), 0
+	if booking.PriceUnit == "month" {
+		if months, err = wholeMonths(dr); err != nil {
+			return nil, err
+		}
+		units = months
+	}
+	if err := checkStayLength(listing, dr, months, booking.PriceUnit); err != nil {
+		return nil, err
+	}
+	// Nothing has been touched yet: a taken range fails before the booking
+	// gives up the nights it already holds.
+	if err := checkStayChangeAvailable(ctx, unit, listing, booking, dr); err != nil {
+		return nil, err
+	}
+	price, err := buildBookingPrice(listing, booking.PriceUnit, units, dr, h.Rounding.Rule(listing.Address.City, "RUB"))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	previous := booking.Range
+	if err := booking.ChangeStay(dr, months, price, now); err != nil {
+		return nil, err
+	}
+	released, err := releaseNights(ctx, unit, booking, now)
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := reserveNights(ctx, unit, listing, booking, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := unit.Booking().Save(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	pending := booking.PendingEvents()
+	booking.ClearEvents()
+	pending = append(pending, released...)
+	pending = append(pending, reserved...)
+	if err := outbox.RecordDomainEvents(ctx, h.Outbox, h.encoder(), pending); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("guest booking stay changed", "booking_id", booking.ID, "guest_id", guestID,
+			"previous_check_out", previous.CheckOut, "check_out", booking.Range.CheckOut)
+	}
+
+	return &ModifyBookingResult{
+		GuestBookingSummary: dto.MapGuestBookingSummary(booking, listing, nil, false),
+		Price:               dto.MapBookingPriceBreakdown(booking.Price),
+	}, nil
+}
+
+func (h *ModifyBookingHandler) encoder() outbox.EventEncoder {
+	if h.Encoder != nil {
+		return h.Encoder
+	}
+	return outbox.JSONEventEncoder{}
+}
+
+// wholeMonths counts the months of a long-term range, which must end on the
+// same day of the month it starts.
+func wholeMonths(dr domainrange.DateRange) (int, error) {
+	for months := 1; months <= 12; months++ {
+		end := dr.CheckIn.AddDate(0, months, 0)
+		if end.Equal(dr.CheckOut) {
+			return months, nil
+		}
+		if end.After(dr.CheckOut) {
+			break
+		}
+	}
+	return 0, ErrWholeMonths
+}
+
+// checkStayChangeAvailable checks the new range against the listing and its
+// unit group while ignoring the blocks the booking itself already holds.
+func checkStayChangeAvailable(ctx context.Context, unit uow.UnitOfWork, listing *domainlistings.Listing, booking *domainbooking.Booking, dr domainrange.DateRange) error {
+	calendar, err := unit.Availability().Calendar(ctx, listing.ID)
+	if err != nil {
+		return err
+	}
+	own := string(booking.ID)
+	if err := checkDatesAvailable(withoutBlocks(calendar, own, own+"-before", own+"-after"), dr); err != nil {
+		return err
+	}
+	mirrors, err := unitGroupCalendars(ctx, unit, listing)
+	if err != nil {
+		return err
+	}
+	mirrored := domainavailability.MirrorReference(own)
+	for _, mirror := range mirrors {
+		if err := checkDatesAvailable(withoutBlocks(mirror, mirrored), dr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withoutBlocks returns a copy of calendar without the blocks carrying the
+// given references, for availability checks only.
+func withoutBlocks(calendar *domainavailability.AvailabilityCalendar, references ...string) *domainavailability.AvailabilityCalendar {
+	view := domainavailability.NewCalendar(calendar.ListingID, calendar.CleaningBufferDays)
+	for _, block := range calendar.Blocks {
+		skip := false
+		for _, reference := range references {
+			if block.Reference == reference {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			view.Blocks = append(view.Blocks, block)
+		}
+	}
+	return view
+}
+
+var _ commands.Handler[ModifyBookingCommand, *ModifyBookingResult] = (*ModifyBookingHandler)(nil)
@@ -0,0 +1,140 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/policies"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+	domainrange "rentme/internal/domain/shared/daterange"
+	domainuser "rentme/internal/domain/user"
+)
+
+const quoteBookingKey = "bookings.quote"
+
+var ErrListingNotFound = errors.New("booking: listing not found")
+
+// QuoteBookingQuery prices a stay without requesting it. Long-term listings
+// take Months from CheckIn, short-term ones an explicit CheckOut, as for a
+// booking request. GuestID is optional and only matters for listings that
+// accept verified guests only.
+type QuoteBookingQuery struct {
+	ListingID string
+	GuestID   string
+	CheckIn   time.Time
+	CheckOut  time.Time
+	Guests    int
+	Months    int
+}
+
+func (q QuoteBookingQuery) Key() string { return quoteBookingKey }
+
+// QuoteBookingHandler runs the checks of a booking request read-only. Input
+// that a request would reject as malformed fails the query; a well-formed stay
+// that cannot be booked, such as taken dates or a stay below the minimum, is
+// still priced and reported as unavailable with the reason.
+type QuoteBookingHandler struct {
+	UoWFactory uow.UoWFactory
+	Pricing    policies.PricingPort
+	Users      domainuser.Repository
+}
+
+func (h *QuoteBookingHandler) Handle(ctx context.Context, q QuoteBookingQuery) (dto.BookingQuote, error) {
+	var zero dto.BookingQuote
+	listingID := strings.TrimSpace(q.ListingID)
+	if listingID == "" {
+		return zero, errors.New("listing id is required")
+	}
+	if q.CheckIn.IsZero() {
+		return zero, errors.New("check_in is required")
+	}
+	if h.Pricing == nil {
+		return zero, errors.New("pricing service unavailable")
+	}
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return zero, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	listing, err := unit.Listings().ByID(execCtx, domainlistings.ListingID(listingID))
+	if err != nil {
+		return zero, fmt.Errorf("%w: %v", ErrListingNotFound, err)
+	}
+	rentalTerm := listing.RentalTermType
+	if rentalTerm == "" {
+		rentalTerm = domainlistings.RentalTermLong
+	}
+	dr, months, priceUnit, err := resolveBookingRange(rentalTerm, q.CheckIn, q.CheckOut, q.Months)
+	if err != nil {
+		return zero, err
+	}
+	guests := q.Guests
+	if guests <= 0 {
+		guests = 1
+	}
+	price, err := h.Pricing.Quote(execCtx, listing, dr, guests)
+	if err != nil {
+		return zero, err
+	}
+
+	quote := dto.MapBookingQuote(string(listing.ID), dr, guests, price)
+	if err := h.checkBookable(execCtx, unit, listing, dr, months, priceUnit, guests, strings.TrimSpace(q.GuestID)); err != nil {
+		if !isUnavailable(err) {
+			return zero, err
+		}
+		quote.IsAvailable = false
+		quote.UnavailableReason = err.Error()
+	}
+	return quote, nil
+}
+
+// checkBookable applies the checks RequestBookingHandler makes before it
+// reserves the nights, in the same order.
+func (h *QuoteBookingHandler) checkBookable(ctx context.Context, unit uow.UnitOfWork, listing *domainlistings.Listing, dr domainrange.DateRange, months int, priceUnit string, guests int, guestID string) error {
+	if err := checkGuestVerified(ctx, h.Users, listing, guestID); err != nil {
+		return err
+	}
+	if err := checkStayLength(listing, dr, months, priceUnit); err != nil {
+		return err
+	}
+	if err := checkAvailableFrom(listing, dr); err != nil {
+		return err
+	}
+	if err := domainbooking.ValidateDateRange(dr, time.Now().UTC()); err != nil {
+		return err
+	}
+	if listing.GuestsLimit > 0 && guests > listing.GuestsLimit {
+		return domainbooking.ErrGuestsExceedLimit
+	}
+	calendar, err := unit.Availability().Calendar(ctx, listing.ID)
+	if err != nil {
+		return err
+	}
+	return checkDatesAvailable(calendar, dr)
+}
+
+// isUnavailable tells the reasons a stay cannot be booked apart from
+// failures to check it.
+func isUnavailable(err error) bool {
+	var unavailable *DatesUnavailableError
+	return errors.As(err, &unavailable) ||
+		errors.Is(err, ErrGuestNotVerified) ||
+		errors.Is(err, domainbooking.ErrBelowMinNights) ||
+		errors.Is(err, domainbooking.ErrExceedsMaxNights) ||
+		errors.Is(err, domainbooking.ErrBeforeAvailableFrom) ||
+		errors.Is(err, domainbooking.ErrGuestsExceedLimit) ||
+		errors.Is(err, domainbooking.ErrCheckInInPast)
+}
+
+var _ queries.Handler[QuoteBookingQuery, dto.BookingQuote] = (*QuoteBookingHandler)(nil)
@@ -3,18 +3,23 @@ package booking
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
 	"rentme/internal/app/middleware"
 	"rentme/internal/app/outbox"
 	"rentme/internal/app/policies"
 	"rentme/internal/app/uow"
+	domainavailability "rentme/internal/domain/availability"
 	domainbooking "rentme/internal/domain/booking"
+	domainguestblock "rentme/internal/domain/guestblock"
 	domainlistings "rentme/internal/domain/listings"
 	domainpricing "rentme/internal/domain/pricing"
 	domainrange "rentme/internal/domain/shared/daterange"
 	"rentme/internal/domain/shared/money"
+	domainuser "rentme/internal/domain/user"
 )
 
 const requestBookingKey = "booking.request"
@@ -36,8 +41,22 @@ func (c RequestBookingCommand) IdempotencyKey() string { return c.IdempotencyKey
 
 func (c RequestBookingCommand) ResultPrototype() any { return &RequestBookingResult{} }
 
+// IdempotencyFingerprint ignores CommandID, which is generated per request.
+func (c RequestBookingCommand) IdempotencyFingerprint() string {
+	return fmt.Sprintf("%s|%s|%s|%s|%d|%d",
+		c.ListingID, c.GuestID,
+		c.CheckIn.UTC().Format(time.RFC3339), c.CheckOut.UTC().Format(time.RFC3339),
+		c.Months, c.Guests)
+}
+
+// RequestBookingResult describes the booking just created so clients need no
+// follow-up read. BookingID duplicates the summary id for older clients.
 type RequestBookingResult struct {
 	BookingID string `json:"booking_id"`
+	dto.GuestBookingSummary
+	Price     dto.BookingPriceBreakdown     `json:"price"`
+	Policy    dto.CancellationPolicyPreview `json:"policy"`
+	ExpiresAt time.Time                     `json:"expires_at"`
 }
 
 type RequestBookingHandler struct {
@@ -45,9 +64,44 @@ type RequestBookingHandler struct {
 	Pricing    policies.PricingPort
 	Outbox     outbox.Outbox
 	Encoder    outbox.EventEncoder
+	Rounding   domainpricing.RoundingPolicy
+	// PendingTTL is how long the host has to answer; it only sets expires_at.
+	PendingTTL time.Duration
+	// Users resolves the guest for listings that accept verified guests only.
+	Users domainuser.Repository
+	// Blocks holds the guests each host refuses to host again.
+	Blocks domainguestblock.Repository
+}
+
+var (
+	ErrUnitOfWorkRequired = errors.New("booking: unit of work required")
+	ErrGuestNotVerified   = errors.New("booking: listing accepts identity-verified guests only")
+	ErrCheckOutRequired   = errors.New("check_out is required")
+	// ErrDatesUnavailable wraps the calendar overlap error so callers matching
+	// availability.ErrOverlappingRange keep working.
+	ErrDatesUnavailable = fmt.Errorf("booking: requested dates are unavailable: %w", domainavailability.ErrOverlappingRange)
+)
+
+// DatesUnavailableError carries the calendar block that the requested stay
+// collides with, so the API can tell the guest which dates are taken.
+type DatesUnavailableError struct {
+	Conflict domainrange.DateRange
+}
+
+func (e *DatesUnavailableError) Error() string {
+	return fmt.Sprintf("%s: taken from %s to %s", ErrDatesUnavailable.Error(),
+		e.Conflict.CheckIn.Format(time.DateOnly), e.Conflict.CheckOut.Format(time.DateOnly))
 }
 
-var ErrUnitOfWorkRequired = errors.New("booking: unit of work required")
+func (e *DatesUnavailableError) Unwrap() error { return ErrDatesUnavailable }
+
+// checkDatesAvailable rejects a range that overlaps any block on calendar.
+func checkDatesAvailable(calendar *domainavailability.AvailabilityCalendar, dr domainrange.DateRange) error {
+	if block, taken := calendar.Conflict(dr); taken {
+		return &DatesUnavailableError{Conflict: block.Range}
+	}
+	return nil
+}
 
 func (h *RequestBookingHandler) Handle(ctx context.Context, cmd RequestBookingCommand) (*RequestBookingResult, error) {
 	unit, ok := uow.FromContext(ctx)
@@ -77,6 +131,12 @@ func (h *RequestBookingHandler) Handle(ctx context.Context, cmd RequestBookingCo
 	if err != nil {
 		return nil, err
 	}
+	if err := checkGuestNotBlocked(ctx, h.Blocks, listing, cmd.GuestID); err != nil {
+		return nil, err
+	}
+	if err := checkGuestVerified(ctx, h.Users, listing, cmd.GuestID); err != nil {
+		return nil, err
+	}
 
 	rentalTerm := listing.RentalTermType
 	if rentalTerm == "" {
@@ -87,29 +147,43 @@ func (h *RequestBookingHandler) Handle(ctx context.Context, cmd RequestBookingCo
 	if err != nil {
 		return nil, err
 	}
+	if err := checkStayLength(listing, dr, months, priceUnit); err != nil {
+		return nil, err
+	}
+	if err := checkAvailableFrom(listing, dr); err != nil {
+		return nil, err
+	}
 	now := time.Now().UTC()
 	if err := domainbooking.ValidateDateRange(dr, now); err != nil {
 		return nil, err
 	}
+	calendar, err := unit.Availability().Calendar(ctx, listing.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDatesAvailable(calendar, dr); err != nil {
+		return nil, err
+	}
 
 	units := dr.Nights()
 	if priceUnit == "month" {
 		units = months
 	}
-	price, err := buildBookingPrice(listing.RateRub, units)
+	price, err := buildBookingPrice(listing, priceUnit, units, dr, h.Rounding.Rule(listing.Address.City, "RUB"))
 	if err != nil {
 		return nil, err
 	}
 
 	booking, err := domainbooking.NewBooking(domainbooking.CreateParams{
-		ID:        domainbooking.BookingID(cmd.CommandID),
-		ListingID: listing.ID,
-		GuestID:   cmd.GuestID,
-		Range:     dr,
-		Guests:    cmd.Guests,
-		Months:    months,
-		PriceUnit: priceUnit,
-		Price:     price,
+		ID:          domainbooking.BookingID(cmd.CommandID),
+		ListingID:   listing.ID,
+		GuestID:     cmd.GuestID,
+		Range:       dr,
+		Guests:      cmd.Guests,
+		GuestsLimit: listing.GuestsLimit,
+		Months:      months,
+		PriceUnit:   priceUnit,
+		Price:       price,
 		Policy: domainbooking.CancellationPolicySnapshot{
 			PolicyID: listing.CancellationPolicyID,
 		},
@@ -119,12 +193,17 @@ func (h *RequestBookingHandler) Handle(ctx context.Context, cmd RequestBookingCo
 		return nil, err
 	}
 
+	reserved, err := reserveNights(ctx, unit, listing, booking, now)
+	if err != nil {
+		return nil, err
+	}
 	if err := unit.Booking().Save(ctx, booking); err != nil {
 		return nil, err
 	}
 
 	r := booking.PendingEvents()
 	booking.ClearEvents()
+	r = append(r, reserved...)
 	if err := outbox.RecordDomainEvents(ctx, h.Outbox, h.encoder(), r); err != nil {
 		return nil, err
 	}
@@ -136,7 +215,42 @@ func (h *RequestBookingHandler) Handle(ctx context.Context, cmd RequestBookingCo
 		committed = true
 	}
 
-	return &RequestBookingResult{BookingID: string(booking.ID)}, nil
+	return &RequestBookingResult{
+		BookingID:           string(booking.ID),
+		GuestBookingSummary: dto.MapGuestBookingSummary(booking, listing, nil, false),
+		Price:               dto.MapBookingPriceBreakdown(booking.Price),
+		Policy:              dto.MapCancellationPolicyPreview(booking, now),
+		ExpiresAt:           booking.CreatedAt.Add(h.pendingTTL()),
+	}, nil
+}
+
+func (h *RequestBookingHandler) pendingTTL() time.Duration {
+	if h.PendingTTL <= 0 {
+		return defaultPendingTTL
+	}
+	return h.PendingTTL
+}
+
+// checkGuestVerified lets anyone book unless the listing takes identity-verified
+// guests only; an unknown guest counts as unverified.
+func checkGuestVerified(ctx context.Context, users domainuser.Repository, listing *domainlistings.Listing, guestID string) error {
+	if !listing.VerifiedGuestsOnly {
+		return nil
+	}
+	if users == nil {
+		return ErrGuestNotVerified
+	}
+	guest, err := users.ByID(ctx, domainuser.ID(guestID))
+	if err != nil {
+		if errors.Is(err, domainuser.ErrNotFound) {
+			return ErrGuestNotVerified
+		}
+		return err
+	}
+	if !guest.IdentityVerified() {
+		return ErrGuestNotVerified
+	}
+	return nil
 }
 
 func (h *RequestBookingHandler) encoder() outbox.EventEncoder {
@@ -146,23 +260,31 @@ func (h *RequestBookingHandler) encoder() outbox.EventEncoder {
 	return outbox.JSONEventEncoder{}
 }
 
+// resolveBookingRange turns the request into a date range: long-term rentals
+// take a number of months from check-in, short-term stays an explicit
+// check-out and no months. Listing month bounds are enforced by checkStayLength.
+// Ranges start and end at UTC midnight of the dates given; a short-term stay
+// whose check-in or check-out carries a time of day is rejected.
 func resolveBookingRange(term domainlistings.RentalTermType, checkIn, checkOut time.Time, months int) (domainrange.DateRange, int, string, error) {
 	switch term {
 	case domainlistings.RentalTermLong:
 		if months < 1 || months > 12 {
-			return domainrange.DateRange{}, 0, "", errors.New("months must be between 1 and 12")
+			return domainrange.DateRange{}, 0, "", domainbooking.ErrMonthsRange
 		}
-		computedOut := checkIn.AddDate(0, months, 0)
-		dr, err := domainrange.New(checkIn, computedOut)
+		year, month, day := checkIn.Date()
+		dr, err := domainrange.NewDates(year, month, day, year, month+time.Month(months), day)
 		if err != nil {
 			return domainrange.DateRange{}, 0, "", err
 		}
 		return dr, months, "month", nil
 	default:
+		if months != 0 {
+			return domainrange.DateRange{}, 0, "", domainbooking.ErrMonthsNotAllowed
+		}
 		if checkOut.IsZero() {
-			return domainrange.DateRange{}, 0, "", errors.New("check_out is required")
+			return domainrange.DateRange{}, 0, "", ErrCheckOutRequired
 		}
-		dr, err := domainrange.New(checkIn, checkOut)
+		dr, err := domainrange.NewNightly(checkIn, checkOut)
 		if err != nil {
 			return domainrange.DateRange{}, 0, "", err
 		}
@@ -170,15 +292,54 @@ func resolveBookingRange(term domainlistings.RentalTermType, checkIn, checkOut t
 	}
 }
 
-func buildBookingPrice(rateRub int64, units int) (domainpricing.PriceBreakdown, error) {
+// checkStayLength enforces the listing bounds: nights for short-term stays,
+// months for long-term rentals. A zero maximum means no upper bound.
+func checkStayLength(listing *domainlistings.Listing, dr domainrange.DateRange, months int, priceUnit string) error {
+	units, minUnits, maxUnits, unitName := dr.Nights(), listing.MinNights, listing.MaxNights, "nights"
+	if priceUnit == "month" {
+		units, minUnits, maxUnits, unitName = months, listing.MinMonths, listing.MaxMonths, "months"
+	}
+	if units < minUnits {
+		return fmt.Errorf("%w: %d %s requested, at least %d required", domainbooking.ErrBelowMinNights, units, unitName, minUnits)
+	}
+	if maxUnits > 0 && units > maxUnits {
+		return fmt.Errorf("%w: %d %s requested, at most %d allowed", domainbooking.ErrExceedsMaxNights, units, unitName, maxUnits)
+	}
+	return nil
+}
+
+// checkAvailableFrom rejects a check-in before the day the listing opens for
+// bookings. Both terms are compared by calendar day in UTC.
+func checkAvailableFrom(listing *domainlistings.Listing, dr domainrange.DateRange) error {
+	if listing.AvailableFrom.IsZero() {
+		return nil
+	}
+	availableFrom := listing.AvailableFrom.UTC().Truncate(24 * time.Hour)
+	if dr.CheckIn.Truncate(24 * time.Hour).Before(availableFrom) {
+		return fmt.Errorf("%w: available from %s", domainbooking.ErrBeforeAvailableFrom, availableFrom.Format(time.DateOnly))
+	}
+	return nil
+}
+
+func buildBookingPrice(listing *domainlistings.Listing, priceUnit string, units int, dr domainrange.DateRange, rounding domainpricing.RoundingRule) (domainpricing.PriceBreakdown, error) {
 	if units <= 0 {
 		return domainpricing.PriceBreakdown{}, errors.New("booking: units must be positive")
 	}
 	breakdown := domainpricing.PriceBreakdown{
+		Unit:    priceUnit,
 		Nights:  units,
-		Nightly: money.Must(rateRub, "RUB"),
+		Nightly: money.Must(listing.RateRub, "RUB"),
+	}
+	if err := breakdown.ApplyWeekendPremium(listing, dr); err != nil {
+		return domainpricing.PriceBreakdown{}, err
+	}
+	if err := breakdown.ApplyLongStayDiscount(listing); err != nil {
+		return domainpricing.PriceBreakdown{}, err
+	}
+	if err := breakdown.ApplyListingFees(listing); err != nil {
+		return domainpricing.PriceBreakdown{}, err
 	}
-	if err := breakdown.RecalculateTotal(); err != nil {
+	if err := breakdown.ApplyRounding(rounding); err != nil {
 		return domainpricing.PriceBreakdown{}, err
 	}
 	return breakdown, nil
@@ -186,3 +347,4 @@ func buildBookingPrice(rateRub int64, units int) (domainpricing.PriceBreakdown,
 
 var _ commands.Handler[RequestBookingCommand, *RequestBookingResult] = (*RequestBookingHandler)(nil)
 var _ middleware.IdempotentCommand = (*RequestBookingCommand)(nil)
+var _ middleware.IdempotencyFingerprinter = (*RequestBookingCommand)(nil)
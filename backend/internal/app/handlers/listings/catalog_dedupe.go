@@ -0,0 +1,125 @@
+package listings
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"rentme/internal/app/dto"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+// Outcomes reported for a deduplicated catalog search.
+const (
+	CatalogSearchComputed = "computed"
+	CatalogSearchShared   = "shared"
+	CatalogSearchCached   = "cached"
+)
+
+// CatalogSearchMetrics counts how catalog searches were answered, so the dedupe
+// hit rate is visible.
+type CatalogSearchMetrics interface {
+	ObserveCatalogSearch(outcome string)
+}
+
+// CatalogDedupe collapses identical catalog searches, as type-ahead fires one
+// per keystroke. Searches with the same normalized filters that run at the
+// same time share one computation; with a positive CacheTTL (meant to be a
+// second or two) a finished result is also reused for that long. The shared
+// result excludes viewer-specific fields, which the handler fills in per call.
+type CatalogDedupe struct {
+	CacheTTL time.Duration
+	Metrics  CatalogSearchMetrics
+
+	group singleflight.Group
+	mu    sync.Mutex
+	cache map[string]cachedCatalog
+}
+
+type cachedCatalog struct {
+	catalog dto.ListingCatalog
+	expires time.Time
+}
+
+// do returns the catalog for params, running search only when neither an
+// in-flight search nor a cached result can answer it.
+func (d *CatalogDedupe) do(params domainlistings.SearchParams, availableOnly bool, search func() (dto.ListingCatalog, error)) (dto.ListingCatalog, error) {
+	key, err := catalogSearchKey(params, availableOnly)
+	if err != nil {
+		return search()
+	}
+	if catalog, ok := d.cached(key, time.Now()); ok {
+		d.observe(CatalogSearchCached)
+		return catalog, nil
+	}
+	leader := false
+	value, err, _ := d.group.Do(key, func() (any, error) {
+		leader = true
+		catalog, err := search()
+		if err == nil {
+			d.store(key, catalog, time.Now())
+		}
+		return catalog, err
+	})
+	if leader {
+		d.observe(CatalogSearchComputed)
+	} else {
+		d.observe(CatalogSearchShared)
+	}
+	if err != nil {
+		return dto.ListingCatalog{}, err
+	}
+	return value.(dto.ListingCatalog), nil
+}
+
+func (d *CatalogDedupe) cached(key string, now time.Time) (dto.ListingCatalog, bool) {
+	if d.CacheTTL <= 0 {
+		return dto.ListingCatalog{}, false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.cache[key]
+	if !ok || !now.Before(entry.expires) {
+		return dto.ListingCatalog{}, false
+	}
+	return entry.catalog, true
+}
+
+func (d *CatalogDedupe) store(key string, catalog dto.ListingCatalog, now time.Time) {
+	if d.CacheTTL <= 0 {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cache == nil {
+		d.cache = make(map[string]cachedCatalog)
+	}
+	// Entries live for seconds, so sweeping on write keeps the map small.
+	for k, entry := range d.cache {
+		if !now.Before(entry.expires) {
+			delete(d.cache, k)
+		}
+	}
+	d.cache[key] = cachedCatalog{catalog: catalog, expires: now.Add(d.CacheTTL)}
+}
+
+func (d *CatalogDedupe) observe(outcome string) {
+	if d.Metrics != nil {
+		d.Metrics.ObserveCatalogSearch(outcome)
+	}
+}
+
+// catalogSearchKey identifies a search by its normalized filters, so
+// differences in letter case, whitespace or repeated tokens collapse into one key.
+func catalogSearchKey(params domainlistings.SearchParams, availableOnly bool) (string, error) {
+	raw, err := json.Marshal(struct {
+		Params        domainlistings.SearchParams
+		AvailableOnly bool
+	}{params.Normalized(), availableOnly})
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
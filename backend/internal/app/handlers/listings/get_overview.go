@@ -51,7 +51,14 @@ func (h *GetOverviewHandler) Handle(ctx context.Context, q GetOverviewQuery) (dt
 		return dto.ListingOverview{}, err
 	}
 
-	return dto.MapListingOverview(listing, calendar, q.From, q.To), nil
+	stats, err := unit.Reviews().StatsByListing(ctx, listing.ID)
+	if err != nil {
+		return dto.ListingOverview{}, err
+	}
+
+	overview := dto.MapListingOverview(listing, calendar, q.From, q.To)
+	dto.ApplyReviewStats(&overview, stats)
+	return overview, nil
 }
 
 var _ queries.Handler[GetOverviewQuery, dto.ListingOverview] = (*GetOverviewHandler)(nil)
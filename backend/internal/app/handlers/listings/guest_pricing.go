@@ -0,0 +1,190 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/policies"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+	domainrange "rentme/internal/domain/shared/daterange"
+)
+
+const getPriceCalendarKey = "listings.price_calendar"
+
+// MaxPriceCalendarDays bounds a single price calendar request.
+const MaxPriceCalendarDays = 90
+
+// priceCalendarQuoteConcurrency bounds the nightly quotes in flight at once,
+// so a full window takes a few round trips to ML pricing instead of 90.
+const priceCalendarQuoteConcurrency = 8
+
+// priceCalendarTTL keeps quotes long enough to absorb calendar scrolling
+// without hammering ML pricing.
+const priceCalendarTTL = 60 * time.Second
+
+var (
+	ErrPriceCalendarRange = errors.New("price calendar window must be between 1 and 90 days")
+	ErrListingNotFound    = errors.New("listing not found")
+)
+
+// GetPriceCalendarQuery asks for per-night prices across [From, To).
+type GetPriceCalendarQuery struct {
+	ListingID string
+	From      time.Time
+	To        time.Time
+	Guests    int
+}
+
+func (q GetPriceCalendarQuery) Key() string { return getPriceCalendarKey }
+
+type GetPriceCalendarHandler struct {
+	Logger     *slog.Logger
+	Pricing    policies.PricingPort
+	UoWFactory uow.UoWFactory
+	Now        func() time.Time
+
+	cache sync.Map
+}
+
+type priceCalendarCacheKey struct {
+	listingID string
+	from      time.Time
+	to        time.Time
+	guests    int
+}
+
+type priceCalendarCacheEntry struct {
+	value     dto.PriceCalendar
+	expiresAt time.Time
+}
+
+func (h *GetPriceCalendarHandler) Handle(ctx context.Context, q GetPriceCalendarQuery) (dto.PriceCalendar, error) {
+	var zero dto.PriceCalendar
+	if strings.TrimSpace(q.ListingID) == "" {
+		return zero, errors.New("listing id is required")
+	}
+	from := domainrange.DateOf(q.From)
+	to := domainrange.DateOf(q.To)
+	days := int(to.Sub(from).Hours() / 24)
+	if days < 1 || days > MaxPriceCalendarDays {
+		return zero, ErrPriceCalendarRange
+	}
+	if h.Pricing == nil {
+		return zero, errors.New("pricing service unavailable")
+	}
+	guests := q.Guests
+	if guests <= 0 {
+		guests = 1
+	}
+
+	now := h.now()
+	key := priceCalendarCacheKey{listingID: q.ListingID, from: from, to: to, guests: guests}
+	if cached, ok := h.cache.Load(key); ok {
+		entry := cached.(priceCalendarCacheEntry)
+		if now.Before(entry.expiresAt) {
+			return entry.value, nil
+		}
+		h.cache.Delete(key)
+	}
+
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return zero, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	listing, err := unit.Listings().ByID(execCtx, domainlistings.ListingID(q.ListingID))
+	if err != nil {
+		if errors.Is(err, domainlistings.ErrListingNotFound) {
+			return zero, fmt.Errorf("%w: %v", ErrListingNotFound, err)
+		}
+		return zero, err
+	}
+	calendar, err := unit.Availability().Calendar(execCtx, listing.ID)
+	if err != nil {
+		return zero, err
+	}
+
+	// Long-term rent is quoted per month, so there is no nightly price to show;
+	// those calendars carry availability and the month bounds only.
+	nightly := listing.RentalTermType == domainlistings.RentalTermShort
+	entries := make([]dto.PriceCalendarEntry, days)
+	group, groupCtx := errgroup.WithContext(execCtx)
+	group.SetLimit(priceCalendarQuoteConcurrency)
+	for i := range entries {
+		day := from.AddDate(0, 0, i)
+		night, err := domainrange.NewNightly(day, day.AddDate(0, 0, 1))
+		if err != nil {
+			return zero, err
+		}
+		entries[i] = dto.PriceCalendarEntry{
+			Date:        day,
+			IsAvailable: calendar == nil || calendar.CanReserve(night),
+		}
+		if !nightly {
+			continue
+		}
+		group.Go(func() error {
+			quote, err := h.Pricing.Quote(groupCtx, listing, night, guests)
+			if err != nil {
+				return err
+			}
+			// The quote total also carries the once-per-stay fees; a day
+			// shows only its rent.
+			entries[i].PriceRub = quote.Nightly.Amount + quote.WeekendSurcharge.Amount
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return zero, err
+	}
+
+	result := dto.PriceCalendar{
+		ListingID:  string(listing.ID),
+		Currency:   "RUB",
+		Guests:     guests,
+		RentalTerm: string(listing.RentalTermType),
+		Days:       entries,
+	}
+	if nightly {
+		result.MinNights, result.MaxNights = listing.MinNights, listing.MaxNights
+	} else {
+		result.RentalTerm = string(domainlistings.RentalTermLong)
+		result.MinMonths, result.MaxMonths = listing.MinMonths, listing.MaxMonths
+	}
+	h.sweepCache(now)
+	h.cache.Store(key, priceCalendarCacheEntry{value: result, expiresAt: now.Add(priceCalendarTTL)})
+	return result, nil
+}
+
+// sweepCache drops expired entries so abandoned windows do not accumulate.
+func (h *GetPriceCalendarHandler) sweepCache(now time.Time) {
+	h.cache.Range(func(key, value any) bool {
+		if entry, ok := value.(priceCalendarCacheEntry); ok && !now.Before(entry.expiresAt) {
+			h.cache.Delete(key)
+		}
+		return true
+	})
+}
+
+func (h *GetPriceCalendarHandler) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
+
+var _ queries.Handler[GetPriceCalendarQuery, dto.PriceCalendar] = (*GetPriceCalendarHandler)(nil)
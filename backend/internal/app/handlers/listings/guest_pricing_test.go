@@ -0,0 +1,196 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	domainlistings "rentme/internal/domain/listings"
+	domainpricing "rentme/internal/domain/pricing"
+	domainrange "rentme/internal/domain/shared/daterange"
+	"rentme/internal/domain/shared/money"
+)
+
+type countingPricing struct {
+	mu       sync.Mutex
+	calls    int
+	inFlight int
+	peak     int
+	delay    time.Duration
+}
+
+func (p *countingPricing) Quote(ctx context.Context, listing *domainlistings.Listing, dr domainrange.DateRange, guests int) (domainpricing.PriceBreakdown, error) {
+	p.mu.Lock()
+	p.calls++
+	p.inFlight++
+	if p.inFlight > p.peak {
+		p.peak = p.inFlight
+	}
+	p.mu.Unlock()
+	time.Sleep(p.delay)
+	p.mu.Lock()
+	p.inFlight--
+	p.mu.Unlock()
+	nightly := money.Money{Amount: int64(1000 * guests), Currency: "RUB"}
+	return domainpricing.PriceBreakdown{Nights: 1, Nightly: nightly, Total: nightly}, nil
+}
+
+// listingPricing quotes from the listing rate with its cleaning fee, weekend
+// premium and a service fee, the way ML pricing breaks a stay down.
+type listingPricing struct{}
+
+func (listingPricing) Quote(ctx context.Context, listing *domainlistings.Listing, dr domainrange.DateRange, guests int) (domainpricing.PriceBreakdown, error) {
+	breakdown := domainpricing.PriceBreakdown{
+		Nights:  dr.Nights(),
+		Nightly: money.Money{Amount: listing.RateRub, Currency: "RUB"},
+		Fees:    []domainpricing.Fee{{Name: domainpricing.FeeService, Amount: money.Money{Amount: 300, Currency: "RUB"}}},
+	}
+	if err := breakdown.ApplyListingFees(listing); err != nil {
+		return domainpricing.PriceBreakdown{}, err
+	}
+	if err := breakdown.ApplyWeekendPremium(listing, dr); err != nil {
+		return domainpricing.PriceBreakdown{}, err
+	}
+	return breakdown, breakdown.RecalculateTotal()
+}
+
+func priceCalendarFactory(listings domainlistings.ListingRepository) stubFactory {
+	return stubFactory{unit: stubUnit{listings: listings, availability: stubAvailability{}}}
+}
+
+func savedListing() stubListings {
+	return stubListings{items: map[domainlistings.ListingID]*domainlistings.Listing{
+		"listing-1": {ID: "listing-1", State: domainlistings.ListingActive, RentalTermType: domainlistings.RentalTermShort},
+	}}
+}
+
+var calendarFrom = time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func TestPriceCalendarCacheIgnoresUnsetGuests(t *testing.T) {
+	pricing := &countingPricing{}
+	handler := &GetPriceCalendarHandler{Pricing: pricing, UoWFactory: priceCalendarFactory(savedListing())}
+	to := calendarFrom.AddDate(0, 0, 3)
+
+	first, err := handler.Handle(context.Background(), GetPriceCalendarQuery{ListingID: "listing-1", From: calendarFrom, To: to})
+	if err != nil {
+		t.Fatalf("first: %v", err)
+	}
+	second, err := handler.Handle(context.Background(), GetPriceCalendarQuery{ListingID: "listing-1", From: calendarFrom, To: to, Guests: 1})
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if pricing.calls != 3 {
+		t.Fatalf("quotes = %d, want 3: guests 0 and 1 should share a cache entry", pricing.calls)
+	}
+	if first.Guests != 1 || second.Guests != 1 {
+		t.Fatalf("guests = %d and %d, want 1", first.Guests, second.Guests)
+	}
+}
+
+func TestPriceCalendarBoundsConcurrentQuotes(t *testing.T) {
+	pricing := &countingPricing{delay: 2 * time.Millisecond}
+	handler := &GetPriceCalendarHandler{Pricing: pricing, UoWFactory: priceCalendarFactory(savedListing())}
+
+	result, err := handler.Handle(context.Background(), GetPriceCalendarQuery{
+		ListingID: "listing-1",
+		From:      calendarFrom,
+		To:        calendarFrom.AddDate(0, 0, MaxPriceCalendarDays),
+		Guests:    2,
+	})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	if pricing.peak > priceCalendarQuoteConcurrency {
+		t.Fatalf("peak quotes in flight = %d, want at most %d", pricing.peak, priceCalendarQuoteConcurrency)
+	}
+	if len(result.Days) != MaxPriceCalendarDays {
+		t.Fatalf("days = %d, want %d", len(result.Days), MaxPriceCalendarDays)
+	}
+	for i, day := range result.Days {
+		if want := calendarFrom.AddDate(0, 0, i); !day.Date.Equal(want) {
+			t.Fatalf("day %d = %s, want %s", i, day.Date, want)
+		}
+		if day.PriceRub != 2000 {
+			t.Fatalf("day %d price = %d, want 2000", i, day.PriceRub)
+		}
+	}
+}
+
+func TestPriceCalendarListingErrors(t *testing.T) {
+	boom := errors.New("connection reset")
+	cases := []struct {
+		name         string
+		err          error
+		wantNotFound bool
+	}{
+		{"missing", domainlistings.ErrListingNotFound, true},
+		{"repository failure", boom, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &GetPriceCalendarHandler{Pricing: &countingPricing{}, UoWFactory: priceCalendarFactory(stubListings{err: tc.err})}
+			_, err := handler.Handle(context.Background(), GetPriceCalendarQuery{ListingID: "listing-1", From: calendarFrom, To: calendarFrom.AddDate(0, 0, 1)})
+			if got := errors.Is(err, ErrListingNotFound); got != tc.wantNotFound {
+				t.Fatalf("errors.Is(%v, ErrListingNotFound) = %t, want %t", err, got, tc.wantNotFound)
+			}
+			if !errors.Is(err, tc.err) && !tc.wantNotFound {
+				t.Fatalf("err = %v, want the repository error", err)
+			}
+		})
+	}
+}
+
+func TestPriceCalendarShowsNightlyRentWithoutStayFees(t *testing.T) {
+	listings := stubListings{items: map[domainlistings.ListingID]*domainlistings.Listing{
+		"listing-1": {
+			ID:                "listing-1",
+			State:             domainlistings.ListingActive,
+			RentalTermType:    domainlistings.RentalTermShort,
+			RateRub:           4000,
+			CleaningFeeRub:    1500,
+			WeekendPremiumPct: 25,
+		},
+	}}
+	handler := &GetPriceCalendarHandler{Pricing: listingPricing{}, UoWFactory: priceCalendarFactory(listings)}
+
+	// 1 May 2026 is a Friday: Friday and Saturday carry the premium, Sunday does not.
+	result, err := handler.Handle(context.Background(), GetPriceCalendarQuery{ListingID: "listing-1", From: calendarFrom, To: calendarFrom.AddDate(0, 0, 3)})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	want := []int64{5000, 5000, 4000}
+	if len(result.Days) != len(want) {
+		t.Fatalf("days = %d, want %d", len(result.Days), len(want))
+	}
+	for i, day := range result.Days {
+		if day.PriceRub != want[i] {
+			t.Errorf("%s price = %d, want %d", day.Date.Weekday(), day.PriceRub, want[i])
+		}
+	}
+}
+
+func TestPriceCalendarSkipsNightlyPricesForLongTermListings(t *testing.T) {
+	pricing := &countingPricing{}
+	listings := stubListings{items: map[domainlistings.ListingID]*domainlistings.Listing{
+		"listing-1": {ID: "listing-1", State: domainlistings.ListingActive, RentalTermType: domainlistings.RentalTermLong, RateRub: 60000, MinMonths: 3},
+	}}
+	handler := &GetPriceCalendarHandler{Pricing: pricing, UoWFactory: priceCalendarFactory(listings)}
+
+	result, err := handler.Handle(context.Background(), GetPriceCalendarQuery{ListingID: "listing-1", From: calendarFrom, To: calendarFrom.AddDate(0, 0, 5)})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	if pricing.calls != 0 {
+		t.Fatalf("quotes = %d, want none for a monthly rental", pricing.calls)
+	}
+	if result.RentalTerm != string(domainlistings.RentalTermLong) || result.MinMonths != 3 || len(result.Days) != 5 {
+		t.Fatalf("calendar = %+v, want five days with the month bounds", result)
+	}
+	for _, day := range result.Days {
+		if day.PriceRub != 0 || !day.IsAvailable {
+			t.Fatalf("day = %+v, want available with no nightly price", day)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"rentme/internal/app/dto"
+	"rentme/internal/app/queries"
+	domainlistings "rentme/internal/domain/listings"
+	domainmarkets "rentme/internal/domain/markets"
+)
+
+const validateHostListingAddressKey = "host.listings.validate_address"
+
+// ValidateHostListingAddressQuery runs the address step of the listing form
+// on its own. It normalizes with the same function create and update use and
+// persists nothing.
+type ValidateHostListingAddressQuery struct {
+	HostID  string
+	Address domainlistings.Address
+}
+
+func (q ValidateHostListingAddressQuery) Key() string { return validateHostListingAddressKey }
+
+type ValidateHostListingAddressHandler struct {
+	Markets domainmarkets.Repository
+}
+
+func (h *ValidateHostListingAddressHandler) Handle(ctx context.Context, q ValidateHostListingAddressQuery) (dto.HostListingAddressValidation, error) {
+	if strings.TrimSpace(q.HostID) == "" {
+		return dto.HostListingAddressValidation{}, errors.New("host id is required")
+	}
+	address, warnings, err := domainlistings.NormalizeAddress(q.Address)
+	if err != nil {
+		return dto.HostListingAddressValidation{}, err
+	}
+	supported := true
+	if address.City != "" {
+		supported, err = marketSupports(ctx, h.Markets, address.City)
+		if err != nil {
+			return dto.HostListingAddressValidation{}, err
+		}
+		if !supported {
+			warnings = append(warnings, "city is outside the supported markets; the listing cannot be published there")
+		}
+	}
+	if warnings == nil {
+		warnings = []string{}
+	}
+	return dto.HostListingAddressValidation{
+		Address:         dto.MapListingAddress(address),
+		MarketSupported: supported,
+		Warnings:        warnings,
+	}, nil
+}
+
+var _ queries.Handler[ValidateHostListingAddressQuery, dto.HostListingAddressValidation] = (*ValidateHostListingAddressHandler)(nil)
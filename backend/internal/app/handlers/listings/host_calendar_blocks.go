@@ -0,0 +1,183 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/outbox"
+	domainavailability "rentme/internal/domain/availability"
+	"rentme/internal/domain/shared/daterange"
+)
+
+const (
+	bulkBlockCalendarKey = "host.listings.calendar.bulk_block"
+
+	// MaxBulkBlockRanges caps how many ranges one request may block.
+	MaxBulkBlockRanges = 50
+	maxBlockNoteLength = 200
+
+	hostBlockReferencePrefix = "host-block:"
+)
+
+var (
+	ErrNoBlockRanges       = errors.New("at least one range is required")
+	ErrTooManyBlockRanges  = fmt.Errorf("at most %d ranges can be blocked at once", MaxBulkBlockRanges)
+	ErrBlockDate           = errors.New("from and to must be dates in YYYY-MM-DD format")
+	ErrBlockInPast         = errors.New("range ends in the past")
+	ErrBlockNoteTooLong    = fmt.Errorf("reason must be at most %d characters", maxBlockNoteLength)
+	ErrBlockRangesOverlap  = errors.New("range overlaps another range in the request")
+	ErrBlockCalendarBooked = errors.New("range overlaps an existing booking or block")
+)
+
+// BlockRangeInput is one range to close, in [From, To) nights. Reason is the
+// host's note for the block.
+type BlockRangeInput struct {
+	From   string
+	To     string
+	Reason string
+}
+
+// BlockRangeError reports why the range at Index was rejected.
+type BlockRangeError struct {
+	Index int
+	Err   error
+}
+
+// BulkBlockError lists every rejected range of a bulk block request; when it
+// is returned no range was blocked.
+type BulkBlockError struct {
+	Ranges []BlockRangeError
+}
+
+func (e *BulkBlockError) Error() string {
+	return fmt.Sprintf("%d of the ranges cannot be blocked", len(e.Ranges))
+}
+
+// BulkBlockCalendarCommand closes several, possibly non-contiguous, ranges of
+// a listing calendar at once.
+type BulkBlockCalendarCommand struct {
+	HostID    string
+	ListingID string
+	Ranges    []BlockRangeInput
+}
+
+func (c BulkBlockCalendarCommand) Key() string { return bulkBlockCalendarKey }
+
+// BulkBlockCalendarHandler validates every range before blocking any, so the
+// host gets all problems in one response and the calendar is changed either
+// for the whole batch or not at all.
+type BulkBlockCalendarHandler struct {
+	Outbox  outbox.Outbox
+	Encoder outbox.EventEncoder
+	Logger  *slog.Logger
+}
+
+func (h *BulkBlockCalendarHandler) Handle(ctx context.Context, cmd BulkBlockCalendarCommand) ([]dto.HostCalendarBlock, error) {
+	if len(cmd.Ranges) == 0 {
+		return nil, ErrNoBlockRanges
+	}
+	if len(cmd.Ranges) > MaxBulkBlockRanges {
+		return nil, ErrTooManyBlockRanges
+	}
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	calendar, err := unit.Availability().Calendar(ctx, listing.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	ranges := make([]daterange.DateRange, len(cmd.Ranges))
+	var rejected []BlockRangeError
+	for i, input := range cmd.Ranges {
+		r, err := validateBlockRange(input, today)
+		if err == nil {
+			for j := 0; j < i; j++ {
+				if !ranges[j].CheckIn.IsZero() && ranges[j].Overlaps(r) {
+					err = ErrBlockRangesOverlap
+					break
+				}
+			}
+		}
+		if err == nil && !calendar.CanReserve(r) {
+			err = ErrBlockCalendarBooked
+		}
+		if err != nil {
+			rejected = append(rejected, BlockRangeError{Index: i, Err: err})
+			continue
+		}
+		ranges[i] = r
+	}
+	if len(rejected) > 0 {
+		return nil, &BulkBlockError{Ranges: rejected}
+	}
+
+	blocks := make([]dto.HostCalendarBlock, 0, len(ranges))
+	for i, r := range ranges {
+		reference := hostBlockReferencePrefix + uuid.NewString()
+		note := strings.TrimSpace(cmd.Ranges[i].Reason)
+		if err := calendar.HostBlock(r, reference, note, now); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, dto.HostCalendarBlock{
+			Reference: reference,
+			From:      r.CheckIn,
+			To:        r.CheckOut,
+			Reason:    string(domainavailability.ReasonHostBlock),
+			Note:      note,
+		})
+	}
+	if err := unit.Availability().Save(ctx, calendar); err != nil {
+		return nil, err
+	}
+	pending := calendar.PendingEvents()
+	calendar.ClearEvents()
+	if err := outbox.RecordDomainEvents(ctx, h.Outbox, h.encoder(), pending); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("host calendar blocked", "listing_id", listing.ID, "host_id", cmd.HostID, "ranges", len(blocks))
+	}
+	return blocks, nil
+}
+
+func (h *BulkBlockCalendarHandler) encoder() outbox.EventEncoder {
+	if h.Encoder != nil {
+		return h.Encoder
+	}
+	return outbox.JSONEventEncoder{}
+}
+
+func validateBlockRange(input BlockRangeInput, today time.Time) (daterange.DateRange, error) {
+	from, errFrom := time.Parse(time.DateOnly, strings.TrimSpace(input.From))
+	to, errTo := time.Parse(time.DateOnly, strings.TrimSpace(input.To))
+	if errFrom != nil || errTo != nil {
+		return daterange.DateRange{}, ErrBlockDate
+	}
+	r, err := daterange.New(from, to)
+	if err != nil {
+		return daterange.DateRange{}, err
+	}
+	if !r.CheckOut.After(today) {
+		return daterange.DateRange{}, ErrBlockInPast
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(input.Reason)) > maxBlockNoteLength {
+		return daterange.DateRange{}, ErrBlockNoteTooLong
+	}
+	return r, nil
+}
+
+var _ commands.Handler[BulkBlockCalendarCommand, []dto.HostCalendarBlock] = (*BulkBlockCalendarHandler)(nil)
@@ -3,6 +3,7 @@ package listings
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"strings"
 	"time"
@@ -11,13 +12,16 @@ import (
 
 	"rentme/internal/app/commands"
 	"rentme/internal/app/dto"
+	"rentme/internal/app/schedule"
 	"rentme/internal/app/uow"
 	domainlistings "rentme/internal/domain/listings"
+	domainmarkets "rentme/internal/domain/markets"
 )
 
 const (
 	createHostListingKey    = "host.listings.create"
 	updateHostListingKey    = "host.listings.update"
+	patchHostListingKey     = "host.listings.patch"
 	publishHostListingKey   = "host.listings.publish"
 	unpublishHostListingKey = "host.listings.unpublish"
 )
@@ -36,7 +40,12 @@ type HostListingPayload struct {
 	GuestsLimit          int
 	MinNights            int
 	MaxNights            int
+	MinMonths            int
+	MaxMonths            int
 	RateRub              int64
+	CleaningFeeRub       int64
+	ServiceFeePct        float64
+	WeekendPremiumPct    float64
 	Bedrooms             int
 	Bathrooms            int
 	Floor                int
@@ -47,8 +56,12 @@ type HostListingPayload struct {
 	TravelMinutes        float64
 	TravelMode           string
 	RentalTermType       domainlistings.RentalTermType
+	VerifiedGuestsOnly   bool
 	AvailableFrom        time.Time
 	Photos               []string
+
+	LongStayDiscountPct     float64
+	LongStayThresholdNights int
 }
 
 type CreateHostListingCommand struct {
@@ -83,11 +96,16 @@ func (h *CreateHostListingHandler) Handle(ctx context.Context, cmd CreateHostLis
 		GuestsLimit:          cmd.Payload.GuestsLimit,
 		MinNights:            cmd.Payload.MinNights,
 		MaxNights:            cmd.Payload.MaxNights,
+		MinMonths:            cmd.Payload.MinMonths,
+		MaxMonths:            cmd.Payload.MaxMonths,
 		HouseRules:           cmd.Payload.HouseRules,
 		CancellationPolicyID: cmd.Payload.CancellationPolicyID,
 		Tags:                 cmd.Payload.Tags,
 		Highlights:           cmd.Payload.Highlights,
 		RateRub:              cmd.Payload.RateRub,
+		CleaningFeeRub:       cmd.Payload.CleaningFeeRub,
+		ServiceFeePct:        cmd.Payload.ServiceFeePct,
+		WeekendPremiumPct:    cmd.Payload.WeekendPremiumPct,
 		Bedrooms:             cmd.Payload.Bedrooms,
 		Bathrooms:            cmd.Payload.Bathrooms,
 		Floor:                cmd.Payload.Floor,
@@ -98,10 +116,14 @@ func (h *CreateHostListingHandler) Handle(ctx context.Context, cmd CreateHostLis
 		TravelMinutes:        cmd.Payload.TravelMinutes,
 		TravelMode:           cmd.Payload.TravelMode,
 		RentalTermType:       cmd.Payload.RentalTermType,
+		VerifiedGuestsOnly:   cmd.Payload.VerifiedGuestsOnly,
 		ThumbnailURL:         cmd.Payload.ThumbnailURL,
 		Photos:               cmd.Payload.Photos,
 		AvailableFrom:        cmd.Payload.AvailableFrom,
 		Now:                  time.Now(),
+
+		LongStayDiscountPct:     cmd.Payload.LongStayDiscountPct,
+		LongStayThresholdNights: cmd.Payload.LongStayThresholdNights,
 	})
 	if err != nil {
 		return nil, err
@@ -165,7 +187,12 @@ func (h *UpdateHostListingHandler) Handle(ctx context.Context, cmd UpdateHostLis
 		GuestsLimit:          cmd.Payload.GuestsLimit,
 		MinNights:            cmd.Payload.MinNights,
 		MaxNights:            cmd.Payload.MaxNights,
+		MinMonths:            cmd.Payload.MinMonths,
+		MaxMonths:            cmd.Payload.MaxMonths,
 		RateRub:              cmd.Payload.RateRub,
+		CleaningFeeRub:       cmd.Payload.CleaningFeeRub,
+		ServiceFeePct:        cmd.Payload.ServiceFeePct,
+		WeekendPremiumPct:    cmd.Payload.WeekendPremiumPct,
 		Bedrooms:             cmd.Payload.Bedrooms,
 		Bathrooms:            cmd.Payload.Bathrooms,
 		Floor:                cmd.Payload.Floor,
@@ -176,9 +203,14 @@ func (h *UpdateHostListingHandler) Handle(ctx context.Context, cmd UpdateHostLis
 		TravelMinutes:        cmd.Payload.TravelMinutes,
 		TravelMode:           cmd.Payload.TravelMode,
 		RentalTermType:       cmd.Payload.RentalTermType,
+		VerifiedGuestsOnly:   cmd.Payload.VerifiedGuestsOnly,
 		AvailableFrom:        cmd.Payload.AvailableFrom,
 		Photos:               cmd.Payload.Photos,
+		ChangedBy:            cmd.HostID,
 		Now:                  time.Now(),
+
+		LongStayDiscountPct:     cmd.Payload.LongStayDiscountPct,
+		LongStayThresholdNights: cmd.Payload.LongStayThresholdNights,
 	}); err != nil {
 		return nil, err
 	}
@@ -195,6 +227,48 @@ func (h *UpdateHostListingHandler) Handle(ctx context.Context, cmd UpdateHostLis
 	return &result, nil
 }
 
+// ErrNoListingFields is returned for a partial update that sets no field.
+var ErrNoListingFields = errors.New("no listing fields to update")
+
+// UpdateHostListingFieldsCommand changes only the listing fields set in
+// Fields, leaving the rest as stored.
+type UpdateHostListingFieldsCommand struct {
+	HostID    string
+	ListingID string
+	Fields    domainlistings.ListingPatch
+}
+
+func (c UpdateHostListingFieldsCommand) Key() string { return patchHostListingKey }
+
+type UpdateHostListingFieldsHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *UpdateHostListingFieldsHandler) Handle(ctx context.Context, cmd UpdateHostListingFieldsCommand) (*dto.HostListingDetail, error) {
+	if cmd.Fields.Empty() {
+		return nil, ErrNoListingFields
+	}
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := listing.ApplyPatch(cmd.Fields, cmd.HostID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("host listing patched", "listing_id", listing.ID, "host_id", cmd.HostID)
+	}
+
+	result := dto.MapHostListingDetail(listing)
+	return &result, nil
+}
+
 type PublishHostListingCommand struct {
 	HostID    string
 	ListingID string
@@ -203,7 +277,10 @@ type PublishHostListingCommand struct {
 func (c PublishHostListingCommand) Key() string { return publishHostListingKey }
 
 type PublishHostListingHandler struct {
-	Logger *slog.Logger
+	Markets domainmarkets.Repository
+	// Relaxed skips the content rules of ensurePublishable, for dev setups.
+	Relaxed bool
+	Logger  *slog.Logger
 }
 
 func (h *PublishHostListingHandler) Handle(ctx context.Context, cmd PublishHostListingCommand) (*dto.HostListingDetail, error) {
@@ -225,6 +302,12 @@ func (h *PublishHostListingHandler) Handle(ctx context.Context, cmd PublishHostL
 	if listing.Host != domainlistings.HostID(cmd.HostID) {
 		return nil, ErrListingNotOwned
 	}
+	if err := ensureMarket(ctx, h.Markets, listing); err != nil {
+		return nil, err
+	}
+	if err := ensurePublishable(listing, h.Relaxed); err != nil {
+		return nil, err
+	}
 
 	if err := listing.Activate(time.Now()); err != nil {
 		if h.Logger != nil {
@@ -249,18 +332,73 @@ func (h *PublishHostListingHandler) Handle(ctx context.Context, cmd PublishHostL
 	}
 
 	result := dto.MapHostListingDetail(listing)
+	// Untagged photos do not block publishing; the host just gets a nudge.
+	if untagged := listing.UntaggedPhotos(); untagged > 0 {
+		result.Recommendations = append(result.Recommendations, fmt.Sprintf("tag %d of %d photos by room so guests can browse the gallery", untagged, len(listing.Photos)))
+	}
 	return &result, nil
 }
 
+// ensureMarket rejects publishing outside supported cities. Listings that are
+// already active are left alone so a market change does not take them down.
+func ensureMarket(ctx context.Context, markets domainmarkets.Repository, listing *domainlistings.Listing) error {
+	if listing.State == domainlistings.ListingActive {
+		return nil
+	}
+	supported, err := marketSupports(ctx, markets, listing.Address.City)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("%w: %q", domainmarkets.ErrCityNotSupported, listing.Address.City)
+	}
+	return nil
+}
+
+// ensurePublishable holds a listing to the content rules before it goes live
+// and reports every rule it misses at once. Listings that are already active
+// stay up, like in ensureMarket; the host dashboard lists their issues.
+func ensurePublishable(listing *domainlistings.Listing, relaxed bool) error {
+	if relaxed || listing.State == domainlistings.ListingActive {
+		return nil
+	}
+	issues := listing.PublishIssues()
+	if len(issues) == 0 {
+		return nil
+	}
+	args := make([]any, len(issues))
+	for i, issue := range issues {
+		args[i] = issue
+	}
+	return fmt.Errorf(strings.TrimSuffix(strings.Repeat("%w; ", len(issues)), "; "), args...)
+}
+
+// marketSupports reports whether city is inside the market whitelist. A nil
+// repository means markets are not enforced.
+func marketSupports(ctx context.Context, markets domainmarkets.Repository, city string) (bool, error) {
+	if markets == nil {
+		return true, nil
+	}
+	settings, err := markets.Current(ctx)
+	if err != nil {
+		return false, err
+	}
+	return settings.Allows(city), nil
+}
+
+// UnpublishHostListingCommand suspends an active listing. A non-zero
+// RepublishAt schedules the listing to go live again at that time.
 type UnpublishHostListingCommand struct {
-	HostID    string
-	ListingID string
+	HostID      string
+	ListingID   string
+	RepublishAt time.Time
 }
 
 func (c UnpublishHostListingCommand) Key() string { return unpublishHostListingKey }
 
 type UnpublishHostListingHandler struct {
-	Logger *slog.Logger
+	Scheduler schedule.Scheduler
+	Logger    *slog.Logger
 }
 
 func (h *UnpublishHostListingHandler) Handle(ctx context.Context, cmd UnpublishHostListingCommand) (*dto.HostListingDetail, error) {
@@ -283,15 +421,35 @@ func (h *UnpublishHostListingHandler) Handle(ctx context.Context, cmd UnpublishH
 		return nil, ErrListingNotOwned
 	}
 
-	if err := listing.Suspend(time.Now(), "host-request"); err != nil {
+	now := time.Now()
+	if !cmd.RepublishAt.IsZero() {
+		if h.Scheduler == nil {
+			return nil, ErrRepublishUnavailable
+		}
+		if !cmd.RepublishAt.After(now) {
+			return nil, domainlistings.ErrRepublishAt
+		}
+	}
+	if err := listing.Suspend(now, "host-request"); err != nil {
 		return nil, err
 	}
+	if !cmd.RepublishAt.IsZero() {
+		if err := listing.ScheduleRepublish(cmd.RepublishAt, now); err != nil {
+			return nil, err
+		}
+	}
 	if err := unit.Listings().Save(ctx, listing); err != nil {
 		return nil, err
 	}
+	if !listing.RepublishAt.IsZero() {
+		payload := RepublishPayload{ListingID: string(listing.ID), RepublishAt: listing.RepublishAt}
+		if err := h.Scheduler.Schedule(ctx, RepublishJob, payload, listing.RepublishAt); err != nil {
+			return nil, err
+		}
+	}
 
 	if h.Logger != nil {
-		h.Logger.Info("host listing unpublished", "listing_id", listing.ID, "host_id", cmd.HostID)
+		h.Logger.Info("host listing unpublished", "listing_id", listing.ID, "host_id", cmd.HostID, "republish_at", listing.RepublishAt)
 	}
 
 	result := dto.MapHostListingDetail(listing)
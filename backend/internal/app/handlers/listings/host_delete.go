@@ -0,0 +1,127 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/uow"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+const (
+	deleteHostListingKey  = "host.listings.delete"
+	restoreHostListingKey = "host.listings.restore"
+)
+
+var ErrListingHasActiveBookings = errors.New("listings: listing has active bookings")
+
+// ActiveBookingsError lists the bookings that keep a listing from being deleted.
+type ActiveBookingsError struct {
+	BookingIDs []string
+}
+
+func (e *ActiveBookingsError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrListingHasActiveBookings.Error(), strings.Join(e.BookingIDs, ", "))
+}
+
+func (e *ActiveBookingsError) Unwrap() error { return ErrListingHasActiveBookings }
+
+// DeleteHostListingCommand soft-deletes a draft or suspended listing.
+type DeleteHostListingCommand struct {
+	HostID    string
+	ListingID string
+}
+
+func (c DeleteHostListingCommand) Key() string { return deleteHostListingKey }
+
+type DeleteHostListingHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *DeleteHostListingHandler) Handle(ctx context.Context, cmd DeleteHostListingCommand) (*dto.HostListingDetail, error) {
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureNoActiveBookings(ctx, unit, listing.ID); err != nil {
+		return nil, err
+	}
+	if err := listing.Delete(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("host listing deleted", "listing_id", listing.ID, "host_id", cmd.HostID)
+	}
+
+	result := dto.MapHostListingDetail(listing)
+	return &result, nil
+}
+
+// RestoreHostListingCommand brings a deleted listing back as a draft while
+// it is still inside the retention window.
+type RestoreHostListingCommand struct {
+	HostID    string
+	ListingID string
+}
+
+func (c RestoreHostListingCommand) Key() string { return restoreHostListingKey }
+
+type RestoreHostListingHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *RestoreHostListingHandler) Handle(ctx context.Context, cmd RestoreHostListingCommand) (*dto.HostListingDetail, error) {
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := listing.Restore(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("host listing restored", "listing_id", listing.ID, "host_id", cmd.HostID)
+	}
+
+	result := dto.MapHostListingDetail(listing)
+	return &result, nil
+}
+
+// ensureNoActiveBookings fails with ActiveBookingsError while any booking of
+// the listing is still open or in progress.
+func ensureNoActiveBookings(ctx context.Context, unit uow.UnitOfWork, id domainlistings.ListingID) error {
+	bookings, err := unit.Booking().ListByListing(ctx, id)
+	if err != nil {
+		return err
+	}
+	var active []string
+	for _, booking := range bookings {
+		switch booking.State {
+		case domainbooking.StatePending, domainbooking.StateAccepted, domainbooking.StateConfirmed, domainbooking.StateCheckedIn:
+			active = append(active, string(booking.ID))
+		}
+	}
+	if len(active) > 0 {
+		return &ActiveBookingsError{BookingIDs: active}
+	}
+	return nil
+}
+
+var (
+	_ commands.Handler[DeleteHostListingCommand, *dto.HostListingDetail]  = (*DeleteHostListingHandler)(nil)
+	_ commands.Handler[RestoreHostListingCommand, *dto.HostListingDetail] = (*RestoreHostListingHandler)(nil)
+)
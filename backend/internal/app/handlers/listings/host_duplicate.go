@@ -0,0 +1,104 @@
+package listings
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+const (
+	duplicateHostListingKey = "host.listings.duplicate"
+
+	duplicateTitlePrefix = "Copy of "
+)
+
+// DuplicateHostListingCommand creates a draft listing from one the host
+// already owns.
+type DuplicateHostListingCommand struct {
+	HostID          string
+	SourceListingID string
+}
+
+func (c DuplicateHostListingCommand) Key() string { return duplicateHostListingKey }
+
+// DuplicateHostListingHandler copies the listing attributes into a new draft.
+// Photos are not copied: their storage objects belong to the source listing
+// and deleting them from one copy would break the other. Co-hosts, the unit
+// group, rating and calendar stay with the source as well.
+type DuplicateHostListingHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *DuplicateHostListingHandler) Handle(ctx context.Context, cmd DuplicateHostListingCommand) (*dto.HostListingDetail, error) {
+	unit, source, err := loadOwnedListing(ctx, cmd.HostID, cmd.SourceListingID)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail := source.ThumbnailURL
+	if slices.Contains(source.Photos, thumbnail) {
+		thumbnail = ""
+	}
+	listing, err := domainlistings.NewListing(domainlistings.CreateListingParams{
+		ID:                   domainlistings.ListingID(uuid.NewString()),
+		Host:                 source.Host,
+		Title:                duplicateTitlePrefix + source.Title,
+		Description:          source.Description,
+		PropertyType:         source.PropertyType,
+		Address:              source.Address,
+		Amenities:            source.Amenities,
+		GuestsLimit:          source.GuestsLimit,
+		MinNights:            source.MinNights,
+		MaxNights:            source.MaxNights,
+		MinMonths:            source.MinMonths,
+		MaxMonths:            source.MaxMonths,
+		HouseRules:           source.HouseRules,
+		CancellationPolicyID: source.CancellationPolicyID,
+		Tags:                 source.Tags,
+		Highlights:           source.Highlights,
+		RateRub:              source.RateRub,
+		CleaningFeeRub:       source.CleaningFeeRub,
+		ServiceFeePct:        source.ServiceFeePct,
+		WeekendPremiumPct:    source.WeekendPremiumPct,
+		Bedrooms:             source.Bedrooms,
+		Bathrooms:            source.Bathrooms,
+		Floor:                source.Floor,
+		FloorsTotal:          source.FloorsTotal,
+		RenovationScore:      source.RenovationScore,
+		BuildingAgeYears:     source.BuildingAgeYears,
+		AreaSquareMeters:     source.AreaSquareMeters,
+		TravelMinutes:        source.TravelMinutes,
+		TravelMode:           source.TravelMode,
+		RentalTermType:       source.RentalTermType,
+		VerifiedGuestsOnly:   source.VerifiedGuestsOnly,
+		ThumbnailURL:         thumbnail,
+		AvailableFrom:        source.AvailableFrom,
+		Now:                  time.Now(),
+
+		LongStayDiscountPct:     source.LongStayDiscountPct,
+		LongStayThresholdNights: source.LongStayThresholdNights,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("host listing duplicated", "listing_id", listing.ID, "source_listing_id", source.ID, "host_id", cmd.HostID)
+	}
+
+	result := dto.MapHostListingDetail(listing)
+	return &result, nil
+}
+
+var _ commands.Handler[DuplicateHostListingCommand, *dto.HostListingDetail] = (*DuplicateHostListingHandler)(nil)
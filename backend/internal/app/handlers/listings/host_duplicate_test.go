@@ -0,0 +1,98 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+// savingListings is a stubListings that also stores saved listings.
+type savingListings struct {
+	stubListings
+}
+
+func (r savingListings) Save(_ context.Context, listing *domainlistings.Listing) error {
+	r.items[listing.ID] = listing
+	return nil
+}
+
+func duplicateSource() *domainlistings.Listing {
+	return &domainlistings.Listing{
+		ID:           "source",
+		Host:         "host-1",
+		Title:        "Loft on Arbat",
+		Address:      domainlistings.Address{Line1: "Arbat 1", City: "Moscow", Country: "RU"},
+		Amenities:    []string{"wifi", "washer"},
+		HouseRules:   []string{"no parties"},
+		Tags:         []string{"center"},
+		Highlights:   []string{"view"},
+		GuestsLimit:  3,
+		RateRub:      5000,
+		State:        domainlistings.ListingActive,
+		Photos:       []string{"listings/source/1.jpg"},
+		ThumbnailURL: "listings/source/1.jpg",
+	}
+}
+
+func duplicateContext(repo savingListings) context.Context {
+	return uow.ContextWithUnitOfWork(context.Background(), stubUnit{listings: repo})
+}
+
+func TestDuplicateHostListingIsIndependent(t *testing.T) {
+	repo := savingListings{stubListings{items: map[domainlistings.ListingID]*domainlistings.Listing{"source": duplicateSource()}}}
+	handler := &DuplicateHostListingHandler{}
+
+	detail, err := handler.Handle(duplicateContext(repo), DuplicateHostListingCommand{HostID: "host-1", SourceListingID: "source"})
+	if err != nil {
+		t.Fatalf("duplicate: %v", err)
+	}
+	if detail.ID == "source" || detail.ID == "" {
+		t.Fatalf("duplicate id = %q", detail.ID)
+	}
+	copied := repo.items[domainlistings.ListingID(detail.ID)]
+	if copied == nil {
+		t.Fatal("duplicate was not saved")
+	}
+	if copied.Title != "Copy of Loft on Arbat" || copied.State != domainlistings.ListingDraft {
+		t.Fatalf("duplicate title %q state %q", copied.Title, copied.State)
+	}
+	if len(copied.Photos) != 0 || copied.ThumbnailURL != "" {
+		t.Fatalf("duplicate kept photos %v thumbnail %q", copied.Photos, copied.ThumbnailURL)
+	}
+
+	copied.Amenities[0] = "sauna"
+	copied.HouseRules[0] = "pets welcome"
+	copied.Tags[0] = "suburb"
+	copied.Highlights[0] = "garden"
+	copied.Address.City = "Kazan"
+	copied.Title = "Renamed"
+
+	source := repo.items["source"]
+	want := duplicateSource()
+	if source.Amenities[0] != want.Amenities[0] || source.HouseRules[0] != want.HouseRules[0] ||
+		source.Tags[0] != want.Tags[0] || source.Highlights[0] != want.Highlights[0] ||
+		source.Address.City != want.Address.City || source.Title != want.Title {
+		t.Fatalf("editing the duplicate changed the source: %+v", source)
+	}
+
+	source.Amenities[1] = "dryer"
+	if copied.Amenities[1] != "washer" {
+		t.Fatalf("editing the source changed the duplicate: %v", copied.Amenities)
+	}
+}
+
+func TestDuplicateHostListingRequiresOwner(t *testing.T) {
+	repo := savingListings{stubListings{items: map[domainlistings.ListingID]*domainlistings.Listing{"source": duplicateSource()}}}
+	handler := &DuplicateHostListingHandler{}
+
+	_, err := handler.Handle(duplicateContext(repo), DuplicateHostListingCommand{HostID: "host-2", SourceListingID: "source"})
+	if !errors.Is(err, ErrListingNotOwned) {
+		t.Fatalf("err = %v, want ErrListingNotOwned", err)
+	}
+	if len(repo.items) != 1 {
+		t.Fatalf("listings = %d, want only the source", len(repo.items))
+	}
+}
@@ -0,0 +1,112 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+const (
+	hostListingHistoryKey      = "host.listings.history"
+	hostListingPriceHistoryKey = "host.listings.price_history"
+)
+
+// HostListingHistoryQuery returns the changelog of a listing the host owns.
+type HostListingHistoryQuery struct {
+	HostID    string
+	ListingID string
+}
+
+func (q HostListingHistoryQuery) Key() string { return hostListingHistoryKey }
+
+type HostListingHistoryHandler struct {
+	UoWFactory uow.UoWFactory
+	Logger     *slog.Logger
+}
+
+func (h *HostListingHistoryHandler) Handle(ctx context.Context, q HostListingHistoryQuery) ([]dto.ListingChangelogEntry, error) {
+	if strings.TrimSpace(q.HostID) == "" {
+		return nil, errors.New("host id is required")
+	}
+	if strings.TrimSpace(q.ListingID) == "" {
+		return nil, errors.New("listing id is required")
+	}
+
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return nil, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	listing, err := unit.Listings().ByID(execCtx, domainlistings.ListingID(q.ListingID))
+	if err != nil {
+		return nil, err
+	}
+	if listing.Host != domainlistings.HostID(q.HostID) {
+		return nil, ErrListingNotOwned
+	}
+
+	entries, err := unit.Listings().History(execCtx, listing.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Debug("host listing history loaded", "listing_id", listing.ID, "host_id", q.HostID, "entries", len(entries))
+	}
+
+	return dto.MapListingChangelog(entries), nil
+}
+
+// HostListingPriceHistoryQuery returns the rate changes of a listing the host
+// owns, newest first.
+type HostListingPriceHistoryQuery struct {
+	HostID    string
+	ListingID string
+}
+
+func (q HostListingPriceHistoryQuery) Key() string { return hostListingPriceHistoryKey }
+
+type HostListingPriceHistoryHandler struct {
+	UoWFactory uow.UoWFactory
+}
+
+func (h *HostListingPriceHistoryHandler) Handle(ctx context.Context, q HostListingPriceHistoryQuery) ([]dto.ListingPriceChange, error) {
+	if strings.TrimSpace(q.HostID) == "" {
+		return nil, errors.New("host id is required")
+	}
+	if strings.TrimSpace(q.ListingID) == "" {
+		return nil, errors.New("listing id is required")
+	}
+
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return nil, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	listing, err := unit.Listings().ByID(execCtx, domainlistings.ListingID(q.ListingID))
+	if err != nil {
+		return nil, err
+	}
+	if listing.Host != domainlistings.HostID(q.HostID) {
+		return nil, ErrListingNotOwned
+	}
+	return dto.MapListingPriceHistory(listing.PriceHistoryNewestFirst()), nil
+}
+
+var (
+	_ queries.Handler[HostListingHistoryQuery, []dto.ListingChangelogEntry]   = (*HostListingHistoryHandler)(nil)
+	_ queries.Handler[HostListingPriceHistoryQuery, []dto.ListingPriceChange] = (*HostListingPriceHistoryHandler)(nil)
+)
@@ -59,7 +59,7 @@ func (h *ListHostListingsHandler) Handle(ctx context.Context, q ListHostListings
 		Host:       domainlistings.HostID(q.HostID),
 		Limit:      limit,
 		Offset:     offset,
-		States:     statesForStatus(q.Status),
+		States:     dto.ListingStatesForStatus(q.Status),
 		Sort:       domainlistings.SortByUpdated,
 		OnlyActive: false,
 	}
@@ -130,18 +130,5 @@ func (h *GetHostListingHandler) Handle(ctx context.Context, q GetHostListingQuer
 	return dto.MapHostListingDetail(listing), nil
 }
 
-func statesForStatus(raw string) []domainlistings.ListingState {
-	switch strings.ToLower(strings.TrimSpace(raw)) {
-	case "draft":
-		return []domainlistings.ListingState{domainlistings.ListingDraft}
-	case "published":
-		return []domainlistings.ListingState{domainlistings.ListingActive}
-	case "archived":
-		return []domainlistings.ListingState{domainlistings.ListingSuspended}
-	default:
-		return nil
-	}
-}
-
 var _ queries.Handler[ListHostListingsQuery, dto.HostListingCatalog] = (*ListHostListingsHandler)(nil)
 var _ queries.Handler[GetHostListingQuery, dto.HostListingDetail] = (*GetHostListingHandler)(nil)
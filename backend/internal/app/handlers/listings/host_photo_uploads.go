@@ -0,0 +1,413 @@
+package listings
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/queries"
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/infra/storage/s3"
+)
+
+const (
+	createHostListingPhotoUploadKey   = "host.listings.photos.uploads.create"
+	getHostListingPhotoUploadKey      = "host.listings.photos.uploads.get"
+	putHostListingPhotoUploadPartKey  = "host.listings.photos.uploads.put_part"
+	completeHostListingPhotoUploadKey = "host.listings.photos.uploads.complete"
+	expireHostListingPhotoUploadsKey  = "host.listings.photos.uploads.expire"
+)
+
+const (
+	// PhotoUploadTTL is how long a resumable upload session stays open.
+	PhotoUploadTTL = 24 * time.Hour
+	// MaxPhotoUploadBytes caps the assembled photo, matching direct uploads.
+	MaxPhotoUploadBytes int64 = 10 * 1024 * 1024
+	// MaxPhotoUploadParts caps the part number of a session.
+	MaxPhotoUploadParts = 100
+
+	photoUploadExpireBatch = 100
+)
+
+var (
+	ErrPhotoUploadNotFound     = errors.New("photo upload not found")
+	ErrPhotoUploadsUnavailable = errors.New("photo uploads are not configured")
+	ErrPhotoUploadPart         = fmt.Errorf("photo upload part must be between 1 and %d", MaxPhotoUploadParts)
+	ErrPhotoUploadEmptyPart    = errors.New("photo upload part is empty")
+	ErrPhotoUploadTooLarge     = fmt.Errorf("photo upload exceeds %d MB", MaxPhotoUploadBytes/1024/1024)
+	ErrPhotoUploadIncomplete   = errors.New("photo upload is missing parts")
+	ErrPhotoUploadContentType  = errors.New("photo upload is not a jpeg, png or webp image")
+)
+
+// PhotoUpload is a resumable upload session. Each part is stored as its own
+// object until the session completes; Parts maps the part number to its size.
+type PhotoUpload struct {
+	ID        string
+	ListingID string
+	HostID    string
+	Parts     map[int]int64
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Size is the number of bytes received so far.
+func (u PhotoUpload) Size() int64 {
+	var total int64
+	for _, size := range u.Parts {
+		total += size
+	}
+	return total
+}
+
+func (u PhotoUpload) expired(now time.Time) bool {
+	return !now.Before(u.ExpiresAt)
+}
+
+func (u PhotoUpload) partKey(n int) string {
+	return fmt.Sprintf("uploads/%s/part-%03d", u.ID, n)
+}
+
+// PhotoUploadStore keeps upload sessions between requests, which may reach
+// different instances.
+type PhotoUploadStore interface {
+	Create(ctx context.Context, upload PhotoUpload) error
+	// Get returns ErrPhotoUploadNotFound for unknown ids.
+	Get(ctx context.Context, id string) (PhotoUpload, error)
+	// SetPart records part n; a part sent again replaces the earlier one.
+	SetPart(ctx context.Context, id string, n int, size int64) error
+	Delete(ctx context.Context, id string) error
+	// Expired lists up to limit sessions that expired before now.
+	Expired(ctx context.Context, now time.Time, limit int) ([]PhotoUpload, error)
+}
+
+// CreateHostListingPhotoUploadCommand opens a resumable upload for a photo of
+// the listing.
+type CreateHostListingPhotoUploadCommand struct {
+	HostID    string
+	ListingID string
+}
+
+func (c CreateHostListingPhotoUploadCommand) Key() string { return createHostListingPhotoUploadKey }
+
+type CreateHostListingPhotoUploadHandler struct {
+	Uploads PhotoUploadStore
+	Logger  *slog.Logger
+}
+
+func (h *CreateHostListingPhotoUploadHandler) Handle(ctx context.Context, cmd CreateHostListingPhotoUploadCommand) (*dto.HostListingPhotoUpload, error) {
+	if h.Uploads == nil {
+		return nil, ErrPhotoUploadsUnavailable
+	}
+	_, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	upload := PhotoUpload{
+		ID:        uuid.NewString(),
+		ListingID: string(listing.ID),
+		HostID:    cmd.HostID,
+		Parts:     map[int]int64{},
+		CreatedAt: now,
+		ExpiresAt: now.Add(PhotoUploadTTL),
+	}
+	if err := h.Uploads.Create(ctx, upload); err != nil {
+		return nil, err
+	}
+	if h.Logger != nil {
+		h.Logger.Info("listing photo upload started", "listing_id", listing.ID, "host_id", cmd.HostID, "upload_id", upload.ID)
+	}
+	result := mapPhotoUpload(upload)
+	return &result, nil
+}
+
+// HostListingPhotoUploadQuery reports which parts of an upload arrived so a
+// client can resume after a dropped connection.
+type HostListingPhotoUploadQuery struct {
+	HostID    string
+	ListingID string
+	UploadID  string
+}
+
+func (q HostListingPhotoUploadQuery) Key() string { return getHostListingPhotoUploadKey }
+
+type HostListingPhotoUploadHandler struct {
+	Uploads PhotoUploadStore
+}
+
+func (h *HostListingPhotoUploadHandler) Handle(ctx context.Context, q HostListingPhotoUploadQuery) (dto.HostListingPhotoUpload, error) {
+	if h.Uploads == nil {
+		return dto.HostListingPhotoUpload{}, ErrPhotoUploadsUnavailable
+	}
+	upload, err := loadPhotoUpload(ctx, h.Uploads, q.HostID, q.ListingID, q.UploadID, time.Now())
+	if err != nil {
+		return dto.HostListingPhotoUpload{}, err
+	}
+	return mapPhotoUpload(upload), nil
+}
+
+// PutHostListingPhotoUploadPartCommand stores part Part of an upload. Parts
+// may arrive in any order and a part sent again replaces the earlier copy.
+type PutHostListingPhotoUploadPartCommand struct {
+	HostID    string
+	ListingID string
+	UploadID  string
+	Part      int
+	Reader    io.Reader
+	Size      int64
+}
+
+func (c PutHostListingPhotoUploadPartCommand) Key() string { return putHostListingPhotoUploadPartKey }
+
+type PutHostListingPhotoUploadPartHandler struct {
+	Uploads  PhotoUploadStore
+	Uploader s3.Uploader
+}
+
+func (h *PutHostListingPhotoUploadPartHandler) Handle(ctx context.Context, cmd PutHostListingPhotoUploadPartCommand) (*dto.HostListingPhotoUpload, error) {
+	if h.Uploads == nil || h.Uploader == nil {
+		return nil, ErrPhotoUploadsUnavailable
+	}
+	if cmd.Part < 1 || cmd.Part > MaxPhotoUploadParts {
+		return nil, ErrPhotoUploadPart
+	}
+	if cmd.Reader == nil || cmd.Size <= 0 {
+		return nil, ErrPhotoUploadEmptyPart
+	}
+	upload, err := loadPhotoUpload(ctx, h.Uploads, cmd.HostID, cmd.ListingID, cmd.UploadID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if upload.Size()-upload.Parts[cmd.Part]+cmd.Size > MaxPhotoUploadBytes {
+		return nil, ErrPhotoUploadTooLarge
+	}
+
+	if _, err := h.Uploader.Upload(ctx, upload.partKey(cmd.Part), cmd.Reader, "application/octet-stream"); err != nil {
+		return nil, fmt.Errorf("upload photo part: %w", err)
+	}
+	if err := h.Uploads.SetPart(ctx, upload.ID, cmd.Part, cmd.Size); err != nil {
+		return nil, err
+	}
+	upload.Parts[cmd.Part] = cmd.Size
+	result := mapPhotoUpload(upload)
+	return &result, nil
+}
+
+// CompleteHostListingPhotoUploadCommand assembles the parts of an upload in
+// order and adds the result to the listing photos. The photo is stored under
+// ObjectPrefix like a direct upload.
+type CompleteHostListingPhotoUploadCommand struct {
+	HostID       string
+	ListingID    string
+	UploadID     string
+	ObjectPrefix string
+	Tag          string
+}
+
+func (c CompleteHostListingPhotoUploadCommand) Key() string { return completeHostListingPhotoUploadKey }
+
+type CompleteHostListingPhotoUploadHandler struct {
+	Uploads  PhotoUploadStore
+	Uploader s3.Uploader
+	Logger   *slog.Logger
+}
+
+func (h *CompleteHostListingPhotoUploadHandler) Handle(ctx context.Context, cmd CompleteHostListingPhotoUploadCommand) (*dto.HostListingPhotoUploadResult, error) {
+	if h.Uploads == nil || h.Uploader == nil {
+		return nil, ErrPhotoUploadsUnavailable
+	}
+	if strings.TrimSpace(cmd.ObjectPrefix) == "" {
+		return nil, errors.New("object prefix is required")
+	}
+	tag, err := domainlistings.ParsePhotoTag(cmd.Tag)
+	if err != nil {
+		return nil, err
+	}
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	upload, err := loadPhotoUpload(ctx, h.Uploads, cmd.HostID, cmd.ListingID, cmd.UploadID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := h.assemble(ctx, upload)
+	if err != nil {
+		return nil, err
+	}
+	// The parts are opaque bytes; only the assembled photo tells its type.
+	contentType := http.DetectContentType(data)
+	ext, ok := photoExtensions[contentType]
+	if !ok {
+		return nil, ErrPhotoUploadContentType
+	}
+	objectKey := cmd.ObjectPrefix + uuid.NewString() + ext
+	publicURL, err := h.Uploader.Upload(ctx, objectKey, bytes.NewReader(data), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("upload photo: %w", err)
+	}
+	if err := addPhoto(listing, publicURL, tag, now); err != nil {
+		return nil, err
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	// The photo is in place, so a failed cleanup is left to the expiry sweep.
+	if err := discardPhotoUpload(ctx, h.Uploads, h.Uploader, upload); err != nil && h.Logger != nil {
+		h.Logger.Warn("listing photo upload not cleaned up", "upload_id", upload.ID, "error", err)
+	}
+	if h.Logger != nil {
+		h.Logger.Info("listing photo upload completed", "listing_id", listing.ID, "host_id", cmd.HostID, "upload_id", upload.ID, "object_key", objectKey, "parts", len(upload.Parts))
+	}
+	return photoResult(listing), nil
+}
+
+func (h *CompleteHostListingPhotoUploadHandler) assemble(ctx context.Context, upload PhotoUpload) ([]byte, error) {
+	if len(upload.Parts) == 0 {
+		return nil, ErrPhotoUploadIncomplete
+	}
+	var buf bytes.Buffer
+	for n := 1; n <= len(upload.Parts); n++ {
+		size, ok := upload.Parts[n]
+		if !ok {
+			return nil, fmt.Errorf("%w: part %d", ErrPhotoUploadIncomplete, n)
+		}
+		part, err := h.Uploader.Open(ctx, upload.partKey(n))
+		if err != nil {
+			return nil, err
+		}
+		copied, err := io.Copy(&buf, io.LimitReader(part, size+1))
+		part.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read photo part %d: %w", n, err)
+		}
+		if copied != size {
+			return nil, fmt.Errorf("%w: part %d has %d bytes, expected %d", ErrPhotoUploadIncomplete, n, copied, size)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// ExpireHostListingPhotoUploadsCommand drops upload sessions that were never
+// completed, together with their stored parts.
+type ExpireHostListingPhotoUploadsCommand struct {
+	Now time.Time
+}
+
+func (c ExpireHostListingPhotoUploadsCommand) Key() string { return expireHostListingPhotoUploadsKey }
+
+type ExpireHostListingPhotoUploadsResult struct {
+	Expired int `json:"expired"`
+}
+
+type ExpireHostListingPhotoUploadsHandler struct {
+	Uploads  PhotoUploadStore
+	Uploader s3.Uploader
+	Logger   *slog.Logger
+}
+
+func (h *ExpireHostListingPhotoUploadsHandler) Handle(ctx context.Context, cmd ExpireHostListingPhotoUploadsCommand) (*ExpireHostListingPhotoUploadsResult, error) {
+	if h.Uploads == nil || h.Uploader == nil {
+		return nil, ErrPhotoUploadsUnavailable
+	}
+	now := cmd.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	expired, err := h.Uploads.Expired(ctx, now.UTC(), photoUploadExpireBatch)
+	if err != nil {
+		return nil, err
+	}
+	result := &ExpireHostListingPhotoUploadsResult{}
+	for _, upload := range expired {
+		if err := discardPhotoUpload(ctx, h.Uploads, h.Uploader, upload); err != nil {
+			if h.Logger != nil {
+				h.Logger.Warn("expired photo upload not removed", "upload_id", upload.ID, "error", err)
+			}
+			continue
+		}
+		result.Expired++
+	}
+	if h.Logger != nil && result.Expired > 0 {
+		h.Logger.Info("expired photo uploads removed", "count", result.Expired)
+	}
+	return result, nil
+}
+
+// discardPhotoUpload removes the stored parts before the session, so a
+// failure leaves the session behind for the next sweep to retry.
+func discardPhotoUpload(ctx context.Context, uploads PhotoUploadStore, uploader s3.Uploader, upload PhotoUpload) error {
+	var errs []error
+	for n := range upload.Parts {
+		if err := uploader.Delete(ctx, upload.partKey(n)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return uploads.Delete(ctx, upload.ID)
+}
+
+// loadPhotoUpload returns the session only to the host and listing it was
+// opened for; anyone else gets ErrPhotoUploadNotFound.
+func loadPhotoUpload(ctx context.Context, uploads PhotoUploadStore, hostID, listingID, uploadID string, now time.Time) (PhotoUpload, error) {
+	if strings.TrimSpace(uploadID) == "" {
+		return PhotoUpload{}, errors.New("upload id is required")
+	}
+	upload, err := uploads.Get(ctx, uploadID)
+	if err != nil {
+		return PhotoUpload{}, err
+	}
+	if upload.HostID != hostID || upload.ListingID != listingID || upload.expired(now) {
+		return PhotoUpload{}, ErrPhotoUploadNotFound
+	}
+	if upload.Parts == nil {
+		upload.Parts = map[int]int64{}
+	}
+	return upload, nil
+}
+
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+func mapPhotoUpload(upload PhotoUpload) dto.HostListingPhotoUpload {
+	parts := make([]dto.HostListingPhotoUploadPart, 0, len(upload.Parts))
+	for n, size := range upload.Parts {
+		parts = append(parts, dto.HostListingPhotoUploadPart{Number: n, Size: size})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Number < parts[j].Number })
+	return dto.HostListingPhotoUpload{
+		UploadID:      upload.ID,
+		ListingID:     upload.ListingID,
+		Parts:         parts,
+		ReceivedBytes: upload.Size(),
+		MaxBytes:      MaxPhotoUploadBytes,
+		ExpiresAt:     upload.ExpiresAt,
+	}
+}
+
+var (
+	_ commands.Handler[CreateHostListingPhotoUploadCommand, *dto.HostListingPhotoUpload]           = (*CreateHostListingPhotoUploadHandler)(nil)
+	_ queries.Handler[HostListingPhotoUploadQuery, dto.HostListingPhotoUpload]                     = (*HostListingPhotoUploadHandler)(nil)
+	_ commands.Handler[PutHostListingPhotoUploadPartCommand, *dto.HostListingPhotoUpload]          = (*PutHostListingPhotoUploadPartHandler)(nil)
+	_ commands.Handler[CompleteHostListingPhotoUploadCommand, *dto.HostListingPhotoUploadResult]   = (*CompleteHostListingPhotoUploadHandler)(nil)
+	_ commands.Handler[ExpireHostListingPhotoUploadsCommand, *ExpireHostListingPhotoUploadsResult] = (*ExpireHostListingPhotoUploadsHandler)(nil)
+)
@@ -0,0 +1,64 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"rentme/internal/infra/storage/s3"
+)
+
+type recordingUploader struct {
+	s3.NoopUploader
+	deleted []string
+	failKey string
+}
+
+func (u *recordingUploader) Delete(_ context.Context, key string) error {
+	if key == u.failKey {
+		return errors.New("s3 down")
+	}
+	u.deleted = append(u.deleted, key)
+	return nil
+}
+
+type recordingUploadStore struct {
+	PhotoUploadStore
+	deleted []string
+}
+
+func (s *recordingUploadStore) Delete(_ context.Context, id string) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func TestDiscardPhotoUploadDeletesPartsByKey(t *testing.T) {
+	uploader := &recordingUploader{}
+	store := &recordingUploadStore{}
+	upload := PhotoUpload{ID: "up-1", Parts: map[int]int64{1: 10, 2: 10}}
+
+	if err := discardPhotoUpload(context.Background(), store, uploader, upload); err != nil {
+		t.Fatalf("discard: %v", err)
+	}
+	sort.Strings(uploader.deleted)
+	if len(uploader.deleted) != 2 || uploader.deleted[0] != "uploads/up-1/part-001" || uploader.deleted[1] != "uploads/up-1/part-002" {
+		t.Fatalf("deleted objects = %v", uploader.deleted)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != "up-1" {
+		t.Fatalf("deleted sessions = %v", store.deleted)
+	}
+}
+
+func TestDiscardPhotoUploadKeepsSessionWhenAPartStays(t *testing.T) {
+	uploader := &recordingUploader{failKey: "uploads/up-1/part-002"}
+	store := &recordingUploadStore{}
+	upload := PhotoUpload{ID: "up-1", Parts: map[int]int64{1: 10, 2: 10}}
+
+	if err := discardPhotoUpload(context.Background(), store, uploader, upload); err == nil {
+		t.Fatal("discard succeeded with a part left behind")
+	}
+	if len(store.deleted) != 0 {
+		t.Fatalf("session deleted although a part stayed: %v", store.deleted)
+	}
+}
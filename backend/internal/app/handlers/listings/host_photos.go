@@ -11,12 +11,24 @@ import (
 
 	"rentme/internal/app/commands"
 	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/queries"
 	"rentme/internal/app/uow"
 	domainlistings "rentme/internal/domain/listings"
 	"rentme/internal/infra/storage/s3"
 )
 
-const uploadHostListingPhotoKey = "host.listings.photos.upload"
+const (
+	uploadHostListingPhotoKey    = "host.listings.photos.upload"
+	deleteHostListingPhotoKey    = "host.listings.photos.delete"
+	reorderHostListingPhotosKey  = "host.listings.photos.reorder"
+	tagHostListingPhotoKey       = "host.listings.photos.tag"
+	registerHostListingPhotoKey  = "host.listings.photos.register"
+	hostListingPhotoUploadURLKey = "host.listings.photos.upload_url"
+)
+
+// PhotoUploadURLTTL is how long a presigned photo upload URL stays valid.
+const PhotoUploadURLTTL = 5 * time.Minute
 
 type UploadHostListingPhotoCommand struct {
 	HostID      string
@@ -24,6 +36,7 @@ type UploadHostListingPhotoCommand struct {
 	ObjectKey   string
 	ContentType string
 	Reader      io.Reader
+	Tag         string
 }
 
 func (c UploadHostListingPhotoCommand) Key() string { return uploadHostListingPhotoKey }
@@ -50,6 +63,10 @@ func (h *UploadHostListingPhotoHandler) Handle(ctx context.Context, cmd UploadHo
 	if strings.TrimSpace(cmd.ObjectKey) == "" {
 		return nil, errors.New("object key is required")
 	}
+	tag, err := domainlistings.ParsePhotoTag(cmd.Tag)
+	if err != nil {
+		return nil, err
+	}
 
 	unit, ok := uow.FromContext(ctx)
 	if !ok {
@@ -73,7 +90,7 @@ func (h *UploadHostListingPhotoHandler) Handle(ctx context.Context, cmd UploadHo
 	if h.Now != nil {
 		now = h.Now()
 	}
-	if err := listing.AddPhoto(publicURL, now); err != nil {
+	if err := addPhoto(listing, publicURL, tag, now); err != nil {
 		return nil, err
 	}
 	if err := unit.Listings().Save(ctx, listing); err != nil {
@@ -84,12 +101,284 @@ func (h *UploadHostListingPhotoHandler) Handle(ctx context.Context, cmd UploadHo
 		h.Logger.Info("listing photo added", "listing_id", listing.ID, "host_id", cmd.HostID, "object_key", cmd.ObjectKey)
 	}
 
-	result := dto.HostListingPhotoUploadResult{
-		ListingID:    cmd.ListingID,
-		Photos:       append([]string(nil), listing.Photos...),
-		ThumbnailURL: listing.ThumbnailURL,
+	return photoResult(listing), nil
+}
+
+// HostListingPhotoUploadURLQuery issues a presigned URL so the browser can
+// upload a photo straight to storage. The photo joins the listing only once
+// it is registered with RegisterHostListingPhotoCommand.
+type HostListingPhotoUploadURLQuery struct {
+	HostID      string
+	ListingID   string
+	ObjectKey   string
+	ContentType string
+}
+
+func (q HostListingPhotoUploadURLQuery) Key() string { return hostListingPhotoUploadURLKey }
+
+type HostListingPhotoUploadURLHandler struct {
+	UoWFactory uow.UoWFactory
+	Uploader   s3.Uploader
+}
+
+func (h *HostListingPhotoUploadURLHandler) Handle(ctx context.Context, q HostListingPhotoUploadURLQuery) (dto.HostListingPhotoUploadURL, error) {
+	if h.Uploader == nil {
+		return dto.HostListingPhotoUploadURL{}, errors.New("photo uploader unavailable")
+	}
+	if strings.TrimSpace(q.HostID) == "" {
+		return dto.HostListingPhotoUploadURL{}, errors.New("host id is required")
+	}
+	if strings.TrimSpace(q.ObjectKey) == "" {
+		return dto.HostListingPhotoUploadURL{}, errors.New("object key is required")
+	}
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return dto.HostListingPhotoUploadURL{}, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	listing, err := unit.Listings().ByID(execCtx, domainlistings.ListingID(q.ListingID))
+	if err != nil {
+		return dto.HostListingPhotoUploadURL{}, err
+	}
+	if listing.Host != domainlistings.HostID(q.HostID) {
+		return dto.HostListingPhotoUploadURL{}, ErrListingNotOwned
+	}
+
+	url, err := h.Uploader.GeneratePresignedUploadURL(ctx, q.ObjectKey, q.ContentType, PhotoUploadURLTTL)
+	if err != nil {
+		return dto.HostListingPhotoUploadURL{}, fmt.Errorf("presign photo upload: %w", err)
+	}
+	return dto.HostListingPhotoUploadURL{
+		URL:       url,
+		Key:       q.ObjectKey,
+		ExpiresIn: int(PhotoUploadURLTTL / time.Second),
+	}, nil
+}
+
+// RegisterHostListingPhotoCommand adds a photo the browser uploaded through a
+// presigned URL. ObjectKey must come from HostListingPhotoUploadURLQuery.
+type RegisterHostListingPhotoCommand struct {
+	HostID    string
+	ListingID string
+	ObjectKey string
+	Tag       string
+}
+
+func (c RegisterHostListingPhotoCommand) Key() string { return registerHostListingPhotoKey }
+
+type RegisterHostListingPhotoHandler struct {
+	Logger   *slog.Logger
+	Uploader s3.Uploader
+}
+
+func (h *RegisterHostListingPhotoHandler) Handle(ctx context.Context, cmd RegisterHostListingPhotoCommand) (*dto.HostListingPhotoUploadResult, error) {
+	if h.Uploader == nil {
+		return nil, errors.New("photo uploader unavailable")
+	}
+	if strings.TrimSpace(cmd.ObjectKey) == "" {
+		return nil, errors.New("object key is required")
+	}
+	tag, err := domainlistings.ParsePhotoTag(cmd.Tag)
+	if err != nil {
+		return nil, err
+	}
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	publicURL, err := h.Uploader.VersionedURL(ctx, cmd.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := addPhoto(listing, publicURL, tag, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("listing photo registered", "listing_id", listing.ID, "host_id", cmd.HostID, "object_key", cmd.ObjectKey)
+	}
+	return photoResult(listing), nil
+}
+
+// DeleteHostListingPhotoCommand removes a photo by URL or, when URL is empty, by position.
+type DeleteHostListingPhotoCommand struct {
+	HostID    string
+	ListingID string
+	URL       string
+	Index     *int
+}
+
+func (c DeleteHostListingPhotoCommand) Key() string { return deleteHostListingPhotoKey }
+
+type DeleteHostListingPhotoHandler struct {
+	Logger   *slog.Logger
+	Uploader s3.Uploader
+}
+
+func (h *DeleteHostListingPhotoHandler) Handle(ctx context.Context, cmd DeleteHostListingPhotoCommand) (*dto.HostListingPhotoUploadResult, error) {
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := resolvePhotoURL(listing, cmd.URL, cmd.Index)
+	if err != nil {
+		return nil, err
+	}
+	if err := listing.RemovePhoto(url, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	// The listing no longer references the object, so a failed delete only leaves an orphan behind.
+	if h.Uploader != nil {
+		if err := h.Uploader.Remove(ctx, url); err != nil && h.Logger != nil {
+			h.Logger.Warn("listing photo object not removed", "listing_id", listing.ID, "url", url, "error", err)
+		}
+	}
+	if h.Logger != nil {
+		h.Logger.Info("listing photo removed", "listing_id", listing.ID, "host_id", cmd.HostID, "url", url)
+	}
+	return photoResult(listing), nil
+}
+
+type ReorderHostListingPhotosCommand struct {
+	HostID    string
+	ListingID string
+	Photos    []string
+}
+
+func (c ReorderHostListingPhotosCommand) Key() string { return reorderHostListingPhotosKey }
+
+type ReorderHostListingPhotosHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *ReorderHostListingPhotosHandler) Handle(ctx context.Context, cmd ReorderHostListingPhotosCommand) (*dto.HostListingPhotoUploadResult, error) {
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := listing.ReorderPhotos(cmd.Photos, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("listing photos reordered", "listing_id", listing.ID, "host_id", cmd.HostID, "count", len(listing.Photos))
 	}
-	return &result, nil
+	return photoResult(listing), nil
 }
 
-var _ commands.Handler[UploadHostListingPhotoCommand, *dto.HostListingPhotoUploadResult] = (*UploadHostListingPhotoHandler)(nil)
+// TagHostListingPhotoCommand sets the room tag of a photo picked by URL or,
+// when URL is empty, by position. An empty Tag clears it.
+type TagHostListingPhotoCommand struct {
+	HostID    string
+	ListingID string
+	URL       string
+	Index     *int
+	Tag       string
+}
+
+func (c TagHostListingPhotoCommand) Key() string { return tagHostListingPhotoKey }
+
+type TagHostListingPhotoHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *TagHostListingPhotoHandler) Handle(ctx context.Context, cmd TagHostListingPhotoCommand) (*dto.HostListingPhotoUploadResult, error) {
+	tag, err := domainlistings.ParsePhotoTag(cmd.Tag)
+	if err != nil {
+		return nil, err
+	}
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	url, err := resolvePhotoURL(listing, cmd.URL, cmd.Index)
+	if err != nil {
+		return nil, err
+	}
+	if err := listing.TagPhoto(url, tag, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("listing photo tagged", "listing_id", listing.ID, "host_id", cmd.HostID, "url", url, "tag", tag)
+	}
+	return photoResult(listing), nil
+}
+
+func resolvePhotoURL(listing *domainlistings.Listing, url string, index *int) (string, error) {
+	if url = strings.TrimSpace(url); url != "" {
+		return url, nil
+	}
+	if index == nil {
+		return "", errors.New("photo url or index is required")
+	}
+	if *index < 0 || *index >= len(listing.Photos) {
+		return "", domainlistings.ErrPhotoNotFound
+	}
+	return listing.Photos[*index], nil
+}
+
+func addPhoto(listing *domainlistings.Listing, url string, tag domainlistings.PhotoTag, now time.Time) error {
+	if err := listing.AddPhoto(url, now); err != nil {
+		return err
+	}
+	if tag == "" {
+		return nil
+	}
+	return listing.TagPhoto(url, tag, now)
+}
+
+func loadOwnedListing(ctx context.Context, hostID, listingID string) (uow.UnitOfWork, *domainlistings.Listing, error) {
+	if strings.TrimSpace(hostID) == "" {
+		return nil, nil, errors.New("host id is required")
+	}
+	if strings.TrimSpace(listingID) == "" {
+		return nil, nil, errors.New("listing id is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, nil, uow.ErrUnitOfWorkMissing
+	}
+	listing, err := unit.Listings().ByID(ctx, domainlistings.ListingID(listingID))
+	if err != nil {
+		return nil, nil, err
+	}
+	if listing.Host != domainlistings.HostID(hostID) {
+		return nil, nil, ErrListingNotOwned
+	}
+	return unit, listing, nil
+}
+
+func photoResult(listing *domainlistings.Listing) *dto.HostListingPhotoUploadResult {
+	return &dto.HostListingPhotoUploadResult{
+		ListingID:    string(listing.ID),
+		Photos:       dto.MediaURLs(listing.Photos),
+		PhotoTags:    dto.MapPhotoTags(listing),
+		ThumbnailURL: dto.MediaURL(listing.ThumbnailURL),
+	}
+}
+
+var (
+	_ commands.Handler[UploadHostListingPhotoCommand, *dto.HostListingPhotoUploadResult]   = (*UploadHostListingPhotoHandler)(nil)
+	_ commands.Handler[DeleteHostListingPhotoCommand, *dto.HostListingPhotoUploadResult]   = (*DeleteHostListingPhotoHandler)(nil)
+	_ commands.Handler[ReorderHostListingPhotosCommand, *dto.HostListingPhotoUploadResult] = (*ReorderHostListingPhotosHandler)(nil)
+	_ commands.Handler[TagHostListingPhotoCommand, *dto.HostListingPhotoUploadResult]      = (*TagHostListingPhotoHandler)(nil)
+	_ commands.Handler[RegisterHostListingPhotoCommand, *dto.HostListingPhotoUploadResult] = (*RegisterHostListingPhotoHandler)(nil)
+	_ queries.Handler[HostListingPhotoUploadURLQuery, dto.HostListingPhotoUploadURL]       = (*HostListingPhotoUploadURLHandler)(nil)
+)
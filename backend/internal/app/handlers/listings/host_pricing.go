@@ -8,16 +8,21 @@ import (
 	"strings"
 	"time"
 
+	"rentme/internal/app/commands"
 	"rentme/internal/app/dto"
 	handlersupport "rentme/internal/app/handlers/support"
 	"rentme/internal/app/policies"
 	"rentme/internal/app/queries"
 	"rentme/internal/app/uow"
 	domainlistings "rentme/internal/domain/listings"
+	domainpricing "rentme/internal/domain/pricing"
 	domainrange "rentme/internal/domain/shared/daterange"
 )
 
-const priceSuggestionKey = "host.listings.price_suggestion"
+const (
+	priceSuggestionKey      = "host.listings.price_suggestion"
+	applyPriceSuggestionKey = "host.listings.price_suggestion.apply"
+)
 
 type HostListingPriceSuggestionQuery struct {
 	HostID    string
@@ -33,6 +38,7 @@ type HostListingPriceSuggestionHandler struct {
 	Logger     *slog.Logger
 	Pricing    policies.PricingPort
 	UoWFactory uow.UoWFactory
+	Rounding   domainpricing.RoundingPolicy
 }
 
 func (h *HostListingPriceSuggestionHandler) Handle(ctx context.Context, q HostListingPriceSuggestionQuery) (dto.HostListingPriceSuggestion, error) {
@@ -59,33 +65,97 @@ func (h *HostListingPriceSuggestionHandler) Handle(ctx context.Context, q HostLi
 		return zero, ErrListingNotOwned
 	}
 
-	if h.Pricing == nil {
+	result, err := suggestPrice(execCtx, h.Pricing, h.Rounding, listing, q.CheckIn, q.CheckOut, q.Guests)
+	if err != nil {
+		return zero, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("price suggestion generated", "listing_id", listing.ID, "host_id", q.HostID, "level", result.PriceLevel)
+	}
+
+	return result, nil
+}
+
+// ApplyPriceSuggestionCommand sets the listing rate to the current price
+// suggestion for the same stay parameters the host was shown.
+type ApplyPriceSuggestionCommand struct {
+	HostID    string
+	ListingID string
+	CheckIn   time.Time
+	CheckOut  time.Time
+	Guests    int
+}
+
+func (c ApplyPriceSuggestionCommand) Key() string { return applyPriceSuggestionKey }
+
+// ApplyPriceSuggestionHandler recomputes the suggestion rather than trusting a
+// client-sent price, and records the change as source ml_applied.
+type ApplyPriceSuggestionHandler struct {
+	Logger   *slog.Logger
+	Pricing  policies.PricingPort
+	Rounding domainpricing.RoundingPolicy
+}
+
+func (h *ApplyPriceSuggestionHandler) Handle(ctx context.Context, cmd ApplyPriceSuggestionCommand) (*dto.HostListingDetail, error) {
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	suggestion, err := suggestPrice(ctx, h.Pricing, h.Rounding, listing, cmd.CheckIn, cmd.CheckOut, cmd.Guests)
+	if err != nil {
+		return nil, err
+	}
+	previous := listing.RateRub
+	if err := listing.ApplySuggestedRate(suggestion.RecommendedPriceRub, cmd.HostID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("price suggestion applied", "listing_id", listing.ID, "host_id", cmd.HostID, "old_rate_rub", previous, "new_rate_rub", listing.RateRub)
+	}
+
+	result := dto.MapHostListingDetail(listing)
+	return &result, nil
+}
+
+// suggestPrice quotes the listing for the stay and compares the rounded
+// recommendation with the current rate. Without a valid range it quotes a week
+// for short-term listings and a month otherwise.
+func suggestPrice(ctx context.Context, pricing policies.PricingPort, roundingPolicy domainpricing.RoundingPolicy, listing *domainlistings.Listing, checkIn, checkOut time.Time, guests int) (dto.HostListingPriceSuggestion, error) {
+	var zero dto.HostListingPriceSuggestion
+	if pricing == nil {
 		return zero, errors.New("pricing service unavailable")
 	}
 
-	checkIn := q.CheckIn
-	checkOut := q.CheckOut
 	if checkIn.IsZero() || checkOut.IsZero() || !checkOut.After(checkIn) {
-		checkIn = time.Now().UTC()
+		checkIn = domainrange.DateOf(time.Now())
 		checkOut = checkIn.AddDate(0, 0, 7)
+		if listing.RentalTermType != domainlistings.RentalTermShort {
+			checkOut = checkIn.AddDate(0, 1, 0)
+		}
 	}
 
-	dr, err := domainrange.New(checkIn, checkOut)
+	dr, err := domainrange.NewNightly(checkIn, checkOut)
 	if err != nil {
 		return zero, err
 	}
 
-	guests := q.Guests
 	if guests <= 0 {
 		guests = listing.GuestsLimit
 	}
 
-	breakdown, err := h.Pricing.Quote(execCtx, listing, dr, guests)
+	breakdown, err := pricing.Quote(ctx, listing, dr, guests)
 	if err != nil {
 		return zero, err
 	}
 
-	recommended := breakdown.Nightly.Amount
+	rounding := roundingPolicy.Rule(listing.Address.City, breakdown.Nightly.Currency)
+	// Nightly is the per-unit price, which is what RateRub holds as well.
+	recommended := rounding.Display(breakdown.Nightly.Amount, breakdown.PriceUnit() == domainpricing.UnitMonth)
 	current := listing.RateRub
 	level := priceLevelFor(current, recommended)
 	gapPercent := priceGapPercent(current, recommended)
@@ -93,20 +163,19 @@ func (h *HostListingPriceSuggestionHandler) Handle(ctx context.Context, q HostLi
 	message := priceMessage(level)
 
 	result := dto.HostListingPriceSuggestion{
-		ListingID:             string(listing.ID),
-		RecommendedPriceRub:   recommended,
-		CurrentPriceRub:       current,
-		PriceLevel:            level,
-		PriceGapPercent:       gapPercent,
-		Message:               message,
+		ListingID:           string(listing.ID),
+		RecommendedPriceRub: recommended,
+		RecommendedPrice:    rounding.Format(recommended),
+		CurrentPriceRub:     current,
+		PriceLevel:          level,
+		PriceGapPercent:     gapPercent,
+		Message:             message,
+		PriceUnit:           breakdown.PriceUnit(),
 		Range: dto.ListingDateRange{
 			CheckIn:  dr.CheckIn,
 			CheckOut: dr.CheckOut,
 		},
-	}
-
-	if h.Logger != nil {
-		h.Logger.Info("price suggestion generated", "listing_id", listing.ID, "host_id", q.HostID, "level", level)
+		Source: breakdown.Source,
 	}
 
 	return result, nil
@@ -147,4 +216,7 @@ func priceMessage(level string) string {
 	}
 }
 
-var _ queries.Handler[HostListingPriceSuggestionQuery, dto.HostListingPriceSuggestion] = (*HostListingPriceSuggestionHandler)(nil)
+var (
+	_ queries.Handler[HostListingPriceSuggestionQuery, dto.HostListingPriceSuggestion] = (*HostListingPriceSuggestionHandler)(nil)
+	_ commands.Handler[ApplyPriceSuggestionCommand, *dto.HostListingDetail]            = (*ApplyPriceSuggestionHandler)(nil)
+)
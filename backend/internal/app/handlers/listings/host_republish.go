@@ -0,0 +1,169 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/policies"
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+	domainmarkets "rentme/internal/domain/markets"
+)
+
+const (
+	republishScheduledListingKey  = "listings.republish_scheduled"
+	cancelRepublishHostListingKey = "host.listings.republish.cancel"
+
+	// RepublishJob is the one-off job that re-activates a listing unpublished
+	// with a republish time.
+	RepublishJob = "listings.republish"
+
+	republishFailedTemplate = "listing.republish_failed"
+)
+
+var ErrRepublishUnavailable = errors.New("listings: scheduled republish is not configured")
+
+// RepublishPayload is the job payload of RepublishJob.
+type RepublishPayload struct {
+	ListingID   string    `json:"listing_id"`
+	RepublishAt time.Time `json:"republish_at"`
+}
+
+// RepublishScheduledListingCommand activates a listing whose republish time
+// has come. RepublishAt must match the schedule stored on the listing, so a
+// job left over from a cancelled or replaced schedule does nothing.
+type RepublishScheduledListingCommand struct {
+	ListingID   string
+	RepublishAt time.Time
+}
+
+func (c RepublishScheduledListingCommand) Key() string { return republishScheduledListingKey }
+
+type RepublishScheduledListingResult struct {
+	Republished bool   `json:"republished"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// RepublishScheduledListingHandler runs the same checks as a manual publish.
+// When they fail the schedule is dropped and the host is notified instead of
+// retrying, since nothing changes until the host edits the listing.
+type RepublishScheduledListingHandler struct {
+	Markets  domainmarkets.Repository
+	Relaxed  bool
+	Notifier policies.Notifier
+	Logger   *slog.Logger
+}
+
+func (h *RepublishScheduledListingHandler) Handle(ctx context.Context, cmd RepublishScheduledListingCommand) (*RepublishScheduledListingResult, error) {
+	if strings.TrimSpace(cmd.ListingID) == "" {
+		return nil, errors.New("listing id is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, uow.ErrUnitOfWorkMissing
+	}
+
+	listing, err := unit.Listings().ByID(ctx, domainlistings.ListingID(cmd.ListingID))
+	if err != nil {
+		return nil, err
+	}
+	if listing.State != domainlistings.ListingSuspended || listing.RepublishAt.IsZero() || !listing.RepublishAt.Equal(cmd.RepublishAt) {
+		return &RepublishScheduledListingResult{Reason: "schedule no longer current"}, nil
+	}
+
+	now := time.Now()
+	publishErr := ensureMarket(ctx, h.Markets, listing)
+	if publishErr == nil {
+		publishErr = ensurePublishable(listing, h.Relaxed)
+	}
+	if publishErr == nil {
+		publishErr = listing.Activate(now)
+	}
+	if publishErr != nil {
+		listing.CancelRepublish(now)
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if publishErr != nil {
+		h.notifyFailure(ctx, listing, publishErr)
+		return &RepublishScheduledListingResult{Reason: publishErr.Error()}, nil
+	}
+	if h.Logger != nil {
+		h.Logger.Info("host listing republished", "listing_id", listing.ID, "host_id", listing.Host)
+	}
+	return &RepublishScheduledListingResult{Republished: true}, nil
+}
+
+func (h *RepublishScheduledListingHandler) notifyFailure(ctx context.Context, listing *domainlistings.Listing, cause error) {
+	if h.Logger != nil {
+		h.Logger.Warn("scheduled listing republish failed", "listing_id", listing.ID, "host_id", listing.Host, "error", cause)
+	}
+	if h.Notifier == nil {
+		return
+	}
+	data := map[string]any{
+		"listing_id": string(listing.ID),
+		"title":      listing.Title,
+		"reason":     cause.Error(),
+	}
+	if err := h.Notifier.Send(ctx, string(listing.Host), republishFailedTemplate, data); err != nil && h.Logger != nil {
+		h.Logger.Warn("republish failure notification failed", "listing_id", listing.ID, "error", err)
+	}
+}
+
+type CancelRepublishHostListingCommand struct {
+	HostID    string
+	ListingID string
+}
+
+func (c CancelRepublishHostListingCommand) Key() string { return cancelRepublishHostListingKey }
+
+type CancelRepublishHostListingHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *CancelRepublishHostListingHandler) Handle(ctx context.Context, cmd CancelRepublishHostListingCommand) (*dto.HostListingDetail, error) {
+	if strings.TrimSpace(cmd.HostID) == "" {
+		return nil, errors.New("host id is required")
+	}
+	if strings.TrimSpace(cmd.ListingID) == "" {
+		return nil, errors.New("listing id is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, uow.ErrUnitOfWorkMissing
+	}
+
+	listing, err := unit.Listings().ByID(ctx, domainlistings.ListingID(cmd.ListingID))
+	if err != nil {
+		return nil, err
+	}
+	if listing.Host != domainlistings.HostID(cmd.HostID) {
+		return nil, ErrListingNotOwned
+	}
+
+	// The queued job stays in place and becomes a no-op once the schedule is gone.
+	listing.CancelRepublish(time.Now())
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("host listing republish cancelled", "listing_id", listing.ID, "host_id", cmd.HostID)
+	}
+
+	result := dto.MapHostListingDetail(listing)
+	return &result, nil
+}
+
+var (
+	_ commands.Handler[RepublishScheduledListingCommand, *RepublishScheduledListingResult] = (*RepublishScheduledListingHandler)(nil)
+	_ commands.Handler[CancelRepublishHostListingCommand, *dto.HostListingDetail]          = (*CancelRepublishHostListingHandler)(nil)
+)
@@ -0,0 +1,108 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	domainlistings "rentme/internal/domain/listings"
+	domainuser "rentme/internal/domain/user"
+)
+
+const (
+	setHostListingCoHostsKey   = "host.listings.cohosts.set"
+	setHostListingUnitGroupKey = "host.listings.unit_group.set"
+)
+
+var ErrCoHostNotHost = errors.New("co-host must be an existing host account")
+
+// SetHostListingCoHostsCommand replaces the co-host team of a listing. Only
+// the owner may change it.
+type SetHostListingCoHostsCommand struct {
+	HostID    string
+	ListingID string
+	CoHostIDs []string
+}
+
+func (c SetHostListingCoHostsCommand) Key() string { return setHostListingCoHostsKey }
+
+type SetHostListingCoHostsHandler struct {
+	Users  domainuser.Repository
+	Logger *slog.Logger
+}
+
+func (h *SetHostListingCoHostsHandler) Handle(ctx context.Context, cmd SetHostListingCoHostsCommand) (*dto.HostListingDetail, error) {
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]domainlistings.HostID, 0, len(cmd.CoHostIDs))
+	for _, raw := range cmd.CoHostIDs {
+		id := strings.TrimSpace(raw)
+		if id == "" {
+			continue
+		}
+		if h.Users != nil && id != cmd.HostID {
+			user, err := h.Users.ByID(ctx, domainuser.ID(id))
+			if err != nil || user == nil || !user.HasRole(domainuser.RoleHost) {
+				return nil, ErrCoHostNotHost
+			}
+		}
+		ids = append(ids, domainlistings.HostID(id))
+	}
+	if err := listing.SetCoHosts(ids, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("listing co-hosts updated", "listing_id", listing.ID, "host_id", cmd.HostID, "co_hosts", len(listing.CoHosts))
+	}
+	detail := dto.MapHostListingDetail(listing)
+	return &detail, nil
+}
+
+// SetHostListingUnitGroupCommand links the listing to the owner's other
+// listings with the same UnitGroupID; bookings on any of them then block the
+// rest. Existing bookings are not mirrored retroactively.
+type SetHostListingUnitGroupCommand struct {
+	HostID      string
+	ListingID   string
+	UnitGroupID string
+}
+
+func (c SetHostListingUnitGroupCommand) Key() string { return setHostListingUnitGroupKey }
+
+type SetHostListingUnitGroupHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *SetHostListingUnitGroupHandler) Handle(ctx context.Context, cmd SetHostListingUnitGroupCommand) (*dto.HostListingDetail, error) {
+	unit, listing, err := loadOwnedListing(ctx, cmd.HostID, cmd.ListingID)
+	if err != nil {
+		return nil, err
+	}
+	if err := listing.SetUnitGroup(cmd.UnitGroupID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := unit.Listings().Save(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("listing unit group updated", "listing_id", listing.ID, "host_id", cmd.HostID, "unit_group_id", listing.UnitGroupID)
+	}
+	detail := dto.MapHostListingDetail(listing)
+	return &detail, nil
+}
+
+var (
+	_ commands.Handler[SetHostListingCoHostsCommand, *dto.HostListingDetail]   = (*SetHostListingCoHostsHandler)(nil)
+	_ commands.Handler[SetHostListingUnitGroupCommand, *dto.HostListingDetail] = (*SetHostListingUnitGroupHandler)(nil)
+)
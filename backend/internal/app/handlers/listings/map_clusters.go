@@ -0,0 +1,174 @@
+package listings
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+
+	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+	domainmarkets "rentme/internal/domain/markets"
+	domainpricing "rentme/internal/domain/pricing"
+)
+
+const getMapClustersKey = "listings.map_clusters"
+
+const (
+	// MaxMapZoom matches the deepest zoom of common web map tiles.
+	MaxMapZoom = 20
+	// DefaultMapClusterMin is the cell size below which listings are returned as pins.
+	DefaultMapClusterMin = 5
+	// DefaultMapListingLimit caps how many listings one request clusters.
+	DefaultMapListingLimit = 2000
+	// mapCellsPerTile splits a 256px tile into 64px cells.
+	mapCellsPerTile = 4
+)
+
+var ErrMapViewport = errors.New("bbox must be south < north with coordinates in range, zoom between 0 and 20")
+
+// GetMapClustersQuery clusters the catalog matches inside BBox for Zoom.
+type GetMapClustersQuery struct {
+	Filters SearchCatalogQuery
+	BBox    domainlistings.BoundingBox
+	Zoom    int
+}
+
+func (q GetMapClustersQuery) Key() string { return getMapClustersKey }
+
+// GetMapClustersHandler buckets listings into a zoom-dependent grid. It applies
+// the catalog filters and market restrictions of SearchCatalogHandler.
+type GetMapClustersHandler struct {
+	UoWFactory      uow.UoWFactory
+	Markets         domainmarkets.Repository
+	HideOutOfMarket bool
+	Rounding        domainpricing.RoundingPolicy
+	ClusterMin      int
+	ListingLimit    int
+}
+
+func (h *GetMapClustersHandler) Handle(ctx context.Context, q GetMapClustersQuery) (dto.ListingMapClusters, error) {
+	if !q.BBox.Valid() || q.Zoom < 0 || q.Zoom > MaxMapZoom {
+		return dto.ListingMapClusters{}, ErrMapViewport
+	}
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return dto.ListingMapClusters{}, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	params, err := catalogSearchParams(execCtx, q.Filters, h.Markets, h.HideOutOfMarket)
+	if err != nil {
+		return dto.ListingMapClusters{}, err
+	}
+	box := q.BBox
+	params.BBox = &box
+	params.Limit = h.listingLimit()
+	params.MaxLimit = h.listingLimit()
+	params.Offset = 0
+	result, err := unit.Listings().Search(execCtx, params)
+	if err != nil {
+		return dto.ListingMapClusters{}, err
+	}
+
+	cells := make(map[mapCell][]*domainlistings.Listing)
+	for _, listing := range result.Items {
+		key := cellFor(listing.Address.Lat, listing.Address.Lon, q.Zoom)
+		cells[key] = append(cells[key], listing)
+	}
+	keys := make([]mapCell, 0, len(cells))
+	for key := range cells {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].row == keys[j].row {
+			return keys[i].col < keys[j].col
+		}
+		return keys[i].row < keys[j].row
+	})
+
+	out := dto.ListingMapClusters{
+		Zoom:      q.Zoom,
+		BBox:      dto.MapBBox{South: box.South, West: box.West, North: box.North, East: box.East},
+		Clusters:  make([]dto.ListingMapCluster, 0),
+		Pins:      make([]dto.ListingMapPin, 0),
+		Total:     result.Total,
+		Truncated: result.Total > len(result.Items),
+	}
+	for _, key := range keys {
+		members := cells[key]
+		if len(members) < h.clusterMin() {
+			for _, listing := range members {
+				card := dto.MapListingCard(listing)
+				dto.ApplyDisplayPrice(&card, h.Rounding.Rule(card.City, "RUB"))
+				out.Pins = append(out.Pins, dto.ListingMapPin{Lat: listing.Address.Lat, Lon: listing.Address.Lon, Listing: card})
+			}
+			continue
+		}
+		out.Clusters = append(out.Clusters, clusterOf(key, members, q.Zoom))
+	}
+	return out, nil
+}
+
+type mapCell struct {
+	row int
+	col int
+}
+
+// cellSize is the grid step in degrees: a tile spans 360/2^zoom degrees.
+func cellSize(zoom int) float64 {
+	return 360 / math.Exp2(float64(zoom)) / mapCellsPerTile
+}
+
+func cellFor(lat, lon float64, zoom int) mapCell {
+	size := cellSize(zoom)
+	return mapCell{row: int(math.Floor((lat + 90) / size)), col: int(math.Floor((lon + 180) / size))}
+}
+
+func clusterOf(key mapCell, members []*domainlistings.Listing, zoom int) dto.ListingMapCluster {
+	size := cellSize(zoom)
+	cluster := dto.ListingMapCluster{
+		Count: len(members),
+		Bounds: dto.MapBBox{
+			South: float64(key.row)*size - 90,
+			West:  float64(key.col)*size - 180,
+			North: float64(key.row+1)*size - 90,
+			East:  float64(key.col+1)*size - 180,
+		},
+	}
+	var latSum, lonSum float64
+	for i, listing := range members {
+		latSum += listing.Address.Lat
+		lonSum += listing.Address.Lon
+		if i == 0 || listing.RateRub < cluster.PriceMinRub {
+			cluster.PriceMinRub = listing.RateRub
+		}
+		if listing.RateRub > cluster.PriceMaxRub {
+			cluster.PriceMaxRub = listing.RateRub
+		}
+	}
+	cluster.Lat = latSum / float64(len(members))
+	cluster.Lon = lonSum / float64(len(members))
+	return cluster
+}
+
+func (h *GetMapClustersHandler) clusterMin() int {
+	if h.ClusterMin <= 0 {
+		return DefaultMapClusterMin
+	}
+	return h.ClusterMin
+}
+
+func (h *GetMapClustersHandler) listingLimit() int {
+	if h.ListingLimit <= 0 {
+		return DefaultMapListingLimit
+	}
+	return h.ListingLimit
+}
+
+var _ queries.Handler[GetMapClustersQuery, dto.ListingMapClusters] = (*GetMapClustersHandler)(nil)
@@ -9,36 +9,64 @@ import (
 	"rentme/internal/app/queries"
 	"rentme/internal/app/uow"
 	domainlistings "rentme/internal/domain/listings"
+	domainmarkets "rentme/internal/domain/markets"
+	domainpricing "rentme/internal/domain/pricing"
 	"rentme/internal/domain/shared/daterange"
 )
 
 const searchCatalogKey = "listings.catalog"
 
+// availableOnlyScanLimit caps how many matching listings an available_only
+// search checks before paginating; matches beyond it are not listed.
+const availableOnlyScanLimit = 1000
+
 // SearchCatalogQuery describes request filters.
 type SearchCatalogQuery struct {
-	City          string
-	Region        string
-	Country       string
-	Location      string
-	Tags          []string
-	Amenities     []string
-	MinGuests     int
-	PriceMinRub   int64
-	PriceMaxRub   int64
-	PropertyTypes []string
-	RentalTerms   []string
-	Sort          string
-	Limit         int
-	Offset        int
-	CheckIn       time.Time
-	CheckOut      time.Time
+	City        string
+	Region      string
+	Country     string
+	Location    string
+	Tags        []string
+	Amenities   []string
+	MinGuests   int
+	PriceMinRub int64
+	PriceMaxRub int64
+	// MaxPricePerSqM bounds the rate per square meter of area.
+	MaxPricePerSqM float64
+	PropertyTypes  []string
+	RentalTerms    []string
+	Sort           string
+	Limit          int
+	Offset         int
+	CheckIn        time.Time
+	CheckOut       time.Time
+	// MaxTravelMinutes and TravelMode filter by commute; CommuteFrom is the
+	// point of interest used to estimate it for listings without declared minutes.
+	MaxTravelMinutes float64
+	TravelMode       string
+	CommuteFrom      *domainlistings.GeoPoint
+	// Lat, Lon and RadiusKm restrict results to a circle around the point.
+	Lat      float64
+	Lon      float64
+	RadiusKm float64
+	// AvailableOnly drops listings that cannot be reserved for CheckIn and
+	// CheckOut instead of marking them; it has no effect without dates.
+	AvailableOnly bool
+	// ViewerID, when set, marks the cards the user saved to their wishlist.
+	ViewerID string
 }
 
 func (q SearchCatalogQuery) Key() string { return searchCatalogKey }
 
-// SearchCatalogHandler loads listings with applied filters.
+// SearchCatalogHandler loads listings with applied filters. When
+// HideOutOfMarket is set, listings outside supported markets are skipped even
+// if they were grandfathered in. A non-nil Dedupe collapses identical searches.
 type SearchCatalogHandler struct {
-	UoWFactory uow.UoWFactory
+	UoWFactory      uow.UoWFactory
+	Markets         domainmarkets.Repository
+	HideOutOfMarket bool
+	Rounding        domainpricing.RoundingPolicy
+	Dedupe          *CatalogDedupe
 }
 
 func (h *SearchCatalogHandler) Handle(ctx context.Context, q SearchCatalogQuery) (dto.ListingCatalog, error) {
@@ -56,65 +84,192 @@ func (h *SearchCatalogHandler) Handle(ctx context.Context, q SearchCatalogQuery)
 		defer unit.Rollback(ctx)
 	}
 
-	searchParams := domainlistings.SearchParams{
-		City:          q.City,
-		Region:        q.Region,
-		Country:       q.Country,
-		LocationQuery: q.Location,
-		Tags:          append([]string(nil), q.Tags...),
-		Amenities:     append([]string(nil), q.Amenities...),
-		MinGuests:     q.MinGuests,
-		PriceMinRub:   q.PriceMinRub,
-		PriceMaxRub:   q.PriceMaxRub,
-		PropertyTypes: append([]string(nil), q.PropertyTypes...),
-		RentalTerms:   parseRentalTerms(q.RentalTerms),
-		Sort:          domainlistings.CatalogSort(q.Sort),
-		Limit:         q.Limit,
-		Offset:        q.Offset,
-		CheckIn:       q.CheckIn,
-		CheckOut:      q.CheckOut,
-		OnlyActive:    true,
-	}
-
-	result, err := unit.Listings().Search(ctx, searchParams)
+	searchParams, err := catalogSearchParams(ctx, q, h.Markets, h.HideOutOfMarket)
+	if err != nil {
+		return dto.ListingCatalog{}, err
+	}
+
+	search := func() (dto.ListingCatalog, error) {
+		return h.search(ctx, unit, q, searchParams)
+	}
+	var catalog dto.ListingCatalog
+	if h.Dedupe != nil {
+		catalog, err = h.Dedupe.do(searchParams, q.AvailableOnly, search)
+	} else {
+		catalog, err = search()
+	}
+	if err != nil {
+		return dto.ListingCatalog{}, err
+	}
+	if viewer := strings.TrimSpace(q.ViewerID); viewer != "" {
+		wishlist, err := unit.Wishlists().ByGuest(ctx, viewer)
+		if err != nil {
+			return dto.ListingCatalog{}, err
+		}
+		// The cards may be shared with other callers; mark a private copy.
+		catalog.Items = append([]dto.ListingCard(nil), catalog.Items...)
+		for i := range catalog.Items {
+			saved := wishlist.Contains(domainlistings.ListingID(catalog.Items[i].ID))
+			catalog.Items[i].IsFavorite = &saved
+		}
+	}
+	return catalog, nil
+}
+
+// search runs the viewer-independent part of the catalog search.
+func (h *SearchCatalogHandler) search(ctx context.Context, unit uow.UnitOfWork, q SearchCatalogQuery, searchParams domainlistings.SearchParams) (dto.ListingCatalog, error) {
+	var dateRange daterange.DateRange
+	withDates := !q.CheckIn.IsZero() && !q.CheckOut.IsZero()
+	if withDates {
+		var err error
+		dateRange, err = daterange.New(q.CheckIn, q.CheckOut)
+		if err != nil {
+			return dto.ListingCatalog{}, err
+		}
+	}
+	availableOnly := withDates && q.AvailableOnly
+
+	// Filtering on availability has to happen before pagination, so the
+	// available_only search loads every match and pages the survivors itself.
+	params := searchParams
+	if availableOnly {
+		params.Limit = availableOnlyScanLimit
+		params.MaxLimit = availableOnlyScanLimit
+		params.Offset = 0
+	}
+	result, err := unit.Listings().Search(ctx, params)
 	if err != nil {
 		return dto.ListingCatalog{}, err
 	}
 
 	var availability map[domainlistings.ListingID]dto.ListingAvailability
-	if !q.CheckIn.IsZero() && !q.CheckOut.IsZero() {
-		dateRange, err := daterange.New(q.CheckIn, q.CheckOut)
+	if withDates {
+		ids := make([]domainlistings.ListingID, 0, len(result.Items))
+		for _, listing := range result.Items {
+			ids = append(ids, listing.ID)
+		}
+		calendars, err := unit.Availability().CalendarsByListingIDs(ctx, ids)
 		if err != nil {
 			return dto.ListingCatalog{}, err
 		}
 		availability = make(map[domainlistings.ListingID]dto.ListingAvailability, len(result.Items))
 		for _, listing := range result.Items {
-			cal, err := unit.Availability().Calendar(ctx, listing.ID)
-			if err != nil {
-				return dto.ListingCatalog{}, err
+			isAvailable := true
+			if cal, ok := calendars[listing.ID]; ok {
+				isAvailable = cal.CanReserve(dateRange)
 			}
-			isAvailable := cal.CanReserve(dateRange)
-			availability[listing.ID] = dto.ListingAvailability{
+			report := dto.ListingAvailability{
 				CheckIn:     dateRange.CheckIn,
 				CheckOut:    dateRange.CheckOut,
 				Nights:      dateRange.Nights(),
 				Guests:      searchParams.MinGuests,
 				IsAvailable: isAvailable,
-				Reason: func() string {
-					if isAvailable {
-						return ""
-					}
-					return "unavailable"
-				}(),
 			}
+			if !isAvailable {
+				report.Reason = "unavailable"
+			}
+			availability[listing.ID] = report
 		}
 	}
+	if availableOnly {
+		result = pageAvailable(result, availability, searchParams.Normalized())
+	}
 
-	return dto.MapCatalog(result, searchParams, availability), nil
+	medians, err := h.pricePerSqMMedians(ctx, unit, result.Items)
+	if err != nil {
+		return dto.ListingCatalog{}, err
+	}
+
+	catalog := dto.MapCatalog(result, searchParams, availability)
+	catalog.Filters.AvailableOnly = availableOnly
+	for i, listing := range result.Items {
+		catalog.Items[i].GoodValue = listing.IsGoodValue(medians[domainlistings.BenchmarkKeyFor(listing)])
+	}
+	for i := range catalog.Items {
+		dto.ApplyDisplayPrice(&catalog.Items[i], h.Rounding.Rule(catalog.Items[i].City, "RUB"))
+	}
+	return catalog, nil
+}
+
+// pricePerSqMMedians loads the city benchmarks for the good value badge, only
+// for the cities on the page that have listings with a known area.
+func (h *SearchCatalogHandler) pricePerSqMMedians(ctx context.Context, unit uow.UnitOfWork, items []*domainlistings.Listing) (map[domainlistings.BenchmarkKey]float64, error) {
+	seen := make(map[string]struct{})
+	cities := make([]string, 0)
+	for _, listing := range items {
+		if _, ok := listing.PricePerSqM(); !ok {
+			continue
+		}
+		city := domainlistings.BenchmarkKeyFor(listing).City
+		if _, ok := seen[city]; ok || city == "" {
+			continue
+		}
+		seen[city] = struct{}{}
+		cities = append(cities, city)
+	}
+	if len(cities) == 0 {
+		return nil, nil
+	}
+	return unit.Listings().PricePerSqMMedians(ctx, cities)
+}
+
+// pageAvailable keeps the reservable listings and cuts the requested page
+// out of them, so the total counts only what the guest can book.
+func pageAvailable(result domainlistings.SearchResult, availability map[domainlistings.ListingID]dto.ListingAvailability, params domainlistings.SearchParams) domainlistings.SearchResult {
+	available := make([]*domainlistings.Listing, 0, len(result.Items))
+	for _, listing := range result.Items {
+		if availability[listing.ID].IsAvailable {
+			available = append(available, listing)
+		}
+	}
+	start := min(params.Offset, len(available))
+	end := min(start+params.Limit, len(available))
+	return domainlistings.SearchResult{Items: available[start:end], Total: len(available)}
 }
 
 var _ queries.Handler[SearchCatalogQuery, dto.ListingCatalog] = (*SearchCatalogHandler)(nil)
 
+// catalogSearchParams maps the query onto domain filters, restricting cities
+// to supported markets when hideOutOfMarket is set.
+func catalogSearchParams(ctx context.Context, q SearchCatalogQuery, markets domainmarkets.Repository, hideOutOfMarket bool) (domainlistings.SearchParams, error) {
+	params := domainlistings.SearchParams{
+		City:             q.City,
+		Region:           q.Region,
+		Country:          q.Country,
+		LocationQuery:    q.Location,
+		Tags:             append([]string(nil), q.Tags...),
+		Amenities:        append([]string(nil), q.Amenities...),
+		MinGuests:        q.MinGuests,
+		PriceMinRub:      q.PriceMinRub,
+		PriceMaxRub:      q.PriceMaxRub,
+		MaxPricePerSqM:   q.MaxPricePerSqM,
+		PropertyTypes:    append([]string(nil), q.PropertyTypes...),
+		RentalTerms:      parseRentalTerms(q.RentalTerms),
+		Sort:             domainlistings.CatalogSort(q.Sort),
+		Limit:            q.Limit,
+		Offset:           q.Offset,
+		CheckIn:          q.CheckIn,
+		CheckOut:         q.CheckOut,
+		MaxTravelMinutes: q.MaxTravelMinutes,
+		TravelMode:       q.TravelMode,
+		CommuteFrom:      q.CommuteFrom,
+		Lat:              q.Lat,
+		Lon:              q.Lon,
+		RadiusKm:         q.RadiusKm,
+		OnlyActive:       true,
+	}
+	if hideOutOfMarket && markets != nil {
+		settings, err := markets.Current(ctx)
+		if err != nil {
+			return domainlistings.SearchParams{}, err
+		}
+		if settings.Restricted() {
+			params.Cities = append([]string(nil), settings.Cities...)
+		}
+	}
+	return params, nil
+}
+
 func parseRentalTerms(tokens []string) []domainlistings.RentalTermType {
 	if len(tokens) == 0 {
 		return nil
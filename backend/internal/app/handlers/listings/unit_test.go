@@ -0,0 +1,56 @@
+package listings
+
+import (
+	"context"
+
+	"rentme/internal/app/uow"
+	domainavailability "rentme/internal/domain/availability"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+// stubUnit serves the repositories a test sets and panics on the others.
+type stubUnit struct {
+	uow.UnitOfWork
+	listings     domainlistings.ListingRepository
+	availability domainavailability.Repository
+}
+
+func (u stubUnit) Listings() domainlistings.ListingRepository  { return u.listings }
+func (u stubUnit) Availability() domainavailability.Repository { return u.availability }
+func (u stubUnit) Commit(context.Context) error                { return nil }
+func (u stubUnit) Rollback(context.Context) error              { return nil }
+
+type stubFactory struct{ unit stubUnit }
+
+func (f stubFactory) Begin(context.Context, uow.TxOptions) (uow.UnitOfWork, error) {
+	return f.unit, nil
+}
+
+// stubListings holds listings by ID; a set err fails every lookup.
+type stubListings struct {
+	domainlistings.ListingRepository
+	items map[domainlistings.ListingID]*domainlistings.Listing
+	err   error
+}
+
+func (r stubListings) ByID(_ context.Context, id domainlistings.ListingID) (*domainlistings.Listing, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	listing, ok := r.items[id]
+	if !ok {
+		return nil, domainlistings.ErrListingNotFound
+	}
+	copied := *listing
+	return &copied, nil
+}
+
+// stubAvailability serves one calendar for every listing, nil by default.
+type stubAvailability struct {
+	domainavailability.Repository
+	calendar *domainavailability.AvailabilityCalendar
+}
+
+func (r stubAvailability) Calendar(context.Context, domainlistings.ListingID) (*domainavailability.AvailabilityCalendar, error) {
+	return r.calendar, nil
+}
@@ -0,0 +1,33 @@
+package markets
+
+import (
+	"context"
+
+	"rentme/internal/app/dto"
+	"rentme/internal/app/queries"
+	domainmarkets "rentme/internal/domain/markets"
+)
+
+const listMarketsKey = "markets.list"
+
+type ListMarketsQuery struct{}
+
+func (q ListMarketsQuery) Key() string { return listMarketsKey }
+
+// ListMarketsHandler exposes the supported cities to clients.
+type ListMarketsHandler struct {
+	Markets domainmarkets.Repository
+}
+
+func (h *ListMarketsHandler) Handle(ctx context.Context, q ListMarketsQuery) (dto.MarketSettings, error) {
+	if h.Markets == nil {
+		return dto.MapMarketSettings(domainmarkets.Settings{}), nil
+	}
+	settings, err := h.Markets.Current(ctx)
+	if err != nil {
+		return dto.MarketSettings{}, err
+	}
+	return dto.MapMarketSettings(settings), nil
+}
+
+var _ queries.Handler[ListMarketsQuery, dto.MarketSettings] = (*ListMarketsHandler)(nil)
@@ -5,20 +5,30 @@ import (
 	"errors"
 	"log/slog"
 	"strings"
-	"time"
 
 	"rentme/internal/app/dto"
 	handlersupport "rentme/internal/app/handlers/support"
 	"rentme/internal/app/queries"
 	"rentme/internal/app/uow"
+	domainbooking "rentme/internal/domain/booking"
 	domainlistings "rentme/internal/domain/listings"
 	domainreviews "rentme/internal/domain/reviews"
 )
 
-const listGuestBookingsKey = "me.bookings.list"
+const (
+	listGuestBookingsKey = "me.bookings.list"
 
+	defaultGuestBookingsLimit = 20
+	maxGuestBookingsLimit     = 100
+)
+
+// ListGuestBookingsQuery asks for one page of the guest's bookings, newest
+// first. Cursor is the NextCursor of the previous page. A query with neither
+// Limit nor Cursor lists every booking on one page, as before paging existed.
 type ListGuestBookingsQuery struct {
 	GuestID string
+	Limit   int
+	Cursor  string
 }
 
 func (q ListGuestBookingsQuery) Key() string { return listGuestBookingsKey }
@@ -41,12 +51,20 @@ func (h *ListGuestBookingsHandler) Handle(ctx context.Context, q ListGuestBookin
 		defer cleanup()
 	}
 
-	bookings, err := unit.Booking().ListByGuest(execCtx, guestID)
+	limit := q.Limit
+	switch {
+	case limit <= 0 && q.Cursor == "":
+		limit = 0
+	case limit <= 0:
+		limit = defaultGuestBookingsLimit
+	default:
+		limit = min(limit, maxGuestBookingsLimit)
+	}
+	bookings, next, err := unit.Booking().ListByGuest(execCtx, guestID, domainbooking.GuestPage{Limit: limit, Cursor: q.Cursor})
 	if err != nil {
 		return dto.GuestBookingCollection{}, err
 	}
 
-	now := time.Now().UTC()
 	listingCache := make(map[domainlistings.ListingID]*domainlistings.Listing)
 	items := make([]dto.GuestBookingSummary, 0, len(bookings))
 	for _, booking := range bookings {
@@ -56,7 +74,8 @@ func (h *ListGuestBookingsHandler) Handle(ctx context.Context, q ListGuestBookin
 				h.Logger.Warn("listing snapshot missing for booking", "booking_id", booking.ID, "listing_id", booking.ListingID, "error", err)
 			}
 		}
-		canReview := !booking.Range.CheckOut.After(now)
+		// Only a stay the host checked the guest out of can be reviewed.
+		canReview := booking.State == domainbooking.StateCheckedOut
 		var review *domainreviews.Review
 		if reviews := unit.Reviews(); reviews != nil {
 			if existing, err := reviews.ByBooking(execCtx, booking.ID, guestID); err == nil {
@@ -73,7 +92,7 @@ func (h *ListGuestBookingsHandler) Handle(ctx context.Context, q ListGuestBookin
 		h.Logger.Debug("guest bookings listed", "guest_id", guestID, "count", len(items))
 	}
 
-	return dto.GuestBookingCollection{Items: items}, nil
+	return dto.GuestBookingCollection{Items: items, NextCursor: next}, nil
 }
 
 func loadListing(
@@ -0,0 +1,62 @@
+package me
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	domainbooking "rentme/internal/domain/booking"
+	"rentme/internal/infra/storage/memory"
+)
+
+// guestBookingsHandler serves n bookings of guest-1, one a day.
+func guestBookingsHandler(t *testing.T, n int) *ListGuestBookingsHandler {
+	t.Helper()
+	bookings := memory.NewBookingRepository()
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		booking := &domainbooking.Booking{
+			ID:        domainbooking.BookingID(fmt.Sprintf("booking-%03d", i)),
+			ListingID: "listing-1",
+			GuestID:   "guest-1",
+			State:     domainbooking.StateConfirmed,
+			CreatedAt: base.AddDate(0, 0, i),
+		}
+		if err := bookings.Save(context.Background(), booking); err != nil {
+			t.Fatalf("save booking: %v", err)
+		}
+	}
+	return &ListGuestBookingsHandler{UoWFactory: memory.Factory{
+		ListingsRepo:     memory.NewListingRepository(),
+		AvailabilityRepo: memory.NewAvailabilityRepository(),
+		BookingRepo:      bookings,
+		ReviewsRepo:      memory.NewReviewsRepository(),
+		WishlistsRepo:    memory.NewWishlistRepository(),
+	}}
+}
+
+func TestListGuestBookingsDefaultPage(t *testing.T) {
+	const total = defaultGuestBookingsLimit + 5
+	handler := guestBookingsHandler(t, total)
+
+	all, err := handler.Handle(context.Background(), ListGuestBookingsQuery{GuestID: "guest-1"})
+	if err != nil {
+		t.Fatalf("no limit: %v", err)
+	}
+	if len(all.Items) != total || all.NextCursor != "" {
+		t.Fatalf("no limit or cursor: %d items, cursor %q, want all %d on one page", len(all.Items), all.NextCursor, total)
+	}
+
+	first, err := handler.Handle(context.Background(), ListGuestBookingsQuery{GuestID: "guest-1", Limit: 5})
+	if err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	next, err := handler.Handle(context.Background(), ListGuestBookingsQuery{GuestID: "guest-1", Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("next page: %v", err)
+	}
+	if len(next.Items) != defaultGuestBookingsLimit || next.NextCursor != "" {
+		t.Fatalf("cursor without limit: %d items, cursor %q, want the default page of %d", len(next.Items), next.NextCursor, defaultGuestBookingsLimit)
+	}
+}
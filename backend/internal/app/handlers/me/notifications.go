@@ -0,0 +1,54 @@
+package me
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	domainuser "rentme/internal/domain/user"
+)
+
+const updateNotificationsKey = "me.notifications.update"
+
+type UpdateNotificationsCommand struct {
+	UserID       string
+	WeeklyDigest bool
+}
+
+func (c UpdateNotificationsCommand) Key() string { return updateNotificationsKey }
+
+type UpdateNotificationsHandler struct {
+	Users  domainuser.Repository
+	Logger *slog.Logger
+}
+
+func (h *UpdateNotificationsHandler) Handle(ctx context.Context, cmd UpdateNotificationsCommand) (*dto.UserProfile, error) {
+	userID := strings.TrimSpace(cmd.UserID)
+	if userID == "" {
+		return nil, errors.New("user id is required")
+	}
+	if h.Users == nil {
+		return nil, errors.New("user repository unavailable")
+	}
+	user, err := h.Users.ByID(ctx, domainuser.ID(userID))
+	if err != nil {
+		return nil, err
+	}
+	user.SetNotificationPreferences(domainuser.NotificationPreferences{
+		WeeklyDigestDisabled: !cmd.WeeklyDigest,
+	}, time.Now())
+	if err := h.Users.Save(ctx, user); err != nil {
+		return nil, err
+	}
+	if h.Logger != nil {
+		h.Logger.Info("notification preferences updated", "user_id", userID, "weekly_digest", cmd.WeeklyDigest)
+	}
+	profile := dto.MapUserProfile(user)
+	return &profile, nil
+}
+
+var _ commands.Handler[UpdateNotificationsCommand, *dto.UserProfile] = (*UpdateNotificationsHandler)(nil)
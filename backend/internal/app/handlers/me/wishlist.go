@@ -0,0 +1,166 @@
+package me
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+const (
+	addToWishlistKey      = "me.wishlist.add"
+	removeFromWishlistKey = "me.wishlist.remove"
+	listWishlistKey       = "me.wishlist.list"
+)
+
+var ErrWishlistListingNotFound = errors.New("wishlist: listing not found")
+
+type AddToWishlistCommand struct {
+	GuestID   string
+	ListingID string
+}
+
+func (c AddToWishlistCommand) Key() string { return addToWishlistKey }
+
+type AddToWishlistHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *AddToWishlistHandler) Handle(ctx context.Context, cmd AddToWishlistCommand) (*dto.WishlistEntry, error) {
+	guestID := strings.TrimSpace(cmd.GuestID)
+	if guestID == "" {
+		return nil, errors.New("guest id is required")
+	}
+	listingID := domainlistings.ListingID(strings.TrimSpace(cmd.ListingID))
+	if listingID == "" {
+		return nil, errors.New("listing id is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, uow.ErrUnitOfWorkMissing
+	}
+
+	listing, err := unit.Listings().ByID(ctx, listingID)
+	if err != nil && !errors.Is(err, domainlistings.ErrListingNotFound) {
+		return nil, err
+	}
+	if err != nil || listing == nil || listing.State != domainlistings.ListingActive {
+		return nil, ErrWishlistListingNotFound
+	}
+
+	wishlist, err := unit.Wishlists().ByGuest(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := wishlist.Add(listingID); err != nil {
+		return nil, err
+	}
+	if err := unit.Wishlists().Save(ctx, wishlist); err != nil {
+		return nil, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("listing saved to wishlist", "guest_id", guestID, "listing_id", listingID)
+	}
+	return &dto.WishlistEntry{ListingID: string(listingID), Saved: true}, nil
+}
+
+type RemoveFromWishlistCommand struct {
+	GuestID   string
+	ListingID string
+}
+
+func (c RemoveFromWishlistCommand) Key() string { return removeFromWishlistKey }
+
+type RemoveFromWishlistHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *RemoveFromWishlistHandler) Handle(ctx context.Context, cmd RemoveFromWishlistCommand) (*dto.WishlistEntry, error) {
+	guestID := strings.TrimSpace(cmd.GuestID)
+	if guestID == "" {
+		return nil, errors.New("guest id is required")
+	}
+	listingID := domainlistings.ListingID(strings.TrimSpace(cmd.ListingID))
+	if listingID == "" {
+		return nil, errors.New("listing id is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return nil, uow.ErrUnitOfWorkMissing
+	}
+
+	wishlist, err := unit.Wishlists().ByGuest(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	if wishlist.Remove(listingID) {
+		if err := unit.Wishlists().Save(ctx, wishlist); err != nil {
+			return nil, err
+		}
+		if h.Logger != nil {
+			h.Logger.Info("listing removed from wishlist", "guest_id", guestID, "listing_id", listingID)
+		}
+	}
+	return &dto.WishlistEntry{ListingID: string(listingID), Saved: false}, nil
+}
+
+type ListWishlistQuery struct {
+	GuestID string
+}
+
+func (q ListWishlistQuery) Key() string { return listWishlistKey }
+
+type ListWishlistHandler struct {
+	UoWFactory uow.UoWFactory
+	Logger     *slog.Logger
+}
+
+func (h *ListWishlistHandler) Handle(ctx context.Context, q ListWishlistQuery) (dto.WishlistCollection, error) {
+	guestID := strings.TrimSpace(q.GuestID)
+	if guestID == "" {
+		return dto.WishlistCollection{}, errors.New("guest id is required")
+	}
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return dto.WishlistCollection{}, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	wishlist, err := unit.Wishlists().ByGuest(execCtx, guestID)
+	if err != nil {
+		return dto.WishlistCollection{}, err
+	}
+
+	items := make([]dto.ListingCard, 0, len(wishlist.ListingIDs))
+	for _, listingID := range wishlist.ListingIDs {
+		listing, err := unit.Listings().ByID(execCtx, listingID)
+		if err != nil {
+			if !errors.Is(err, domainlistings.ErrListingNotFound) {
+				return dto.WishlistCollection{}, err
+			}
+			if h.Logger != nil {
+				h.Logger.Warn("wishlist listing missing", "guest_id", guestID, "listing_id", listingID, "error", err)
+			}
+			continue
+		}
+		items = append(items, dto.MapListingCard(listing))
+	}
+
+	return dto.WishlistCollection{Items: items, Total: len(items)}, nil
+}
+
+var (
+	_ commands.Handler[AddToWishlistCommand, *dto.WishlistEntry]      = (*AddToWishlistHandler)(nil)
+	_ commands.Handler[RemoveFromWishlistCommand, *dto.WishlistEntry] = (*RemoveFromWishlistHandler)(nil)
+	_ queries.Handler[ListWishlistQuery, dto.WishlistCollection]      = (*ListWishlistHandler)(nil)
+)
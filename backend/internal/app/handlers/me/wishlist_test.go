@@ -0,0 +1,82 @@
+package me
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/infra/storage/memory"
+)
+
+type failingListings struct {
+	domainlistings.ListingRepository
+	err error
+}
+
+func (r failingListings) ByID(context.Context, domainlistings.ListingID) (*domainlistings.Listing, error) {
+	return nil, r.err
+}
+
+func wishlistContext(t *testing.T, listings domainlistings.ListingRepository) context.Context {
+	t.Helper()
+	unit, err := memory.Factory{
+		ListingsRepo:     listings,
+		AvailabilityRepo: memory.NewAvailabilityRepository(),
+		BookingRepo:      memory.NewBookingRepository(),
+		ReviewsRepo:      memory.NewReviewsRepository(),
+		WishlistsRepo:    memory.NewWishlistRepository(),
+	}.Begin(context.Background(), uow.TxOptions{})
+	if err != nil {
+		t.Fatalf("begin unit: %v", err)
+	}
+	return uow.ContextWithUnitOfWork(context.Background(), unit)
+}
+
+func TestAddToWishlistMissingListingIsNotFound(t *testing.T) {
+	ctx := wishlistContext(t, memory.NewListingRepository())
+	_, err := (&AddToWishlistHandler{}).Handle(ctx, AddToWishlistCommand{GuestID: "guest-1", ListingID: "missing"})
+	if !errors.Is(err, ErrWishlistListingNotFound) {
+		t.Fatalf("err = %v, want ErrWishlistListingNotFound", err)
+	}
+}
+
+func TestAddToWishlistRepositoryFailureIsNotNotFound(t *testing.T) {
+	boom := errors.New("connection reset")
+	ctx := wishlistContext(t, failingListings{err: boom})
+	_, err := (&AddToWishlistHandler{}).Handle(ctx, AddToWishlistCommand{GuestID: "guest-1", ListingID: "listing-1"})
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want the repository error", err)
+	}
+	if errors.Is(err, ErrWishlistListingNotFound) {
+		t.Fatalf("repository failure reported as not found: %v", err)
+	}
+}
+
+func TestListWishlistRepositoryFailureFails(t *testing.T) {
+	boom := errors.New("connection reset")
+	wishlists := memory.NewWishlistRepository()
+	factory := memory.Factory{
+		ListingsRepo:     failingListings{err: boom},
+		AvailabilityRepo: memory.NewAvailabilityRepository(),
+		BookingRepo:      memory.NewBookingRepository(),
+		ReviewsRepo:      memory.NewReviewsRepository(),
+		WishlistsRepo:    wishlists,
+	}
+	wishlist, err := wishlists.ByGuest(context.Background(), "guest-1")
+	if err != nil {
+		t.Fatalf("wishlist: %v", err)
+	}
+	if err := wishlist.Add("listing-1"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := wishlists.Save(context.Background(), wishlist); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	_, err = (&ListWishlistHandler{UoWFactory: factory}).Handle(context.Background(), ListWishlistQuery{GuestID: "guest-1"})
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want the repository error", err)
+	}
+}
@@ -0,0 +1,133 @@
+package reviews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+	domainreviews "rentme/internal/domain/reviews"
+)
+
+const (
+	adminListListingReviewsKey = "admin.reviews.listing.list"
+	moderateReviewKey          = "admin.reviews.moderate"
+)
+
+// AdminListListingReviewsQuery lists every review of a listing, moderated
+// ones included.
+type AdminListListingReviewsQuery struct {
+	ListingID string
+	Limit     int
+	Offset    int
+}
+
+func (q AdminListListingReviewsQuery) Key() string { return adminListListingReviewsKey }
+
+type AdminListListingReviewsHandler struct {
+	UoWFactory uow.UoWFactory
+	Logger     *slog.Logger
+}
+
+func (h *AdminListListingReviewsHandler) Handle(ctx context.Context, q AdminListListingReviewsQuery) (dto.AdminReviewCollection, error) {
+	limit := normalizeLimit(q.Limit)
+	offset := q.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
+	if err != nil {
+		return dto.AdminReviewCollection{}, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	listingID := domainlistings.ListingID(q.ListingID)
+	if _, err := unit.Listings().ByID(execCtx, listingID); err != nil {
+		return dto.AdminReviewCollection{}, fmt.Errorf("%w: %v", ErrListingNotFound, err)
+	}
+	all, err := unit.Reviews().ListByListing(execCtx, listingID, 0, 0)
+	if err != nil {
+		return dto.AdminReviewCollection{}, err
+	}
+	page, err := unit.Reviews().ListByListing(execCtx, listingID, limit, offset)
+	if err != nil {
+		return dto.AdminReviewCollection{}, err
+	}
+
+	items := make([]dto.AdminReview, 0, len(page))
+	for _, review := range page {
+		items = append(items, dto.MapAdminReview(review))
+	}
+	if h.Logger != nil {
+		h.Logger.Debug("admin listing reviews listed", "listing_id", listingID, "count", len(items), "total", len(all))
+	}
+	return dto.AdminReviewCollection{Items: items, Total: len(all)}, nil
+}
+
+// ModerateReviewCommand hides a review from public view without deleting it.
+type ModerateReviewCommand struct {
+	AdminID  string
+	ReviewID string
+	Reason   string
+	Now      time.Time
+}
+
+func (c ModerateReviewCommand) Key() string { return moderateReviewKey }
+
+// ModerateReviewHandler marks the review moderated and recalculates the
+// listing rating without it.
+type ModerateReviewHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *ModerateReviewHandler) Handle(ctx context.Context, cmd ModerateReviewCommand) (dto.AdminReview, error) {
+	reviewID := strings.TrimSpace(cmd.ReviewID)
+	if reviewID == "" {
+		return dto.AdminReview{}, errors.New("review id is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return dto.AdminReview{}, uow.ErrUnitOfWorkMissing
+	}
+
+	now := cmd.Now
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+
+	review, err := unit.Reviews().ByID(ctx, domainreviews.ReviewID(reviewID))
+	if err != nil {
+		return dto.AdminReview{}, err
+	}
+	if err := review.Moderate(cmd.Reason, now); err != nil {
+		return dto.AdminReview{}, err
+	}
+	if err := unit.Reviews().Save(ctx, review); err != nil {
+		return dto.AdminReview{}, err
+	}
+	if err := recalculateListingRating(ctx, unit, review.ListingID, now); err != nil {
+		return dto.AdminReview{}, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("review moderated", "review_id", review.ID, "listing_id", review.ListingID, "admin_id", cmd.AdminID, "reason", review.ModerationReason)
+	}
+
+	return dto.MapAdminReview(review), nil
+}
+
+var (
+	_ queries.Handler[AdminListListingReviewsQuery, dto.AdminReviewCollection] = (*AdminListListingReviewsHandler)(nil)
+	_ commands.Handler[ModerateReviewCommand, dto.AdminReview]                 = (*ModerateReviewHandler)(nil)
+)
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"rentme/internal/app/dto"
 	handlersupport "rentme/internal/app/handlers/support"
 	"rentme/internal/app/queries"
 	"rentme/internal/app/uow"
 	domainlistings "rentme/internal/domain/listings"
+	domainreviews "rentme/internal/domain/reviews"
+	domainuser "rentme/internal/domain/user"
 )
 
 const listListingReviewsKey = "reviews.listing.list"
@@ -26,13 +29,16 @@ type ListListingReviewsQuery struct {
 
 func (q ListListingReviewsQuery) Key() string { return listListingReviewsKey }
 
-// ListListingReviewsHandler loads paginated reviews for a listing.
+// ListListingReviewsHandler loads paginated reviews for a listing, leaving
+// out moderated ones. Authors are resolved through Users to a display name so
+// the public payload carries no user ids.
 type ListListingReviewsHandler struct {
 	UoWFactory uow.UoWFactory
+	Users      domainuser.Repository
 	Logger     *slog.Logger
 }
 
-func (h *ListListingReviewsHandler) Handle(ctx context.Context, q ListListingReviewsQuery) (dto.ReviewCollection, error) {
+func (h *ListListingReviewsHandler) Handle(ctx context.Context, q ListListingReviewsQuery) (dto.PublicReviewCollection, error) {
 	limit := normalizeLimit(q.Limit)
 	offset := q.Offset
 	if offset < 0 {
@@ -41,7 +47,7 @@ func (h *ListListingReviewsHandler) Handle(ctx context.Context, q ListListingRev
 
 	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, h.UoWFactory)
 	if err != nil {
-		return dto.ReviewCollection{}, err
+		return dto.PublicReviewCollection{}, err
 	}
 	if cleanup != nil {
 		defer cleanup()
@@ -49,12 +55,18 @@ func (h *ListListingReviewsHandler) Handle(ctx context.Context, q ListListingRev
 
 	listingID := domainlistings.ListingID(q.ListingID)
 	if _, err := unit.Listings().ByID(execCtx, listingID); err != nil {
-		return dto.ReviewCollection{}, fmt.Errorf("%w: %v", ErrListingNotFound, err)
+		return dto.PublicReviewCollection{}, fmt.Errorf("%w: %v", ErrListingNotFound, err)
 	}
 
-	all, err := unit.Reviews().ListByListing(execCtx, listingID, 0, 0)
+	stored, err := unit.Reviews().ListByListing(execCtx, listingID, 0, 0)
 	if err != nil {
-		return dto.ReviewCollection{}, err
+		return dto.PublicReviewCollection{}, err
+	}
+	all := make([]*domainreviews.Review, 0, len(stored))
+	for _, review := range stored {
+		if !review.Moderated {
+			all = append(all, review)
+		}
 	}
 	total := len(all)
 
@@ -67,16 +79,43 @@ func (h *ListListingReviewsHandler) Handle(ctx context.Context, q ListListingRev
 	}
 	slice := all[offset:windowEnd]
 
-	items := make([]dto.Review, 0, len(slice))
+	names := make(map[string]string)
+	items := make([]dto.PublicReview, 0, len(slice))
 	for _, review := range slice {
-		items = append(items, dto.MapReview(review))
+		name, seen := names[review.AuthorID]
+		if !seen {
+			name = h.authorName(execCtx, review.AuthorID)
+			names[review.AuthorID] = name
+		}
+		var stay *time.Time
+		if booking, err := unit.Booking().ByID(execCtx, review.BookingID); err == nil {
+			checkIn := booking.Range.CheckIn
+			stay = &checkIn
+		}
+		items = append(items, dto.MapPublicReview(review, name, stay))
 	}
 
 	if h.Logger != nil {
 		h.Logger.Debug("listing reviews listed", "listing_id", listingID, "count", len(items), "total", total)
 	}
 
-	return dto.ReviewCollection{Items: items, Total: total}, nil
+	return dto.PublicReviewCollection{Items: items, Total: total}, nil
+}
+
+// authorName returns the author's full name; a failed lookup falls back to the
+// generic display name instead of failing the list.
+func (h *ListListingReviewsHandler) authorName(ctx context.Context, authorID string) string {
+	if h.Users == nil {
+		return ""
+	}
+	author, err := h.Users.ByID(ctx, domainuser.ID(authorID))
+	if err != nil {
+		if h.Logger != nil && !errors.Is(err, domainuser.ErrNotFound) {
+			h.Logger.Warn("load review author failed", "author_id", authorID, "error", err)
+		}
+		return ""
+	}
+	return author.Name
 }
 
 func normalizeLimit(limit int) int {
@@ -89,4 +128,4 @@ func normalizeLimit(limit int) int {
 	return limit
 }
 
-var _ queries.Handler[ListListingReviewsQuery, dto.ReviewCollection] = (*ListListingReviewsHandler)(nil)
+var _ queries.Handler[ListListingReviewsQuery, dto.PublicReviewCollection] = (*ListListingReviewsHandler)(nil)
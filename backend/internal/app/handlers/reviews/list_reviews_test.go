@@ -0,0 +1,106 @@
+package reviews
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+	domainreviews "rentme/internal/domain/reviews"
+	domainrange "rentme/internal/domain/shared/daterange"
+	domainuser "rentme/internal/domain/user"
+	"rentme/internal/infra/storage/memory"
+)
+
+func TestPublicReviewsCarryNoAuthorIdentity(t *testing.T) {
+	ctx := context.Background()
+	listings := memory.NewListingRepository()
+	bookings := memory.NewBookingRepository()
+	reviews := memory.NewReviewsRepository()
+	users := memory.NewUserRepository()
+	if err := listings.Save(ctx, &domainlistings.Listing{ID: "listing-1", Host: "host-1", Title: "Loft"}); err != nil {
+		t.Fatalf("save listing: %v", err)
+	}
+	authors := []*domainuser.User{
+		{ID: "user-7f3a", Email: "anna.kuznetsova@example.com", Name: "Anna Kuznetsova"},
+		{ID: "user-91bc", Email: "no-name@example.com"},
+	}
+	stay, err := domainrange.NewDates(2026, time.March, 10, 2026, time.March, 14)
+	if err != nil {
+		t.Fatalf("range: %v", err)
+	}
+	for i, author := range authors {
+		if err := users.Save(ctx, author); err != nil {
+			t.Fatalf("save user: %v", err)
+		}
+		bookingID := domainbooking.BookingID(fmt.Sprintf("booking-%d", i+1))
+		if err := bookings.Save(ctx, &domainbooking.Booking{ID: bookingID, ListingID: "listing-1", GuestID: string(author.ID), Range: stay, State: domainbooking.StateCheckedOut}); err != nil {
+			t.Fatalf("save booking: %v", err)
+		}
+		review, err := domainreviews.Submit(domainreviews.SubmitParams{
+			ID:        domainreviews.ReviewID(fmt.Sprintf("review-%d", i+1)),
+			BookingID: bookingID,
+			AuthorID:  string(author.ID),
+			ListingID: "listing-1",
+			Rating:    5 - i,
+			Text:      "Great stay",
+			CreatedAt: stay.CheckOut.Add(time.Duration(i) * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+		if err := reviews.Save(ctx, review); err != nil {
+			t.Fatalf("save review: %v", err)
+		}
+	}
+	handler := &ListListingReviewsHandler{
+		UoWFactory: memory.Factory{
+			ListingsRepo:     listings,
+			AvailabilityRepo: memory.NewAvailabilityRepository(),
+			BookingRepo:      bookings,
+			ReviewsRepo:      reviews,
+			WishlistsRepo:    memory.NewWishlistRepository(),
+		},
+		Users: users,
+	}
+
+	result, err := handler.Handle(ctx, ListListingReviewsQuery{ListingID: "listing-1"})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if result.Total != 2 || len(result.Items) != 2 {
+		t.Fatalf("reviews = %+v, want both", result)
+	}
+	names := map[string]bool{}
+	for _, item := range result.Items {
+		names[item.AuthorName] = true
+		if item.StayMonth != "2026-03" {
+			t.Fatalf("stay month = %q, want 2026-03", item.StayMonth)
+		}
+	}
+	if !names["Anna K."] || !names["Guest"] {
+		t.Fatalf("author names = %v, want Anna K. and Guest", names)
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	payload := string(body)
+	for _, author := range authors {
+		for _, secret := range []string{string(author.ID), author.Email, "Kuznetsova"} {
+			if secret != "" && strings.Contains(payload, secret) {
+				t.Fatalf("public payload exposes %q: %s", secret, payload)
+			}
+		}
+	}
+	for _, field := range []string{`"author_id"`, `"booking_id"`, `"email"`} {
+		if strings.Contains(payload, field) {
+			t.Fatalf("public payload has field %s: %s", field, payload)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package reviews
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	"rentme/internal/app/uow"
+	domainlistings "rentme/internal/domain/listings"
+	domainreviews "rentme/internal/domain/reviews"
+)
+
+const replyToReviewKey = "reviews.reply"
+
+var ErrReviewNotHosted = errors.New("reviews: listing does not belong to current host")
+
+// ReplyToReviewCommand publishes the host's answer to a guest review.
+type ReplyToReviewCommand struct {
+	ListingID string
+	ReviewID  string
+	HostID    string
+	Text      string
+	Now       time.Time
+}
+
+func (c ReplyToReviewCommand) Key() string { return replyToReviewKey }
+
+// ReplyToReviewHandler checks listing ownership and stores the reply.
+type ReplyToReviewHandler struct {
+	Logger *slog.Logger
+}
+
+func (h *ReplyToReviewHandler) Handle(ctx context.Context, cmd ReplyToReviewCommand) (dto.Review, error) {
+	if cmd.ReviewID == "" {
+		return dto.Review{}, errors.New("review id is required")
+	}
+	unit, ok := uow.FromContext(ctx)
+	if !ok {
+		return dto.Review{}, uow.ErrUnitOfWorkMissing
+	}
+
+	now := cmd.Now
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+
+	review, err := unit.Reviews().ByID(ctx, domainreviews.ReviewID(cmd.ReviewID))
+	if err != nil {
+		return dto.Review{}, err
+	}
+	if cmd.ListingID != "" && review.ListingID != domainlistings.ListingID(cmd.ListingID) {
+		return dto.Review{}, domainreviews.ErrNotFound
+	}
+	listing, err := unit.Listings().ByID(ctx, review.ListingID)
+	if err != nil {
+		return dto.Review{}, err
+	}
+	if listing.Host != domainlistings.HostID(cmd.HostID) {
+		return dto.Review{}, ErrReviewNotHosted
+	}
+	if err := review.Reply(cmd.Text, now); err != nil {
+		return dto.Review{}, err
+	}
+	if err := unit.Reviews().Save(ctx, review); err != nil {
+		return dto.Review{}, err
+	}
+
+	if h.Logger != nil {
+		h.Logger.Info("review replied", "review_id", review.ID, "listing_id", review.ListingID, "host_id", cmd.HostID)
+	}
+
+	return dto.MapReview(review), nil
+}
+
+var _ commands.Handler[ReplyToReviewCommand, dto.Review] = (*ReplyToReviewHandler)(nil)
@@ -76,7 +76,7 @@ func (h *SubmitReviewHandler) Handle(ctx context.Context, cmd SubmitReviewComman
 	if booking.GuestID != cmd.AuthorID {
 		return dto.Review{}, ErrBookingOwnership
 	}
-	if booking.Range.CheckOut.After(now) {
+	if booking.State != domainbooking.StateCheckedOut {
 		return dto.Review{}, ErrStayNotFinished
 	}
 
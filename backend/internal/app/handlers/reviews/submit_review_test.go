@@ -0,0 +1,111 @@
+package reviews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	listingapp "rentme/internal/app/handlers/listings"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/infra/storage/memory"
+)
+
+func TestSubmitReviewsUpdateListingRating(t *testing.T) {
+	ctx := context.Background()
+	listings := memory.NewListingRepository()
+	bookings := memory.NewBookingRepository()
+	factory := memory.Factory{
+		ListingsRepo:     listings,
+		AvailabilityRepo: memory.NewAvailabilityRepository(),
+		BookingRepo:      bookings,
+		ReviewsRepo:      memory.NewReviewsRepository(),
+		WishlistsRepo:    memory.NewWishlistRepository(),
+	}
+	if err := listings.Save(ctx, &domainlistings.Listing{ID: "listing-1", Host: "host-1", Title: "Loft", Rating: 4.9}); err != nil {
+		t.Fatalf("save listing: %v", err)
+	}
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	handler := &SubmitReviewHandler{UoWFactory: factory}
+	overviews := &listingapp.GetOverviewHandler{UoWFactory: factory}
+	ratings := []int{5, 3, 4, 3}
+	for i, rating := range ratings {
+		guest := fmt.Sprintf("guest-%d", i)
+		booking := &domainbooking.Booking{ID: domainbooking.BookingID("booking-" + guest), ListingID: "listing-1", GuestID: guest, State: domainbooking.StateCheckedOut}
+		if err := bookings.Save(ctx, booking); err != nil {
+			t.Fatalf("save booking: %v", err)
+		}
+		if _, err := handler.Handle(ctx, SubmitReviewCommand{BookingID: string(booking.ID), AuthorID: guest, Rating: rating, Now: now}); err != nil {
+			t.Fatalf("submit review %d: %v", i, err)
+		}
+
+		total := 0
+		for _, r := range ratings[:i+1] {
+			total += r
+		}
+		want := float64(total) / float64(i+1)
+		listing, err := listings.ByID(ctx, "listing-1")
+		if err != nil {
+			t.Fatalf("load listing: %v", err)
+		}
+		if math.Abs(listing.Rating-want) > 1e-9 {
+			t.Fatalf("after %d reviews rating = %v, want %v", i+1, listing.Rating, want)
+		}
+
+		overview, err := overviews.Handle(ctx, listingapp.GetOverviewQuery{ListingID: "listing-1"})
+		if err != nil {
+			t.Fatalf("overview: %v", err)
+		}
+		if overview.ReviewCount != i+1 || math.Abs(overview.Rating-want) > 1e-9 {
+			t.Fatalf("overview after %d reviews: count %d rating %v", i+1, overview.ReviewCount, overview.Rating)
+		}
+	}
+
+	overview, err := overviews.Handle(ctx, listingapp.GetOverviewQuery{ListingID: "listing-1"})
+	if err != nil {
+		t.Fatalf("overview: %v", err)
+	}
+	wantBreakdown := map[string]int{"1": 0, "2": 0, "3": 2, "4": 1, "5": 1}
+	for star, count := range wantBreakdown {
+		if got, ok := overview.RatingBreakdown[star]; !ok || got != count {
+			t.Fatalf("breakdown[%s] = %d (present %v), want %d", star, got, ok, count)
+		}
+	}
+}
+
+func TestSubmitReviewRejectsSecondReviewForBooking(t *testing.T) {
+	ctx := context.Background()
+	listings := memory.NewListingRepository()
+	bookings := memory.NewBookingRepository()
+	factory := memory.Factory{
+		ListingsRepo:     listings,
+		AvailabilityRepo: memory.NewAvailabilityRepository(),
+		BookingRepo:      bookings,
+		ReviewsRepo:      memory.NewReviewsRepository(),
+		WishlistsRepo:    memory.NewWishlistRepository(),
+	}
+	if err := listings.Save(ctx, &domainlistings.Listing{ID: "listing-1", Host: "host-1", Title: "Loft"}); err != nil {
+		t.Fatalf("save listing: %v", err)
+	}
+	if err := bookings.Save(ctx, &domainbooking.Booking{ID: "booking-1", ListingID: "listing-1", GuestID: "guest-1", State: domainbooking.StateCheckedOut}); err != nil {
+		t.Fatalf("save booking: %v", err)
+	}
+	handler := &SubmitReviewHandler{UoWFactory: factory}
+	if _, err := handler.Handle(ctx, SubmitReviewCommand{BookingID: "booking-1", AuthorID: "guest-1", Rating: 5}); err != nil {
+		t.Fatalf("first review: %v", err)
+	}
+	if _, err := handler.Handle(ctx, SubmitReviewCommand{BookingID: "booking-1", AuthorID: "guest-1", Rating: 1}); !errors.Is(err, ErrDuplicateReview) {
+		t.Fatalf("second review err = %v, want ErrDuplicateReview", err)
+	}
+	listing, err := listings.ByID(ctx, "listing-1")
+	if err != nil {
+		t.Fatalf("load listing: %v", err)
+	}
+	if listing.Rating != 5 {
+		t.Fatalf("rating = %v, want 5", listing.Rating)
+	}
+}
@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrNoWork is returned by a job that found nothing to do. The run counts as
+// a success but is not written to the run log, so frequent pollers such as
+// the outbox relay do not flood it.
+var ErrNoWork = errors.New("jobs: nothing to do")
+
+// Func executes one attempt of a task.
+type Func func(ctx context.Context, task Task) error
+
+// Definition describes a job kind. A definition with Every > 0 is recurring:
+// the runner keeps one task for it, first due after Delay. Definitions
+// without Every only run tasks enqueued through Runner.Schedule.
+type Definition struct {
+	Name    string
+	Every   time.Duration
+	Delay   time.Duration
+	Timeout time.Duration
+	Retry   RetryPolicy
+	Run     Func
+}
+
+// RetryPolicy controls failed attempts. Recurring jobs fall back to their
+// regular schedule once attempts are exhausted; one-off tasks are dropped.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     []time.Duration
+}
+
+// Delay returns the wait before the given retry and whether one is allowed.
+func (p RetryPolicy) Delay(attempt int) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+	if len(p.Backoff) == 0 {
+		return 0, true
+	}
+	idx := attempt - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(p.Backoff) {
+		idx = len(p.Backoff) - 1
+	}
+	return p.Backoff[idx], true
+}
+
+// Task is a queued execution of a job. Recurring tasks use the job name as ID.
+type Task struct {
+	ID         string
+	Job        string
+	Payload    json.RawMessage
+	RunAt      time.Time
+	Attempts   int
+	LeaseOwner string
+	LeaseUntil time.Time
+	LastError  string
+	CreatedAt  time.Time
+}
+
+// Decode unmarshals the task payload into v.
+func (t Task) Decode(v any) error {
+	if len(t.Payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(t.Payload, v)
+}
+
+// RunStatus is the outcome of a task attempt.
+type RunStatus string
+
+const (
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+)
+
+// Run is a finished task attempt as shown to operators.
+type Run struct {
+	ID         string
+	TaskID     string
+	Job        string
+	Owner      string
+	Attempt    int
+	Status     RunStatus
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Error      string
+}
+
+// Duration is the wall time of the attempt.
+func (r Run) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// Store persists the task queue and the run log. Lease must be atomic per
+// task so that only one instance runs a due task at a time.
+type Store interface {
+	// Enqueue inserts the task; an existing task with the same ID is kept.
+	Enqueue(ctx context.Context, task Task) error
+	// Lease claims up to limit due tasks whose lease is free or lapsed.
+	Lease(ctx context.Context, owner string, now time.Time, ttl time.Duration, limit int) ([]Task, error)
+	// Reschedule releases the lease held by owner and sets the next run.
+	// A zero runAt removes the task.
+	Reschedule(ctx context.Context, id, owner string, runAt time.Time, attempts int, lastError string) error
+	RecordRun(ctx context.Context, run Run) error
+	ListRuns(ctx context.Context, job string, limit int) ([]Run, error)
+}
@@ -0,0 +1,260 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rentme/internal/app/schedule"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultLeaseTTL     = time.Minute
+	defaultBatchSize    = 20
+)
+
+var (
+	ErrUnknownJob        = errors.New("jobs: unknown job")
+	ErrRunnerNotReady    = errors.New("jobs: runner missing store")
+	ErrInvalidDefinition = errors.New("jobs: definition requires a name and a func")
+)
+
+// Runner polls the store and executes due tasks of registered jobs. LeaseTTL
+// must exceed the longest job Timeout, otherwise a slow task can be leased
+// again by another instance while it is still running.
+type Runner struct {
+	Store     Store
+	Owner     string
+	Poll      time.Duration
+	LeaseTTL  time.Duration
+	BatchSize int
+	Logger    *slog.Logger
+	Now       func() time.Time
+
+	mu        sync.RWMutex
+	defs      map[string]Definition
+	ownerOnce sync.Once
+}
+
+// Register adds a job definition; it must be called before Run.
+func (r *Runner) Register(def Definition) error {
+	if def.Name == "" || def.Run == nil {
+		return ErrInvalidDefinition
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.defs == nil {
+		r.defs = make(map[string]Definition)
+	}
+	r.defs[def.Name] = def
+	return nil
+}
+
+// Schedule enqueues a one-off task of a registered job at runAt.
+func (r *Runner) Schedule(ctx context.Context, name string, payload any, runAt time.Time) error {
+	if r.Store == nil {
+		return ErrRunnerNotReady
+	}
+	if _, ok := r.definition(name); !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownJob, name)
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return r.Store.Enqueue(ctx, Task{
+		ID:        uuid.NewString(),
+		Job:       name,
+		Payload:   raw,
+		RunAt:     runAt.UTC(),
+		CreatedAt: r.now(),
+	})
+}
+
+// Run seeds recurring tasks and executes due tasks until ctx is cancelled.
+func (r *Runner) Run(ctx context.Context) error {
+	if r.Store == nil {
+		return ErrRunnerNotReady
+	}
+	if err := r.seed(ctx); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(r.poll())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.RunOnce(ctx); err != nil && ctx.Err() == nil {
+				r.logger().Warn("job poll failed", "error", err)
+			}
+		}
+	}
+}
+
+// RunOnce leases due tasks and executes them sequentially.
+func (r *Runner) RunOnce(ctx context.Context) error {
+	tasks, err := r.Store.Lease(ctx, r.owner(), r.now(), r.leaseTTL(), r.batchSize())
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if err := r.execute(ctx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Runner) seed(ctx context.Context) error {
+	now := r.now()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, def := range r.defs {
+		if def.Every <= 0 {
+			continue
+		}
+		task := Task{ID: def.Name, Job: def.Name, RunAt: now.Add(def.Delay), CreatedAt: now}
+		if err := r.Store.Enqueue(ctx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Runner) execute(ctx context.Context, task Task) error {
+	def, ok := r.definition(task.Job)
+	if !ok {
+		// Another build owns this job; let the lease lapse so it can pick it up.
+		return nil
+	}
+	attempt := task.Attempts + 1
+	runCtx := ctx
+	cancel := func() {}
+	if def.Timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, def.Timeout)
+	}
+	started := r.now()
+	runErr := runSafely(runCtx, def.Run, task)
+	cancel()
+	finished := r.now()
+
+	if runErr == nil || errors.Is(runErr, ErrNoWork) {
+		next := time.Time{}
+		if def.Every > 0 {
+			next = finished.Add(def.Every)
+		}
+		if err := r.Store.Reschedule(ctx, task.ID, r.owner(), next, 0, ""); err != nil {
+			return err
+		}
+		if runErr != nil {
+			return nil
+		}
+		return r.record(ctx, task, attempt, started, finished, nil)
+	}
+
+	r.logger().Error("job run failed", "job", task.Job, "task_id", task.ID, "attempt", attempt, "error", runErr)
+	next := time.Time{}
+	attempts := attempt
+	if delay, retry := def.Retry.Delay(attempt); retry {
+		next = finished.Add(delay)
+	} else if def.Every > 0 {
+		next = finished.Add(def.Every)
+		attempts = 0
+	}
+	if err := r.Store.Reschedule(ctx, task.ID, r.owner(), next, attempts, runErr.Error()); err != nil {
+		return err
+	}
+	return r.record(ctx, task, attempt, started, finished, runErr)
+}
+
+func (r *Runner) record(ctx context.Context, task Task, attempt int, started, finished time.Time, runErr error) error {
+	run := Run{
+		ID:         uuid.NewString(),
+		TaskID:     task.ID,
+		Job:        task.Job,
+		Owner:      r.owner(),
+		Attempt:    attempt,
+		Status:     RunSucceeded,
+		StartedAt:  started,
+		FinishedAt: finished,
+	}
+	if runErr != nil {
+		run.Status = RunFailed
+		run.Error = runErr.Error()
+	}
+	return r.Store.RecordRun(ctx, run)
+}
+
+// runSafely turns a panicking job into a failed run instead of killing the runner.
+func runSafely(ctx context.Context, fn Func, task Task) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("jobs: panic: %v", rec)
+		}
+	}()
+	return fn(ctx, task)
+}
+
+func (r *Runner) definition(name string) (Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	def, ok := r.defs[name]
+	return def, ok
+}
+
+func (r *Runner) owner() string {
+	r.ownerOnce.Do(func() {
+		if r.Owner == "" {
+			host, _ := os.Hostname()
+			r.Owner = fmt.Sprintf("%s-%d", host, os.Getpid())
+		}
+	})
+	return r.Owner
+}
+
+func (r *Runner) now() time.Time {
+	if r.Now != nil {
+		return r.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func (r *Runner) poll() time.Duration {
+	if r.Poll <= 0 {
+		return defaultPollInterval
+	}
+	return r.Poll
+}
+
+func (r *Runner) leaseTTL() time.Duration {
+	if r.LeaseTTL <= 0 {
+		return defaultLeaseTTL
+	}
+	return r.LeaseTTL
+}
+
+func (r *Runner) batchSize() int {
+	if r.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return r.BatchSize
+}
+
+func (r *Runner) logger() *slog.Logger {
+	if r.Logger != nil {
+		return r.Logger
+	}
+	return slog.Default()
+}
+
+var _ schedule.Scheduler = (*Runner)(nil)
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+
+	gin "github.com/gin-gonic/gin"
+)
+
+// ConcurrencyLimiterByKey allows at most limit requests per key in flight at
+// once and answers 429 beyond that. Unlike the token buckets it does not care
+// how fast requests arrive, only how many overlap, which is what a burst of
+// type-ahead searches looks like. A non-positive limit disables it; requests
+// with an empty key pass through.
+func ConcurrencyLimiterByKey(limit int, key func(c *gin.Context) string) gin.HandlerFunc {
+	if limit <= 0 || key == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	var (
+		mu       sync.Mutex
+		inFlight = make(map[string]int)
+	)
+	return func(c *gin.Context) {
+		k := key(c)
+		if k == "" {
+			c.Next()
+			return
+		}
+		mu.Lock()
+		if inFlight[k] >= limit {
+			mu.Unlock()
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many concurrent requests", "code": "RATE_LIMITED"})
+			return
+		}
+		inFlight[k]++
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			// Dropping idle keys keeps the map as small as the set of active clients.
+			if inFlight[k]--; inFlight[k] <= 0 {
+				delete(inFlight, k)
+			}
+			mu.Unlock()
+		}()
+		c.Next()
+	}
+}
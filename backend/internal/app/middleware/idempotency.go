@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"reflect"
@@ -17,13 +19,30 @@ type IdempotentCommand interface {
 	ResultPrototype() any // should match the handler result type
 }
 
+// IdempotencyFingerprinter lets a command choose which fields identify its
+// payload. Commands without it are fingerprinted by their encoded form, so
+// per-request values such as generated IDs must be left out here.
+type IdempotencyFingerprinter interface {
+	IdempotencyFingerprint() string
+}
+
 type IdempotencyRecord struct {
-	Key        string
-	Payload    []byte
-	Error      string
-	OccurredAt time.Time
+	Key         string
+	Fingerprint string
+	Payload     []byte
+	Error       string
+	OccurredAt  time.Time
+	ExpiresAt   time.Time
+}
+
+// Expired reports whether the record is past its retention window.
+func (r IdempotencyRecord) Expired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && !now.Before(r.ExpiresAt)
 }
 
+// IdempotencyStore keeps command results for a limited time. Stores stamp
+// ExpiresAt on Save from their configured TTL and must not return expired
+// records from Get.
 type IdempotencyStore interface {
 	Get(ctx context.Context, key string) (IdempotencyRecord, bool, error)
 	Save(ctx context.Context, rec IdempotencyRecord) error
@@ -46,6 +65,8 @@ func (JSONResultCodec) Decode(data []byte, out any) error {
 
 var (
 	errMissingPrototype = errors.New("middleware: idempotent command requires result prototype")
+
+	ErrIdempotencyConflict = errors.New("middleware: idempotency key reused with a different payload")
 )
 
 func Idempotency(store IdempotencyStore, codec ResultCodec) CommandMiddleware {
@@ -66,11 +87,18 @@ func Idempotency(store IdempotencyStore, codec ResultCodec) CommandMiddleware {
 			if key == "" {
 				return nextFn(ctx, cmd)
 			}
+			fingerprint, err := commandFingerprint(idCmd, codec)
+			if err != nil {
+				return nil, err
+			}
 			rec, found, err := store.Get(ctx, key)
 			if err != nil {
 				return nil, err
 			}
 			if found {
+				if rec.Fingerprint != "" && rec.Fingerprint != fingerprint {
+					return nil, ErrIdempotencyConflict
+				}
 				if rec.Error != "" {
 					return nil, errors.New(rec.Error)
 				}
@@ -85,8 +113,9 @@ func Idempotency(store IdempotencyStore, codec ResultCodec) CommandMiddleware {
 			}
 			result, err := nextFn(ctx, cmd)
 			record := IdempotencyRecord{
-				Key:        key,
-				OccurredAt: time.Now().UTC(),
+				Key:         key,
+				Fingerprint: fingerprint,
+				OccurredAt:  time.Now().UTC(),
 			}
 			if err != nil {
 				record.Error = err.Error()
@@ -110,6 +139,21 @@ func Idempotency(store IdempotencyStore, codec ResultCodec) CommandMiddleware {
 	}
 }
 
+func commandFingerprint(cmd IdempotentCommand, codec ResultCodec) (string, error) {
+	var raw []byte
+	if fp, ok := cmd.(IdempotencyFingerprinter); ok {
+		raw = []byte(fp.IdempotencyFingerprint())
+	} else {
+		encoded, err := codec.Encode(cmd)
+		if err != nil {
+			return "", err
+		}
+		raw = encoded
+	}
+	sum := sha256.Sum256(append([]byte(cmd.Key()+"\x00"), raw...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
 func normalizePrototype(proto any) any {
 	rv := reflect.ValueOf(proto)
 	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
@@ -2,24 +2,49 @@ package middleware
 
 import (
 	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
 
 	"rentme/internal/app/commands"
 	"rentme/internal/app/outbox"
 )
 
-func OutboxFlush(box outbox.Outbox) CommandMiddleware {
+// OutboxFlushObserver counts outbox flushes that failed after their command
+// committed.
+type OutboxFlushObserver interface {
+	ObserveOutboxFlushFailure(command string)
+}
+
+// OutboxFlush scopes the records a command adds to the outbox to one batch.
+// It must wrap Transaction: handlers write the records in the command's unit
+// of work and only once it has committed does Flush make them dispatchable. A
+// failed command discards its batch. A failed flush cannot undo the commit, so
+// it is logged with the command key and counted instead of failing the command.
+func OutboxFlush(box outbox.Outbox, logger *slog.Logger, observer OutboxFlushObserver) CommandMiddleware {
 	if box == nil {
 		panic("middleware: outbox required")
 	}
 	return func(next commands.Bus) commands.Bus {
 		nextFn := wrapCommand(next)
 		return commandFunc(func(ctx context.Context, cmd commands.Command) (any, error) {
+			ctx = outbox.WithBatch(ctx, uuid.NewString())
 			res, err := nextFn(ctx, cmd)
 			if err != nil {
+				if discarder, ok := box.(outbox.Discarder); ok {
+					if discardErr := discarder.Discard(ctx); discardErr != nil && logger != nil {
+						logger.Error("outbox discard failed", "command", cmd.Key(), "error", discardErr)
+					}
+				}
 				return nil, err
 			}
 			if err := box.Flush(ctx); err != nil {
-				return nil, err
+				if logger != nil {
+					logger.Error("outbox flush failed", "command", cmd.Key(), "error", err)
+				}
+				if observer != nil {
+					observer.ObserveOutboxFlushFailure(cmd.Key())
+				}
 			}
 			return res, nil
 		})
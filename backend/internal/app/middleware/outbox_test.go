@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/outbox"
+	"rentme/internal/app/uow"
+	"rentme/internal/domain/shared/events"
+)
+
+// recordingUnit keeps the aggregates a handler saves until Commit.
+type recordingUnit struct {
+	uow.UnitOfWork
+	pending    []string
+	committed  []string
+	rolledBack bool
+}
+
+func (u *recordingUnit) Commit(context.Context) error {
+	u.committed = append(u.committed, u.pending...)
+	u.pending = nil
+	return nil
+}
+
+func (u *recordingUnit) Rollback(context.Context) error {
+	u.pending = nil
+	u.rolledBack = true
+	return nil
+}
+
+type recordingFactory struct{ unit *recordingUnit }
+
+func (f recordingFactory) Begin(context.Context, uow.TxOptions) (uow.UnitOfWork, error) {
+	return f.unit, nil
+}
+
+// stagingOutbox holds records per batch until Flush releases them.
+type stagingOutbox struct {
+	staged       map[string][]outbox.EventRecord
+	dispatchable []outbox.EventRecord
+	flushErr     error
+}
+
+func newStagingOutbox() *stagingOutbox {
+	return &stagingOutbox{staged: map[string][]outbox.EventRecord{}}
+}
+
+func (o *stagingOutbox) Add(ctx context.Context, record outbox.EventRecord) error {
+	batch, _ := outbox.BatchFromContext(ctx)
+	o.staged[batch] = append(o.staged[batch], record)
+	return nil
+}
+
+func (o *stagingOutbox) Flush(ctx context.Context) error {
+	if o.flushErr != nil {
+		return o.flushErr
+	}
+	batch, _ := outbox.BatchFromContext(ctx)
+	o.dispatchable = append(o.dispatchable, o.staged[batch]...)
+	delete(o.staged, batch)
+	return nil
+}
+
+func (o *stagingOutbox) Discard(ctx context.Context) error {
+	batch, _ := outbox.BatchFromContext(ctx)
+	delete(o.staged, batch)
+	return nil
+}
+
+type bookingRequested struct{ id string }
+
+func (e bookingRequested) EventName() string     { return "booking.requested" }
+func (e bookingRequested) AggregateID() string   { return e.id }
+func (e bookingRequested) OccurredAt() time.Time { return time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+// failingEncoder encodes the first failAfter events and fails on the next.
+type failingEncoder struct {
+	failAfter int
+	encoded   int
+}
+
+func (e *failingEncoder) Encode(ev events.DomainEvent) (outbox.EventRecord, error) {
+	if e.encoded == e.failAfter {
+		return outbox.EventRecord{}, errors.New("encode: unsupported payload")
+	}
+	e.encoded++
+	return outbox.JSONEventEncoder{IDGenerator: func() string { return ev.AggregateID() }}.Encode(ev)
+}
+
+type saveBookingCommand struct{ ID string }
+
+func (saveBookingCommand) Key() string { return "booking.save" }
+
+type flushFailures struct{ commands []string }
+
+func (o *flushFailures) ObserveOutboxFlushFailure(command string) {
+	o.commands = append(o.commands, command)
+}
+
+// outboxBus saves the booking and records two events with encoder, inside
+// OutboxFlush and Transaction as wired in production.
+func outboxBus(unit *recordingUnit, box outbox.Outbox, encoder outbox.EventEncoder, logger *slog.Logger, observer OutboxFlushObserver) commands.Bus {
+	bus := commands.NewInMemoryBus()
+	commands.RegisterHandler[saveBookingCommand, string](bus, saveBookingCommand{}.Key(),
+		commands.HandlerFunc[saveBookingCommand, string](func(ctx context.Context, cmd saveBookingCommand) (string, error) {
+			current, _ := uow.FromContext(ctx)
+			current.(*recordingUnit).pending = append(current.(*recordingUnit).pending, cmd.ID)
+			evs := []events.DomainEvent{bookingRequested{id: cmd.ID + "-requested"}, bookingRequested{id: cmd.ID + "-reserved"}}
+			if err := outbox.RecordDomainEvents(ctx, box, encoder, evs); err != nil {
+				return "", err
+			}
+			return cmd.ID, nil
+		}))
+	return ChainCommands(bus, OutboxFlush(box, logger, observer), Transaction(recordingFactory{unit: unit}, nil))
+}
+
+func TestOutboxWriteFailureRollsBackCommand(t *testing.T) {
+	unit := &recordingUnit{}
+	box := newStagingOutbox()
+	bus := outboxBus(unit, box, &failingEncoder{failAfter: 1}, nil, nil)
+
+	if _, err := bus.Dispatch(context.Background(), saveBookingCommand{ID: "booking-1"}); err == nil {
+		t.Fatal("command succeeded although the outbox write failed")
+	}
+	if !unit.rolledBack || len(unit.committed) != 0 {
+		t.Fatalf("rolled back %v, committed %v; want a rollback and nothing committed", unit.rolledBack, unit.committed)
+	}
+	if len(box.dispatchable) != 0 {
+		t.Fatalf("dispatchable records = %+v, want none", box.dispatchable)
+	}
+	for batch, records := range box.staged {
+		t.Fatalf("batch %q left %d staged records", batch, len(records))
+	}
+}
+
+func TestOutboxReleasesRecordsAfterCommit(t *testing.T) {
+	unit := &recordingUnit{}
+	box := newStagingOutbox()
+	bus := outboxBus(unit, box, nil, nil, nil)
+
+	if _, err := bus.Dispatch(context.Background(), saveBookingCommand{ID: "booking-1"}); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if len(unit.committed) != 1 || len(box.dispatchable) != 2 {
+		t.Fatalf("committed %v, dispatchable %d records; want the booking and both events", unit.committed, len(box.dispatchable))
+	}
+}
+
+func TestOutboxFlushFailureIsLoggedAndCounted(t *testing.T) {
+	unit := &recordingUnit{}
+	box := newStagingOutbox()
+	box.flushErr = errors.New("outbox store unavailable")
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	observer := &flushFailures{}
+	bus := outboxBus(unit, box, nil, logger, observer)
+
+	res, err := bus.Dispatch(context.Background(), saveBookingCommand{ID: "booking-1"})
+	if err != nil {
+		t.Fatalf("a flush failure after commit failed the command: %v", err)
+	}
+	if res != "booking-1" || len(unit.committed) != 1 {
+		t.Fatalf("result %v, committed %v", res, unit.committed)
+	}
+	if len(observer.commands) != 1 || observer.commands[0] != "booking.save" {
+		t.Fatalf("observed flush failures = %v, want one for booking.save", observer.commands)
+	}
+	line := logs.String()
+	if !strings.Contains(line, "outbox flush failed") || !strings.Contains(line, "command=booking.save") || !strings.Contains(line, "outbox store unavailable") {
+		t.Fatalf("log = %q, want the failure with the command key", line)
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitIdleTTL is how long a client may stay quiet before its bucket is dropped.
+const rateLimitIdleTTL = 3 * time.Minute
+
+// RateLimiter throttles requests per client IP with a token bucket refilled at
+// perSecond tokens and holding up to burst. A non-positive perSecond disables it.
+func RateLimiter(perSecond int, burst int) gin.HandlerFunc {
+	return RateLimiterByKey(perSecond, burst, func(c *gin.Context) string {
+		return c.ClientIP()
+	})
+}
+
+// RateLimiterByKey throttles requests per key. Requests with an empty key pass
+// through, which lets a per-user limiter skip anonymous traffic.
+func RateLimiterByKey(perSecond int, burst int, key func(c *gin.Context) string) gin.HandlerFunc {
+	return NewRateLimitRule("", perSecond, burst, RateLimitEnforce, key).Handler(nil)
+}
+
+type rateBuckets struct {
+	mu    sync.Mutex
+	limit rate.Limit
+	burst int
+	items map[string]*rateBucket
+	swept time.Time
+}
+
+type rateBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newRateBuckets(limit rate.Limit, burst int) *rateBuckets {
+	return &rateBuckets{limit: limit, burst: burst, items: make(map[string]*rateBucket)}
+}
+
+func (b *rateBuckets) limiter(key string, now time.Time) *rate.Limiter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.Sub(b.swept) >= rateLimitIdleTTL {
+		for k, bucket := range b.items {
+			if now.Sub(bucket.lastSeen) >= rateLimitIdleTTL {
+				delete(b.items, k)
+			}
+		}
+		b.swept = now
+	}
+	bucket, ok := b.items[key]
+	if !ok {
+		bucket = &rateBucket{limiter: rate.NewLimiter(b.limit, b.burst)}
+		b.items[key] = bucket
+	}
+	bucket.lastSeen = now
+	return bucket.limiter
+}
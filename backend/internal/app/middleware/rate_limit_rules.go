@@ -0,0 +1,264 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitMode decides what a rule does with a request over its limit.
+type RateLimitMode string
+
+const (
+	// RateLimitEnforce answers 429 once the limit is exceeded.
+	RateLimitEnforce RateLimitMode = "enforce"
+	// RateLimitShadow lets the request through, marking it with X-RateLimit-*
+	// and Warning headers and recording the offender, so a limit can be tuned
+	// before it is enforced.
+	RateLimitShadow RateLimitMode = "shadow"
+)
+
+// ErrUnknownRateLimitRule is returned when switching a rule that is not registered.
+var ErrUnknownRateLimitRule = errors.New("unknown rate limit rule")
+
+// ParseRateLimitMode validates a mode read from config or an admin request.
+func ParseRateLimitMode(raw string) (RateLimitMode, error) {
+	switch mode := RateLimitMode(strings.ToLower(strings.TrimSpace(raw))); mode {
+	case RateLimitEnforce, RateLimitShadow:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("rate limit mode must be %q or %q", RateLimitEnforce, RateLimitShadow)
+	}
+}
+
+const (
+	// rateLimitOffenderCap bounds the offenders remembered per rule; when full,
+	// the offender with the fewest hits makes room for a new one.
+	rateLimitOffenderCap = 1000
+	// rateLimitWarning is the Warning header value of a shadowed request.
+	rateLimitWarning = `199 rentme "rate limit exceeded; not enforced yet"`
+)
+
+// RateLimitObserver counts requests that exceeded a rule.
+type RateLimitObserver interface {
+	ObserveRateLimitExceeded(rule string, mode string)
+}
+
+// RateLimitRule is a named token bucket limiter whose mode can be switched at
+// runtime, which is how a new limit is rolled out: shadow first, enforce once
+// the offenders look right.
+type RateLimitRule struct {
+	name      string
+	perSecond int
+	burst     int
+	key       func(c *gin.Context) string
+	buckets   *rateBuckets
+
+	mu        sync.RWMutex
+	mode      RateLimitMode
+	offenders map[string]*RateLimitOffender
+}
+
+// RateLimitOffender is one key that exceeded a rule.
+type RateLimitOffender struct {
+	Key       string    `json:"key"`
+	Exceeded  int       `json:"exceeded"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// RateLimitRuleStatus describes a rule for the admin view.
+type RateLimitRuleStatus struct {
+	Name      string              `json:"name"`
+	Mode      RateLimitMode       `json:"mode"`
+	PerSecond int                 `json:"per_second"`
+	Burst     int                 `json:"burst"`
+	Offenders []RateLimitOffender `json:"top_offenders"`
+}
+
+// NewRateLimitRule builds a rule keyed by key. A non-positive perSecond
+// disables it, as with RateLimiterByKey.
+func NewRateLimitRule(name string, perSecond, burst int, mode RateLimitMode, key func(c *gin.Context) string) *RateLimitRule {
+	if burst < 1 {
+		burst = perSecond
+	}
+	if mode == "" {
+		mode = RateLimitEnforce
+	}
+	rule := &RateLimitRule{
+		name:      name,
+		perSecond: perSecond,
+		burst:     burst,
+		key:       key,
+		mode:      mode,
+		offenders: make(map[string]*RateLimitOffender),
+	}
+	if perSecond > 0 {
+		rule.buckets = newRateBuckets(rate.Limit(perSecond), burst)
+	}
+	return rule
+}
+
+// Mode returns the current mode of the rule.
+func (r *RateLimitRule) Mode() RateLimitMode {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.mode
+}
+
+func (r *RateLimitRule) setMode(mode RateLimitMode) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mode = mode
+}
+
+// Handler applies the rule. Requests with an empty key pass through.
+func (r *RateLimitRule) Handler(observer RateLimitObserver) gin.HandlerFunc {
+	if r.buckets == nil || r.key == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return func(c *gin.Context) {
+		k := r.key(c)
+		if k == "" {
+			c.Next()
+			return
+		}
+		now := time.Now()
+		reservation := r.buckets.limiter(k, now).Reserve()
+		delay := reservation.Delay()
+		if delay == 0 {
+			c.Next()
+			return
+		}
+		reservation.Cancel()
+		retryAfter := int(math.Ceil(delay.Seconds()))
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		mode := r.Mode()
+		if observer != nil {
+			observer.ObserveRateLimitExceeded(r.name, string(mode))
+		}
+		if mode == RateLimitEnforce {
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded", "code": "RATE_LIMITED"})
+			return
+		}
+		r.recordOffender(k, now)
+		c.Header("X-RateLimit-Limit", strconv.Itoa(r.perSecond))
+		c.Header("X-RateLimit-Remaining", "0")
+		c.Header("X-RateLimit-Reset", strconv.Itoa(retryAfter))
+		c.Header("Warning", rateLimitWarning)
+		c.Next()
+	}
+}
+
+func (r *RateLimitRule) recordOffender(key string, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	offender, ok := r.offenders[key]
+	if !ok {
+		if len(r.offenders) >= rateLimitOffenderCap {
+			r.evictLeastOffending()
+		}
+		offender = &RateLimitOffender{Key: key, FirstSeen: now}
+		r.offenders[key] = offender
+	}
+	offender.Exceeded++
+	offender.LastSeen = now
+}
+
+func (r *RateLimitRule) evictLeastOffending() {
+	var victim *RateLimitOffender
+	for _, offender := range r.offenders {
+		if victim == nil || offender.Exceeded < victim.Exceeded ||
+			(offender.Exceeded == victim.Exceeded && offender.LastSeen.Before(victim.LastSeen)) {
+			victim = offender
+		}
+	}
+	if victim != nil {
+		delete(r.offenders, victim.Key)
+	}
+}
+
+// Status returns the rule with its top offenders, most frequent first.
+func (r *RateLimitRule) Status(top int) RateLimitRuleStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	offenders := make([]RateLimitOffender, 0, len(r.offenders))
+	for _, offender := range r.offenders {
+		offenders = append(offenders, *offender)
+	}
+	sort.Slice(offenders, func(i, j int) bool {
+		if offenders[i].Exceeded != offenders[j].Exceeded {
+			return offenders[i].Exceeded > offenders[j].Exceeded
+		}
+		return offenders[i].Key < offenders[j].Key
+	})
+	if top > 0 && len(offenders) > top {
+		offenders = offenders[:top]
+	}
+	return RateLimitRuleStatus{
+		Name:      r.name,
+		Mode:      r.mode,
+		PerSecond: r.perSecond,
+		Burst:     r.burst,
+		Offenders: offenders,
+	}
+}
+
+// RateLimitRules is the set of named rules the server applies. Modes live in
+// process memory, so a switch applies to this instance until it restarts with
+// the configured modes.
+type RateLimitRules struct {
+	rules []*RateLimitRule
+}
+
+// NewRateLimitRules collects rules in the order they are listed.
+func NewRateLimitRules(rules ...*RateLimitRule) *RateLimitRules {
+	return &RateLimitRules{rules: rules}
+}
+
+// Rule returns the rule named name, or nil.
+func (s *RateLimitRules) Rule(name string) *RateLimitRule {
+	if s == nil {
+		return nil
+	}
+	for _, rule := range s.rules {
+		if rule.name == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// SetMode switches the rule named name to mode.
+func (s *RateLimitRules) SetMode(name string, mode RateLimitMode) error {
+	rule := s.Rule(name)
+	if rule == nil {
+		return ErrUnknownRateLimitRule
+	}
+	rule.setMode(mode)
+	return nil
+}
+
+// Statuses describes every rule with up to top offenders each.
+func (s *RateLimitRules) Statuses(top int) []RateLimitRuleStatus {
+	if s == nil {
+		return nil
+	}
+	out := make([]RateLimitRuleStatus, 0, len(s.rules))
+	for _, rule := range s.rules {
+		out = append(out, rule.Status(top))
+	}
+	return out
+}
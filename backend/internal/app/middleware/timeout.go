@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+)
+
+// RequestTimeout bounds the request context by d and answers 504 when the
+// handler chain runs past the deadline. Handlers are expected to honour
+// ctx cancellation; whatever they try to write after the deadline is
+// discarded so the client sees a single, well-formed timeout response. A
+// non-positive d disables it.
+func RequestTimeout(d time.Duration) gin.HandlerFunc {
+	if d <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+
+		original := c.Writer
+		tw := &timeoutWriter{ResponseWriter: original, ctx: ctx}
+		c.Writer = tw
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		c.Writer = original
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) || tw.wroteBeforeDeadline() {
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{"error": "request timed out", "code": "REQUEST_TIMEOUT"})
+	}
+}
+
+// timeoutWriter forwards writes until the deadline passes and drops them
+// afterwards, remembering whether anything reached the client in time.
+type timeoutWriter struct {
+	gin.ResponseWriter
+	ctx context.Context
+
+	mu      sync.Mutex
+	written bool
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	if w.admit() {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	if w.admit() {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	if !w.admit() {
+		return 0, w.ctx.Err()
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	if !w.admit() {
+		return 0, w.ctx.Err()
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// admit reports whether a write may go through. Once a write has started
+// before the deadline the response is committed, so later writes are kept
+// too rather than truncating a body mid-stream.
+func (w *timeoutWriter) admit() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.written {
+		return true
+	}
+	if w.ctx.Err() != nil {
+		return false
+	}
+	w.written = true
+	return true
+}
+
+func (w *timeoutWriter) wroteBeforeDeadline() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.written || w.ResponseWriter.Written()
+}
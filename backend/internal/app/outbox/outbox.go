@@ -18,11 +18,50 @@ type EventRecord struct {
 	Headers    map[string]string
 }
 
+// Outbox stores event records next to the aggregates that raised them. Flush
+// makes the records of the batch in ctx dispatchable once the batch's unit of
+// work has committed.
 type Outbox interface {
 	Add(ctx context.Context, record EventRecord) error
 	Flush(ctx context.Context) error
 }
 
+// Discarder is implemented by outboxes that must drop the records of a failed
+// batch themselves because the unit of work cannot roll them back.
+type Discarder interface {
+	Discard(ctx context.Context) error
+}
+
+type batchKey struct{}
+
+// WithBatch scopes the records added with the returned context to batch.
+func WithBatch(ctx context.Context, batch string) context.Context {
+	return context.WithValue(ctx, batchKey{}, batch)
+}
+
+// BatchFromContext returns the batch records added with ctx belong to.
+func BatchFromContext(ctx context.Context) (string, bool) {
+	batch, ok := ctx.Value(batchKey{}).(string)
+	return batch, ok && batch != ""
+}
+
+// PendingRecord is an outbox record waiting to be published.
+type PendingRecord struct {
+	EventRecord
+	Attempts int
+}
+
+// Dispatchable is implemented by outboxes whose records are shipped to a broker
+// by a background dispatcher. FetchPending lists the pending events and
+// MarkSent acknowledges one once published; MarkFailed keeps a record pending
+// until its next retry, so a broker outage delays events instead of dropping
+// them.
+type Dispatchable interface {
+	FetchPending(ctx context.Context, limit int, now time.Time) ([]PendingRecord, error)
+	MarkSent(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, next time.Time, errMsg string) error
+}
+
 type EventEncoder interface {
 	Encode(ev events.DomainEvent) (EventRecord, error)
 }
@@ -0,0 +1,156 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rentme/internal/app/jobs"
+)
+
+// Publisher ships an encoded event to a broker topic.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
+
+// RelayJob is the job name of the outbox relay.
+const RelayJob = "outbox.dispatch"
+
+var ErrRelayNotConfigured = errors.New("outbox: relay missing dependencies")
+
+// Relay polls the outbox and publishes each pending record as a CloudEvent
+// to TopicPrefix + "." + the event name. Records are acknowledged once the
+// publisher accepts them; failures are retried with Backoff.
+type Relay struct {
+	Store       Dispatchable
+	Publisher   Publisher
+	Interval    time.Duration
+	BatchSize   int
+	TopicPrefix string
+	Source      string
+	Backoff     []time.Duration
+	Logger      *slog.Logger
+}
+
+// Job polls the outbox every Interval on the job runner. Publish failures are
+// rescheduled on the record and never fail the run.
+func (r *Relay) Job() jobs.Definition {
+	return jobs.Definition{
+		Name:  RelayJob,
+		Every: r.interval(),
+		Run: func(ctx context.Context, _ jobs.Task) error {
+			if r.Store == nil || r.Publisher == nil {
+				return ErrRelayNotConfigured
+			}
+			relayed, err := r.relayOnce(ctx)
+			if err != nil {
+				return err
+			}
+			if relayed == 0 {
+				return jobs.ErrNoWork
+			}
+			return nil
+		},
+	}
+}
+
+func (r *Relay) relayOnce(ctx context.Context) (int, error) {
+	records, err := r.Store.FetchPending(ctx, r.batchSize(), time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	for _, rec := range records {
+		topic := r.Topic(rec.Name)
+		payload, err := r.encode(rec.EventRecord)
+		if err == nil {
+			err = r.Publisher.Publish(ctx, topic, payload)
+		}
+		if err != nil {
+			r.logger().Warn("outbox publish failed", "event_id", rec.ID, "event", rec.Name, "topic", topic, "attempts", rec.Attempts+1, "error", err)
+			if markErr := r.Store.MarkFailed(ctx, rec.ID, r.nextRetry(rec.Attempts), err.Error()); markErr != nil {
+				return 0, markErr
+			}
+			continue
+		}
+		if err := r.Store.MarkSent(ctx, rec.ID); err != nil {
+			return 0, err
+		}
+	}
+	return len(records), nil
+}
+
+// Topic returns the topic of an event, e.g. booking.requested with prefix
+// rentme goes to rentme.booking.requested. Without a prefix the event name
+// is the topic.
+func (r *Relay) Topic(name string) string {
+	prefix := strings.TrimSpace(r.TopicPrefix)
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// encode wraps the record payload in a CloudEvents 1.0 envelope.
+func (r *Relay) encode(rec EventRecord) ([]byte, error) {
+	data := map[string]any{}
+	if err := json.Unmarshal(rec.Payload, &data); err != nil {
+		return nil, err
+	}
+	evt := map[string]any{
+		"specversion":     "1.0",
+		"id":              uuid.NewString(),
+		"type":            rec.Name + ".v1",
+		"source":          r.source(),
+		"subject":         rec.Aggregate,
+		"time":            rec.OccurredAt,
+		"datacontenttype": "application/json",
+		"data":            data,
+	}
+	if trace, ok := rec.Headers["traceparent"]; ok {
+		evt["traceparent"] = trace
+	}
+	return json.Marshal(evt)
+}
+
+func (r *Relay) batchSize() int {
+	if r.BatchSize <= 0 {
+		return 100
+	}
+	return r.BatchSize
+}
+
+func (r *Relay) logger() *slog.Logger {
+	if r.Logger != nil {
+		return r.Logger
+	}
+	return slog.Default()
+}
+
+func (r *Relay) interval() time.Duration {
+	if r.Interval <= 0 {
+		return 500 * time.Millisecond
+	}
+	return r.Interval
+}
+
+func (r *Relay) nextRetry(attempts int) time.Time {
+	if attempts < len(r.Backoff) {
+		return time.Now().Add(r.Backoff[attempts])
+	}
+	if len(r.Backoff) > 0 {
+		return time.Now().Add(r.Backoff[len(r.Backoff)-1])
+	}
+	return time.Now().Add(5 * time.Second)
+}
+
+func (r *Relay) source() string {
+	if r.Source != "" {
+		return r.Source
+	}
+	return "app://rentme"
+}
@@ -0,0 +1,119 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"rentme/internal/app/jobs"
+)
+
+type fakeStore struct {
+	pending []PendingRecord
+	sent    []string
+	failed  map[string]string
+}
+
+func (s *fakeStore) FetchPending(context.Context, int, time.Time) ([]PendingRecord, error) {
+	return s.pending, nil
+}
+
+func (s *fakeStore) MarkSent(_ context.Context, id string) error {
+	s.sent = append(s.sent, id)
+	return nil
+}
+
+func (s *fakeStore) MarkFailed(_ context.Context, id string, _ time.Time, errMsg string) error {
+	if s.failed == nil {
+		s.failed = map[string]string{}
+	}
+	s.failed[id] = errMsg
+	return nil
+}
+
+type published struct {
+	topic   string
+	payload []byte
+}
+
+type fakePublisher struct {
+	messages []published
+	failOn   string
+}
+
+func (p *fakePublisher) Publish(_ context.Context, topic string, payload []byte) error {
+	if topic == p.failOn {
+		return errors.New("broker down")
+	}
+	p.messages = append(p.messages, published{topic: topic, payload: payload})
+	return nil
+}
+
+func pending(id, name string) PendingRecord {
+	return PendingRecord{EventRecord: EventRecord{
+		ID:         id,
+		Name:       name,
+		Payload:    []byte(`{"booking_id":"b-1"}`),
+		OccurredAt: time.Date(2026, 4, 1, 10, 0, 0, 0, time.UTC),
+		Aggregate:  "b-1",
+		Headers:    map[string]string{"traceparent": "00-abc-def-01"},
+	}}
+}
+
+func TestRelayTopicIsPrefixAndEventName(t *testing.T) {
+	cases := []struct {
+		prefix string
+		want   string
+	}{
+		{"rentme", "rentme.booking.requested"},
+		{" rentme ", "rentme.booking.requested"},
+		{"", "booking.requested"},
+	}
+	for _, tc := range cases {
+		if got := (&Relay{TopicPrefix: tc.prefix}).Topic("booking.requested"); got != tc.want {
+			t.Errorf("prefix %q: topic = %q, want %q", tc.prefix, got, tc.want)
+		}
+	}
+}
+
+func TestRelayPublishesAndAcknowledges(t *testing.T) {
+	store := &fakeStore{pending: []PendingRecord{pending("evt-1", "booking.requested"), pending("evt-2", "listing.published")}}
+	publisher := &fakePublisher{failOn: "rentme.listing.published"}
+	relay := &Relay{Store: store, Publisher: publisher, TopicPrefix: "rentme"}
+
+	if err := relay.Job().Run(context.Background(), jobs.Task{}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(store.sent) != 1 || store.sent[0] != "evt-1" {
+		t.Fatalf("acknowledged = %v, want evt-1", store.sent)
+	}
+	if _, ok := store.failed["evt-2"]; !ok || len(store.failed) != 1 {
+		t.Fatalf("failed = %v, want evt-2 rescheduled", store.failed)
+	}
+	if len(publisher.messages) != 1 || publisher.messages[0].topic != "rentme.booking.requested" {
+		t.Fatalf("published = %+v, want one message on rentme.booking.requested", publisher.messages)
+	}
+
+	var evt map[string]any
+	if err := json.Unmarshal(publisher.messages[0].payload, &evt); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if evt["type"] != "booking.requested.v1" || evt["subject"] != "b-1" || evt["traceparent"] != "00-abc-def-01" {
+		t.Fatalf("event = %v, want the CloudEvent of evt-1", evt)
+	}
+	if data, _ := evt["data"].(map[string]any); data["booking_id"] != "b-1" {
+		t.Fatalf("data = %v, want the record payload", evt["data"])
+	}
+}
+
+func TestRelayWithoutWork(t *testing.T) {
+	relay := &Relay{Store: &fakeStore{}, Publisher: &fakePublisher{}}
+	if err := relay.Job().Run(context.Background(), jobs.Task{}); !errors.Is(err, jobs.ErrNoWork) {
+		t.Fatalf("err = %v, want ErrNoWork", err)
+	}
+	if err := (&Relay{}).Job().Run(context.Background(), jobs.Task{}); !errors.Is(err, ErrRelayNotConfigured) {
+		t.Fatalf("err = %v, want ErrRelayNotConfigured", err)
+	}
+}
@@ -18,6 +18,8 @@ var (
 	ErrInvalidCredentials = errors.New("auth: invalid credentials")
 	ErrPasswordTooShort   = errors.New("auth: password must be at least 8 characters")
 	ErrUserBlocked        = errors.New("auth: user blocked")
+	ErrWrongPassword      = errors.New("auth: current password is incorrect")
+	ErrInvalidRefresh     = errors.New("auth: invalid refresh token")
 )
 
 type PasswordHasher interface {
@@ -35,6 +37,7 @@ type Service struct {
 	Passwords  PasswordHasher
 	Tokens     TokenGenerator
 	SessionTTL time.Duration
+	RefreshTTL time.Duration
 	Logger     *slog.Logger
 }
 
@@ -51,8 +54,9 @@ type LoginParams struct {
 }
 
 type AuthResult struct {
-	User  *domainuser.User
-	Token string
+	User         *domainuser.User
+	Token        string
+	RefreshToken string
 }
 
 type ResolveResult struct {
@@ -75,6 +79,11 @@ func (s *Service) Register(ctx context.Context, params RegisterParams) (*AuthRes
 	if err := s.validatePassword(params.Password); err != nil {
 		return nil, err
 	}
+	if _, err := s.Users.ByEmail(ctx, email); err == nil {
+		return nil, domainuser.ErrEmailAlreadyUsed
+	} else if !errors.Is(err, domainuser.ErrNotFound) {
+		return nil, err
+	}
 	hash, err := s.Passwords.Hash(params.Password)
 	if err != nil {
 		return nil, err
@@ -94,17 +103,22 @@ func (s *Service) Register(ctx context.Context, params RegisterParams) (*AuthRes
 	if err != nil {
 		return nil, err
 	}
-	if err := s.Users.Save(ctx, user); err != nil {
+	// The lookup above is only a fast path; Create settles concurrent
+	// registrations with the same email.
+	if err := s.Users.Create(ctx, user); err != nil {
+		if errors.Is(err, domainuser.ErrEmailAlreadyUsed) {
+			return nil, domainuser.ErrEmailAlreadyUsed
+		}
 		return nil, err
 	}
-	token, err := s.issueSession(ctx, user)
+	result, err := s.issueSession(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 	if s.Logger != nil {
 		s.Logger.Info("user registered", "user_id", user.ID, "email", user.Email, "roles", user.Roles)
 	}
-	return &AuthResult{User: user, Token: token}, nil
+	return result, nil
 }
 
 func (s *Service) Login(ctx context.Context, params LoginParams) (*AuthResult, error) {
@@ -128,14 +142,98 @@ func (s *Service) Login(ctx context.Context, params LoginParams) (*AuthResult, e
 	if err := s.Passwords.Compare(user.PasswordHash, params.Password); err != nil {
 		return nil, ErrInvalidCredentials
 	}
-	token, err := s.issueSession(ctx, user)
+	result, err := s.issueSession(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 	if s.Logger != nil {
 		s.Logger.Info("user authenticated", "user_id", user.ID)
 	}
-	return &AuthResult{User: user, Token: token}, nil
+	return result, nil
+}
+
+// Refresh exchanges a refresh token for a new session and rotates the refresh
+// token. Reusing a rotated token revokes every session of its owner.
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (*AuthResult, error) {
+	if err := s.ensureDependencies(); err != nil {
+		return nil, err
+	}
+	refreshToken = strings.TrimSpace(refreshToken)
+	if refreshToken == "" {
+		return nil, ErrInvalidRefresh
+	}
+	session, err := s.Sessions.ConsumeRefresh(ctx, domainauth.Token(refreshToken), time.Now())
+	if err != nil {
+		if errors.Is(err, domainauth.ErrRefreshTokenReused) && session != nil {
+			if s.Logger != nil {
+				s.Logger.Warn("refresh token reuse detected; revoking sessions", "user_id", session.UserID)
+			}
+			if delErr := s.Sessions.DeleteByUser(ctx, session.UserID); delErr != nil {
+				return nil, delErr
+			}
+			return nil, ErrInvalidRefresh
+		}
+		if errors.Is(err, domainauth.ErrSessionNotFound) {
+			return nil, ErrInvalidRefresh
+		}
+		return nil, err
+	}
+	user, err := s.Users.ByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, domainuser.ErrNotFound) {
+			return nil, ErrInvalidRefresh
+		}
+		return nil, err
+	}
+	if user.Blocked {
+		_ = s.Sessions.DeleteByUser(ctx, user.ID)
+		return nil, ErrUserBlocked
+	}
+	return s.issueSession(ctx, user)
+}
+
+// RefreshSession swaps a still valid access token for a new session of the
+// same user. The old token, and its refresh token, stop working at once.
+func (s *Service) RefreshSession(ctx context.Context, token string) (*AuthResult, error) {
+	if err := s.ensureDependencies(); err != nil {
+		return nil, err
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrInvalidRefresh
+	}
+	old, err := s.Sessions.Get(ctx, domainauth.Token(token))
+	if err != nil {
+		if errors.Is(err, domainauth.ErrSessionNotFound) {
+			return nil, ErrInvalidRefresh
+		}
+		return nil, err
+	}
+	user, err := s.Users.ByID(ctx, old.UserID)
+	if err != nil {
+		if errors.Is(err, domainuser.ErrNotFound) {
+			return nil, ErrInvalidRefresh
+		}
+		return nil, err
+	}
+	if user.Blocked {
+		_ = s.Sessions.DeleteByUser(ctx, user.ID)
+		return nil, ErrUserBlocked
+	}
+	session, result, err := s.newSession(user)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Sessions.Refresh(ctx, *old, *session); err != nil {
+		if errors.Is(err, domainauth.ErrSessionNotFound) {
+			return nil, ErrInvalidRefresh
+		}
+		return nil, err
+	}
+	if s.Logger != nil {
+		s.Logger.Info("session refreshed", "user_id", user.ID)
+	}
+	return result, nil
 }
 
 func (s *Service) Logout(ctx context.Context, token string) error {
@@ -182,25 +280,120 @@ func (s *Service) ResolveToken(ctx context.Context, token string) (*ResolveResul
 	return &ResolveResult{User: user, Session: session}, nil
 }
 
-func (s *Service) issueSession(ctx context.Context, user *domainuser.User) (string, error) {
+// UserByID loads a user for flows that start from an authenticated principal.
+func (s *Service) UserByID(ctx context.Context, id domainuser.ID) (*domainuser.User, error) {
+	if err := s.ensureDependencies(); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(string(id)) == "" {
+		return nil, domainuser.ErrNotFound
+	}
+	return s.Users.ByID(ctx, id)
+}
+
+// ChangePassword replaces the user's password and signs out every session,
+// including the one used for the request.
+func (s *Service) ChangePassword(ctx context.Context, userID, oldPassword, newPassword string) error {
+	return s.changePassword(ctx, userID, oldPassword, newPassword, "")
+}
+
+// ChangePasswordKeepingSession replaces the user's password and signs out
+// every session except current, so the caller stays logged in.
+func (s *Service) ChangePasswordKeepingSession(ctx context.Context, userID, current, oldPassword, newPassword string) error {
+	return s.changePassword(ctx, userID, oldPassword, newPassword, domainauth.Token(strings.TrimSpace(current)))
+}
+
+func (s *Service) changePassword(ctx context.Context, userID, oldPassword, newPassword string, keep domainauth.Token) error {
+	user, err := s.UserByID(ctx, domainuser.ID(strings.TrimSpace(userID)))
+	if err != nil {
+		return err
+	}
+	if user.Blocked {
+		return ErrUserBlocked
+	}
+	if err := s.Passwords.Compare(user.PasswordHash, oldPassword); err != nil {
+		return ErrWrongPassword
+	}
+	if err := s.validatePassword(newPassword); err != nil {
+		return err
+	}
+	hash, err := s.Passwords.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := user.SetPasswordHash(hash, time.Now()); err != nil {
+		return err
+	}
+	if err := s.Users.Save(ctx, user); err != nil {
+		return err
+	}
+	if keep == "" {
+		err = s.Sessions.DeleteByUser(ctx, user.ID)
+	} else {
+		err = s.Sessions.DeleteByUserExcept(ctx, user.ID, keep)
+	}
+	if err != nil {
+		return err
+	}
+	if s.Logger != nil {
+		s.Logger.Info("user password changed", "user_id", user.ID, "kept_session", keep != "")
+	}
+	return nil
+}
+
+// UpdateProfile changes the user's display name.
+func (s *Service) UpdateProfile(ctx context.Context, userID, name string) (*domainuser.User, error) {
+	user, err := s.UserByID(ctx, domainuser.ID(strings.TrimSpace(userID)))
+	if err != nil {
+		return nil, err
+	}
+	if err := user.UpdateName(name, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.Users.Save(ctx, user); err != nil {
+		return nil, err
+	}
+	if s.Logger != nil {
+		s.Logger.Info("user profile updated", "user_id", user.ID)
+	}
+	return user, nil
+}
+
+func (s *Service) issueSession(ctx context.Context, user *domainuser.User) (*AuthResult, error) {
+	session, result, err := s.newSession(user)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Sessions.Save(ctx, session); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// newSession builds a session with fresh access and refresh tokens without
+// storing it.
+func (s *Service) newSession(user *domainuser.User) (*domainauth.Session, *AuthResult, error) {
 	token, err := s.Tokens.NewToken()
 	if err != nil {
-		return "", err
+		return nil, nil, err
+	}
+	refresh, err := s.Tokens.NewToken()
+	if err != nil {
+		return nil, nil, err
 	}
 	session, err := domainauth.NewSession(domainauth.CreateSessionParams{
-		Token:  domainauth.Token(token),
-		UserID: user.ID,
-		Roles:  append([]domainuser.Role(nil), user.Roles...),
-		TTL:    s.sessionTTL(),
-		Now:    time.Now(),
+		Token:        domainauth.Token(token),
+		UserID:       user.ID,
+		Roles:        append([]domainuser.Role(nil), user.Roles...),
+		TTL:          s.sessionTTL(),
+		RefreshToken: domainauth.Token(refresh),
+		RefreshTTL:   s.refreshTTL(),
+		Now:          time.Now(),
 	})
 	if err != nil {
-		return "", err
-	}
-	if err := s.Sessions.Save(ctx, session); err != nil {
-		return "", err
+		return nil, nil, err
 	}
-	return token, nil
+	return session, &AuthResult{User: user, Token: token, RefreshToken: refresh}, nil
 }
 
 func (s *Service) sessionTTL() time.Duration {
@@ -210,6 +403,13 @@ func (s *Service) sessionTTL() time.Duration {
 	return 24 * time.Hour
 }
 
+func (s *Service) refreshTTL() time.Duration {
+	if s.RefreshTTL > 0 {
+		return s.RefreshTTL
+	}
+	return 30 * 24 * time.Hour
+}
+
 func (s *Service) validatePassword(password string) error {
 	if utf8.RuneCountInString(password) < 8 {
 		return ErrPasswordTooShort
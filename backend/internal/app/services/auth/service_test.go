@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	domainuser "rentme/internal/domain/user"
+	"rentme/internal/infra/storage/memory"
+)
+
+// plainHasher skips bcrypt; a set barrier holds every Hash call until the
+// barrier is released.
+type plainHasher struct {
+	calls   atomic.Int32
+	barrier *sync.WaitGroup
+}
+
+func (h *plainHasher) Hash(password string) (string, error) {
+	h.calls.Add(1)
+	if h.barrier != nil {
+		h.barrier.Done()
+		h.barrier.Wait()
+	}
+	return "plain:" + password, nil
+}
+
+func (h *plainHasher) Compare(hash, password string) error {
+	if hash != "plain:"+password {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+type counterTokens struct{ n atomic.Int64 }
+
+func (g *counterTokens) NewToken() (string, error) {
+	return fmt.Sprintf("token-%d", g.n.Add(1)), nil
+}
+
+func newTestService(hasher PasswordHasher) *Service {
+	return &Service{
+		Users:     memory.NewUserRepository(),
+		Sessions:  memory.NewSessionStore(),
+		Passwords: hasher,
+		Tokens:    &counterTokens{},
+	}
+}
+
+func TestConcurrentRegistrationWithOneEmail(t *testing.T) {
+	const attempts = 8
+	// Every attempt passes the email lookup before any of them creates the
+	// user, so only Create can tell them apart.
+	var barrier sync.WaitGroup
+	barrier.Add(attempts)
+	svc := newTestService(&plainHasher{barrier: &barrier})
+
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+		conflicts atomic.Int32
+		others    = make(chan error, attempts)
+	)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := svc.Register(context.Background(), RegisterParams{
+				Email:    " Same@Example.com",
+				Name:     fmt.Sprintf("Guest %d", i),
+				Password: "password1",
+			})
+			switch {
+			case err == nil:
+				succeeded.Add(1)
+			case errors.Is(err, domainuser.ErrEmailAlreadyUsed):
+				conflicts.Add(1)
+			default:
+				others <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(others)
+	for err := range others {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if succeeded.Load() != 1 || conflicts.Load() != attempts-1 {
+		t.Fatalf("succeeded = %d, conflicts = %d; want 1 and %d", succeeded.Load(), conflicts.Load(), attempts-1)
+	}
+	if _, err := svc.Users.ByEmail(context.Background(), "same@example.com"); err != nil {
+		t.Fatalf("registered user not found: %v", err)
+	}
+}
+
+func TestRegisterTakenEmailSkipsHashing(t *testing.T) {
+	hasher := &plainHasher{}
+	svc := newTestService(hasher)
+	ctx := context.Background()
+
+	if _, err := svc.Register(ctx, RegisterParams{Email: "anna@example.com", Name: "Anna", Password: "password1"}); err != nil {
+		t.Fatalf("first registration: %v", err)
+	}
+	_, err := svc.Register(ctx, RegisterParams{Email: "ANNA@example.com ", Name: "Anna again", Password: "password2"})
+	if !errors.Is(err, domainuser.ErrEmailAlreadyUsed) {
+		t.Fatalf("second registration err = %v, want ErrEmailAlreadyUsed", err)
+	}
+	if calls := hasher.calls.Load(); calls != 1 {
+		t.Fatalf("password hashed %d times, want only for the first registration", calls)
+	}
+}
@@ -0,0 +1,313 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"rentme/internal/app/dto"
+	listingapp "rentme/internal/app/handlers/listings"
+	handlersupport "rentme/internal/app/handlers/support"
+	"rentme/internal/app/policies"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/uow"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+	domainuser "rentme/internal/domain/user"
+)
+
+// Template is the notification template of the weekly host digest.
+const Template = "host.weekly_digest"
+
+const (
+	digestWindow          = 7 * 24 * time.Hour
+	priceAlertGapPercent  = 15.0
+	hostPageSize          = 100
+	listingPageSize       = 60
+	bookingPageSize       = 100
+	reviewsPerListing     = 50
+	maxItemsPerSection    = 20
+	unreadCountLimitPages = 10
+)
+
+var ErrNotConfigured = errors.New("digest: service not configured")
+
+// SendLog remembers which digests went out so a rerun of the job does not
+// send the same period twice.
+type SendLog interface {
+	// Claim marks the digest of the host for period as sent and reports false
+	// when it already was.
+	Claim(ctx context.Context, hostID, period string, at time.Time) (bool, error)
+	// Release drops a claim whose digest could not be delivered.
+	Release(ctx context.Context, hostID, period string) error
+}
+
+// UnreadCounter reports how many chat messages the user has not read yet.
+type UnreadCounter interface {
+	UnreadCount(ctx context.Context, userID string, maxPages int) (int, error)
+}
+
+// Result summarises one digest run.
+type Result struct {
+	Period  string
+	Hosts   int
+	Sent    int
+	Skipped int
+	Failed  int
+}
+
+// Service builds and sends the weekly host digest. Every section is
+// best-effort: a failing pricing or chat lookup leaves that section empty
+// instead of holding back the whole digest.
+type Service struct {
+	UoWFactory uow.UoWFactory
+	Users      domainuser.Repository
+	Queries    queries.Bus
+	Chats      UnreadCounter
+	Notifier   policies.Notifier
+	SendLog    SendLog
+	Now        func() time.Time
+	Logger     *slog.Logger
+}
+
+// SendWeekly sends the digest of the current ISO week to every host that has
+// not received it yet.
+func (s *Service) SendWeekly(ctx context.Context) (Result, error) {
+	if s == nil || s.Users == nil || s.UoWFactory == nil || s.Notifier == nil || s.SendLog == nil {
+		return Result{}, ErrNotConfigured
+	}
+	now := s.now()
+	result := Result{Period: Period(now)}
+	for offset := 0; ; offset += hostPageSize {
+		users, total, err := s.Users.List(ctx, domainuser.ListParams{Limit: hostPageSize, Offset: offset})
+		if err != nil {
+			return result, err
+		}
+		for _, user := range users {
+			if !user.HasRole(domainuser.RoleHost) {
+				continue
+			}
+			result.Hosts++
+			sent, err := s.sendOne(ctx, user, result.Period, now)
+			switch {
+			case err != nil:
+				result.Failed++
+				if s.Logger != nil {
+					s.Logger.Warn("host digest failed", "host_id", user.ID, "period", result.Period, "error", err)
+				}
+			case sent:
+				result.Sent++
+			default:
+				result.Skipped++
+			}
+		}
+		if len(users) < hostPageSize || offset+len(users) >= total {
+			break
+		}
+	}
+	if s.Logger != nil {
+		s.Logger.Info("host digests processed", "period", result.Period, "hosts", result.Hosts, "sent", result.Sent, "skipped", result.Skipped, "failed", result.Failed)
+	}
+	return result, nil
+}
+
+func (s *Service) sendOne(ctx context.Context, host *domainuser.User, period string, now time.Time) (bool, error) {
+	if !host.WantsWeeklyDigest() {
+		return false, nil
+	}
+	hostID := string(host.ID)
+	claimed, err := s.SendLog.Claim(ctx, hostID, period, now)
+	if err != nil || !claimed {
+		return false, err
+	}
+	digest, err := s.Build(ctx, host, now)
+	if err != nil {
+		return false, errors.Join(err, s.SendLog.Release(ctx, hostID, period))
+	}
+	// An empty digest keeps its claim: there is nothing to tell this week.
+	if digest.Empty() {
+		return false, nil
+	}
+	if err := s.Notifier.Send(ctx, hostID, Template, digest); err != nil {
+		return false, errors.Join(err, s.SendLog.Release(ctx, hostID, period))
+	}
+	return true, nil
+}
+
+// Build collects the digest sections of one host as of now.
+func (s *Service) Build(ctx context.Context, host *domainuser.User, now time.Time) (dto.HostDigest, error) {
+	digest := dto.HostDigest{
+		HostID:   string(host.ID),
+		HostName: host.Name,
+		Period:   Period(now),
+		From:     now.Add(-digestWindow),
+		To:       now.Add(digestWindow),
+	}
+	unit, execCtx, cleanup, err := handlersupport.BeginReadOnlyUnit(ctx, s.UoWFactory)
+	if err != nil {
+		return digest, err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	hostListings, err := listHostListings(execCtx, unit, domainlistings.HostID(host.ID))
+	if err != nil {
+		return digest, err
+	}
+	if len(hostListings) > 0 {
+		if err := s.addBookings(execCtx, unit, &digest, hostListings, now); err != nil {
+			return digest, err
+		}
+		if err := addReviews(execCtx, unit, &digest, hostListings, now); err != nil {
+			return digest, err
+		}
+	}
+	s.addPriceAlerts(ctx, &digest, hostListings)
+	s.addUnread(ctx, &digest)
+	return digest, nil
+}
+
+func (s *Service) addBookings(ctx context.Context, unit uow.UnitOfWork, digest *dto.HostDigest, hostListings map[domainlistings.ListingID]*domainlistings.Listing, now time.Time) error {
+	ids := make([]domainlistings.ListingID, 0, len(hostListings))
+	for id := range hostListings {
+		ids = append(ids, id)
+	}
+	horizon := now.Add(digestWindow)
+	return eachBooking(ctx, unit, ids, func(booking *domainbooking.Booking) {
+		summary := func() dto.HostBookingSummary {
+			return dto.MapHostBookingSummary(booking, hostListings[booking.ListingID])
+		}
+		switch booking.State {
+		case domainbooking.StatePending:
+			digest.PendingRequests = appendCapped(digest.PendingRequests, summary())
+		case domainbooking.StateConfirmed:
+			if within(booking.Range.CheckIn, now, horizon) {
+				digest.UpcomingCheckIns = appendCapped(digest.UpcomingCheckIns, summary())
+			}
+			if within(booking.Range.CheckOut, now, horizon) {
+				digest.UpcomingCheckOuts = appendCapped(digest.UpcomingCheckOuts, summary())
+			}
+		case domainbooking.StateCheckedIn:
+			if within(booking.Range.CheckOut, now, horizon) {
+				digest.UpcomingCheckOuts = appendCapped(digest.UpcomingCheckOuts, summary())
+			}
+		}
+	})
+}
+
+func addReviews(ctx context.Context, unit uow.UnitOfWork, digest *dto.HostDigest, hostListings map[domainlistings.ListingID]*domainlistings.Listing, now time.Time) error {
+	since := now.Add(-digestWindow)
+	for id := range hostListings {
+		reviews, err := unit.Reviews().ListByListing(ctx, id, reviewsPerListing, 0)
+		if err != nil {
+			return err
+		}
+		for _, review := range reviews {
+			if review.Submitted && !review.Moderated && !review.CreatedAt.Before(since) {
+				digest.NewReviews = appendCapped(digest.NewReviews, dto.MapReview(review))
+			}
+		}
+	}
+	return nil
+}
+
+// addPriceAlerts reuses the host price suggestion query and keeps listings
+// whose rate is more than priceAlertGapPercent off the recommendation.
+func (s *Service) addPriceAlerts(ctx context.Context, digest *dto.HostDigest, hostListings map[domainlistings.ListingID]*domainlistings.Listing) {
+	if s.Queries == nil {
+		return
+	}
+	for _, listing := range hostListings {
+		if listing.State != domainlistings.ListingActive {
+			continue
+		}
+		query := listingapp.HostListingPriceSuggestionQuery{HostID: digest.HostID, ListingID: string(listing.ID)}
+		suggestion, err := queries.Ask[listingapp.HostListingPriceSuggestionQuery, dto.HostListingPriceSuggestion](ctx, s.Queries, query)
+		if err != nil {
+			if s.Logger != nil {
+				s.Logger.Debug("digest price suggestion skipped", "listing_id", listing.ID, "error", err)
+			}
+			continue
+		}
+		if math.Abs(suggestion.PriceGapPercent) > priceAlertGapPercent {
+			digest.PriceAlerts = appendCapped(digest.PriceAlerts, suggestion)
+		}
+	}
+}
+
+func (s *Service) addUnread(ctx context.Context, digest *dto.HostDigest) {
+	if s.Chats == nil {
+		return
+	}
+	count, err := s.Chats.UnreadCount(ctx, digest.HostID, unreadCountLimitPages)
+	if err != nil {
+		if s.Logger != nil {
+			s.Logger.Debug("digest unread count skipped", "host_id", digest.HostID, "error", err)
+		}
+		return
+	}
+	digest.UnreadMessages = count
+}
+
+func listHostListings(ctx context.Context, unit uow.UnitOfWork, hostID domainlistings.HostID) (map[domainlistings.ListingID]*domainlistings.Listing, error) {
+	result := make(map[domainlistings.ListingID]*domainlistings.Listing)
+	for offset := 0; ; offset += listingPageSize {
+		page, err := unit.Listings().Search(ctx, domainlistings.SearchParams{
+			Host:   hostID,
+			Limit:  listingPageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, listing := range page.Items {
+			result[listing.ID] = listing
+		}
+		if len(page.Items) < listingPageSize || offset+len(page.Items) >= page.Total {
+			return result, nil
+		}
+	}
+}
+
+func eachBooking(ctx context.Context, unit uow.UnitOfWork, ids []domainlistings.ListingID, fn func(*domainbooking.Booking)) error {
+	for offset := 0; ; offset += bookingPageSize {
+		page, total, err := unit.Booking().ListByListingIDs(ctx, ids, "", bookingPageSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, booking := range page {
+			fn(booking)
+		}
+		if len(page) < bookingPageSize || offset+len(page) >= total {
+			return nil
+		}
+	}
+}
+
+func appendCapped[T any](items []T, item T) []T {
+	if len(items) >= maxItemsPerSection {
+		return items
+	}
+	return append(items, item)
+}
+
+func within(at, from, to time.Time) bool {
+	return !at.Before(from) && at.Before(to)
+}
+
+// Period names the ISO week of t, e.g. "2026-W42".
+func Period(t time.Time) string {
+	year, week := t.UTC().ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+func (s *Service) now() time.Time {
+	if s.Now != nil {
+		return s.Now().UTC()
+	}
+	return time.Now().UTC()
+}
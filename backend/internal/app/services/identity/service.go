@@ -0,0 +1,79 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	domainuser "rentme/internal/domain/user"
+)
+
+var (
+	ErrNotConfigured    = errors.New("identity: verification service not configured")
+	ErrProviderMissing  = errors.New("identity: verification provider not configured")
+	ErrProviderNoResult = errors.New("identity: provider returned no verification status")
+)
+
+// Service updates the identity verification of users. Admins set the status
+// directly; Sync pulls it from the external provider once one is plugged in.
+type Service struct {
+	Users    domainuser.Repository
+	Provider domainuser.VerificationProvider
+	Now      func() time.Time
+	Logger   *slog.Logger
+}
+
+// Set records a verification status decided by an admin.
+func (s *Service) Set(ctx context.Context, userID domainuser.ID, status domainuser.VerificationStatus, method string) (*domainuser.User, error) {
+	if s == nil || s.Users == nil {
+		return nil, ErrNotConfigured
+	}
+	user, err := s.Users.ByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.SetVerification(status, method, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.Users.Save(ctx, user); err != nil {
+		return nil, err
+	}
+	s.log(user)
+	return user, nil
+}
+
+// Sync asks the provider for the current outcome of the user's check.
+func (s *Service) Sync(ctx context.Context, userID domainuser.ID) (*domainuser.User, error) {
+	if s == nil || s.Users == nil {
+		return nil, ErrNotConfigured
+	}
+	if s.Provider == nil {
+		return nil, ErrProviderMissing
+	}
+	result, err := s.Provider.Verification(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if result.Status == "" {
+		return nil, ErrProviderNoResult
+	}
+	return s.Set(ctx, userID, result.Status, result.Method)
+}
+
+func (s *Service) log(user *domainuser.User) {
+	if s.Logger == nil {
+		return
+	}
+	s.Logger.Info("user verification updated",
+		"user_id", user.ID,
+		"status", user.Verification.Status,
+		"method", user.Verification.Method)
+}
+
+func (s *Service) now() time.Time {
+	if s != nil && s.Now != nil {
+		return s.Now().UTC()
+	}
+	return time.Now().UTC()
+}
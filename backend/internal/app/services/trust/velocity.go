@@ -0,0 +1,220 @@
+package trust
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	domainuser "rentme/internal/domain/user"
+)
+
+var ErrNotConfigured = errors.New("trust: velocity service not configured")
+
+// Action is a user operation subject to velocity checks.
+type Action string
+
+const (
+	ActionRegister Action = "register"
+	ActionBooking  Action = "booking"
+)
+
+// Decision is the outcome of a velocity check.
+type Decision string
+
+const (
+	DecisionAllow  Decision = "allow"
+	DecisionVerify Decision = "verify"
+	DecisionReject Decision = "reject"
+)
+
+// Limits caps attempts per subject; zero disables a window.
+type Limits struct {
+	PerHour int
+	PerDay  int
+}
+
+// Thresholds escalate from Verify to Reject as attempt counts grow.
+type Thresholds struct {
+	Verify Limits
+	Reject Limits
+}
+
+// Attempt describes one operation. Subjects without a value are not counted.
+type Attempt struct {
+	Action Action
+	IP     string
+	Device string
+	UserID string
+}
+
+// Verdict records a decision together with the reason it was taken.
+type Verdict struct {
+	Action   Action
+	Decision Decision
+	Reason   string
+	UserID   string
+	IP       string
+	Device   string
+	At       time.Time
+}
+
+// Store keeps attempt timestamps and non-allow decisions.
+type Store interface {
+	Hit(ctx context.Context, key string, at time.Time) error
+	Count(ctx context.Context, key string, since time.Time) (int, error)
+	AppendDecision(ctx context.Context, verdict Verdict) error
+	Decisions(ctx context.Context, userID string, limit int) ([]Verdict, error)
+}
+
+// Service counts attempts per IP, device fingerprint and user and decides
+// whether to allow them, ask for verification or reject them.
+type Service struct {
+	Store  Store
+	Users  domainuser.Repository
+	Rules  map[Action]Thresholds
+	Now    func() time.Time
+	Logger *slog.Logger
+}
+
+// Check records the attempt and returns the strictest decision across its subjects.
+func (s *Service) Check(ctx context.Context, attempt Attempt) (Verdict, error) {
+	now := s.now()
+	verdict := Verdict{
+		Action:   attempt.Action,
+		Decision: DecisionAllow,
+		UserID:   attempt.UserID,
+		IP:       attempt.IP,
+		Device:   attempt.Device,
+		At:       now,
+	}
+	if s == nil || s.Store == nil {
+		return verdict, nil
+	}
+	rules, ok := s.Rules[attempt.Action]
+	if !ok {
+		return verdict, nil
+	}
+	for _, subject := range attempt.subjects() {
+		key := string(attempt.Action) + "|" + subject
+		if err := s.Store.Hit(ctx, key, now); err != nil {
+			return verdict, err
+		}
+		hourly, err := s.Store.Count(ctx, key, now.Add(-time.Hour))
+		if err != nil {
+			return verdict, err
+		}
+		daily, err := s.Store.Count(ctx, key, now.Add(-24*time.Hour))
+		if err != nil {
+			return verdict, err
+		}
+		decision, reason := evaluate(rules, subject, attempt.Action, hourly, daily)
+		if severity(decision) > severity(verdict.Decision) {
+			verdict.Decision = decision
+			verdict.Reason = reason
+		}
+	}
+	s.log(verdict)
+	if verdict.Decision != DecisionAllow && verdict.UserID != "" {
+		if err := s.Store.AppendDecision(ctx, verdict); err != nil {
+			return verdict, err
+		}
+	}
+	return verdict, nil
+}
+
+// Flag marks userID for verification and stores the verdict for admin review.
+func (s *Service) Flag(ctx context.Context, userID domainuser.ID, verdict Verdict) (*domainuser.User, error) {
+	if s == nil || s.Users == nil {
+		return nil, ErrNotConfigured
+	}
+	user, err := s.Users.ByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.RequireVerification(verdict.Reason, s.now())
+	if err := s.Users.Save(ctx, user); err != nil {
+		return nil, err
+	}
+	if verdict.UserID == "" && s.Store != nil {
+		verdict.UserID = string(userID)
+		if err := s.Store.AppendDecision(ctx, verdict); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// Decisions returns the most recent non-allow decisions for userID.
+func (s *Service) Decisions(ctx context.Context, userID string, limit int) ([]Verdict, error) {
+	if s == nil || s.Store == nil {
+		return nil, nil
+	}
+	return s.Store.Decisions(ctx, userID, limit)
+}
+
+func (a Attempt) subjects() []string {
+	var out []string
+	if ip := strings.TrimSpace(a.IP); ip != "" {
+		out = append(out, "ip:"+ip)
+	}
+	if device := strings.TrimSpace(a.Device); device != "" {
+		out = append(out, "device:"+device)
+	}
+	if user := strings.TrimSpace(a.UserID); user != "" {
+		out = append(out, "user:"+user)
+	}
+	return out
+}
+
+func evaluate(rules Thresholds, subject string, action Action, hourly, daily int) (Decision, string) {
+	if reason, hit := exceeds(rules.Reject, subject, action, hourly, daily); hit {
+		return DecisionReject, reason
+	}
+	if reason, hit := exceeds(rules.Verify, subject, action, hourly, daily); hit {
+		return DecisionVerify, reason
+	}
+	return DecisionAllow, ""
+}
+
+func exceeds(limits Limits, subject string, action Action, hourly, daily int) (string, bool) {
+	if limits.PerHour > 0 && hourly > limits.PerHour {
+		return fmt.Sprintf("%s: %d %s attempts in 1h (limit %d)", subject, hourly, action, limits.PerHour), true
+	}
+	if limits.PerDay > 0 && daily > limits.PerDay {
+		return fmt.Sprintf("%s: %d %s attempts in 24h (limit %d)", subject, daily, action, limits.PerDay), true
+	}
+	return "", false
+}
+
+func severity(d Decision) int {
+	switch d {
+	case DecisionReject:
+		return 2
+	case DecisionVerify:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (s *Service) log(v Verdict) {
+	if s.Logger == nil {
+		return
+	}
+	attrs := []any{"action", v.Action, "decision", v.Decision, "user_id", v.UserID, "ip", v.IP, "device", v.Device}
+	if v.Decision == DecisionAllow {
+		s.Logger.Debug("velocity check", attrs...)
+		return
+	}
+	s.Logger.Warn("velocity check", append(attrs, "reason", v.Reason)...)
+}
+
+func (s *Service) now() time.Time {
+	if s != nil && s.Now != nil {
+		return s.Now().UTC()
+	}
+	return time.Now().UTC()
+}
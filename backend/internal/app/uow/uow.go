@@ -8,6 +8,7 @@ import (
 	domainlistings "rentme/internal/domain/listings"
 	domainpricing "rentme/internal/domain/pricing"
 	domainreviews "rentme/internal/domain/reviews"
+	domainwishlist "rentme/internal/domain/wishlist"
 )
 
 // UnitOfWork coordinates repositories inside a transaction boundary.
@@ -17,6 +18,7 @@ type UnitOfWork interface {
 	Booking() domainbooking.Repository
 	Pricing() domainpricing.Calculator
 	Reviews() domainreviews.Repository
+	Wishlists() domainwishlist.Repository
 
 	Commit(ctx context.Context) error
 	Rollback(ctx context.Context) error
@@ -0,0 +1,55 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"rentme/internal/app/jobs"
+	"rentme/internal/app/services/digest"
+)
+
+const defaultDigestInterval = 6 * time.Hour
+
+var ErrDigestWorkerNotConfigured = errors.New("workers: digest worker missing service")
+
+// DigestJob is the job name of the weekly host digest.
+const DigestJob = "hosts.weekly_digest"
+
+// DigestWorker sends the weekly host digest. It ticks several times a day;
+// the service's send log turns every run after the first of a week into a
+// no-op and picks up hosts that failed earlier.
+type DigestWorker struct {
+	Service  *digest.Service
+	Interval time.Duration
+}
+
+// Job runs SendWeekly every Interval on the job runner.
+func (w *DigestWorker) Job() jobs.Definition {
+	return jobs.Definition{
+		Name:    DigestJob,
+		Every:   w.interval(),
+		Timeout: 10 * time.Minute,
+		Retry:   jobs.RetryPolicy{MaxAttempts: 2, Backoff: []time.Duration{5 * time.Minute}},
+		Run: func(ctx context.Context, _ jobs.Task) error {
+			if w.Service == nil {
+				return ErrDigestWorkerNotConfigured
+			}
+			result, err := w.Service.SendWeekly(ctx)
+			if err != nil {
+				return err
+			}
+			if result.Sent == 0 {
+				return jobs.ErrNoWork
+			}
+			return nil
+		},
+	}
+}
+
+func (w *DigestWorker) interval() time.Duration {
+	if w.Interval <= 0 {
+		return defaultDigestInterval
+	}
+	return w.Interval
+}
@@ -0,0 +1,69 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"rentme/internal/app/commands"
+	bookingapp "rentme/internal/app/handlers/booking"
+	"rentme/internal/app/jobs"
+)
+
+const defaultExpiryInterval = 5 * time.Minute
+
+var ErrExpiryWorkerNotConfigured = errors.New("workers: expiry worker missing command bus")
+
+// ExpiryWorker periodically dispatches ExpirePendingBookingsCommand so the
+// sweep runs through the transaction and outbox middleware like any other
+// write.
+type ExpiryWorker struct {
+	Commands commands.Bus
+	Interval time.Duration
+	Logger   *slog.Logger
+}
+
+// ExpiryJob is the job name of the booking expiry sweep.
+const ExpiryJob = "bookings.expire_pending"
+
+// Job runs ExpireOnce every Interval on the job runner.
+func (w *ExpiryWorker) Job() jobs.Definition {
+	return jobs.Definition{
+		Name:    ExpiryJob,
+		Every:   w.interval(),
+		Timeout: w.interval(),
+		Retry:   jobs.RetryPolicy{MaxAttempts: 3, Backoff: []time.Duration{30 * time.Second, time.Minute}},
+		Run: func(ctx context.Context, _ jobs.Task) error {
+			expired, err := w.ExpireOnce(ctx, time.Now().UTC())
+			if err != nil {
+				return err
+			}
+			if expired == 0 {
+				return jobs.ErrNoWork
+			}
+			return nil
+		},
+	}
+}
+
+// ExpireOnce expires every overdue PENDING booking and returns how many were
+// expired.
+func (w *ExpiryWorker) ExpireOnce(ctx context.Context, now time.Time) (int, error) {
+	if w.Commands == nil {
+		return 0, ErrExpiryWorkerNotConfigured
+	}
+	cmd := bookingapp.ExpirePendingBookingsCommand{Now: now}
+	result, err := commands.Dispatch[bookingapp.ExpirePendingBookingsCommand, *bookingapp.ExpirePendingBookingsResult](ctx, w.Commands, cmd)
+	if err != nil {
+		return 0, err
+	}
+	return result.Expired, nil
+}
+
+func (w *ExpiryWorker) interval() time.Duration {
+	if w.Interval <= 0 {
+		return defaultExpiryInterval
+	}
+	return w.Interval
+}
@@ -0,0 +1,66 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"rentme/internal/app/commands"
+	listingapp "rentme/internal/app/handlers/listings"
+	"rentme/internal/app/jobs"
+)
+
+const defaultPhotoUploadJanitorInterval = time.Hour
+
+var ErrPhotoUploadJanitorNotConfigured = errors.New("workers: photo upload janitor missing command bus")
+
+// PhotoUploadJanitor removes resumable photo uploads that were abandoned,
+// including the parts already stored.
+type PhotoUploadJanitor struct {
+	Commands commands.Bus
+	Interval time.Duration
+}
+
+// PhotoUploadJanitorJob is the job name of the abandoned upload sweep.
+const PhotoUploadJanitorJob = "listings.photo_uploads.cleanup"
+
+// Job runs CleanupOnce every Interval on the job runner.
+func (w *PhotoUploadJanitor) Job() jobs.Definition {
+	return jobs.Definition{
+		Name:    PhotoUploadJanitorJob,
+		Every:   w.interval(),
+		Timeout: 5 * time.Minute,
+		Retry:   jobs.RetryPolicy{MaxAttempts: 3, Backoff: []time.Duration{time.Minute, 5 * time.Minute}},
+		Run: func(ctx context.Context, _ jobs.Task) error {
+			removed, err := w.CleanupOnce(ctx, time.Now().UTC())
+			if err != nil {
+				return err
+			}
+			if removed == 0 {
+				return jobs.ErrNoWork
+			}
+			return nil
+		},
+	}
+}
+
+// CleanupOnce removes one batch of expired uploads and returns how many were
+// removed.
+func (w *PhotoUploadJanitor) CleanupOnce(ctx context.Context, now time.Time) (int, error) {
+	if w.Commands == nil {
+		return 0, ErrPhotoUploadJanitorNotConfigured
+	}
+	cmd := listingapp.ExpireHostListingPhotoUploadsCommand{Now: now}
+	result, err := commands.Dispatch[listingapp.ExpireHostListingPhotoUploadsCommand, *listingapp.ExpireHostListingPhotoUploadsResult](ctx, w.Commands, cmd)
+	if err != nil {
+		return 0, err
+	}
+	return result.Expired, nil
+}
+
+func (w *PhotoUploadJanitor) interval() time.Duration {
+	if w.Interval <= 0 {
+		return defaultPhotoUploadJanitorInterval
+	}
+	return w.Interval
+}
@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"rentme/internal/app/commands"
+	listingapp "rentme/internal/app/handlers/listings"
+	"rentme/internal/app/jobs"
+)
+
+var ErrRepublishWorkerNotConfigured = errors.New("workers: republish worker missing command bus")
+
+// RepublishWorker runs the one-off tasks scheduled when a host unpublishes a
+// listing with a republish time.
+type RepublishWorker struct {
+	Commands commands.Bus
+	Logger   *slog.Logger
+}
+
+// Job handles tasks of listingapp.RepublishJob. Failed publish checks are not
+// errors here; only infrastructure failures are retried.
+func (w *RepublishWorker) Job() jobs.Definition {
+	return jobs.Definition{
+		Name:    listingapp.RepublishJob,
+		Timeout: time.Minute,
+		Retry:   jobs.RetryPolicy{MaxAttempts: 3, Backoff: []time.Duration{30 * time.Second, 2 * time.Minute}},
+		Run: func(ctx context.Context, task jobs.Task) error {
+			var payload listingapp.RepublishPayload
+			if err := task.Decode(&payload); err != nil {
+				return err
+			}
+			return w.Republish(ctx, payload)
+		},
+	}
+}
+
+// Republish dispatches RepublishScheduledListingCommand for the payload.
+func (w *RepublishWorker) Republish(ctx context.Context, payload listingapp.RepublishPayload) error {
+	if w.Commands == nil {
+		return ErrRepublishWorkerNotConfigured
+	}
+	cmd := listingapp.RepublishScheduledListingCommand{
+		ListingID:   payload.ListingID,
+		RepublishAt: payload.RepublishAt,
+	}
+	result, err := commands.Dispatch[listingapp.RepublishScheduledListingCommand, *listingapp.RepublishScheduledListingResult](ctx, w.Commands, cmd)
+	if err != nil {
+		return err
+	}
+	if !result.Republished && w.Logger != nil {
+		w.Logger.Info("scheduled republish skipped", "listing_id", payload.ListingID, "reason", result.Reason)
+	}
+	return nil
+}
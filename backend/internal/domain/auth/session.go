@@ -14,6 +14,8 @@ var (
 	ErrUserRequired    = errors.New("auth: user is required")
 	ErrTTLInvalid      = errors.New("auth: ttl must be positive")
 	ErrSessionNotFound = errors.New("auth: session not found")
+	// ErrRefreshTokenReused means an already rotated refresh token was presented.
+	ErrRefreshTokenReused = errors.New("auth: refresh token reused")
 )
 
 type Token string
@@ -24,14 +26,19 @@ type Session struct {
 	Roles     []user.Role
 	CreatedAt time.Time
 	ExpiresAt time.Time
+	// RefreshToken exchanges for a new session until RefreshExpiresAt.
+	RefreshToken     Token
+	RefreshExpiresAt time.Time
 }
 
 type CreateSessionParams struct {
-	Token  Token
-	UserID user.ID
-	Roles  []user.Role
-	TTL    time.Duration
-	Now    time.Time
+	Token        Token
+	UserID       user.ID
+	Roles        []user.Role
+	TTL          time.Duration
+	RefreshToken Token
+	RefreshTTL   time.Duration
+	Now          time.Time
 }
 
 func NewSession(params CreateSessionParams) (*Session, error) {
@@ -50,13 +57,21 @@ func NewSession(params CreateSessionParams) (*Session, error) {
 		now = time.Now()
 	}
 	now = now.UTC()
-	return &Session{
+	session := &Session{
 		Token:     Token(token),
 		UserID:    params.UserID,
 		Roles:     append([]user.Role(nil), params.Roles...),
 		CreatedAt: now,
 		ExpiresAt: now.Add(params.TTL),
-	}, nil
+	}
+	if refresh := strings.TrimSpace(string(params.RefreshToken)); refresh != "" {
+		if params.RefreshTTL <= 0 {
+			return nil, ErrTTLInvalid
+		}
+		session.RefreshToken = Token(refresh)
+		session.RefreshExpiresAt = now.Add(params.RefreshTTL)
+	}
+	return session, nil
 }
 
 func (s *Session) Expired(at time.Time) bool {
@@ -66,9 +81,31 @@ func (s *Session) Expired(at time.Time) bool {
 	return !s.ExpiresAt.After(at.UTC())
 }
 
+// RefreshExpired reports whether the session can no longer be refreshed.
+func (s *Session) RefreshExpired(at time.Time) bool {
+	if s.RefreshToken == "" {
+		return true
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return !s.RefreshExpiresAt.After(at.UTC())
+}
+
 type SessionStore interface {
 	Save(ctx context.Context, session *Session) error
 	Get(ctx context.Context, token Token) (*Session, error)
 	Delete(ctx context.Context, token Token) error
 	DeleteByUser(ctx context.Context, userID user.ID) error
+	// DeleteByUserExcept removes every session of the user but keep.
+	DeleteByUserExcept(ctx context.Context, userID user.ID, keep Token) error
+	// ConsumeRefresh atomically looks up the session by refresh token, removes
+	// it and remembers the token as rotated. Presenting a rotated token again
+	// returns ErrRefreshTokenReused with a session carrying the owner's UserID.
+	ConsumeRefresh(ctx context.Context, refresh Token, now time.Time) (*Session, error)
+	// Refresh atomically replaces old with next, so at most one of two
+	// concurrent refreshes of the same session succeeds. It returns
+	// ErrSessionNotFound when old is already gone; the refresh token of old
+	// counts as rotated afterwards.
+	Refresh(ctx context.Context, old, next Session) error
 }
@@ -27,6 +27,8 @@ type Block struct {
 	Range     daterange.DateRange
 	Reason    BlockReason
 	Reference string
+	// Note is the host's own label for a host block, e.g. "family visit".
+	Note      string
 	CreatedAt time.Time
 }
 
@@ -40,6 +42,9 @@ type AvailabilityCalendar struct {
 
 type Repository interface {
 	Calendar(ctx context.Context, id listings.ListingID) (*AvailabilityCalendar, error)
+	// CalendarsByListingIDs loads the calendars of several listings at once.
+	// Listings without a stored calendar get an empty one, which is not saved.
+	CalendarsByListingIDs(ctx context.Context, ids []listings.ListingID) (map[listings.ListingID]*AvailabilityCalendar, error)
 	Save(ctx context.Context, calendar *AvailabilityCalendar) error
 }
 
@@ -56,6 +61,44 @@ func (c *AvailabilityCalendar) CanReserve(r daterange.DateRange) bool {
 	return true
 }
 
+// BlockingReason reports why r cannot be reserved. A booking outranks a host
+// block, which outranks a cleaning buffer, so overlapping blocks surface the
+// most meaningful reason.
+func (c *AvailabilityCalendar) BlockingReason(r daterange.DateRange) (BlockReason, bool) {
+	block, ok := c.Conflict(r)
+	return block.Reason, ok
+}
+
+// Conflict returns the block that keeps r from being reserved, ranked like
+// BlockingReason; the earliest block wins among equally ranked ones.
+func (c *AvailabilityCalendar) Conflict(r daterange.DateRange) (Block, bool) {
+	var (
+		found Block
+		rank  int
+	)
+	for _, block := range c.Blocks {
+		if !block.Range.Overlaps(r) {
+			continue
+		}
+		next := reasonRank(block.Reason)
+		if next > rank || (next == rank && block.Range.CheckIn.Before(found.Range.CheckIn)) {
+			found, rank = block, next
+		}
+	}
+	return found, rank > 0
+}
+
+func reasonRank(reason BlockReason) int {
+	switch reason {
+	case ReasonBooking:
+		return 3
+	case ReasonHostBlock:
+		return 2
+	default:
+		return 1
+	}
+}
+
 func (c *AvailabilityCalendar) Reserve(r daterange.DateRange, bookingID string, now time.Time) error {
 	if !c.CanReserve(r) {
 		c.Record(CalendarOverbookingPreventedEvent(c.ListingID, r, now))
@@ -95,6 +138,19 @@ func (c *AvailabilityCalendar) BlockRange(r daterange.DateRange, reason BlockRea
 	return nil
 }
 
+// HostBlock closes r for the host's own use under reference.
+func (c *AvailabilityCalendar) HostBlock(r daterange.DateRange, reference, note string, now time.Time) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if !c.CanReserve(r) {
+		return ErrOverlappingRange
+	}
+	c.appendBlock(Block{Range: r, Reason: ReasonHostBlock, Reference: reference, Note: note, CreatedAt: now.UTC()})
+	c.Record(CalendarBlockedEvent(c.ListingID, r, ReasonHostBlock, now))
+	return nil
+}
+
 func (c *AvailabilityCalendar) Release(reference string, now time.Time) error {
 	idx := -1
 	for i, block := range c.Blocks {
@@ -112,6 +168,12 @@ func (c *AvailabilityCalendar) Release(reference string, now time.Time) error {
 	return nil
 }
 
+// MirrorReference is the reference of the host block a booking leaves on the
+// other listings of its unit group.
+func MirrorReference(bookingID string) string {
+	return "unit-group:" + bookingID
+}
+
 func (c *AvailabilityCalendar) appendBlock(block Block) {
 	c.Blocks = append(c.Blocks, block)
 }
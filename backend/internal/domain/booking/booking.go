@@ -14,9 +14,32 @@ import (
 
 var (
 	ErrInvalidGuests       = errors.New("booking: guests count must be positive")
+	ErrGuestsExceedLimit   = errors.New("booking: guests count exceeds listing limit")
+	ErrBelowMinNights      = errors.New("booking: stay is shorter than the listing minimum")
+	ErrExceedsMaxNights    = errors.New("booking: stay is longer than the listing maximum")
+	ErrBeforeAvailableFrom = errors.New("booking: check-in is before the listing is available")
+	ErrMonthsRange         = errors.New("booking: months must be between 1 and 12")
+	ErrMonthsNotAllowed    = errors.New("booking: months applies to long-term listings only; short-term stays take check_out")
 	ErrInvalidState        = errors.New("booking: invalid state transition")
 	ErrPaymentHoldRequired = errors.New("booking: payment hold required before confirmation")
 	ErrBookingNotFound     = errors.New("booking: not found")
+	ErrInvalidInitiator    = errors.New("booking: cancellation initiator must be guest, host or platform")
+	ErrRefundOutOfRange    = errors.New("booking: refund must be between zero and the booking total")
+	ErrCheckInTooEarly     = errors.New("booking: check-in is not open yet")
+	ErrNoShowTooEarly      = errors.New("booking: no-show can be recorded only after the check-in date")
+)
+
+// CheckInGrace is how long before the check-in date the host may already
+// check the guest in, for early arrivals.
+const CheckInGrace = 12 * time.Hour
+
+// CancellationInitiator names the party a cancellation is attributed to.
+type CancellationInitiator string
+
+const (
+	InitiatorGuest    CancellationInitiator = "guest"
+	InitiatorHost     CancellationInitiator = "host"
+	InitiatorPlatform CancellationInitiator = "platform"
 )
 
 type BookingID string
@@ -56,28 +79,52 @@ type Booking struct {
 type Repository interface {
 	ByID(ctx context.Context, id BookingID) (*Booking, error)
 	Save(ctx context.Context, booking *Booking) error
-	ListByGuest(ctx context.Context, guestID string) ([]*Booking, error)
+	// ListByGuest returns one page of the guest's bookings, newest first, and
+	// the cursor of the next page, empty on the last one.
+	ListByGuest(ctx context.Context, guestID string, page GuestPage) ([]*Booking, string, error)
 	ListByListing(ctx context.Context, listingID listings.ListingID) ([]*Booking, error)
+	ListByState(ctx context.Context, state BookingState, olderThan time.Time) ([]*Booking, error)
+	// ListByListingIDs returns one page of bookings for the listings, newest
+	// first, and the total number of matches. An empty state matches any state.
+	ListByListingIDs(ctx context.Context, ids []listings.ListingID, state BookingState, limit, offset int) ([]*Booking, int, error)
+	// List returns one page of bookings across all guests and listings,
+	// newest first, and the total number of matches.
+	List(ctx context.Context, filter BookingFilter) ([]*Booking, int, error)
+}
+
+// BookingFilter narrows List. Empty fields match everything; a non-nil but
+// empty ListingIDs matches nothing.
+type BookingFilter struct {
+	GuestID    string
+	ListingIDs []listings.ListingID
+	State      BookingState
+	Limit      int
+	Offset     int
 }
 
 type CreateParams struct {
-	ID        BookingID
-	ListingID listings.ListingID
-	GuestID   string
-	Range     daterange.DateRange
-	Guests    int
-	Months    int
-	PriceUnit string
-	Price     pricing.PriceBreakdown
-	Policy    CancellationPolicySnapshot
-	CreatedAt time.Time
-	AllowZero bool
+	ID          BookingID
+	ListingID   listings.ListingID
+	GuestID     string
+	Range       daterange.DateRange
+	Guests      int
+	GuestsLimit int
+	Months      int
+	PriceUnit   string
+	Price       pricing.PriceBreakdown
+	Policy      CancellationPolicySnapshot
+	CreatedAt   time.Time
+	AllowZero   bool
 }
 
 func NewBooking(params CreateParams) (*Booking, error) {
 	if params.Guests <= 0 {
 		return nil, ErrInvalidGuests
 	}
+	// A zero limit means the listing does not cap guests.
+	if params.GuestsLimit > 0 && params.Guests > params.GuestsLimit {
+		return nil, ErrGuestsExceedLimit
+	}
 	if params.GuestID == "" {
 		return nil, errors.New("booking: guest id required")
 	}
@@ -141,6 +188,17 @@ func (b *Booking) Decline(reason string, now time.Time) error {
 	return nil
 }
 
+// Expire closes a request the host never answered.
+func (b *Booking) Expire(now time.Time) error {
+	if b.State != StatePending {
+		return ErrInvalidState
+	}
+	b.State = StateExpired
+	b.UpdatedAt = now.UTC()
+	b.Record(BookingExpired{BookingID: b.ID, ListingID: b.ListingID, At: b.UpdatedAt})
+	return nil
+}
+
 func (b *Booking) Confirm(paymentHoldID string, now time.Time) error {
 	if b.State != StateAccepted && b.State != StatePending {
 		return ErrInvalidState
@@ -156,25 +214,92 @@ func (b *Booking) Confirm(paymentHoldID string, now time.Time) error {
 }
 
 func (b *Booking) Cancel(reason string, now time.Time) (money.Money, money.Money, error) {
+	return b.cancel("", nil, reason, now)
+}
+
+// CancelOnBehalf cancels the booking for the initiator. A nil refund applies
+// the cancellation policy; otherwise the refund overrides it and the rest of
+// the total becomes the penalty.
+func (b *Booking) CancelOnBehalf(initiator CancellationInitiator, refund *money.Money, reason string, now time.Time) (money.Money, money.Money, error) {
+	switch initiator {
+	case InitiatorGuest, InitiatorHost, InitiatorPlatform:
+	default:
+		return money.Money{}, money.Money{}, ErrInvalidInitiator
+	}
+	return b.cancel(initiator, refund, reason, now)
+}
+
+func (b *Booking) cancel(initiator CancellationInitiator, override *money.Money, reason string, now time.Time) (money.Money, money.Money, error) {
 	switch b.State {
 	case StatePending, StateAccepted, StateConfirmed:
 	default:
 		return money.Money{}, money.Money{}, ErrInvalidState
 	}
-	refund, penalty, err := b.Policy.CalculateRefund(b.Price.Total, now, b.Range.CheckIn)
-	if err != nil {
-		return money.Money{}, money.Money{}, err
+	var refund, penalty money.Money
+	if override != nil {
+		if override.Currency != b.Price.Total.Currency || override.Amount < 0 || override.Amount > b.Price.Total.Amount {
+			return money.Money{}, money.Money{}, ErrRefundOutOfRange
+		}
+		refund = *override
+		penalty = money.Money{Amount: b.Price.Total.Amount - refund.Amount, Currency: refund.Currency}
+	} else {
+		var err error
+		refund, penalty, err = b.Policy.CalculateRefund(b.Price.Total, now, b.Range.CheckIn)
+		if err != nil {
+			return money.Money{}, money.Money{}, err
+		}
 	}
 	b.State = StateCancelled
 	b.UpdatedAt = now.UTC()
-	b.Record(BookingCancelled{BookingID: b.ID, Refund: refund, Penalty: penalty, Reason: reason, At: b.UpdatedAt})
+	b.Record(BookingCancelled{
+		BookingID:      b.ID,
+		Refund:         refund,
+		Penalty:        penalty,
+		Reason:         reason,
+		Initiator:      initiator,
+		RefundOverride: override != nil,
+		At:             b.UpdatedAt,
+	})
 	return refund, penalty, nil
 }
 
+// ChangeStay moves the check-out of an accepted or confirmed booking before
+// the guest arrives. The caller prices the new range and makes sure the
+// calendar can hold it.
+func (b *Booking) ChangeStay(r daterange.DateRange, months int, price pricing.PriceBreakdown, now time.Time) error {
+	if b.State != StateAccepted && b.State != StateConfirmed {
+		return ErrInvalidState
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if !r.CheckIn.Equal(b.Range.CheckIn) {
+		return errors.New("booking: check-in cannot change")
+	}
+	if err := price.RecalculateTotal(); err != nil {
+		return err
+	}
+	if price.Total.Amount <= 0 {
+		return errors.New("booking: total must be positive")
+	}
+	previous := b.Range
+	b.Range = r
+	if b.PriceUnit == "month" {
+		b.Months = months
+	}
+	b.Price = price.Copy()
+	b.UpdatedAt = now.UTC()
+	b.Record(BookingStayChanged{BookingID: b.ID, ListingID: b.ListingID, Previous: previous, Range: b.Range, Total: b.Price.Total, At: b.UpdatedAt})
+	return nil
+}
+
 func (b *Booking) CheckIn(now time.Time) error {
 	if b.State != StateConfirmed {
 		return ErrInvalidState
 	}
+	if now.Before(b.Range.CheckIn.Add(-CheckInGrace)) {
+		return ErrCheckInTooEarly
+	}
 	b.State = StateCheckedIn
 	b.UpdatedAt = now.UTC()
 	b.Record(CheckInCompleted{BookingID: b.ID, At: b.UpdatedAt})
@@ -191,10 +316,15 @@ func (b *Booking) CheckOut(now time.Time) error {
 	return nil
 }
 
+// MarkNoShow is allowed once the check-in date has passed without the guest
+// being checked in.
 func (b *Booking) MarkNoShow(now time.Time) error {
 	if b.State != StateConfirmed {
 		return ErrInvalidState
 	}
+	if !now.After(b.Range.CheckIn) {
+		return ErrNoShowTooEarly
+	}
 	b.State = StateNoShow
 	b.UpdatedAt = now.UTC()
 	b.Record(NoShowRecorded{BookingID: b.ID, At: b.UpdatedAt})
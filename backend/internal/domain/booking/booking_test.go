@@ -0,0 +1,142 @@
+package booking
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"rentme/internal/domain/pricing"
+	"rentme/internal/domain/shared/daterange"
+	"rentme/internal/domain/shared/money"
+)
+
+var checkIn = daterange.Date(2026, time.June, 10)
+
+func createParams(guests, limit int) CreateParams {
+	return CreateParams{
+		ID:          "booking-1",
+		ListingID:   "listing-1",
+		GuestID:     "guest-1",
+		Range:       daterange.DateRange{CheckIn: checkIn, CheckOut: checkIn.AddDate(0, 0, 3)},
+		Guests:      guests,
+		GuestsLimit: limit,
+		Price: pricing.PriceBreakdown{
+			Unit:    "night",
+			Nights:  3,
+			Nightly: money.Money{Amount: 5000, Currency: "RUB"},
+		},
+		CreatedAt: checkIn.AddDate(0, 0, -20),
+	}
+}
+
+func TestNewBookingGuestsAgainstListingLimit(t *testing.T) {
+	cases := []struct {
+		name   string
+		guests int
+		limit  int
+		want   error
+	}{
+		{"below the limit", 2, 4, nil},
+		{"at the limit", 4, 4, nil},
+		{"over the limit", 5, 4, ErrGuestsExceedLimit},
+		{"no limit set", 12, 0, nil},
+		{"no guests", 0, 4, ErrInvalidGuests},
+		{"negative guests", -1, 0, ErrInvalidGuests},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBooking(createParams(tc.guests, tc.limit))
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("err = %v, want %v", err, tc.want)
+			}
+			if tc.want == nil && (b == nil || b.Guests != tc.guests || b.State != StatePending) {
+				t.Fatalf("booking = %+v, want a pending booking for %d guests", b, tc.guests)
+			}
+		})
+	}
+}
+
+// transitions applies every state change to a booking; now is a day after
+// check-in so the time checks of check-in and no-show pass.
+var transitions = map[string]func(b *Booking, now time.Time) error{
+	"accept":    func(b *Booking, now time.Time) error { return b.Accept(now) },
+	"decline":   func(b *Booking, now time.Time) error { return b.Decline("busy", now) },
+	"expire":    func(b *Booking, now time.Time) error { return b.Expire(now) },
+	"confirm":   func(b *Booking, now time.Time) error { return b.Confirm("hold-1", now) },
+	"check-in":  func(b *Booking, now time.Time) error { return b.CheckIn(now) },
+	"check-out": func(b *Booking, now time.Time) error { return b.CheckOut(now) },
+	"no-show":   func(b *Booking, now time.Time) error { return b.MarkNoShow(now) },
+	"cancel": func(b *Booking, now time.Time) error {
+		_, _, err := b.CancelOnBehalf(InitiatorPlatform, &money.Money{Currency: "RUB"}, "test", now)
+		return err
+	},
+}
+
+func TestBookingStateTransitions(t *testing.T) {
+	allowed := map[BookingState]map[string]BookingState{
+		StatePending:    {"accept": StateAccepted, "decline": StateDeclined, "expire": StateExpired, "confirm": StateConfirmed, "cancel": StateCancelled},
+		StateAccepted:   {"decline": StateDeclined, "confirm": StateConfirmed, "cancel": StateCancelled},
+		StateConfirmed:  {"check-in": StateCheckedIn, "no-show": StateNoShow, "cancel": StateCancelled},
+		StateCheckedIn:  {"check-out": StateCheckedOut},
+		StateDeclined:   {},
+		StateExpired:    {},
+		StateCancelled:  {},
+		StateCheckedOut: {},
+		StateNoShow:     {},
+	}
+	now := checkIn.AddDate(0, 0, 1)
+	for from, targets := range allowed {
+		for action, apply := range transitions {
+			t.Run(string(from)+"/"+action, func(t *testing.T) {
+				b, err := NewBooking(createParams(2, 0))
+				if err != nil {
+					t.Fatalf("new booking: %v", err)
+				}
+				b.State = from
+				b.ClearEvents()
+
+				err = apply(b, now)
+				want, ok := targets[action]
+				if !ok {
+					if !errors.Is(err, ErrInvalidState) {
+						t.Fatalf("err = %v, want ErrInvalidState", err)
+					}
+					if b.State != from || len(b.PendingEvents()) != 0 {
+						t.Fatalf("rejected transition changed the booking: state %s", b.State)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("err = %v, want %s", err, want)
+				}
+				if b.State != want {
+					t.Fatalf("state = %s, want %s", b.State, want)
+				}
+				if len(b.PendingEvents()) != 1 {
+					t.Fatalf("transition to %s recorded no single event", want)
+				}
+			})
+		}
+	}
+}
+
+func TestBookingTransitionTimeChecks(t *testing.T) {
+	b, err := NewBooking(createParams(2, 0))
+	if err != nil {
+		t.Fatalf("new booking: %v", err)
+	}
+	b.State = StateConfirmed
+	if err := b.CheckIn(checkIn.Add(-CheckInGrace - time.Minute)); !errors.Is(err, ErrCheckInTooEarly) {
+		t.Fatalf("early check-in err = %v, want ErrCheckInTooEarly", err)
+	}
+	if err := b.MarkNoShow(checkIn); !errors.Is(err, ErrNoShowTooEarly) {
+		t.Fatalf("no-show on the check-in instant err = %v, want ErrNoShowTooEarly", err)
+	}
+	if err := b.Confirm("", checkIn); !errors.Is(err, ErrInvalidState) {
+		t.Fatalf("confirm twice err = %v, want ErrInvalidState", err)
+	}
+	b.State = StatePending
+	if err := b.Confirm("", checkIn); !errors.Is(err, ErrPaymentHoldRequired) {
+		t.Fatalf("confirm without hold err = %v, want ErrPaymentHoldRequired", err)
+	}
+}
@@ -0,0 +1,63 @@
+package booking
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("booking: invalid cursor")
+
+// Cursor marks the last booking of a page in the newest-first guest list.
+// It carries the same "<unix nanos>|<id>" pair as the messaging service
+// conversation cursor, base64-encoded so clients treat it as opaque.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        BookingID
+}
+
+// GuestPage selects one page of a guest's bookings. A zero Limit returns
+// every booking after the cursor.
+type GuestPage struct {
+	Limit  int
+	Cursor string
+}
+
+// CursorFor returns the cursor that continues the list after b.
+func CursorFor(b *Booking) string {
+	raw := fmt.Sprintf("%d|%s", b.CreatedAt.UTC().UnixNano(), b.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a cursor; an empty string is the first page.
+func ParseCursor(raw string) (*Cursor, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	nanos, id, ok := strings.Cut(string(decoded), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	value, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{CreatedAt: time.Unix(0, value).UTC(), ID: BookingID(id)}, nil
+}
+
+// Before reports whether b comes after the cursor in the newest-first
+// order, ties on CreatedAt broken by descending id.
+func (c Cursor) Before(b *Booking) bool {
+	if b.CreatedAt.Equal(c.CreatedAt) {
+		return b.ID < c.ID
+	}
+	return b.CreatedAt.Before(c.CreatedAt)
+}
@@ -41,6 +41,16 @@ func (e BookingDeclined) EventName() string     { return "booking.declined" }
 func (e BookingDeclined) AggregateID() string   { return string(e.BookingID) }
 func (e BookingDeclined) OccurredAt() time.Time { return e.At }
 
+type BookingExpired struct {
+	BookingID BookingID
+	ListingID listings.ListingID
+	At        time.Time
+}
+
+func (e BookingExpired) EventName() string     { return "booking.expired" }
+func (e BookingExpired) AggregateID() string   { return string(e.BookingID) }
+func (e BookingExpired) OccurredAt() time.Time { return e.At }
+
 type BookingConfirmed struct {
 	BookingID BookingID
 	ListingID listings.ListingID
@@ -53,14 +63,32 @@ func (e BookingConfirmed) EventName() string     { return "booking.confirmed" }
 func (e BookingConfirmed) AggregateID() string   { return string(e.BookingID) }
 func (e BookingConfirmed) OccurredAt() time.Time { return e.At }
 
-type BookingCancelled struct {
+// BookingStayChanged records a guest moving the check-out of a booking.
+type BookingStayChanged struct {
 	BookingID BookingID
-	Refund    money.Money
-	Penalty   money.Money
-	Reason    string
+	ListingID listings.ListingID
+	Previous  daterange.DateRange
+	Range     daterange.DateRange
+	Total     money.Money
 	At        time.Time
 }
 
+func (e BookingStayChanged) EventName() string     { return "booking.stay_changed" }
+func (e BookingStayChanged) AggregateID() string   { return string(e.BookingID) }
+func (e BookingStayChanged) OccurredAt() time.Time { return e.At }
+
+// BookingCancelled carries the refund split. RefundOverride is set when the
+// refund was entered by support instead of computed from the policy.
+type BookingCancelled struct {
+	BookingID      BookingID
+	Refund         money.Money
+	Penalty        money.Money
+	Reason         string
+	Initiator      CancellationInitiator
+	RefundOverride bool
+	At             time.Time
+}
+
 func (e BookingCancelled) EventName() string     { return "booking.cancelled" }
 func (e BookingCancelled) AggregateID() string   { return string(e.BookingID) }
 func (e BookingCancelled) OccurredAt() time.Time { return e.At }
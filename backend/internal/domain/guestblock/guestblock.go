@@ -0,0 +1,81 @@
+package guestblock
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"rentme/internal/domain/listings"
+)
+
+var (
+	ErrHostRequired  = errors.New("guestblock: host id is required")
+	ErrGuestRequired = errors.New("guestblock: guest id is required")
+	ErrSelfBlock     = errors.New("guestblock: a host cannot block themselves")
+	ErrReasonTooLong = errors.New("guestblock: reason is too long")
+	ErrNotFound      = errors.New("guestblock: block not found")
+)
+
+// MaxReasonLength caps the private note a host keeps on a block.
+const MaxReasonLength = 500
+
+// Block stops a guest from booking or messaging about any listing of the
+// host. The reason is for the host and admins only; the guest is never told.
+type Block struct {
+	HostID    listings.HostID
+	GuestID   string
+	Reason    string
+	CreatedAt time.Time
+}
+
+type Repository interface {
+	// Get returns ErrNotFound when the host has not blocked the guest.
+	Get(ctx context.Context, hostID listings.HostID, guestID string) (*Block, error)
+	ByHost(ctx context.Context, hostID listings.HostID) ([]*Block, error)
+	ByGuest(ctx context.Context, guestID string) ([]*Block, error)
+	// Save creates the block or replaces the reason of an existing one.
+	Save(ctx context.Context, block *Block) error
+	// Delete returns ErrNotFound when there was nothing to remove.
+	Delete(ctx context.Context, hostID listings.HostID, guestID string) error
+}
+
+func New(hostID listings.HostID, guestID, reason string, now time.Time) (*Block, error) {
+	hostID = listings.HostID(strings.TrimSpace(string(hostID)))
+	if hostID == "" {
+		return nil, ErrHostRequired
+	}
+	guestID = strings.TrimSpace(guestID)
+	if guestID == "" {
+		return nil, ErrGuestRequired
+	}
+	if string(hostID) == guestID {
+		return nil, ErrSelfBlock
+	}
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > MaxReasonLength {
+		return nil, ErrReasonTooLong
+	}
+	return &Block{
+		HostID:    hostID,
+		GuestID:   guestID,
+		Reason:    reason,
+		CreatedAt: now.UTC(),
+	}, nil
+}
+
+// IsBlocked reports whether the host has blocked the guest. A nil repository
+// blocks nobody.
+func IsBlocked(ctx context.Context, repo Repository, hostID listings.HostID, guestID string) (bool, error) {
+	if repo == nil || strings.TrimSpace(guestID) == "" {
+		return false, nil
+	}
+	if _, err := repo.Get(ctx, hostID, guestID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
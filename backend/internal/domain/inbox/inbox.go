@@ -0,0 +1,99 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"rentme/internal/domain/listings"
+)
+
+var (
+	ErrConversationRequired = errors.New("inbox: conversation id is required")
+	ErrAssigneeRequired     = errors.New("inbox: assignee id is required")
+	ErrAuthorRequired       = errors.New("inbox: author id is required")
+	ErrNoteTextRequired     = errors.New("inbox: note text is required")
+	ErrNoteTooLong          = errors.New("inbox: note text is too long")
+)
+
+// MaxNoteLength caps a single internal note.
+const MaxNoteLength = 4000
+
+// Assignment records which host team member handles a conversation. It lives
+// next to the listing data rather than in the messaging service.
+type Assignment struct {
+	ConversationID string
+	ListingID      listings.ListingID
+	AssigneeID     listings.HostID
+	AssignedBy     string
+	AssignedAt     time.Time
+}
+
+// Note is an internal remark on a conversation, visible to the host team only.
+type Note struct {
+	ID             string
+	ConversationID string
+	ListingID      listings.ListingID
+	AuthorID       string
+	Text           string
+	CreatedAt      time.Time
+}
+
+type AssignmentRepository interface {
+	ByConversation(ctx context.Context, conversationID string) (*Assignment, error)
+	// ByAssignee lists the assignee's conversations, newest assignment first
+	// and by conversation id within the same instant.
+	ByAssignee(ctx context.Context, assigneeID listings.HostID) ([]*Assignment, error)
+	Save(ctx context.Context, assignment *Assignment) error
+	Delete(ctx context.Context, conversationID string) error
+}
+
+type NoteRepository interface {
+	ListByConversation(ctx context.Context, conversationID string) ([]*Note, error)
+	Append(ctx context.Context, note *Note) error
+}
+
+func NewAssignment(conversationID string, listingID listings.ListingID, assigneeID listings.HostID, assignedBy string, now time.Time) (*Assignment, error) {
+	conversationID = strings.TrimSpace(conversationID)
+	if conversationID == "" {
+		return nil, ErrConversationRequired
+	}
+	assigneeID = listings.HostID(strings.TrimSpace(string(assigneeID)))
+	if assigneeID == "" {
+		return nil, ErrAssigneeRequired
+	}
+	return &Assignment{
+		ConversationID: conversationID,
+		ListingID:      listingID,
+		AssigneeID:     assigneeID,
+		AssignedBy:     strings.TrimSpace(assignedBy),
+		AssignedAt:     now.UTC(),
+	}, nil
+}
+
+func NewNote(id, conversationID string, listingID listings.ListingID, authorID, text string, now time.Time) (*Note, error) {
+	conversationID = strings.TrimSpace(conversationID)
+	if conversationID == "" {
+		return nil, ErrConversationRequired
+	}
+	authorID = strings.TrimSpace(authorID)
+	if authorID == "" {
+		return nil, ErrAuthorRequired
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrNoteTextRequired
+	}
+	if len([]rune(text)) > MaxNoteLength {
+		return nil, ErrNoteTooLong
+	}
+	return &Note{
+		ID:             strings.TrimSpace(id),
+		ConversationID: conversationID,
+		ListingID:      listingID,
+		AuthorID:       authorID,
+		Text:           text,
+		CreatedAt:      now.UTC(),
+	}, nil
+}
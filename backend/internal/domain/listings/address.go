@@ -0,0 +1,104 @@
+package listings
+
+import (
+	"errors"
+	"strings"
+)
+
+const maxAddressFieldLength = 200
+
+var (
+	ErrAddressCoordinates = errors.New("listings: address coordinates are out of range")
+	ErrAddressField       = errors.New("listings: address fields must be at most 200 characters")
+)
+
+// countryAliases maps common spellings of the countries we operate in to
+// their ISO 3166-1 alpha-2 code, which is what listings store.
+var countryAliases = map[string]string{
+	"russia":             "RU",
+	"russian federation": "RU",
+	"россия":             "RU",
+	"рф":                 "RU",
+	"poland":             "PL",
+	"polska":             "PL",
+	"czechia":            "CZ",
+	"czech republic":     "CZ",
+	"lithuania":          "LT",
+	"latvia":             "LV",
+	"switzerland":        "CH",
+	"schweiz":            "CH",
+	"kazakhstan":         "KZ",
+	"georgia":            "GE",
+	"armenia":            "AM",
+	"serbia":             "RS",
+	"turkey":             "TR",
+	"türkiye":            "TR",
+}
+
+// NormalizeAddress trims the address, maps the country to its ISO code and
+// defaults the region to the country. Errors reject the address outright;
+// warnings describe what is still missing before the listing can go live.
+// Create, update and the address preview all go through it.
+func NormalizeAddress(a Address) (Address, []string, error) {
+	out := Address{
+		Line1:   strings.TrimSpace(a.Line1),
+		Line2:   strings.TrimSpace(a.Line2),
+		City:    strings.TrimSpace(a.City),
+		Region:  strings.TrimSpace(a.Region),
+		Country: strings.TrimSpace(a.Country),
+		Lat:     a.Lat,
+		Lon:     a.Lon,
+	}
+	for _, field := range []string{out.Line1, out.Line2, out.City, out.Region, out.Country} {
+		if len([]rune(field)) > maxAddressFieldLength {
+			return Address{}, nil, ErrAddressField
+		}
+	}
+	if out.Lat < -90 || out.Lat > 90 || out.Lon < -180 || out.Lon > 180 {
+		return Address{}, nil, ErrAddressCoordinates
+	}
+
+	var warnings []string
+	if out.Country != "" {
+		code, ok := normalizeCountry(out.Country)
+		if !ok {
+			warnings = append(warnings, "country is not recognised; use a two-letter ISO code")
+		}
+		out.Country = code
+	}
+	if out.Region == "" {
+		out.Region = out.Country
+	}
+	if out.Line1 == "" {
+		warnings = append(warnings, "street address is required to publish")
+	}
+	if out.City == "" {
+		warnings = append(warnings, "city is required to publish")
+	}
+	if out.Region == "" {
+		warnings = append(warnings, "region or country is required to publish")
+	}
+	if out.Lat == 0 && out.Lon == 0 {
+		warnings = append(warnings, "coordinates are missing; the listing will not show up in map and radius search")
+	}
+	return out, warnings, nil
+}
+
+func normalizeCountry(raw string) (string, bool) {
+	if len(raw) == 2 && isASCIILetters(raw) {
+		return strings.ToUpper(raw), true
+	}
+	if code, ok := countryAliases[strings.ToLower(raw)]; ok {
+		return code, true
+	}
+	return raw, false
+}
+
+func isASCIILetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
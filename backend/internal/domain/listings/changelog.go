@@ -0,0 +1,88 @@
+package listings
+
+import (
+	"slices"
+	"time"
+)
+
+// ChangelogEntry records which attributes one update changed. Version is the
+// listing version the update was saved as.
+type ChangelogEntry struct {
+	Version   int64
+	ChangedBy string
+	At        time.Time
+	Fields    []string
+}
+
+// recordChanges appends a changelog entry when the listing differs from the
+// snapshot taken before the update. Fields use the API attribute names.
+func (l *Listing) recordChanges(before Listing, changedBy string, now time.Time) {
+	fields := changedFields(before, *l)
+	if len(fields) == 0 {
+		return
+	}
+	l.Changelog = append(l.Changelog, ChangelogEntry{
+		Version:   l.Version + 1,
+		ChangedBy: changedBy,
+		At:        now,
+		Fields:    fields,
+	})
+}
+
+func changedFields(before, after Listing) []string {
+	var fields []string
+	add := func(name string, changed bool) {
+		if changed {
+			fields = append(fields, name)
+		}
+	}
+	add("title", before.Title != after.Title)
+	add("description", before.Description != after.Description)
+	add("property_type", before.PropertyType != after.PropertyType)
+	add("address", before.Address != after.Address)
+	add("amenities", !slices.Equal(before.Amenities, after.Amenities))
+	add("house_rules", !slices.Equal(before.HouseRules, after.HouseRules))
+	add("tags", !slices.Equal(before.Tags, after.Tags))
+	add("highlights", !slices.Equal(before.Highlights, after.Highlights))
+	add("thumbnail_url", before.ThumbnailURL != after.ThumbnailURL)
+	add("cancellation_policy_id", before.CancellationPolicyID != after.CancellationPolicyID)
+	add("guests_limit", before.GuestsLimit != after.GuestsLimit)
+	add("min_nights", before.MinNights != after.MinNights)
+	add("max_nights", before.MaxNights != after.MaxNights)
+	add("min_months", before.MinMonths != after.MinMonths)
+	add("max_months", before.MaxMonths != after.MaxMonths)
+	add("rate_rub", before.RateRub != after.RateRub)
+	add("cleaning_fee_rub", before.CleaningFeeRub != after.CleaningFeeRub)
+	add("service_fee_pct", before.ServiceFeePct != after.ServiceFeePct)
+	add("weekend_premium_pct", before.WeekendPremiumPct != after.WeekendPremiumPct)
+	add("long_stay_discount_pct", before.LongStayDiscountPct != after.LongStayDiscountPct)
+	add("long_stay_threshold_nights", before.LongStayThresholdNights != after.LongStayThresholdNights)
+	add("bedrooms", before.Bedrooms != after.Bedrooms)
+	add("bathrooms", before.Bathrooms != after.Bathrooms)
+	add("floor", before.Floor != after.Floor)
+	add("floors_total", before.FloorsTotal != after.FloorsTotal)
+	add("renovation_score", before.RenovationScore != after.RenovationScore)
+	add("building_age_years", before.BuildingAgeYears != after.BuildingAgeYears)
+	add("area_sq_m", before.AreaSquareMeters != after.AreaSquareMeters)
+	add("travel_minutes", before.TravelMinutes != after.TravelMinutes)
+	add("travel_mode", before.TravelMode != after.TravelMode)
+	add("rental_term", before.RentalTermType != after.RentalTermType)
+	add("verified_guests_only", before.VerifiedGuestsOnly != after.VerifiedGuestsOnly)
+	add("available_from", !before.AvailableFrom.Equal(after.AvailableFrom))
+	add("photos", !slices.Equal(before.Photos, after.Photos))
+	return fields
+}
+
+// SortChangelogNewestFirst orders entries by version, newest first.
+func SortChangelogNewestFirst(entries []ChangelogEntry) {
+	slices.SortStableFunc(entries, func(a, b ChangelogEntry) int {
+		switch {
+		case a.Version > b.Version:
+			return -1
+		case a.Version < b.Version:
+			return 1
+		default:
+			return b.At.Compare(a.At)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package listings
+
+import (
+	"math"
+	"strings"
+)
+
+// MaxTravelMinutes caps the commute a host may declare.
+const MaxTravelMinutes = 240
+
+// Travel modes understood by search and ML pricing.
+const (
+	TravelModeWalk    = "walk"
+	TravelModeBike    = "bike"
+	TravelModeTransit = "transit"
+	TravelModeCar     = "car"
+)
+
+// TravelSource tells whether commute minutes came from the host or were estimated.
+type TravelSource string
+
+const (
+	TravelDeclared  TravelSource = "declared"
+	TravelEstimated TravelSource = "estimated"
+)
+
+// detourFactor stretches straight-line distance to approximate street routes.
+const detourFactor = 1.3
+
+// travelSpeedsKmH are average door-to-door speeds per mode in a city.
+var travelSpeedsKmH = map[string]float64{
+	TravelModeWalk:    4.5,
+	TravelModeBike:    14,
+	TravelModeTransit: 20,
+	TravelModeCar:     28,
+}
+
+// GeoPoint is a WGS84 coordinate.
+type GeoPoint struct {
+	Lat float64
+	Lon float64
+}
+
+// Commute is the travel time used for filtering and shown on cards.
+type Commute struct {
+	Minutes float64
+	Mode    string
+	Source  TravelSource
+}
+
+// NormalizeTravelMode maps aliases to a known mode and returns "" for unknown ones.
+func NormalizeTravelMode(raw string) string {
+	mode := strings.ToLower(strings.TrimSpace(raw))
+	switch mode {
+	case "public", "metro":
+		mode = TravelModeTransit
+	case "foot", "walking":
+		mode = TravelModeWalk
+	case "bicycle", "cycling":
+		mode = TravelModeBike
+	case "auto", "driving", "taxi":
+		mode = TravelModeCar
+	}
+	if _, ok := travelSpeedsKmH[mode]; !ok {
+		return ""
+	}
+	return mode
+}
+
+// Commute returns the travel time for mode. Declared minutes win when the
+// host's mode matches (or no mode is requested); otherwise the time is
+// estimated from the straight-line distance to from. The second result is
+// false when neither is available.
+func (l *Listing) Commute(mode string, from *GeoPoint) (Commute, bool) {
+	mode = NormalizeTravelMode(mode)
+	declaredMode := NormalizeTravelMode(l.TravelMode)
+	if declaredMode == "" {
+		declaredMode = TravelModeCar
+	}
+	if l.TravelMinutes > 0 && (mode == "" || mode == declaredMode) {
+		return Commute{Minutes: l.TravelMinutes, Mode: declaredMode, Source: TravelDeclared}, true
+	}
+	if from == nil || (l.Address.Lat == 0 && l.Address.Lon == 0) {
+		return Commute{}, false
+	}
+	if mode == "" {
+		mode = declaredMode
+	}
+	km := haversineKm(GeoPoint{Lat: l.Address.Lat, Lon: l.Address.Lon}, *from) * detourFactor
+	minutes := math.Round(km / travelSpeedsKmH[mode] * 60)
+	return Commute{Minutes: minutes, Mode: mode, Source: TravelEstimated}, true
+}
+
+func haversineKm(a, b GeoPoint) float64 {
+	const earthRadiusKm = 6371.0
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -26,6 +26,7 @@ func (e ListingActivatedEvent) OccurredAt() time.Time { return e.At }
 
 type ListingSuspendedEvent struct {
 	ListingID ListingID
+	HostID    HostID
 	Reason    string
 	At        time.Time
 }
@@ -34,6 +35,38 @@ func (e ListingSuspendedEvent) EventName() string     { return "listing.suspende
 func (e ListingSuspendedEvent) AggregateID() string   { return string(e.ListingID) }
 func (e ListingSuspendedEvent) OccurredAt() time.Time { return e.At }
 
+type ListingDeletedEvent struct {
+	ListingID ListingID
+	HostID    HostID
+	At        time.Time
+}
+
+func (e ListingDeletedEvent) EventName() string     { return "listing.deleted" }
+func (e ListingDeletedEvent) AggregateID() string   { return string(e.ListingID) }
+func (e ListingDeletedEvent) OccurredAt() time.Time { return e.At }
+
+type ListingRestoredEvent struct {
+	ListingID ListingID
+	HostID    HostID
+	At        time.Time
+}
+
+func (e ListingRestoredEvent) EventName() string     { return "listing.restored" }
+func (e ListingRestoredEvent) AggregateID() string   { return string(e.ListingID) }
+func (e ListingRestoredEvent) OccurredAt() time.Time { return e.At }
+
+type ListingPriceChangedEvent struct {
+	ListingID  ListingID
+	OldRateRub int64
+	NewRateRub int64
+	Source     PriceChangeSource
+	At         time.Time
+}
+
+func (e ListingPriceChangedEvent) EventName() string     { return "listing.price_changed" }
+func (e ListingPriceChangedEvent) AggregateID() string   { return string(e.ListingID) }
+func (e ListingPriceChangedEvent) OccurredAt() time.Time { return e.At }
+
 type ListingUpdatedEvent struct {
 	ListingID ListingID
 	At        time.Time
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"rentme/internal/domain/shared/events"
 )
@@ -12,18 +13,51 @@ import (
 var (
 	ErrGuestsLimit     = errors.New("listings: guests limit must be at least 1")
 	ErrNightsRange     = errors.New("listings: min nights must be <= max nights")
+	ErrMonthsRange     = errors.New("listings: min months must be <= max months")
 	ErrInvalidState    = errors.New("listings: invalid state transition")
 	ErrAddressRequired = errors.New("listings: address must be provided when activating")
 	ErrTitleRequired   = errors.New("listings: title is required")
 	ErrRate            = errors.New("listings: rate must be non-negative")
+	ErrCleaningFee     = errors.New("listings: cleaning fee must be non-negative")
+	ErrServiceFee      = errors.New("listings: service fee must be between 0 and 30 percent")
+	ErrWeekendPremium  = errors.New("listings: weekend premium must be between 0 and 100 percent")
+	ErrLongStay        = errors.New("listings: long-stay discount must be between 0 and 50 percent and needs a positive threshold")
 	ErrInvalidFloor    = errors.New("listings: floor must be >= 0")
 	ErrFloorsTotal     = errors.New("listings: floors total must be >= floor")
 	ErrRenovationScore = errors.New("listings: renovation score must be between 0 and 10")
 	ErrBuildingAge     = errors.New("listings: building age must be non-negative")
 	ErrRentalTerm      = errors.New("listings: rental term must be short_term or long_term")
 	ErrPhotoURL        = errors.New("listings: photo URL is required")
+	ErrPhotoNotFound   = errors.New("listings: photo does not belong to listing")
+	ErrPhotoOrder      = errors.New("listings: photo order must list every photo exactly once")
+	ErrTravelMinutes   = errors.New("listings: travel minutes must be between 0 and 240")
+	ErrPhotoTag        = errors.New("listings: photo tag must be one of kitchen, bathroom, bedroom, view, floorplan")
+	ErrCoHostIsOwner   = errors.New("listings: owner cannot be added as a co-host")
+	ErrRepublishAt     = errors.New("listings: republish time must be in the future")
+	ErrUnitGroupID     = errors.New("listings: unit group id must be at most 64 letters, digits, '-' or '_'")
+	ErrPhotoRequired   = errors.New("listings: at least one photo is required to publish")
+	ErrDescriptionLen  = errors.New("listings: description must be at least 200 characters to publish")
+	ErrRateRequired    = errors.New("listings: rate must be positive to publish")
+	ErrRestoreExpired  = errors.New("listings: deleted listing is past the restore window")
+	ErrListingNotFound = errors.New("listings: listing not found")
 )
 
+// MaxServiceFeePct caps the service fee a listing charges on the rent.
+const MaxServiceFeePct = 30
+
+// MaxWeekendPremiumPct caps the premium on Friday and Saturday nights.
+const MaxWeekendPremiumPct = 100
+
+// MinPublishDescriptionLen is the shortest description, in characters, a
+// listing can be published with.
+const MinPublishDescriptionLen = 200
+
+// DeletedRetention is how long a deleted listing can still be restored.
+const DeletedRetention = 30 * 24 * time.Hour
+
+// MaxLongStayDiscountPct caps the discount on stays past the long-stay threshold.
+const MaxLongStayDiscountPct = 50
+
 type ListingID string
 type HostID string
 
@@ -33,6 +67,7 @@ const (
 	ListingDraft     ListingState = "DRAFT"
 	ListingActive    ListingState = "ACTIVE"
 	ListingSuspended ListingState = "SUSPENDED"
+	ListingDeleted   ListingState = "DELETED"
 )
 
 type RentalTermType string
@@ -42,6 +77,28 @@ const (
 	RentalTermLong  RentalTermType = "long_term"
 )
 
+// PhotoTag names the room or amenity a photo shows so galleries can group by it.
+type PhotoTag string
+
+const (
+	PhotoTagKitchen   PhotoTag = "kitchen"
+	PhotoTagBathroom  PhotoTag = "bathroom"
+	PhotoTagBedroom   PhotoTag = "bedroom"
+	PhotoTagView      PhotoTag = "view"
+	PhotoTagFloorplan PhotoTag = "floorplan"
+)
+
+// ParsePhotoTag validates a raw tag. An empty value means "untagged".
+func ParsePhotoTag(raw string) (PhotoTag, error) {
+	tag := PhotoTag(strings.ToLower(strings.TrimSpace(raw)))
+	switch tag {
+	case "", PhotoTagKitchen, PhotoTagBathroom, PhotoTagBedroom, PhotoTagView, PhotoTagFloorplan:
+		return tag, nil
+	default:
+		return "", ErrPhotoTag
+	}
+}
+
 type Address struct {
 	Line1   string
 	Line2   string
@@ -62,6 +119,8 @@ func (a Address) Valid() bool {
 type Listing struct {
 	ID                   ListingID
 	Host                 HostID
+	CoHosts              []HostID
+	UnitGroupID          string
 	Title                string
 	Description          string
 	PropertyType         string
@@ -70,12 +129,17 @@ type Listing struct {
 	GuestsLimit          int
 	MinNights            int
 	MaxNights            int
+	MinMonths            int
+	MaxMonths            int
 	HouseRules           []string
 	CancellationPolicyID string
 	State                ListingState
 	Tags                 []string
 	Highlights           []string
 	RateRub              int64
+	CleaningFeeRub       int64
+	ServiceFeePct        float64
+	WeekendPremiumPct    float64
 	Bedrooms             int
 	Bathrooms            int
 	Floor                int
@@ -86,13 +150,26 @@ type Listing struct {
 	TravelMinutes        float64
 	TravelMode           string
 	RentalTermType       RentalTermType
+	VerifiedGuestsOnly   bool
 	ThumbnailURL         string
 	Rating               float64
 	Photos               []string
+	PhotoTags            map[string]PhotoTag
 	AvailableFrom        time.Time
+	RepublishAt          time.Time
+	DeletedAt            time.Time
 	Version              int64
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
+	// LongStayDiscountPct comes off the rent of nightly stays longer than
+	// LongStayThresholdNights nights.
+	LongStayDiscountPct     float64
+	LongStayThresholdNights int
+	// Changelog holds the entries recorded since the listing was loaded;
+	// repositories move them to the listing history on Save.
+	Changelog []ChangelogEntry
+	// PriceHistory holds the last MaxPriceHistory rate changes, oldest first.
+	PriceHistory []PriceChange
 	events.EventRecorder
 }
 
@@ -100,6 +177,12 @@ type ListingRepository interface {
 	ByID(ctx context.Context, id ListingID) (*Listing, error)
 	Save(ctx context.Context, listing *Listing) error
 	Search(ctx context.Context, params SearchParams) (SearchResult, error)
+	// History returns the saved changelog of a listing, newest first.
+	History(ctx context.Context, id ListingID) ([]ChangelogEntry, error)
+	// PricePerSqMMedians returns the median price per square meter of active
+	// listings in the given cities, by city and rental term. Groups too small
+	// to benchmark are left out.
+	PricePerSqMMedians(ctx context.Context, cities []string) (map[BenchmarkKey]float64, error)
 }
 
 type CreateListingParams struct {
@@ -113,11 +196,16 @@ type CreateListingParams struct {
 	GuestsLimit          int
 	MinNights            int
 	MaxNights            int
+	MinMonths            int
+	MaxMonths            int
 	HouseRules           []string
 	CancellationPolicyID string
 	Tags                 []string
 	Highlights           []string
 	RateRub              int64
+	CleaningFeeRub       int64
+	ServiceFeePct        float64
+	WeekendPremiumPct    float64
 	Bedrooms             int
 	Bathrooms            int
 	Floor                int
@@ -128,11 +216,15 @@ type CreateListingParams struct {
 	TravelMinutes        float64
 	TravelMode           string
 	RentalTermType       RentalTermType
+	VerifiedGuestsOnly   bool
 	ThumbnailURL         string
 	Rating               float64
 	AvailableFrom        time.Time
 	Now                  time.Time
 	Photos               []string
+
+	LongStayDiscountPct     float64
+	LongStayThresholdNights int
 }
 
 func NewListing(params CreateListingParams) (*Listing, error) {
@@ -154,9 +246,18 @@ func NewListing(params CreateListingParams) (*Listing, error) {
 	if params.MaxNights > 0 && params.MinNights > params.MaxNights {
 		return nil, ErrNightsRange
 	}
+	if err := validateMonthsRange(params.MinMonths, params.MaxMonths); err != nil {
+		return nil, err
+	}
 	if params.RateRub < 0 {
 		return nil, ErrRate
 	}
+	if err := validateFees(params.CleaningFeeRub, params.ServiceFeePct, params.WeekendPremiumPct); err != nil {
+		return nil, err
+	}
+	if err := validateLongStay(params.LongStayDiscountPct, params.LongStayThresholdNights); err != nil {
+		return nil, err
+	}
 	if params.Floor < 0 {
 		return nil, ErrInvalidFloor
 	}
@@ -172,11 +273,18 @@ func NewListing(params CreateListingParams) (*Listing, error) {
 	if params.TravelMinutes < 0 {
 		params.TravelMinutes = 0
 	}
+	if params.TravelMinutes > MaxTravelMinutes {
+		return nil, ErrTravelMinutes
+	}
+	address, _, err := NormalizeAddress(params.Address)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRentalTerm(params.RentalTermType); err != nil {
+		return nil, err
+	}
 	rentalTerm := normalizeRentalTerm(params.RentalTermType)
 	if rentalTerm == "" {
-		if params.RentalTermType != "" {
-			return nil, ErrRentalTerm
-		}
 		rentalTerm = RentalTermLong
 	}
 	availableFrom := params.AvailableFrom
@@ -190,17 +298,22 @@ func NewListing(params CreateListingParams) (*Listing, error) {
 		Title:                strings.TrimSpace(params.Title),
 		Description:          strings.TrimSpace(params.Description),
 		PropertyType:         strings.TrimSpace(params.PropertyType),
-		Address:              params.Address,
+		Address:              address,
 		Amenities:            append([]string(nil), params.Amenities...),
 		GuestsLimit:          params.GuestsLimit,
 		MinNights:            params.MinNights,
 		MaxNights:            params.MaxNights,
+		MinMonths:            params.MinMonths,
+		MaxMonths:            params.MaxMonths,
 		HouseRules:           append([]string(nil), params.HouseRules...),
 		CancellationPolicyID: params.CancellationPolicyID,
 		State:                ListingDraft,
 		Tags:                 append([]string(nil), params.Tags...),
 		Highlights:           append([]string(nil), params.Highlights...),
 		RateRub:              params.RateRub,
+		CleaningFeeRub:       params.CleaningFeeRub,
+		ServiceFeePct:        params.ServiceFeePct,
+		WeekendPremiumPct:    params.WeekendPremiumPct,
 		Bedrooms:             params.Bedrooms,
 		Bathrooms:            params.Bathrooms,
 		Floor:                params.Floor,
@@ -211,12 +324,16 @@ func NewListing(params CreateListingParams) (*Listing, error) {
 		TravelMinutes:        params.TravelMinutes,
 		TravelMode:           strings.TrimSpace(strings.ToLower(params.TravelMode)),
 		RentalTermType:       rentalTerm,
+		VerifiedGuestsOnly:   params.VerifiedGuestsOnly,
 		ThumbnailURL:         strings.TrimSpace(params.ThumbnailURL),
 		Rating:               params.Rating,
 		Photos:               append([]string(nil), params.Photos...),
 		AvailableFrom:        availableFrom.UTC(),
 		CreatedAt:            params.Now.UTC(),
 		UpdatedAt:            params.Now.UTC(),
+
+		LongStayDiscountPct:     params.LongStayDiscountPct,
+		LongStayThresholdNights: params.LongStayThresholdNights,
 	}
 
 	listing.Record(newListingCreatedEvent(listing.ID, listing.Host, listing.CreatedAt))
@@ -237,6 +354,7 @@ func (l *Listing) Activate(now time.Time) error {
 		return ErrNightsRange
 	}
 	l.State = ListingActive
+	l.RepublishAt = time.Time{}
 	l.UpdatedAt = now.UTC()
 	l.Record(newListingActivatedEvent(l.ID, l.Host, l.UpdatedAt))
 	return nil
@@ -248,10 +366,62 @@ func (l *Listing) Suspend(now time.Time, reason string) error {
 	}
 	l.State = ListingSuspended
 	l.UpdatedAt = now.UTC()
-	l.Record(newListingSuspendedEvent(l.ID, reason, l.UpdatedAt))
+	l.Record(newListingSuspendedEvent(l.ID, l.Host, reason, l.UpdatedAt))
 	return nil
 }
 
+// Delete removes a draft or suspended listing from every catalog. It can be
+// restored within DeletedRetention.
+func (l *Listing) Delete(now time.Time) error {
+	if l.State != ListingDraft && l.State != ListingSuspended {
+		return ErrInvalidState
+	}
+	l.State = ListingDeleted
+	l.RepublishAt = time.Time{}
+	l.DeletedAt = now.UTC()
+	l.UpdatedAt = l.DeletedAt
+	l.Record(ListingDeletedEvent{ListingID: l.ID, HostID: l.Host, At: l.UpdatedAt})
+	return nil
+}
+
+// Restore brings a deleted listing back as a draft.
+func (l *Listing) Restore(now time.Time) error {
+	if l.State != ListingDeleted {
+		return ErrInvalidState
+	}
+	if now.Sub(l.DeletedAt) > DeletedRetention {
+		return ErrRestoreExpired
+	}
+	l.State = ListingDraft
+	l.DeletedAt = time.Time{}
+	l.UpdatedAt = now.UTC()
+	l.Record(ListingRestoredEvent{ListingID: l.ID, HostID: l.Host, At: l.UpdatedAt})
+	return nil
+}
+
+// ScheduleRepublish asks for a suspended listing to be activated again at the
+// given time. The time is kept to the second so it survives storage as-is.
+func (l *Listing) ScheduleRepublish(at, now time.Time) error {
+	if l.State != ListingSuspended {
+		return ErrInvalidState
+	}
+	if !at.After(now) {
+		return ErrRepublishAt
+	}
+	l.RepublishAt = at.UTC().Truncate(time.Second)
+	l.UpdatedAt = now.UTC()
+	return nil
+}
+
+// CancelRepublish drops a pending republish schedule, if any.
+func (l *Listing) CancelRepublish(now time.Time) {
+	if l.RepublishAt.IsZero() {
+		return
+	}
+	l.RepublishAt = time.Time{}
+	l.UpdatedAt = now.UTC()
+}
+
 func (l *Listing) UpdateDetails(title, description string, rules, amenities []string, now time.Time) error {
 	if strings.TrimSpace(title) == "" {
 		return ErrTitleRequired
@@ -274,6 +444,87 @@ func (l *Listing) UpdateRating(rating float64, now time.Time) {
 	l.UpdatedAt = now.UTC()
 }
 
+// SetCoHosts replaces the co-host team of the listing. Blank and duplicate
+// ids are dropped.
+func (l *Listing) SetCoHosts(ids []HostID, now time.Time) error {
+	seen := make(map[HostID]struct{}, len(ids))
+	team := make([]HostID, 0, len(ids))
+	for _, id := range ids {
+		id = HostID(strings.TrimSpace(string(id)))
+		if id == "" {
+			continue
+		}
+		if id == l.Host {
+			return ErrCoHostIsOwner
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		team = append(team, id)
+	}
+	l.CoHosts = team
+	l.UpdatedAt = now.UTC()
+	l.Record(newListingUpdatedEvent(l.ID, l.UpdatedAt))
+	return nil
+}
+
+// SetUnitGroup links the listing to the other listings of the owner that
+// share id, i.e. rent out the same physical unit, so a booking on one blocks
+// the calendars of the others. An empty id unlinks it.
+func (l *Listing) SetUnitGroup(id string, now time.Time) error {
+	id = strings.TrimSpace(id)
+	if !validUnitGroupID(id) {
+		return ErrUnitGroupID
+	}
+	l.UnitGroupID = id
+	l.UpdatedAt = now.UTC()
+	l.Record(newListingUpdatedEvent(l.ID, l.UpdatedAt))
+	return nil
+}
+
+func validUnitGroupID(id string) bool {
+	if len(id) > 64 {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validateMonthsRange checks the stay length bounds of long-term rentals;
+// zero means no bound.
+func validateMonthsRange(minMonths, maxMonths int) error {
+	if minMonths < 0 || maxMonths < 0 {
+		return ErrMonthsRange
+	}
+	if maxMonths > 0 && minMonths > maxMonths {
+		return ErrMonthsRange
+	}
+	return nil
+}
+
+// IsHostTeamMember reports whether the user is the owner or a co-host.
+func (l *Listing) IsHostTeamMember(id HostID) bool {
+	if id == "" {
+		return false
+	}
+	if l.Host == id {
+		return true
+	}
+	for _, coHost := range l.CoHosts {
+		if coHost == id {
+			return true
+		}
+	}
+	return false
+}
+
 type UpdateListingParams struct {
 	Title                string
 	Description          string
@@ -288,7 +539,12 @@ type UpdateListingParams struct {
 	GuestsLimit          int
 	MinNights            int
 	MaxNights            int
+	MinMonths            int
+	MaxMonths            int
 	RateRub              int64
+	CleaningFeeRub       int64
+	ServiceFeePct        float64
+	WeekendPremiumPct    float64
 	Bedrooms             int
 	Bathrooms            int
 	Floor                int
@@ -300,8 +556,13 @@ type UpdateListingParams struct {
 	TravelMode           string
 	AvailableFrom        time.Time
 	RentalTermType       RentalTermType
+	VerifiedGuestsOnly   bool
 	Photos               []string
+	ChangedBy            string
 	Now                  time.Time
+
+	LongStayDiscountPct     float64
+	LongStayThresholdNights int
 }
 
 func (l *Listing) UpdateAttributes(params UpdateListingParams) error {
@@ -310,6 +571,7 @@ func (l *Listing) UpdateAttributes(params UpdateListingParams) error {
 		now = time.Now()
 	}
 	now = now.UTC()
+	before := *l
 
 	if strings.TrimSpace(params.Title) == "" {
 		return ErrTitleRequired
@@ -323,9 +585,18 @@ func (l *Listing) UpdateAttributes(params UpdateListingParams) error {
 	if params.MaxNights > 0 && params.MinNights > params.MaxNights {
 		return ErrNightsRange
 	}
+	if err := validateMonthsRange(params.MinMonths, params.MaxMonths); err != nil {
+		return err
+	}
 	if params.RateRub < 0 {
 		return ErrRate
 	}
+	if err := validateFees(params.CleaningFeeRub, params.ServiceFeePct, params.WeekendPremiumPct); err != nil {
+		return err
+	}
+	if err := validateLongStay(params.LongStayDiscountPct, params.LongStayThresholdNights); err != nil {
+		return err
+	}
 	if params.Floor < 0 {
 		return ErrInvalidFloor
 	}
@@ -338,21 +609,27 @@ func (l *Listing) UpdateAttributes(params UpdateListingParams) error {
 	if params.BuildingAgeYears < 0 {
 		return ErrBuildingAge
 	}
+	if err := validateRentalTerm(params.RentalTermType); err != nil {
+		return err
+	}
 	if params.RentalTermType != "" {
-		term := normalizeRentalTerm(params.RentalTermType)
-		if term == "" {
-			return ErrRentalTerm
-		}
-		l.RentalTermType = term
+		l.RentalTermType = normalizeRentalTerm(params.RentalTermType)
 	}
 	if params.TravelMinutes < 0 {
 		params.TravelMinutes = 0
 	}
+	if params.TravelMinutes > MaxTravelMinutes {
+		return ErrTravelMinutes
+	}
+	address, _, err := NormalizeAddress(params.Address)
+	if err != nil {
+		return err
+	}
 
 	l.Title = strings.TrimSpace(params.Title)
 	l.Description = strings.TrimSpace(params.Description)
 	l.PropertyType = strings.TrimSpace(params.PropertyType)
-	l.Address = params.Address
+	l.Address = address
 	l.Amenities = append([]string(nil), params.Amenities...)
 	l.HouseRules = append([]string(nil), params.HouseRules...)
 	l.Tags = append([]string(nil), params.Tags...)
@@ -361,7 +638,14 @@ func (l *Listing) UpdateAttributes(params UpdateListingParams) error {
 	l.GuestsLimit = params.GuestsLimit
 	l.MinNights = params.MinNights
 	l.MaxNights = params.MaxNights
+	l.MinMonths = params.MinMonths
+	l.MaxMonths = params.MaxMonths
 	l.RateRub = params.RateRub
+	l.CleaningFeeRub = params.CleaningFeeRub
+	l.ServiceFeePct = params.ServiceFeePct
+	l.WeekendPremiumPct = params.WeekendPremiumPct
+	l.LongStayDiscountPct = params.LongStayDiscountPct
+	l.LongStayThresholdNights = params.LongStayThresholdNights
 	l.Bedrooms = params.Bedrooms
 	l.Bathrooms = params.Bathrooms
 	l.Floor = params.Floor
@@ -371,16 +655,176 @@ func (l *Listing) UpdateAttributes(params UpdateListingParams) error {
 	l.AreaSquareMeters = params.AreaSquareMeters
 	l.TravelMinutes = params.TravelMinutes
 	l.TravelMode = strings.TrimSpace(strings.ToLower(params.TravelMode))
+	l.VerifiedGuestsOnly = params.VerifiedGuestsOnly
 	l.ThumbnailURL = strings.TrimSpace(params.ThumbnailURL)
 	if !params.AvailableFrom.IsZero() {
 		l.AvailableFrom = params.AvailableFrom.UTC()
 	}
 	l.Photos = append([]string(nil), params.Photos...)
+	l.prunePhotoTags()
 	l.UpdatedAt = now
+	l.recordPriceChange(before.RateRub, PriceSourceManual, params.ChangedBy, now)
+	l.recordChanges(before, params.ChangedBy, now)
 	l.Record(newListingUpdatedEvent(l.ID, now))
 	return nil
 }
 
+// ListingPatch lists the attributes to change; nil fields keep their
+// current value.
+type ListingPatch struct {
+	Title                *string
+	Description          *string
+	PropertyType         *string
+	Address              *Address
+	Amenities            *[]string
+	HouseRules           *[]string
+	Tags                 *[]string
+	Highlights           *[]string
+	ThumbnailURL         *string
+	CancellationPolicyID *string
+	GuestsLimit          *int
+	MinNights            *int
+	MaxNights            *int
+	MinMonths            *int
+	MaxMonths            *int
+	RateRub              *int64
+	CleaningFeeRub       *int64
+	ServiceFeePct        *float64
+	WeekendPremiumPct    *float64
+	Bedrooms             *int
+	Bathrooms            *int
+	Floor                *int
+	FloorsTotal          *int
+	RenovationScore      *int
+	BuildingAgeYears     *int
+	AreaSquareMeters     *float64
+	TravelMinutes        *float64
+	TravelMode           *string
+	AvailableFrom        *time.Time
+	RentalTermType       *RentalTermType
+	VerifiedGuestsOnly   *bool
+	Photos               *[]string
+
+	LongStayDiscountPct     *float64
+	LongStayThresholdNights *int
+}
+
+// Empty reports whether the patch changes nothing.
+func (p ListingPatch) Empty() bool {
+	return p == ListingPatch{}
+}
+
+// ApplyPatch changes only the attributes set in the patch. The merged result
+// goes through UpdateAttributes, so a patch is held to the same rules as a
+// full update.
+func (l *Listing) ApplyPatch(patch ListingPatch, changedBy string, now time.Time) error {
+	params := UpdateListingParams{
+		Title:                l.Title,
+		Description:          l.Description,
+		PropertyType:         l.PropertyType,
+		Address:              l.Address,
+		Amenities:            l.Amenities,
+		HouseRules:           l.HouseRules,
+		Tags:                 l.Tags,
+		Highlights:           l.Highlights,
+		ThumbnailURL:         l.ThumbnailURL,
+		CancellationPolicyID: l.CancellationPolicyID,
+		GuestsLimit:          l.GuestsLimit,
+		MinNights:            l.MinNights,
+		MaxNights:            l.MaxNights,
+		MinMonths:            l.MinMonths,
+		MaxMonths:            l.MaxMonths,
+		RateRub:              l.RateRub,
+		CleaningFeeRub:       l.CleaningFeeRub,
+		ServiceFeePct:        l.ServiceFeePct,
+		WeekendPremiumPct:    l.WeekendPremiumPct,
+		Bedrooms:             l.Bedrooms,
+		Bathrooms:            l.Bathrooms,
+		Floor:                l.Floor,
+		FloorsTotal:          l.FloorsTotal,
+		RenovationScore:      l.RenovationScore,
+		BuildingAgeYears:     l.BuildingAgeYears,
+		AreaSquareMeters:     l.AreaSquareMeters,
+		TravelMinutes:        l.TravelMinutes,
+		TravelMode:           l.TravelMode,
+		AvailableFrom:        l.AvailableFrom,
+		RentalTermType:       l.RentalTermType,
+		VerifiedGuestsOnly:   l.VerifiedGuestsOnly,
+		Photos:               l.Photos,
+		ChangedBy:            changedBy,
+		Now:                  now,
+
+		LongStayDiscountPct:     l.LongStayDiscountPct,
+		LongStayThresholdNights: l.LongStayThresholdNights,
+	}
+	setIfPresent(&params.Title, patch.Title)
+	setIfPresent(&params.Description, patch.Description)
+	setIfPresent(&params.PropertyType, patch.PropertyType)
+	setIfPresent(&params.Address, patch.Address)
+	setIfPresent(&params.Amenities, patch.Amenities)
+	setIfPresent(&params.HouseRules, patch.HouseRules)
+	setIfPresent(&params.Tags, patch.Tags)
+	setIfPresent(&params.Highlights, patch.Highlights)
+	setIfPresent(&params.ThumbnailURL, patch.ThumbnailURL)
+	setIfPresent(&params.CancellationPolicyID, patch.CancellationPolicyID)
+	setIfPresent(&params.GuestsLimit, patch.GuestsLimit)
+	setIfPresent(&params.MinNights, patch.MinNights)
+	setIfPresent(&params.MaxNights, patch.MaxNights)
+	setIfPresent(&params.MinMonths, patch.MinMonths)
+	setIfPresent(&params.MaxMonths, patch.MaxMonths)
+	setIfPresent(&params.RateRub, patch.RateRub)
+	setIfPresent(&params.CleaningFeeRub, patch.CleaningFeeRub)
+	setIfPresent(&params.ServiceFeePct, patch.ServiceFeePct)
+	setIfPresent(&params.WeekendPremiumPct, patch.WeekendPremiumPct)
+	setIfPresent(&params.LongStayDiscountPct, patch.LongStayDiscountPct)
+	setIfPresent(&params.LongStayThresholdNights, patch.LongStayThresholdNights)
+	setIfPresent(&params.Bedrooms, patch.Bedrooms)
+	setIfPresent(&params.Bathrooms, patch.Bathrooms)
+	setIfPresent(&params.Floor, patch.Floor)
+	setIfPresent(&params.FloorsTotal, patch.FloorsTotal)
+	setIfPresent(&params.RenovationScore, patch.RenovationScore)
+	setIfPresent(&params.BuildingAgeYears, patch.BuildingAgeYears)
+	setIfPresent(&params.AreaSquareMeters, patch.AreaSquareMeters)
+	setIfPresent(&params.TravelMinutes, patch.TravelMinutes)
+	setIfPresent(&params.TravelMode, patch.TravelMode)
+	setIfPresent(&params.AvailableFrom, patch.AvailableFrom)
+	setIfPresent(&params.RentalTermType, patch.RentalTermType)
+	setIfPresent(&params.VerifiedGuestsOnly, patch.VerifiedGuestsOnly)
+	setIfPresent(&params.Photos, patch.Photos)
+	return l.UpdateAttributes(params)
+}
+
+func validateFees(cleaningFeeRub int64, serviceFeePct, weekendPremiumPct float64) error {
+	if cleaningFeeRub < 0 {
+		return ErrCleaningFee
+	}
+	if serviceFeePct < 0 || serviceFeePct > MaxServiceFeePct {
+		return ErrServiceFee
+	}
+	if weekendPremiumPct < 0 || weekendPremiumPct > MaxWeekendPremiumPct {
+		return ErrWeekendPremium
+	}
+	return nil
+}
+
+// validateLongStay allows no discount, or up to MaxLongStayDiscountPct once a
+// stay runs past a positive threshold.
+func validateLongStay(discountPct float64, thresholdNights int) error {
+	if discountPct < 0 || discountPct > MaxLongStayDiscountPct || thresholdNights < 0 {
+		return ErrLongStay
+	}
+	if discountPct > 0 && thresholdNights == 0 {
+		return ErrLongStay
+	}
+	return nil
+}
+
+func setIfPresent[T any](dst *T, value *T) {
+	if value != nil {
+		*dst = *value
+	}
+}
+
 func (l *Listing) AddPhoto(url string, now time.Time) error {
 	cleaned := strings.TrimSpace(url)
 	if cleaned == "" {
@@ -403,6 +847,148 @@ func (l *Listing) AddPhoto(url string, now time.Time) error {
 	return nil
 }
 
+// RemovePhoto drops a photo; when it was the thumbnail, the first remaining
+// photo takes its place.
+func (l *Listing) RemovePhoto(url string, now time.Time) error {
+	cleaned := strings.TrimSpace(url)
+	idx := -1
+	for i, existing := range l.Photos {
+		if existing == cleaned {
+			idx = i
+			break
+		}
+	}
+	if cleaned == "" || idx < 0 {
+		return ErrPhotoNotFound
+	}
+	l.Photos = append(l.Photos[:idx:idx], l.Photos[idx+1:]...)
+	delete(l.PhotoTags, cleaned)
+	if l.ThumbnailURL == cleaned {
+		l.ThumbnailURL = ""
+		if len(l.Photos) > 0 {
+			l.ThumbnailURL = l.Photos[0]
+		}
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+	l.UpdatedAt = now.UTC()
+	l.Record(newListingUpdatedEvent(l.ID, l.UpdatedAt))
+	return nil
+}
+
+// ReorderPhotos applies a new photo order. The order must contain every
+// existing photo exactly once; the first photo becomes the thumbnail unless
+// the thumbnail points outside the gallery.
+func (l *Listing) ReorderPhotos(urls []string, now time.Time) error {
+	if len(urls) != len(l.Photos) {
+		return ErrPhotoOrder
+	}
+	known := make(map[string]bool, len(l.Photos))
+	for _, existing := range l.Photos {
+		known[existing] = false
+	}
+	ordered := make([]string, 0, len(urls))
+	for _, url := range urls {
+		cleaned := strings.TrimSpace(url)
+		used, ok := known[cleaned]
+		if !ok {
+			return ErrPhotoNotFound
+		}
+		if used {
+			return ErrPhotoOrder
+		}
+		known[cleaned] = true
+		ordered = append(ordered, cleaned)
+	}
+	_, thumbnailInGallery := known[l.ThumbnailURL]
+	l.Photos = ordered
+	if len(ordered) > 0 && (l.ThumbnailURL == "" || thumbnailInGallery) {
+		l.ThumbnailURL = ordered[0]
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+	l.UpdatedAt = now.UTC()
+	l.Record(newListingUpdatedEvent(l.ID, l.UpdatedAt))
+	return nil
+}
+
+// TagPhoto sets the room tag of a gallery photo; an empty tag clears it.
+func (l *Listing) TagPhoto(url string, tag PhotoTag, now time.Time) error {
+	cleaned := strings.TrimSpace(url)
+	found := false
+	for _, existing := range l.Photos {
+		if existing == cleaned {
+			found = true
+			break
+		}
+	}
+	if cleaned == "" || !found {
+		return ErrPhotoNotFound
+	}
+	tag, err := ParsePhotoTag(string(tag))
+	if err != nil {
+		return err
+	}
+	if tag == "" {
+		delete(l.PhotoTags, cleaned)
+	} else {
+		if l.PhotoTags == nil {
+			l.PhotoTags = make(map[string]PhotoTag)
+		}
+		l.PhotoTags[cleaned] = tag
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+	l.UpdatedAt = now.UTC()
+	l.Record(newListingUpdatedEvent(l.ID, l.UpdatedAt))
+	return nil
+}
+
+// PublishIssues lists the content rules the listing falls short of: a photo
+// (a thumbnail counts), a description of MinPublishDescriptionLen characters
+// and a positive rate. Publishing requires none; listings activated before the
+// rules existed may still have some.
+func (l *Listing) PublishIssues() []error {
+	var issues []error
+	if len(l.Photos) == 0 && strings.TrimSpace(l.ThumbnailURL) == "" {
+		issues = append(issues, ErrPhotoRequired)
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(l.Description)) < MinPublishDescriptionLen {
+		issues = append(issues, ErrDescriptionLen)
+	}
+	if l.RateRub <= 0 {
+		issues = append(issues, ErrRateRequired)
+	}
+	return issues
+}
+
+// UntaggedPhotos counts gallery photos without a room tag.
+func (l *Listing) UntaggedPhotos() int {
+	count := 0
+	for _, url := range l.Photos {
+		if l.PhotoTags[url] == "" {
+			count++
+		}
+	}
+	return count
+}
+
+func (l *Listing) prunePhotoTags() {
+	if len(l.PhotoTags) == 0 {
+		return
+	}
+	kept := make(map[string]PhotoTag, len(l.PhotoTags))
+	for _, url := range l.Photos {
+		if tag, ok := l.PhotoTags[url]; ok {
+			kept[url] = tag
+		}
+	}
+	l.PhotoTags = kept
+}
+
 func newListingCreatedEvent(id ListingID, host HostID, at time.Time) events.DomainEvent {
 	return ListingCreatedEvent{ListingID: id, HostID: host, At: at}
 }
@@ -411,14 +997,23 @@ func newListingActivatedEvent(id ListingID, host HostID, at time.Time) events.Do
 	return ListingActivatedEvent{ListingID: id, HostID: host, At: at}
 }
 
-func newListingSuspendedEvent(id ListingID, reason string, at time.Time) events.DomainEvent {
-	return ListingSuspendedEvent{ListingID: id, Reason: reason, At: at}
+func newListingSuspendedEvent(id ListingID, host HostID, reason string, at time.Time) events.DomainEvent {
+	return ListingSuspendedEvent{ListingID: id, HostID: host, Reason: reason, At: at}
 }
 
 func newListingUpdatedEvent(id ListingID, at time.Time) events.DomainEvent {
 	return ListingUpdatedEvent{ListingID: id, At: at}
 }
 
+// validateRentalTerm accepts an empty term, which callers default or leave
+// unchanged, and the known terms in any case.
+func validateRentalTerm(term RentalTermType) error {
+	if term == "" || normalizeRentalTerm(term) != "" {
+		return nil
+	}
+	return ErrRentalTerm
+}
+
 func normalizeRentalTerm(value RentalTermType) RentalTermType {
 	switch strings.TrimSpace(strings.ToLower(string(value))) {
 	case string(RentalTermShort):
@@ -0,0 +1,84 @@
+package listings
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var termNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func termListingParams(term RentalTermType) CreateListingParams {
+	return CreateListingParams{
+		ID:             "listing-1",
+		Host:           "host-1",
+		Title:          "Loft",
+		GuestsLimit:    2,
+		RateRub:        5000,
+		RentalTermType: term,
+		Now:            termNow,
+	}
+}
+
+func TestValidateRentalTerm(t *testing.T) {
+	cases := []struct {
+		term RentalTermType
+		want error
+	}{
+		{"", nil},
+		{RentalTermShort, nil},
+		{RentalTermLong, nil},
+		{" Long_Term ", nil},
+		{"weekly", ErrRentalTerm},
+		{"monthly", ErrRentalTerm},
+	}
+	for _, tc := range cases {
+		if err := validateRentalTerm(tc.term); !errors.Is(err, tc.want) {
+			t.Errorf("validateRentalTerm(%q) = %v, want %v", tc.term, err, tc.want)
+		}
+	}
+}
+
+func TestNewListingRentalTerm(t *testing.T) {
+	if _, err := NewListing(termListingParams("weekly")); !errors.Is(err, ErrRentalTerm) {
+		t.Fatalf("weekly: err = %v, want ErrRentalTerm", err)
+	}
+	listing, err := NewListing(termListingParams(""))
+	if err != nil {
+		t.Fatalf("empty term: %v", err)
+	}
+	if listing.RentalTermType != RentalTermLong {
+		t.Fatalf("empty term defaulted to %q, want %q", listing.RentalTermType, RentalTermLong)
+	}
+	listing, err = NewListing(termListingParams(" SHORT_TERM"))
+	if err != nil {
+		t.Fatalf("short term: %v", err)
+	}
+	if listing.RentalTermType != RentalTermShort {
+		t.Fatalf("term = %q, want %q", listing.RentalTermType, RentalTermShort)
+	}
+}
+
+func TestUpdateAttributesRentalTerm(t *testing.T) {
+	listing, err := NewListing(termListingParams(RentalTermShort))
+	if err != nil {
+		t.Fatalf("new listing: %v", err)
+	}
+	update := UpdateListingParams{Title: "Loft", GuestsLimit: 2, RateRub: 5000, Now: termNow}
+
+	update.RentalTermType = "weekly"
+	if err := listing.UpdateAttributes(update); !errors.Is(err, ErrRentalTerm) {
+		t.Fatalf("weekly: err = %v, want ErrRentalTerm", err)
+	}
+	if listing.RentalTermType != RentalTermShort {
+		t.Fatalf("rejected update changed the term to %q", listing.RentalTermType)
+	}
+
+	update.RentalTermType = ""
+	if err := listing.UpdateAttributes(update); err != nil {
+		t.Fatalf("empty term: %v", err)
+	}
+	if listing.RentalTermType != RentalTermShort {
+		t.Fatalf("empty term changed the term to %q, want it kept", listing.RentalTermType)
+	}
+}
@@ -0,0 +1,77 @@
+package listings
+
+import (
+	"slices"
+	"time"
+)
+
+// PriceChangeSource tells a manual rate edit from an accepted suggestion.
+type PriceChangeSource string
+
+const (
+	PriceSourceManual    PriceChangeSource = "manual"
+	PriceSourceMLApplied PriceChangeSource = "ml_applied"
+)
+
+// MaxPriceHistory is how many rate changes a listing keeps; older ones are
+// dropped.
+const MaxPriceHistory = 50
+
+// PriceChange records one change of RateRub.
+type PriceChange struct {
+	OldRateRub int64
+	NewRateRub int64
+	Source     PriceChangeSource
+	ChangedBy  string
+	At         time.Time
+}
+
+// ApplySuggestedRate sets the rate to the price suggestion the host accepted.
+func (l *Listing) ApplySuggestedRate(rate int64, changedBy string, now time.Time) error {
+	if rate <= 0 {
+		return ErrRate
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.UTC()
+	before := *l
+	l.RateRub = rate
+	l.UpdatedAt = now
+	l.recordPriceChange(before.RateRub, PriceSourceMLApplied, changedBy, now)
+	l.recordChanges(before, changedBy, now)
+	l.Record(newListingUpdatedEvent(l.ID, now))
+	return nil
+}
+
+// recordPriceChange appends to the price history when the rate differs from
+// old, keeping at most MaxPriceHistory entries.
+func (l *Listing) recordPriceChange(old int64, source PriceChangeSource, changedBy string, now time.Time) {
+	if old == l.RateRub {
+		return
+	}
+	l.PriceHistory = append(l.PriceHistory, PriceChange{
+		OldRateRub: old,
+		NewRateRub: l.RateRub,
+		Source:     source,
+		ChangedBy:  changedBy,
+		At:         now,
+	})
+	if overflow := len(l.PriceHistory) - MaxPriceHistory; overflow > 0 {
+		l.PriceHistory = append([]PriceChange(nil), l.PriceHistory[overflow:]...)
+	}
+	l.Record(ListingPriceChangedEvent{
+		ListingID:  l.ID,
+		OldRateRub: old,
+		NewRateRub: l.RateRub,
+		Source:     source,
+		At:         now,
+	})
+}
+
+// PriceHistoryNewestFirst returns a copy of the price history, newest first.
+func (l *Listing) PriceHistoryNewestFirst() []PriceChange {
+	entries := slices.Clone(l.PriceHistory)
+	slices.Reverse(entries)
+	return entries
+}
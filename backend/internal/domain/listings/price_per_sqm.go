@@ -0,0 +1,53 @@
+package listings
+
+import (
+	"sort"
+	"strings"
+)
+
+// minBenchmarkSample is how many comparable listings a city needs before its
+// median price per square meter is trusted for the good value badge.
+const minBenchmarkSample = 3
+
+// PricePerSqM returns the rate per square meter in the listing's price unit;
+// ok is false when the area is unknown.
+func (l *Listing) PricePerSqM() (float64, bool) {
+	if l == nil || l.AreaSquareMeters <= 0 || l.RateRub <= 0 {
+		return 0, false
+	}
+	return float64(l.RateRub) / l.AreaSquareMeters, true
+}
+
+// BenchmarkKey groups listings whose prices per square meter compare: nightly
+// and monthly rates of the same city are benchmarked separately.
+type BenchmarkKey struct {
+	City string
+	Term RentalTermType
+}
+
+// BenchmarkKeyFor returns the group the listing is compared within.
+func BenchmarkKeyFor(l *Listing) BenchmarkKey {
+	return BenchmarkKey{City: strings.ToLower(strings.TrimSpace(l.Address.City)), Term: l.RentalTermType}
+}
+
+// MedianPricePerSqM returns the median of values, or zero when the sample is
+// too small to benchmark against.
+func MedianPricePerSqM(values []float64) float64 {
+	if len(values) < minBenchmarkSample {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// IsGoodValue reports whether the listing is cheaper per square meter than
+// the median of its city.
+func (l *Listing) IsGoodValue(median float64) bool {
+	price, ok := l.PricePerSqM()
+	return ok && median > 0 && price < median
+}
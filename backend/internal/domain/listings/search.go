@@ -1,6 +1,7 @@
 package listings
 
 import (
+	"math"
 	"strings"
 	"time"
 )
@@ -14,16 +15,25 @@ const (
 	SortByRating    CatalogSort = "rating_desc"
 	SortByNewest    CatalogSort = "newest"
 	SortByUpdated   CatalogSort = "updated"
+	// SortByPricePerSqM puts listings with a known area first, cheapest per
+	// square meter first; the rest follow by price.
+	SortByPricePerSqM CatalogSort = "price_per_sq_m"
 
 	defaultSearchLimit = 24
 	maxSearchLimit     = 60
+
+	// MaxSearchRadiusKm caps the geo radius filter.
+	MaxSearchRadiusKm = 100.0
 )
 
 // SearchParams describe catalog filters and paging options.
 type SearchParams struct {
-	Host          HostID
+	Host        HostID
+	UnitGroupID string
+	// States limits the listing states; without it deleted listings are left out.
 	States        []ListingState
 	City          string
+	Cities        []string
 	Region        string
 	Country       string
 	LocationQuery string
@@ -32,20 +42,66 @@ type SearchParams struct {
 	MinGuests     int
 	PriceMinRub   int64
 	PriceMaxRub   int64
-	PropertyTypes []string
-	RentalTerms   []RentalTermType
-	CheckIn       time.Time
-	CheckOut      time.Time
-	Sort          CatalogSort
-	Limit         int
-	Offset        int
-	OnlyActive    bool
+	// MaxPricePerSqM keeps listings with a known area priced at most this
+	// much per square meter; listings without area are excluded while set.
+	MaxPricePerSqM float64
+	PropertyTypes  []string
+	RentalTerms    []RentalTermType
+	CheckIn        time.Time
+	CheckOut       time.Time
+	// MaxTravelMinutes keeps listings whose commute in TravelMode is within
+	// the bound; CommuteFrom enables estimates for listings without declared minutes.
+	MaxTravelMinutes float64
+	TravelMode       string
+	CommuteFrom      *GeoPoint
+	// Lat, Lon and RadiusKm keep listings within RadiusKm of the point;
+	// listings without coordinates are excluded while the filter is set.
+	Lat      float64
+	Lon      float64
+	RadiusKm float64
+	// BBox keeps listings inside the box; like the radius filter it excludes
+	// listings without coordinates.
+	BBox       *BoundingBox
+	Sort       CatalogSort
+	Limit      int
+	Offset     int
+	OnlyActive bool
+	// MaxLimit raises the page size cap for internal callers such as map
+	// clustering; zero keeps the catalog default.
+	MaxLimit int
+}
+
+// BoundingBox is a map viewport. West may exceed East when the box crosses
+// the antimeridian.
+type BoundingBox struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// Valid reports whether the box has sane bounds.
+func (b BoundingBox) Valid() bool {
+	return b.South >= -90 && b.North <= 90 && b.South < b.North &&
+		b.West >= -180 && b.West <= 180 && b.East >= -180 && b.East <= 180 && b.West != b.East
+}
+
+// Contains reports whether the point lies inside the box.
+func (b BoundingBox) Contains(p GeoPoint) bool {
+	if p.Lat < b.South || p.Lat > b.North {
+		return false
+	}
+	if b.West <= b.East {
+		return p.Lon >= b.West && p.Lon <= b.East
+	}
+	return p.Lon >= b.West || p.Lon <= b.East
 }
 
 // Normalized returns a sanitized copy of params.
 func (p SearchParams) Normalized() SearchParams {
 	normalized := p
 	normalized.City = strings.TrimSpace(strings.ToLower(normalized.City))
+	normalized.Cities = normalizeTokens(normalized.Cities)
 	normalized.Region = strings.TrimSpace(strings.ToLower(normalized.Region))
 	normalized.Country = strings.TrimSpace(strings.ToLower(normalized.Country))
 	normalized.LocationQuery = strings.TrimSpace(strings.ToLower(normalized.LocationQuery))
@@ -58,6 +114,11 @@ func (p SearchParams) Normalized() SearchParams {
 	if !normalized.CheckIn.IsZero() && !normalized.CheckOut.IsZero() && !normalized.CheckOut.After(normalized.CheckIn) {
 		normalized.CheckOut = time.Time{}
 	}
+	normalized.TravelMode = NormalizeTravelMode(normalized.TravelMode)
+	if normalized.MaxTravelMinutes < 0 {
+		normalized.MaxTravelMinutes = 0
+	}
+	normalized.Lat, normalized.Lon, normalized.RadiusKm = normalizeGeoFilter(normalized.Lat, normalized.Lon, normalized.RadiusKm)
 	if normalized.MinGuests < 0 {
 		normalized.MinGuests = 0
 	}
@@ -67,24 +128,75 @@ func (p SearchParams) Normalized() SearchParams {
 	if normalized.PriceMaxRub > 0 && normalized.PriceMaxRub < normalized.PriceMinRub {
 		normalized.PriceMaxRub = 0
 	}
+	if normalized.MaxPricePerSqM < 0 || math.IsNaN(normalized.MaxPricePerSqM) {
+		normalized.MaxPricePerSqM = 0
+	}
 	if normalized.Limit <= 0 {
 		normalized.Limit = defaultSearchLimit
 	}
-	if normalized.Limit > maxSearchLimit {
-		normalized.Limit = maxSearchLimit
+	if normalized.BBox != nil && !normalized.BBox.Valid() {
+		normalized.BBox = nil
+	}
+	limitCap := maxSearchLimit
+	if normalized.MaxLimit > limitCap {
+		limitCap = normalized.MaxLimit
+	}
+	if normalized.Limit > limitCap {
+		normalized.Limit = limitCap
 	}
 	if normalized.Offset < 0 {
 		normalized.Offset = 0
 	}
 	switch normalized.Sort {
 	case SortByPriceAsc, SortByPriceDesc, SortByRating, SortByNewest:
-	case SortByUpdated:
+	case SortByUpdated, SortByPricePerSqM:
 	default:
 		normalized.Sort = SortByPriceAsc
 	}
 	return normalized
 }
 
+// GeoFilter returns the center and radius of the geo filter, if one is set.
+func (p SearchParams) GeoFilter() (GeoPoint, float64, bool) {
+	if p.RadiusKm <= 0 {
+		return GeoPoint{}, 0, false
+	}
+	return GeoPoint{Lat: p.Lat, Lon: p.Lon}, p.RadiusKm, true
+}
+
+// WithinRadius reports whether the listing lies within radiusKm of center.
+// Listings without coordinates never match.
+func (l *Listing) WithinRadius(center GeoPoint, radiusKm float64) bool {
+	if !l.HasCoordinates() {
+		return false
+	}
+	return haversineKm(GeoPoint{Lat: l.Address.Lat, Lon: l.Address.Lon}, center) <= radiusKm
+}
+
+// WithinBBox reports whether the listing lies inside the box. Listings
+// without coordinates never match.
+func (l *Listing) WithinBBox(box BoundingBox) bool {
+	if !l.HasCoordinates() {
+		return false
+	}
+	return box.Contains(GeoPoint{Lat: l.Address.Lat, Lon: l.Address.Lon})
+}
+
+// HasCoordinates reports whether the address carries a geo position.
+func (l *Listing) HasCoordinates() bool {
+	return l.Address.Lat != 0 || l.Address.Lon != 0
+}
+
+func normalizeGeoFilter(lat, lon, radiusKm float64) (float64, float64, float64) {
+	if radiusKm <= 0 || math.IsNaN(radiusKm) || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, 0
+	}
+	if radiusKm > MaxSearchRadiusKm {
+		radiusKm = MaxSearchRadiusKm
+	}
+	return lat, lon, radiusKm
+}
+
 func normalizeTokens(tokens []string) []string {
 	if len(tokens) == 0 {
 		return nil
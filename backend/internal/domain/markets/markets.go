@@ -0,0 +1,62 @@
+package markets
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrCityNotSupported = errors.New("markets: city is not in a supported market")
+
+// Settings lists the cities where listings may be published. An empty list
+// means the platform is not restricted to any market.
+type Settings struct {
+	Cities            []string
+	GrandfatherActive bool
+	UpdatedBy         string
+	UpdatedAt         time.Time
+}
+
+// NewSettings trims and de-duplicates city names, keeping their first spelling.
+func NewSettings(cities []string, grandfatherActive bool) Settings {
+	out := make([]string, 0, len(cities))
+	seen := make(map[string]struct{}, len(cities))
+	for _, city := range cities {
+		city = strings.TrimSpace(city)
+		if city == "" {
+			continue
+		}
+		key := strings.ToLower(city)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, city)
+	}
+	return Settings{Cities: out, GrandfatherActive: grandfatherActive}
+}
+
+// Restricted reports whether publishing is limited to the listed cities.
+func (s Settings) Restricted() bool {
+	return len(s.Cities) > 0
+}
+
+// Allows reports whether a listing in city may be published.
+func (s Settings) Allows(city string) bool {
+	if !s.Restricted() {
+		return true
+	}
+	city = strings.TrimSpace(city)
+	for _, allowed := range s.Cities {
+		if strings.EqualFold(allowed, city) {
+			return true
+		}
+	}
+	return false
+}
+
+type Repository interface {
+	Current(ctx context.Context) (Settings, error)
+	Save(ctx context.Context, settings Settings) error
+}
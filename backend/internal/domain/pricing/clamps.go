@@ -0,0 +1,177 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"rentme/internal/domain/listings"
+)
+
+var (
+	ErrInvalidClampRange = errors.New("pricing: clamp min_rub and max_rub must be positive with min_rub below max_rub")
+	ErrUnknownClampTerm  = errors.New("pricing: clamp rental term must be short_term or long_term")
+	ErrClampCityRequired = errors.New("pricing: clamp city is required")
+)
+
+// ClampRange bounds an ML price recommendation in whole rubles.
+type ClampRange struct {
+	MinRub int64 `json:"min_rub"`
+	MaxRub int64 `json:"max_rub"`
+}
+
+func (r ClampRange) Validate() error {
+	if r.MinRub <= 0 || r.MaxRub <= 0 || r.MinRub >= r.MaxRub {
+		return ErrInvalidClampRange
+	}
+	return nil
+}
+
+// ClampTerms holds one clamp range per rental term.
+type ClampTerms map[listings.RentalTermType]ClampRange
+
+func (t ClampTerms) Validate() error {
+	for term, rng := range t {
+		if term != listings.RentalTermShort && term != listings.RentalTermLong {
+			return fmt.Errorf("%w: %q", ErrUnknownClampTerm, term)
+		}
+		if err := rng.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", term, err)
+		}
+	}
+	return nil
+}
+
+// ClampTable is the full set of ML price clamps: platform defaults per term
+// and per-city overrides keyed by normalized city name.
+type ClampTable struct {
+	Defaults ClampTerms            `json:"defaults"`
+	Cities   map[string]ClampTerms `json:"cities"`
+}
+
+// Validate checks every range and city name of the table.
+func (t ClampTable) Validate() error {
+	if err := t.Defaults.Validate(); err != nil {
+		return fmt.Errorf("defaults: %w", err)
+	}
+	for city, terms := range t.Cities {
+		if NormalizeCity(city) == "" {
+			return ErrClampCityRequired
+		}
+		if err := terms.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", city, err)
+		}
+	}
+	return nil
+}
+
+// Empty reports whether the table holds no ranges at all.
+func (t ClampTable) Empty() bool {
+	return len(t.Defaults) == 0 && len(t.Cities) == 0
+}
+
+// Normalized returns a copy of the table with city keys normalized; entries
+// that normalize to the same city are merged term by term.
+func (t ClampTable) Normalized() ClampTable {
+	out := ClampTable{
+		Defaults: cloneClampTerms(t.Defaults),
+		Cities:   make(map[string]ClampTerms, len(t.Cities)),
+	}
+	for city, terms := range t.Cities {
+		key := NormalizeCity(city)
+		if key == "" || len(terms) == 0 {
+			continue
+		}
+		merged := out.Cities[key]
+		if merged == nil {
+			merged = make(ClampTerms, len(terms))
+		}
+		for term, rng := range terms {
+			merged[term] = rng
+		}
+		out.Cities[key] = merged
+	}
+	return out
+}
+
+// Over lays the table on top of base: every default and every city term set
+// here replaces the one in base, everything else is kept from base.
+func (t ClampTable) Over(base ClampTable) ClampTable {
+	out := base.Normalized()
+	for term, rng := range t.Defaults {
+		if out.Defaults == nil {
+			out.Defaults = make(ClampTerms, len(t.Defaults))
+		}
+		out.Defaults[term] = rng
+	}
+	for city, terms := range t.Normalized().Cities {
+		merged := out.Cities[city]
+		if merged == nil {
+			merged = make(ClampTerms, len(terms))
+		}
+		for term, rng := range terms {
+			merged[term] = rng
+		}
+		out.Cities[city] = merged
+	}
+	return out
+}
+
+// City returns the ranges that apply in city: its own terms first, then the
+// defaults.
+func (t ClampTable) City(city string) ClampTerms {
+	out := cloneClampTerms(t.Defaults)
+	if out == nil {
+		out = ClampTerms{}
+	}
+	for term, rng := range t.Cities[NormalizeCity(city)] {
+		out[term] = rng
+	}
+	return out
+}
+
+// Range returns the clamp for term in city. Terms other than short_term are
+// clamped as long-term rents.
+func (t ClampTable) Range(city string, term listings.RentalTermType) (ClampRange, bool) {
+	if term != listings.RentalTermShort {
+		term = listings.RentalTermLong
+	}
+	rng, ok := t.City(city)[term]
+	return rng, ok
+}
+
+// NormalizeCity maps the spellings the ML service knows to one city key.
+func NormalizeCity(raw string) string {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return ""
+	}
+	switch strings.ToLower(trimmed) {
+	case "moscow", "москва":
+		return "Москва"
+	case "krasnodar", "краснодар":
+		return "Краснодар"
+	default:
+		return trimmed
+	}
+}
+
+func cloneClampTerms(terms ClampTerms) ClampTerms {
+	if terms == nil {
+		return nil
+	}
+	out := make(ClampTerms, len(terms))
+	for term, rng := range terms {
+		out[term] = rng
+	}
+	return out
+}
+
+// ClampStore persists the clamps edited by admins. An empty table means no
+// overrides: the configured clamps apply unchanged.
+type ClampStore interface {
+	Table(ctx context.Context) (ClampTable, error)
+	SaveCity(ctx context.Context, city string, terms ClampTerms) error
+	Replace(ctx context.Context, table ClampTable) error
+}
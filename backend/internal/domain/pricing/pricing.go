@@ -3,6 +3,8 @@ package pricing
 import (
 	"context"
 	"errors"
+	"math"
+	"time"
 
 	"rentme/internal/domain/listings"
 	"rentme/internal/domain/shared/daterange"
@@ -12,6 +14,7 @@ import (
 var (
 	ErrNegativeComponent = errors.New("pricing: components cannot be negative unless modeled as discount")
 	ErrCurrencyUnset     = errors.New("pricing: currency must be defined")
+	ErrUnknownUnit       = errors.New("pricing: unit must be night or month")
 )
 
 type Fee struct {
@@ -29,13 +32,52 @@ type Discount struct {
 	Amount money.Money
 }
 
+// Adjustment is a signed correction such as rounding; it is never silent.
+type Adjustment struct {
+	Name   string
+	Amount money.Money
+}
+
+// Names of the fee lines listings charge on top of the rent.
+const (
+	FeeCleaning = "cleaning_fee"
+	FeeService  = "service_fee"
+)
+
+// DiscountLongStay names the discount line of stays past the listing's
+// long-stay threshold.
+const DiscountLongStay = "long_stay_discount"
+
+// Price units a breakdown can be quoted in.
+const (
+	UnitNight = "night"
+	UnitMonth = "month"
+)
+
+// Quote sources of calculators that can answer from more than one engine. An
+// empty Source is the rules engine.
+const (
+	SourceML       = "ml"
+	SourceFallback = "fallback"
+)
+
+// PriceBreakdown prices Nights units at Nightly each. For monthly rentals Unit
+// is UnitMonth, Nights counts months and Nightly is the monthly rent; an empty
+// Unit means nights, which is what breakdowns stored before units existed use.
+// WeekendSurcharge is the extra charged for Friday and Saturday nights on top
+// of the base Nightly rate.
 type PriceBreakdown struct {
-	Nights    int
-	Nightly   money.Money
-	Fees      []Fee
-	Taxes     []Tax
-	Discounts []Discount
-	Total     money.Money
+	Unit             string
+	Nights           int
+	Nightly          money.Money
+	WeekendSurcharge money.Money
+	Fees             []Fee
+	Taxes            []Tax
+	Discounts        []Discount
+	Adjustments      []Adjustment
+	Total            money.Money
+	// Source tells which engine quoted the price, see SourceML.
+	Source string
 }
 
 func (p *PriceBreakdown) Validate() error {
@@ -45,14 +87,27 @@ func (p *PriceBreakdown) Validate() error {
 	if p.Nights <= 0 {
 		return errors.New("pricing: nights must be positive")
 	}
+	switch p.Unit {
+	case "", UnitNight, UnitMonth:
+	default:
+		return ErrUnknownUnit
+	}
 	return nil
 }
 
+// PriceUnit reports the unit Nights and Nightly refer to.
+func (p PriceBreakdown) PriceUnit() string {
+	if p.Unit == "" {
+		return UnitNight
+	}
+	return p.Unit
+}
+
 func (p *PriceBreakdown) RecalculateTotal() error {
 	if err := p.Validate(); err != nil {
 		return err
 	}
-	total := p.Nightly.Multiply(int64(p.Nights))
+	total := p.Rent()
 	addMoney := func(m money.Money) {
 		res, _ := total.Add(m)
 		total = res
@@ -75,6 +130,9 @@ func (p *PriceBreakdown) RecalculateTotal() error {
 		}
 		addMoney(discount.Amount)
 	}
+	for _, adj := range p.Adjustments {
+		addMoney(adj.Amount)
+	}
 	if total.Amount < 0 {
 		total = money.Money{Amount: 0, Currency: total.Currency}
 	}
@@ -82,20 +140,169 @@ func (p *PriceBreakdown) RecalculateTotal() error {
 	return nil
 }
 
+// Rent is the price of the units, weekend surcharge included, before fees,
+// taxes and discounts.
+func (p PriceBreakdown) Rent() money.Money {
+	rent := p.Nightly.Multiply(int64(p.Nights))
+	if p.WeekendSurcharge.Amount != 0 {
+		rent, _ = rent.Add(p.WeekendSurcharge)
+	}
+	return rent
+}
+
+// Fee sums the fee lines called name; it is zero when there are none.
+func (p PriceBreakdown) Fee(name string) money.Money {
+	total := money.Money{Currency: p.Nightly.Currency}
+	for _, fee := range p.Fees {
+		if fee.Name == name {
+			total, _ = total.Add(fee.Amount)
+		}
+	}
+	return total
+}
+
+// DiscountTotal sums the discount lines as a positive amount.
+func (p PriceBreakdown) DiscountTotal() money.Money {
+	total := money.Money{Currency: p.Nightly.Currency}
+	for _, discount := range p.Discounts {
+		amount := discount.Amount
+		if amount.Amount < 0 {
+			amount = amount.Neg()
+		}
+		total, _ = total.Add(amount)
+	}
+	return total
+}
+
+// ApplyListingFees adds the listing's cleaning fee and its service fee, a
+// percentage of the rent, as fee lines and recalculates the total. Only
+// nightly stays carry the cleaning fee; fees of zero are left out.
+func (p *PriceBreakdown) ApplyListingFees(listing *listings.Listing) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if listing == nil {
+		return p.RecalculateTotal()
+	}
+	currency := p.Nightly.Currency
+	if p.PriceUnit() == UnitNight && listing.CleaningFeeRub > 0 {
+		p.Fees = append(p.Fees, Fee{Name: FeeCleaning, Amount: money.Money{Amount: listing.CleaningFeeRub, Currency: currency}})
+	}
+	if listing.ServiceFeePct > 0 {
+		amount := int64(math.Round(float64(p.Rent().Amount) * listing.ServiceFeePct / 100))
+		if amount > 0 {
+			p.Fees = append(p.Fees, Fee{Name: FeeService, Amount: money.Money{Amount: amount, Currency: currency}})
+		}
+	}
+	return p.RecalculateTotal()
+}
+
+// ApplyWeekendPremium sets WeekendSurcharge to the listing's weekend premium
+// on every Friday and Saturday night of dr. Only nightly stays carry it; call
+// it before ApplyListingFees so the service fee covers the surcharge.
+func (p *PriceBreakdown) ApplyWeekendPremium(listing *listings.Listing, dr daterange.DateRange) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	p.WeekendSurcharge = money.Money{}
+	if listing == nil || listing.WeekendPremiumPct <= 0 || p.PriceUnit() != UnitNight {
+		return nil
+	}
+	nights := WeekendNights(dr)
+	if nights == 0 {
+		return nil
+	}
+	perNight := int64(math.Round(float64(p.Nightly.Amount) * listing.WeekendPremiumPct / 100))
+	if perNight <= 0 {
+		return nil
+	}
+	p.WeekendSurcharge = money.Money{Amount: perNight * int64(nights), Currency: p.Nightly.Currency}
+	return nil
+}
+
+// ApplyLongStayDiscount adds the listing's long-stay discount, a percentage
+// of the rent, as a discount line when a nightly stay runs longer than the
+// listing's threshold. Call it before ApplyListingFees, which recalculates the
+// total; fees are charged on the undiscounted rent.
+func (p *PriceBreakdown) ApplyLongStayDiscount(listing *listings.Listing) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if listing == nil || listing.LongStayDiscountPct <= 0 || p.PriceUnit() != UnitNight {
+		return nil
+	}
+	if p.Nights <= listing.LongStayThresholdNights {
+		return nil
+	}
+	amount := int64(math.Round(float64(p.Rent().Amount) * listing.LongStayDiscountPct / 100))
+	if amount <= 0 {
+		return nil
+	}
+	p.Discounts = append(p.Discounts, Discount{Name: DiscountLongStay, Amount: money.Money{Amount: amount, Currency: p.Nightly.Currency}})
+	return nil
+}
+
+// WeekendNights counts the nights of dr that start on a Friday or Saturday.
+func WeekendNights(dr daterange.DateRange) int {
+	count := 0
+	for night := dr.CheckIn; night.Before(dr.CheckOut); night = night.AddDate(0, 0, 1) {
+		if day := night.Weekday(); day == time.Friday || day == time.Saturday {
+			count++
+		}
+	}
+	return count
+}
+
 func (p PriceBreakdown) Copy() PriceBreakdown {
 	clone := p
 	clone.Fees = append([]Fee(nil), p.Fees...)
 	clone.Taxes = append([]Tax(nil), p.Taxes...)
 	clone.Discounts = append([]Discount(nil), p.Discounts...)
+	clone.Adjustments = append([]Adjustment(nil), p.Adjustments...)
 	return clone
 }
 
+// QuoteInput describes the stay to price. RentalTerm falls back to the
+// listing's term; Months is only read for long-term rentals and, when zero, is
+// derived from Range.
 type QuoteInput struct {
-	ListingID listings.ListingID
-	Listing   *listings.Listing
+	ListingID  listings.ListingID
+	Listing    *listings.Listing
 	RentalTerm listings.RentalTermType
-	Range     daterange.DateRange
-	Guests    int
+	Range      daterange.DateRange
+	Months     int
+	Guests     int
+}
+
+// Units resolves how the stay is billed: per month for long-term rentals and
+// per night otherwise, with at least one unit either way. Listings without a
+// term are long-term, as everywhere else.
+func (in QuoteInput) Units() (string, int) {
+	term := in.RentalTerm
+	if term == "" && in.Listing != nil {
+		term = in.Listing.RentalTermType
+	}
+	if term == listings.RentalTermShort {
+		return UnitNight, max(in.Range.Nights(), 1)
+	}
+	months := in.Months
+	if months <= 0 {
+		months = MonthsIn(in.Range)
+	}
+	return UnitMonth, max(months, 1)
+}
+
+// MonthsIn counts the calendar months the range spans, rounding a partial
+// month up; a range of 1 March to 1 June is three months.
+func MonthsIn(dr daterange.DateRange) int {
+	if dr.CheckIn.IsZero() || !dr.CheckOut.After(dr.CheckIn) {
+		return 0
+	}
+	months := 0
+	for dr.CheckIn.AddDate(0, months, 0).Before(dr.CheckOut) {
+		months++
+	}
+	return months
 }
 
 type Calculator interface {
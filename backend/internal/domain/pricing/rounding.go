@@ -0,0 +1,183 @@
+package pricing
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"rentme/internal/domain/shared/money"
+)
+
+// RoundingAdjustmentName labels the breakdown line that carries rounding.
+const RoundingAdjustmentName = "rounding"
+
+// RoundingRule describes how amounts of one currency are charged and shown.
+// RUB amounts are stored in whole rubles; other currencies in minor units.
+type RoundingRule struct {
+	// Scale is the number of stored units per major unit (1 for RUB, 100 for cents).
+	Scale int64 `json:"scale"`
+	// Step is the charge rounding increment in stored units.
+	Step int64 `json:"step"`
+	// MonthlyDisplayStep rounds monthly rents on marketing surfaces.
+	MonthlyDisplayStep int64 `json:"monthly_display_step"`
+	// Symbol is appended when formatting amounts for display.
+	Symbol string `json:"symbol"`
+}
+
+// RoundingPolicy resolves rules per market (city) and currency.
+type RoundingPolicy struct {
+	Currencies map[string]RoundingRule            `json:"currencies"`
+	Markets    map[string]map[string]RoundingRule `json:"markets"`
+}
+
+// DefaultRoundingPolicy rounds RUB to whole rubles (monthly rents to 100 on
+// display) and other currencies to two decimals.
+func DefaultRoundingPolicy() RoundingPolicy {
+	return RoundingPolicy{
+		Currencies: map[string]RoundingRule{
+			"RUB": {Scale: 1, Step: 1, MonthlyDisplayStep: 100, Symbol: "₽"},
+		},
+	}
+}
+
+// Rule returns the rule for currency in market, falling back to the currency
+// default and then to two-decimal rounding.
+func (p RoundingPolicy) Rule(market, currency string) RoundingRule {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	market = strings.TrimSpace(market)
+	for name, rules := range p.Markets {
+		if !strings.EqualFold(name, market) {
+			continue
+		}
+		if rule, ok := rules[currency]; ok {
+			return rule.normalized(currency)
+		}
+	}
+	if rule, ok := p.Currencies[currency]; ok {
+		return rule.normalized(currency)
+	}
+	if currency == "RUB" {
+		return DefaultRoundingPolicy().Currencies["RUB"]
+	}
+	return RoundingRule{Scale: 100, Step: 1, MonthlyDisplayStep: 100 * 100, Symbol: currency}.normalized(currency)
+}
+
+func (r RoundingRule) normalized(currency string) RoundingRule {
+	if r.Scale <= 0 {
+		r.Scale = 1
+	}
+	if r.Step <= 0 {
+		r.Step = 1
+	}
+	if r.MonthlyDisplayStep <= 0 {
+		r.MonthlyDisplayStep = r.Step
+	}
+	if r.Symbol == "" {
+		r.Symbol = currency
+	}
+	return r
+}
+
+// Round rounds stored units half away from zero to the charge step.
+func (r RoundingRule) Round(amount int64) int64 {
+	return roundToStep(amount, r.normalized("").Step)
+}
+
+// RoundMajor converts a major-unit amount (e.g. 1234.567 EUR) to rounded stored units.
+func (r RoundingRule) RoundMajor(amount float64) int64 {
+	rule := r.normalized("")
+	units := int64(math.Round(amount * float64(rule.Scale)))
+	return roundToStep(units, rule.Step)
+}
+
+// Display rounds an amount for marketing surfaces; monthly rents use the
+// coarser MonthlyDisplayStep.
+func (r RoundingRule) Display(amount int64, monthly bool) int64 {
+	rule := r.normalized("")
+	if monthly {
+		return roundToStep(amount, rule.MonthlyDisplayStep)
+	}
+	return roundToStep(amount, rule.Step)
+}
+
+// Format renders stored units with thousands separators and the currency symbol.
+func (r RoundingRule) Format(amount int64) string {
+	rule := r.normalized("")
+	neg := amount < 0
+	if neg {
+		amount = -amount
+	}
+	major := amount / rule.Scale
+	minor := amount % rule.Scale
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteString(groupThousands(strconv.FormatInt(major, 10)))
+	if rule.Scale > 1 {
+		digits := len(strconv.FormatInt(rule.Scale, 10)) - 1
+		frac := strconv.FormatInt(minor, 10)
+		b.WriteByte(',')
+		b.WriteString(strings.Repeat("0", digits-len(frac)) + frac)
+	}
+	if rule.Symbol != "" {
+		b.WriteString(" ")
+		b.WriteString(rule.Symbol)
+	}
+	return b.String()
+}
+
+// ApplyRounding recalculates the total and rounds it to the rule's step. Any
+// difference is recorded as an explicit adjustment line so that the lines
+// always sum to Total; a previous rounding line is replaced.
+func (p *PriceBreakdown) ApplyRounding(rule RoundingRule) error {
+	adjustments := p.Adjustments[:0:0]
+	for _, adj := range p.Adjustments {
+		if adj.Name != RoundingAdjustmentName {
+			adjustments = append(adjustments, adj)
+		}
+	}
+	p.Adjustments = adjustments
+	if err := p.RecalculateTotal(); err != nil {
+		return err
+	}
+	rounded := rule.Round(p.Total.Amount)
+	diff := rounded - p.Total.Amount
+	if diff == 0 {
+		return nil
+	}
+	p.Adjustments = append(p.Adjustments, Adjustment{
+		Name:   RoundingAdjustmentName,
+		Amount: money.Money{Amount: diff, Currency: p.Total.Currency},
+	})
+	return p.RecalculateTotal()
+}
+
+func roundToStep(amount, step int64) int64 {
+	if step <= 1 {
+		return amount
+	}
+	half := step / 2
+	if amount < 0 {
+		return -((-amount + half) / step * step)
+	}
+	return (amount + half) / step * step
+}
+
+func groupThousands(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		b.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,132 @@
+package pricing
+
+import (
+	"testing"
+
+	"rentme/internal/domain/shared/money"
+)
+
+// lineSum adds up the breakdown lines the way a receipt shows them.
+func lineSum(p PriceBreakdown) int64 {
+	sum := p.Rent().Amount
+	for _, fee := range p.Fees {
+		sum += fee.Amount.Amount
+	}
+	for _, tax := range p.Taxes {
+		sum += tax.Amount.Amount
+	}
+	sum -= p.DiscountTotal().Amount
+	for _, adj := range p.Adjustments {
+		sum += adj.Amount.Amount
+	}
+	return sum
+}
+
+func roundingLines(p PriceBreakdown) int {
+	n := 0
+	for _, adj := range p.Adjustments {
+		if adj.Name == RoundingAdjustmentName {
+			n++
+		}
+	}
+	return n
+}
+
+func TestApplyRoundingLinesSumToTotal(t *testing.T) {
+	eur := func(amount int64) money.Money { return money.Money{Amount: amount, Currency: "EUR"} }
+	rub := func(amount int64) money.Money { return money.Money{Amount: amount, Currency: "RUB"} }
+	cases := []struct {
+		name      string
+		breakdown PriceBreakdown
+		rule      RoundingRule
+		wantTotal int64
+	}{
+		{
+			name:      "rub whole rubles need no line",
+			breakdown: PriceBreakdown{Nights: 3, Nightly: rub(4999), Fees: []Fee{{Name: FeeService, Amount: rub(750)}}},
+			rule:      DefaultRoundingPolicy().Rule("", "RUB"),
+			wantTotal: 15747,
+		},
+		{
+			name:      "rub rounded up to tens",
+			breakdown: PriceBreakdown{Nights: 3, Nightly: rub(4999), Fees: []Fee{{Name: FeeService, Amount: rub(750)}}},
+			rule:      RoundingRule{Scale: 1, Step: 10},
+			wantTotal: 15750,
+		},
+		{
+			name: "eur cents rounded down to whole euros",
+			breakdown: PriceBreakdown{
+				Nights:    2,
+				Nightly:   eur(10033),
+				Taxes:     []Tax{{Name: "city_tax", Amount: eur(210)}},
+				Discounts: []Discount{{Name: DiscountLongStay, Amount: eur(1234)}},
+			},
+			rule:      RoundingRule{Scale: 100, Step: 100},
+			wantTotal: 19000,
+		},
+		{
+			name: "keeps other adjustments",
+			breakdown: PriceBreakdown{
+				Nights:      1,
+				Nightly:     eur(9999),
+				Adjustments: []Adjustment{{Name: "goodwill", Amount: eur(-500)}},
+			},
+			rule:      RoundingRule{Scale: 100, Step: 100},
+			wantTotal: 9500,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tc.breakdown.Copy()
+			if err := p.ApplyRounding(tc.rule); err != nil {
+				t.Fatalf("apply rounding: %v", err)
+			}
+			if p.Total.Amount != tc.wantTotal {
+				t.Fatalf("total = %d, want %d", p.Total.Amount, tc.wantTotal)
+			}
+			if got := lineSum(p); got != p.Total.Amount {
+				t.Fatalf("lines sum to %d, total is %d", got, p.Total.Amount)
+			}
+			// Rounding twice must not stack rounding lines.
+			if err := p.ApplyRounding(tc.rule); err != nil {
+				t.Fatalf("apply rounding again: %v", err)
+			}
+			if p.Total.Amount != tc.wantTotal || lineSum(p) != p.Total.Amount || roundingLines(p) > 1 {
+				t.Fatalf("second rounding: total %d, lines %d, rounding lines %d", p.Total.Amount, lineSum(p), roundingLines(p))
+			}
+		})
+	}
+}
+
+func TestRoundingRuleDisplayAndFormat(t *testing.T) {
+	rub := DefaultRoundingPolicy().Rule("Москва", "rub")
+	if got := rub.Display(61249, true); got != 61200 {
+		t.Errorf("monthly display = %d, want 61200", got)
+	}
+	if got := rub.Display(61249, false); got != 61249 {
+		t.Errorf("nightly display = %d, want 61249", got)
+	}
+	if got := rub.Format(1234567); got != "1 234 567 ₽" {
+		t.Errorf("format = %q", got)
+	}
+	eur := DefaultRoundingPolicy().Rule("", "EUR")
+	if got := eur.Format(123405); got != "1 234,05 EUR" {
+		t.Errorf("eur format = %q", got)
+	}
+	if got := eur.RoundMajor(12.345); got != 1235 {
+		t.Errorf("round major = %d, want 1235", got)
+	}
+}
+
+func TestRoundingPolicyMarketOverride(t *testing.T) {
+	policy := RoundingPolicy{
+		Currencies: map[string]RoundingRule{"RUB": {Scale: 1, Step: 1}},
+		Markets:    map[string]map[string]RoundingRule{"Sochi": {"RUB": {Scale: 1, Step: 10}}},
+	}
+	if got := policy.Rule("sochi", "RUB").Step; got != 10 {
+		t.Errorf("market step = %d, want 10", got)
+	}
+	if got := policy.Rule("Kazan", "RUB").Step; got != 1 {
+		t.Errorf("default step = %d, want 1", got)
+	}
+}
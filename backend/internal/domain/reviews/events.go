@@ -27,3 +27,24 @@ type ReviewUpdated struct {
 func (e ReviewUpdated) EventName() string     { return "review.updated" }
 func (e ReviewUpdated) AggregateID() string   { return string(e.ReviewID) }
 func (e ReviewUpdated) OccurredAt() time.Time { return e.At }
+
+type ReviewReplied struct {
+	ReviewID  ReviewID
+	ListingID listings.ListingID
+	At        time.Time
+}
+
+func (e ReviewReplied) EventName() string     { return "review.replied" }
+func (e ReviewReplied) AggregateID() string   { return string(e.ReviewID) }
+func (e ReviewReplied) OccurredAt() time.Time { return e.At }
+
+type ReviewModerated struct {
+	ReviewID  ReviewID
+	ListingID listings.ListingID
+	Reason    string
+	At        time.Time
+}
+
+func (e ReviewModerated) EventName() string     { return "review.moderated" }
+func (e ReviewModerated) AggregateID() string   { return string(e.ReviewID) }
+func (e ReviewModerated) OccurredAt() time.Time { return e.At }
@@ -14,8 +14,15 @@ import (
 var (
 	ErrInvalidRating = errors.New("reviews: rating must be between 1 and 5")
 	ErrNotFound      = errors.New("reviews: not found")
+	ErrReplyRequired = errors.New("reviews: reply text is required")
+	ErrReplyTooLong  = errors.New("reviews: reply text is too long")
+	ErrReplyToDraft  = errors.New("reviews: cannot reply to draft state")
+	// ErrModerationReason is returned when a review is hidden without saying why.
+	ErrModerationReason = errors.New("reviews: moderation reason is required")
 )
 
+const maxReplyLength = 2000
+
 type ReviewID string
 
 type Review struct {
@@ -27,6 +34,15 @@ type Review struct {
 	Text      string
 	CreatedAt time.Time
 	Submitted bool
+
+	HostReplyText string
+	HostReplyAt   *time.Time
+
+	// Moderated reviews stay stored but are hidden from the public list and
+	// left out of the listing rating.
+	Moderated        bool
+	ModerationReason string
+	ModeratedAt      *time.Time
 	events.EventRecorder
 }
 
@@ -34,9 +50,29 @@ type Repository interface {
 	ByID(ctx context.Context, id ReviewID) (*Review, error)
 	ByBooking(ctx context.Context, bookingID booking.BookingID, authorID string) (*Review, error)
 	ListByListing(ctx context.Context, listingID listings.ListingID, limit, offset int) ([]*Review, error)
+	StatsByListing(ctx context.Context, listingID listings.ListingID) (ListingStats, error)
 	Save(ctx context.Context, review *Review) error
 }
 
+// ListingStats aggregates the submitted reviews of a listing. Stars[i] counts
+// reviews rated i+1.
+type ListingStats struct {
+	Count   int
+	Average float64
+	Stars   [5]int
+}
+
+// Add counts a review in the aggregate.
+func (s *ListingStats) Add(rating int) {
+	if rating < 1 || rating > 5 {
+		return
+	}
+	total := s.Average*float64(s.Count) + float64(rating)
+	s.Count++
+	s.Stars[rating-1]++
+	s.Average = total / float64(s.Count)
+}
+
 type SubmitParams struct {
 	ID        ReviewID
 	BookingID booking.BookingID
@@ -86,3 +122,38 @@ func (r *Review) Update(rating int, text string, now time.Time) error {
 	r.Record(ReviewUpdated{ReviewID: r.ID, At: now.UTC()})
 	return nil
 }
+
+// Reply stores the host's public answer to the review. Replying again
+// replaces the previous answer.
+func (r *Review) Reply(text string, at time.Time) error {
+	if !r.Submitted {
+		return ErrReplyToDraft
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ErrReplyRequired
+	}
+	if len([]rune(text)) > maxReplyLength {
+		return ErrReplyTooLong
+	}
+	repliedAt := at.UTC()
+	r.HostReplyText = text
+	r.HostReplyAt = &repliedAt
+	r.Record(ReviewReplied{ReviewID: r.ID, ListingID: r.ListingID, At: repliedAt})
+	return nil
+}
+
+// Moderate hides the review from public view. Moderating again only replaces
+// the reason.
+func (r *Review) Moderate(reason string, at time.Time) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrModerationReason
+	}
+	moderatedAt := at.UTC()
+	r.Moderated = true
+	r.ModerationReason = reason
+	r.ModeratedAt = &moderatedAt
+	r.Record(ReviewModerated{ReviewID: r.ID, ListingID: r.ListingID, Reason: reason, At: moderatedAt})
+	return nil
+}
@@ -0,0 +1,31 @@
+package reviews
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReplyToDraftIsRejected(t *testing.T) {
+	review := &Review{ID: "review-1"}
+	if err := review.Reply("thanks", time.Now()); !errors.Is(err, ErrReplyToDraft) {
+		t.Fatalf("err = %v, want ErrReplyToDraft", err)
+	}
+	if review.HostReplyText != "" || review.HostReplyAt != nil {
+		t.Fatalf("draft review got a reply: %+v", review)
+	}
+}
+
+func TestReplyStoresTrimmedText(t *testing.T) {
+	review := &Review{ID: "review-1", Submitted: true}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("MSK", 3*3600))
+	if err := review.Reply("  thanks for staying  ", at); err != nil {
+		t.Fatalf("reply: %v", err)
+	}
+	if review.HostReplyText != "thanks for staying" {
+		t.Fatalf("reply text = %q", review.HostReplyText)
+	}
+	if review.HostReplyAt == nil || !review.HostReplyAt.Equal(at) || review.HostReplyAt.Location() != time.UTC {
+		t.Fatalf("reply at = %v, want %v in UTC", review.HostReplyAt, at)
+	}
+}
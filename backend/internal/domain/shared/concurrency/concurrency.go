@@ -0,0 +1,9 @@
+// Package concurrency holds the optimistic-locking contract shared by every
+// repository implementation.
+package concurrency
+
+import "errors"
+
+// ErrConcurrentUpdate is returned by Save when the stored aggregate changed
+// since it was loaded. Callers reload and retry.
+var ErrConcurrentUpdate = errors.New("concurrent update detected")
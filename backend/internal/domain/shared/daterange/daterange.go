@@ -6,7 +6,8 @@ import (
 )
 
 var (
-	ErrInvalidRange = errors.New("daterange: checkout must be after checkin")
+	ErrInvalidRange   = errors.New("daterange: checkout must be after checkin")
+	ErrNotDateAligned = errors.New("daterange: check-in and check-out must be dates without a time of day")
 )
 
 // DateRange represents a half-open interval [checkIn, checkOut)
@@ -23,6 +24,44 @@ func New(checkIn, checkOut time.Time) (DateRange, error) {
 	return dr, nil
 }
 
+// Date returns midnight UTC of the calendar day. Every range of whole nights
+// is built on these instants, whatever zone the dates were written in.
+func Date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// DateOf returns midnight UTC of t's calendar day in t's own location, so
+// 2024-03-31T00:00:00+03:00 stays March 31 instead of becoming March 30.
+func DateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return Date(year, month, day)
+}
+
+// NewDates builds the range between two calendar days at UTC midnight.
+// Out-of-range months and days normalize as in time.Date, so adding months to
+// the check-in month is safe.
+func NewDates(inYear int, inMonth time.Month, inDay int, outYear int, outMonth time.Month, outDay int) (DateRange, error) {
+	return New(Date(inYear, inMonth, inDay), Date(outYear, outMonth, outDay))
+}
+
+// NewNightly builds a range of whole nights. Both ends must be midnight in
+// their own location; a time of day is rejected rather than rounded.
+func NewNightly(checkIn, checkOut time.Time) (DateRange, error) {
+	if !IsDateAligned(checkIn) || !IsDateAligned(checkOut) {
+		return DateRange{}, ErrNotDateAligned
+	}
+	inYear, inMonth, inDay := checkIn.Date()
+	outYear, outMonth, outDay := checkOut.Date()
+	return NewDates(inYear, inMonth, inDay, outYear, outMonth, outDay)
+}
+
+// IsDateAligned reports whether t is midnight in its own location, i.e. a
+// plain date.
+func IsDateAligned(t time.Time) bool {
+	hour, minute, sec := t.Clock()
+	return hour == 0 && minute == 0 && sec == 0 && t.Nanosecond() == 0
+}
+
 func (dr DateRange) Validate() error {
 	if dr.CheckOut.IsZero() || dr.CheckIn.IsZero() {
 		return ErrInvalidRange
@@ -33,8 +72,10 @@ func (dr DateRange) Validate() error {
 	return nil
 }
 
+// Nights counts calendar days between check-in and check-out, so a range
+// spanning a DST change still has a whole number of nights.
 func (dr DateRange) Nights() int {
-	return int(dr.CheckOut.Sub(dr.CheckIn).Hours() / 24)
+	return int(DateOf(dr.CheckOut).Sub(DateOf(dr.CheckIn)) / (24 * time.Hour))
 }
 
 func (dr DateRange) Overlaps(other DateRange) bool {
@@ -0,0 +1,88 @@
+package daterange
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestNightsAcrossDST(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	newYork := mustLoad(t, "America/New_York")
+	cases := []struct {
+		name     string
+		checkIn  time.Time
+		checkOut time.Time
+		want     int
+	}{
+		// 47 hours pass between the two midnights.
+		{"berlin spring forward", time.Date(2026, 3, 28, 0, 0, 0, 0, berlin), time.Date(2026, 3, 30, 0, 0, 0, 0, berlin), 2},
+		// 49 hours pass between the two midnights.
+		{"berlin fall back", time.Date(2026, 10, 24, 0, 0, 0, 0, berlin), time.Date(2026, 10, 26, 0, 0, 0, 0, berlin), 2},
+		{"new york spring forward", time.Date(2026, 3, 7, 0, 0, 0, 0, newYork), time.Date(2026, 3, 14, 0, 0, 0, 0, newYork), 7},
+		{"new york fall back", time.Date(2026, 10, 31, 0, 0, 0, 0, newYork), time.Date(2026, 11, 2, 0, 0, 0, 0, newYork), 2},
+		{"single night over the change", time.Date(2026, 3, 29, 0, 0, 0, 0, berlin), time.Date(2026, 3, 30, 0, 0, 0, 0, berlin), 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dr, err := NewNightly(tc.checkIn, tc.checkOut)
+			if err != nil {
+				t.Fatalf("NewNightly: %v", err)
+			}
+			if got := dr.Nights(); got != tc.want {
+				t.Fatalf("Nights() = %d, want %d", got, tc.want)
+			}
+			if !IsDateAligned(dr.CheckIn) || dr.CheckIn.Location() != time.UTC {
+				t.Fatalf("check-in %s is not UTC midnight", dr.CheckIn)
+			}
+		})
+	}
+}
+
+func TestNightlyKeepsTheCalendarDay(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*3600)
+	dr, err := NewNightly(time.Date(2026, 12, 1, 0, 0, 0, 0, moscow), time.Date(2026, 12, 3, 0, 0, 0, 0, moscow))
+	if err != nil {
+		t.Fatalf("NewNightly: %v", err)
+	}
+	if !dr.CheckIn.Equal(Date(2026, time.December, 1)) || !dr.CheckOut.Equal(Date(2026, time.December, 3)) {
+		t.Fatalf("range = [%s, %s), want [Dec 1, Dec 3) UTC", dr.CheckIn, dr.CheckOut)
+	}
+}
+
+func TestNightlyRejectsTimeOfDay(t *testing.T) {
+	checkIn := time.Date(2026, 12, 1, 14, 0, 0, 0, time.UTC)
+	if _, err := NewNightly(checkIn, Date(2026, time.December, 3)); !errors.Is(err, ErrNotDateAligned) {
+		t.Fatalf("err = %v, want ErrNotDateAligned", err)
+	}
+	if _, err := NewNightly(Date(2026, time.December, 3), Date(2026, time.December, 3)); !errors.Is(err, ErrInvalidRange) {
+		t.Fatalf("err = %v, want ErrInvalidRange", err)
+	}
+}
+
+func TestNewDatesNormalizesLikeTimeDate(t *testing.T) {
+	dr, err := NewDates(2026, time.November, 30, 2026, time.November+3, 30)
+	if err != nil {
+		t.Fatalf("NewDates: %v", err)
+	}
+	if want := Date(2027, time.March, 2); !dr.CheckOut.Equal(want) {
+		t.Fatalf("check-out = %s, want %s", dr.CheckOut, want)
+	}
+}
+
+func TestDateOfUsesOwnLocation(t *testing.T) {
+	late := time.Date(2026, 3, 31, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*3600))
+	if got, want := DateOf(late), Date(2026, time.March, 31); !got.Equal(want) {
+		t.Fatalf("DateOf = %s, want %s", got, want)
+	}
+}
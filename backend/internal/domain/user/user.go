@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 )
@@ -15,6 +16,9 @@ var (
 	ErrInvalidRole         = errors.New("user: invalid role")
 	ErrEmailAlreadyUsed    = errors.New("user: email already used")
 	ErrNotFound            = errors.New("user: not found")
+	ErrVerificationStatus  = errors.New("user: verification status must be unverified, pending or verified")
+	ErrUnassignableRole    = errors.New("user: role must be one of guest, host, admin")
+	ErrLastAdmin           = errors.New("user: cannot remove the admin role from the last admin")
 )
 
 type ID string
@@ -24,11 +28,78 @@ type Role string
 const (
 	RoleGuest Role = "guest"
 	RoleHost  Role = "host"
+	RoleAdmin Role = "admin"
 )
 
 // ReservedRoles lists roles reserved for internal usage.
 var ReservedRoles = []Role{RoleGuest, RoleHost}
 
+// AssignableRoles lists the roles admins may grant.
+var AssignableRoles = []Role{RoleGuest, RoleHost, RoleAdmin}
+
+// ParseAssignableRoles validates roles requested by an admin against
+// AssignableRoles.
+func ParseAssignableRoles(raw []string) ([]Role, error) {
+	roles := make([]Role, 0, len(raw))
+	for _, value := range raw {
+		role := normalizeRole(Role(value))
+		assignable := false
+		for _, allowed := range AssignableRoles {
+			if role == allowed {
+				assignable = true
+				break
+			}
+		}
+		if !assignable {
+			return nil, fmt.Errorf("%w: %q", ErrUnassignableRole, value)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// VerificationStatus is the state of the identity check of a user.
+type VerificationStatus string
+
+const (
+	VerificationUnverified VerificationStatus = "unverified"
+	VerificationPending    VerificationStatus = "pending"
+	VerificationVerified   VerificationStatus = "verified"
+)
+
+// ParseVerificationStatus validates a raw status.
+func ParseVerificationStatus(raw string) (VerificationStatus, error) {
+	status := VerificationStatus(strings.ToLower(strings.TrimSpace(raw)))
+	switch status {
+	case VerificationUnverified, VerificationPending, VerificationVerified:
+		return status, nil
+	default:
+		return "", ErrVerificationStatus
+	}
+}
+
+// Verification describes how and when the identity of a user was checked.
+// Method names the check (document, video call, provider id); VerifiedAt is
+// set only while the status is verified.
+type Verification struct {
+	Status     VerificationStatus
+	Method     string
+	VerifiedAt time.Time
+	UpdatedAt  time.Time
+}
+
+// VerificationProvider is the port for an external identity (KYC) service
+// that reports the outcome of the check it runs for a user.
+type VerificationProvider interface {
+	Verification(ctx context.Context, id ID) (Verification, error)
+}
+
+// NotificationPreferences holds the notifications a user opted out of; the
+// zero value receives everything.
+type NotificationPreferences struct {
+	WeeklyDigestDisabled bool
+}
+
 type User struct {
 	ID           ID
 	Email        string
@@ -36,8 +107,14 @@ type User struct {
 	PasswordHash string
 	Roles        []Role
 	Blocked      bool
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// VerificationRequired is set by trust & safety checks; VerificationReason
+	// explains why.
+	VerificationRequired bool
+	VerificationReason   string
+	Verification         Verification
+	Notifications        NotificationPreferences
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
 }
 
 type Repository interface {
@@ -45,6 +122,9 @@ type Repository interface {
 	ByEmail(ctx context.Context, email string) (*User, error)
 	List(ctx context.Context, params ListParams) ([]*User, int, error)
 	Save(ctx context.Context, user *User) error
+	// Create inserts a new user atomically and fails with ErrEmailAlreadyUsed
+	// when the email (or ID) is already taken.
+	Create(ctx context.Context, user *User) error
 }
 
 type CreateParams struct {
@@ -58,8 +138,10 @@ type CreateParams struct {
 }
 
 // ListParams defines pagination and filtering for user search.
+// ListParams filters users; a non-empty Role keeps only users holding it.
 type ListParams struct {
 	Query  string
+	Role   Role
 	Limit  int
 	Offset int
 }
@@ -170,6 +252,58 @@ func (u *User) SetBlocked(blocked bool, now time.Time) {
 	u.touch(now)
 }
 
+// RequireVerification flags the user for additional verification.
+func (u *User) RequireVerification(reason string, now time.Time) {
+	u.VerificationRequired = true
+	u.VerificationReason = strings.TrimSpace(reason)
+	u.touch(now)
+}
+
+// SetVerification records the outcome of an identity check. A verified
+// status clears the trust & safety flag set by RequireVerification.
+func (u *User) SetVerification(status VerificationStatus, method string, now time.Time) error {
+	status, err := ParseVerificationStatus(string(status))
+	if err != nil {
+		return err
+	}
+	u.touch(now)
+	u.Verification = Verification{
+		Status:    status,
+		Method:    strings.TrimSpace(method),
+		UpdatedAt: u.UpdatedAt,
+	}
+	if status == VerificationVerified {
+		u.Verification.VerifiedAt = u.UpdatedAt
+		u.VerificationRequired = false
+	}
+	return nil
+}
+
+// IdentityVerified reports whether the identity check of the user passed.
+func (u *User) IdentityVerified() bool {
+	return u != nil && u.Verification.Status == VerificationVerified
+}
+
+// VerificationState returns the verification status, treating users that
+// were never checked as unverified.
+func (u *User) VerificationState() VerificationStatus {
+	if u == nil || u.Verification.Status == "" {
+		return VerificationUnverified
+	}
+	return u.Verification.Status
+}
+
+// SetNotificationPreferences replaces the notification opt-outs of the user.
+func (u *User) SetNotificationPreferences(prefs NotificationPreferences, now time.Time) {
+	u.Notifications = prefs
+	u.touch(now)
+}
+
+// WantsWeeklyDigest reports whether the weekly host digest may be sent.
+func (u *User) WantsWeeklyDigest() bool {
+	return u != nil && !u.Blocked && !u.Notifications.WeeklyDigestDisabled
+}
+
 func (u *User) touch(now time.Time) {
 	if now.IsZero() {
 		now = time.Now()
@@ -203,6 +337,8 @@ func normalizeRole(role Role) Role {
 		return RoleGuest
 	case "host":
 		return RoleHost
+	case "admin":
+		return RoleAdmin
 	default:
 		return Role(strings.ToLower(strings.TrimSpace(string(role))))
 	}
@@ -0,0 +1,66 @@
+package wishlist
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"rentme/internal/domain/listings"
+)
+
+var (
+	ErrGuestRequired   = errors.New("wishlist: guest id is required")
+	ErrListingRequired = errors.New("wishlist: listing id is required")
+)
+
+// Wishlist keeps the listings a guest saved for later, most recent first.
+type Wishlist struct {
+	GuestID    string
+	ListingIDs []listings.ListingID
+}
+
+type Repository interface {
+	ByGuest(ctx context.Context, guestID string) (*Wishlist, error)
+	Save(ctx context.Context, wishlist *Wishlist) error
+}
+
+func New(guestID string) (*Wishlist, error) {
+	guestID = strings.TrimSpace(guestID)
+	if guestID == "" {
+		return nil, ErrGuestRequired
+	}
+	return &Wishlist{GuestID: guestID}, nil
+}
+
+// Add saves the listing at the top of the wishlist. Adding an already saved
+// listing is a no-op.
+func (w *Wishlist) Add(listingID listings.ListingID) error {
+	if strings.TrimSpace(string(listingID)) == "" {
+		return ErrListingRequired
+	}
+	if w.Contains(listingID) {
+		return nil
+	}
+	w.ListingIDs = append([]listings.ListingID{listingID}, w.ListingIDs...)
+	return nil
+}
+
+// Remove drops the listing from the wishlist and reports whether it was saved.
+func (w *Wishlist) Remove(listingID listings.ListingID) bool {
+	for i, id := range w.ListingIDs {
+		if id == listingID {
+			w.ListingIDs = append(w.ListingIDs[:i], w.ListingIDs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Wishlist) Contains(listingID listings.ListingID) bool {
+	for _, id := range w.ListingIDs {
+		if id == listingID {
+			return true
+		}
+	}
+	return false
+}
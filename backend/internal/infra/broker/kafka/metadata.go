@@ -0,0 +1,40 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// CheckBrokers connects to the cluster and fetches its metadata. sarama has
+// no context support, so the context deadline bounds the dial and read
+// timeouts instead; metadata retries are disabled to fail fast.
+func CheckBrokers(ctx context.Context, brokers []string) error {
+	if len(brokers) == 0 {
+		return errors.New("kafka: no brokers configured")
+	}
+	cfg := sarama.NewConfig()
+	cfg.Metadata.Retry.Max = 0
+	if deadline, ok := ctx.Deadline(); ok {
+		if timeout := time.Until(deadline); timeout > 0 {
+			cfg.Net.DialTimeout = timeout
+			cfg.Net.ReadTimeout = timeout
+			cfg.Net.WriteTimeout = timeout
+		}
+	}
+	client, err := sarama.NewClient(brokers, cfg)
+	if err != nil {
+		return fmt.Errorf("kafka: connect: %w", err)
+	}
+	defer client.Close()
+	if err := client.RefreshMetadata(); err != nil {
+		return fmt.Errorf("kafka: fetch metadata: %w", err)
+	}
+	if len(client.Brokers()) == 0 {
+		return errors.New("kafka: metadata lists no brokers")
+	}
+	return nil
+}
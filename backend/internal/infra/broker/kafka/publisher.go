@@ -0,0 +1,39 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+
+	appoutbox "rentme/internal/app/outbox"
+)
+
+// Publisher writes outbox events with kafka-go. The writer connects lazily, so
+// an unreachable broker shows up as publish failures the relay retries.
+type Publisher struct {
+	writer *kafkago.Writer
+}
+
+func NewPublisher(brokers []string) *Publisher {
+	return &Publisher{writer: &kafkago.Writer{
+		Addr:                   kafkago.TCP(brokers...),
+		RequiredAcks:           kafkago.RequireAll,
+		AllowAutoTopicCreation: true,
+		BatchTimeout:           10 * time.Millisecond,
+	}}
+}
+
+func (p *Publisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	return p.writer.WriteMessages(ctx, kafkago.Message{
+		Topic:   topic,
+		Value:   payload,
+		Headers: []kafkago.Header{{Key: "content-type", Value: []byte("application/cloudevents+json")}},
+	})
+}
+
+func (p *Publisher) Close() error {
+	return p.writer.Close()
+}
+
+var _ appoutbox.Publisher = (*Publisher)(nil)
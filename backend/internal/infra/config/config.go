@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -13,6 +14,8 @@ type Config struct {
 	HTTPAddr           string
 	MongoURI           string
 	MongoDB            string
+	MongoMigrations    string
+	StartupChecks      string
 	KafkaBrokers       []string
 	KafkaTopicPrefix   string
 	IdempotencyTTL     time.Duration
@@ -20,18 +23,60 @@ type Config struct {
 	RetryBackoff       []time.Duration
 	PricingMode        string
 	MLPricingURL       string
+	MLBreakerFailures  int
+	MLBreakerCooldown  time.Duration
 	MLPriceClamps      string
+	PriceRounding      string
 	S3Endpoint         string
 	S3PublicEndpoint   string
 	S3AccessKey        string
 	S3SecretKey        string
 	S3Bucket           string
 	S3UseSSL           bool
+	MediaBaseURL       string
 	MessagingGRPCAddr  string
 	MessagingGRPCDial  time.Duration
 	MessagingGRPCTime  time.Duration
+	IntegrityCheck     time.Duration
+	BookingExpiryTick  time.Duration
+	BookingPendingTTL  time.Duration
+	AllowedCities      []string
+	MarketGrandfather  bool
+	MarketHideOutside  bool
+	PublishRelaxed     bool
+	RateLimitRPS       int
+	RateLimitBurst     int
+	UserRateLimitRPS   int
+	UserRateLimitBurst int
+	RateLimitMode      string
+	UserRateLimitMode  string
+	CatalogDedupe      bool
+	CatalogCacheTTL    time.Duration
+	CatalogConcurrency int
+	CatalogTimeout     time.Duration
+	PhotoUploadTimeout time.Duration
+	MLSuggestTimeout   time.Duration
+	RegisterVelocity   VelocityLimits
+	BookingVelocity    VelocityLimits
+	MetricsEnabled     bool
+	OTelEndpoint       string
+	CalendarFeedKey    string
 }
 
+// VelocityLimits are trust & safety thresholds per IP, device or user for one
+// action. Zero disables a window.
+type VelocityLimits struct {
+	VerifyHourly int
+	VerifyDaily  int
+	RejectHourly int
+	RejectDaily  int
+}
+
+var (
+	DefaultRegisterVelocity = VelocityLimits{VerifyHourly: 3, VerifyDaily: 10, RejectHourly: 10, RejectDaily: 30}
+	DefaultBookingVelocity  = VelocityLimits{VerifyHourly: 5, VerifyDaily: 20, RejectHourly: 15, RejectDaily: 60}
+)
+
 // Load parses configuration from the current environment.
 func Load() (Config, error) {
 	cfg := Config{
@@ -39,16 +84,22 @@ func Load() (Config, error) {
 		HTTPAddr:          getEnv("HTTP_ADDR", ":8080"),
 		MongoURI:          os.Getenv("MONGO_URI"),
 		MongoDB:           getEnv("MONGO_DB", "rentals"),
+		MongoMigrations:   strings.ToLower(getEnv("MONGO_MIGRATIONS", "warn")),
+		StartupChecks:     strings.ToLower(getEnv("STARTUP_CHECKS", "off")),
 		KafkaTopicPrefix:  getEnv("KAFKA_TOPIC_PREFIX", ""),
 		PricingMode:       strings.ToLower(getEnv("PRICING_MODE", "memory")),
 		MLPricingURL:      getEnv("ML_PRICING_URL", "http://localhost:8000/predict"),
 		MLPriceClamps:     os.Getenv("ML_PRICE_CLAMPS"),
+		PriceRounding:     os.Getenv("PRICE_ROUNDING"),
 		S3Endpoint:        getEnv("S3_ENDPOINT", "http://localhost:9000"),
 		S3PublicEndpoint:  getEnv("S3_PUBLIC_ENDPOINT", ""),
 		S3AccessKey:       getEnv("S3_ACCESS_KEY", "minioadmin"),
 		S3SecretKey:       getEnv("S3_SECRET_KEY", "minioadmin"),
 		S3Bucket:          getEnv("S3_BUCKET", "rentme-photos"),
+		MediaBaseURL:      os.Getenv("MEDIA_BASE_URL"),
 		MessagingGRPCAddr: getEnv("MESSAGING_GRPC_ADDR", "localhost:9000"),
+		OTelEndpoint:      os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		CalendarFeedKey:   os.Getenv("CALENDAR_FEED_KEY"),
 	}
 	brokers := getEnv("KAFKA_BROKERS", "")
 	if brokers != "" {
@@ -78,6 +129,24 @@ func Load() (Config, error) {
 	}
 	cfg.MessagingGRPCTime = callTimeout
 
+	integrityCheck, err := parseDurationEnv("INTEGRITY_CHECK_INTERVAL", 24*time.Hour)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.IntegrityCheck = integrityCheck
+
+	expiryTick, err := parseDurationEnv("BOOKING_EXPIRY_INTERVAL", 5*time.Minute)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.BookingExpiryTick = expiryTick
+
+	pendingTTL, err := parseDurationEnv("BOOKING_PENDING_TTL", 24*time.Hour)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.BookingPendingTTL = pendingTTL
+
 	retryStr := getEnv("RETRY_BACKOFF", "1s,5s,30s")
 	for _, raw := range strings.Split(retryStr, ",") {
 		val := strings.TrimSpace(raw)
@@ -90,6 +159,75 @@ func Load() (Config, error) {
 		}
 		cfg.RetryBackoff = append(cfg.RetryBackoff, d)
 	}
+	if cities := getEnv("ALLOWED_CITIES", ""); cities != "" {
+		cfg.AllowedCities = strings.Split(cities, ",")
+	}
+	grandfather, err := parseBoolEnv("MARKET_GRANDFATHER_ACTIVE", true)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.MarketGrandfather = grandfather
+	hideOutside, err := parseBoolEnv("MARKET_SEARCH_HIDE_OUTSIDE", false)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.MarketHideOutside = hideOutside
+	if cfg.PublishRelaxed, err = parseBoolEnv("LISTING_PUBLISH_RELAXED", false); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.RateLimitRPS, err = parseIntEnv("RATE_LIMIT_RPS", 20); err != nil {
+		return Config{}, err
+	}
+	if cfg.RateLimitBurst, err = parseIntEnv("RATE_LIMIT_BURST", 40); err != nil {
+		return Config{}, err
+	}
+	if cfg.UserRateLimitRPS, err = parseIntEnv("USER_RATE_LIMIT_RPS", 0); err != nil {
+		return Config{}, err
+	}
+	if cfg.UserRateLimitBurst, err = parseIntEnv("USER_RATE_LIMIT_BURST", 0); err != nil {
+		return Config{}, err
+	}
+	cfg.RateLimitMode = strings.ToLower(getEnv("RATE_LIMIT_MODE", "enforce"))
+	cfg.UserRateLimitMode = strings.ToLower(getEnv("USER_RATE_LIMIT_MODE", "enforce"))
+
+	if cfg.CatalogDedupe, err = parseBoolEnv("CATALOG_SEARCH_DEDUPE", false); err != nil {
+		return Config{}, err
+	}
+	if cfg.CatalogCacheTTL, err = parseDurationEnv("CATALOG_SEARCH_CACHE_TTL", 0); err != nil {
+		return Config{}, err
+	}
+	if cfg.CatalogConcurrency, err = parseIntEnv("CATALOG_SEARCH_CONCURRENCY", 0); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.CatalogTimeout, err = parseDurationEnv("CATALOG_REQUEST_TIMEOUT", 5*time.Second); err != nil {
+		return Config{}, err
+	}
+	if cfg.PhotoUploadTimeout, err = parseDurationEnv("PHOTO_UPLOAD_TIMEOUT", 30*time.Second); err != nil {
+		return Config{}, err
+	}
+	if cfg.MLSuggestTimeout, err = parseDurationEnv("PRICE_SUGGESTION_TIMEOUT", 10*time.Second); err != nil {
+		return Config{}, err
+	}
+	if cfg.MLBreakerFailures, err = parseIntEnv("ML_PRICING_CB_FAILURES", 5); err != nil {
+		return Config{}, err
+	}
+	if cfg.MLBreakerCooldown, err = parseDurationEnv("ML_PRICING_CB_COOLDOWN", 30*time.Second); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.RegisterVelocity, err = parseVelocityEnv("VELOCITY_REGISTER", DefaultRegisterVelocity); err != nil {
+		return Config{}, err
+	}
+	if cfg.BookingVelocity, err = parseVelocityEnv("VELOCITY_BOOKING", DefaultBookingVelocity); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.MetricsEnabled, err = parseBoolEnv("METRICS_ENABLED", false); err != nil {
+		return Config{}, err
+	}
+
 	useSSL, err := parseBoolEnv("S3_USE_SSL", false)
 	if err != nil {
 		return Config{}, err
@@ -130,6 +268,39 @@ func parseDurationEnv(key string, def time.Duration) (time.Duration, error) {
 	return d, nil
 }
 
+func parseIntEnv(key string, def int) (int, error) {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return def, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s integer: %w", key, err)
+	}
+	return v, nil
+}
+
+// parseVelocityEnv reads <prefix>_VERIFY_HOURLY, _VERIFY_DAILY, _REJECT_HOURLY and _REJECT_DAILY.
+func parseVelocityEnv(prefix string, def VelocityLimits) (VelocityLimits, error) {
+	var (
+		limits VelocityLimits
+		err    error
+	)
+	if limits.VerifyHourly, err = parseIntEnv(prefix+"_VERIFY_HOURLY", def.VerifyHourly); err != nil {
+		return VelocityLimits{}, err
+	}
+	if limits.VerifyDaily, err = parseIntEnv(prefix+"_VERIFY_DAILY", def.VerifyDaily); err != nil {
+		return VelocityLimits{}, err
+	}
+	if limits.RejectHourly, err = parseIntEnv(prefix+"_REJECT_HOURLY", def.RejectHourly); err != nil {
+		return VelocityLimits{}, err
+	}
+	if limits.RejectDaily, err = parseIntEnv(prefix+"_REJECT_DAILY", def.RejectDaily); err != nil {
+		return VelocityLimits{}, err
+	}
+	return limits, nil
+}
+
 func parseBoolEnv(key string, def bool) (bool, error) {
 	raw := os.Getenv(key)
 	if raw == "" {
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sources of a setting value.
+const (
+	SourceEnv     = "env"
+	SourceDefault = "default"
+)
+
+// Setting is one effective configuration value. Secret values are masked to
+// their last four characters.
+type Setting struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Secret bool   `json:"secret"`
+}
+
+// Describe lists the effective configuration by environment variable, so a
+// misconfiguration can be spotted without shell access. A value counts as
+// coming from the environment when its variable is set and non-empty, which is
+// the same rule Load applies.
+func (c Config) Describe() []Setting {
+	settings := []Setting{
+		setting("APP_ENV", c.Env),
+		setting("HTTP_ADDR", c.HTTPAddr),
+		{Name: "MONGO_URI", Value: maskURL(c.MongoURI), Secret: true},
+		setting("MONGO_DB", c.MongoDB),
+		setting("MONGO_MIGRATIONS", c.MongoMigrations),
+		setting("STARTUP_CHECKS", c.StartupChecks),
+		setting("KAFKA_BROKERS", strings.Join(c.KafkaBrokers, ",")),
+		setting("KAFKA_TOPIC_PREFIX", c.KafkaTopicPrefix),
+		setting("IDEMP_TTL", c.IdempotencyTTL.String()),
+		setting("OUTBOX_POLL_INTERVAL", c.OutboxPollInterval.String()),
+		setting("RETRY_BACKOFF", joinDurations(c.RetryBackoff)),
+		setting("PRICING_MODE", c.PricingMode),
+		setting("ML_PRICING_URL", c.MLPricingURL),
+		setting("ML_PRICING_CB_FAILURES", strconv.Itoa(c.MLBreakerFailures)),
+		setting("ML_PRICING_CB_COOLDOWN", c.MLBreakerCooldown.String()),
+		setting("ML_PRICE_CLAMPS", c.MLPriceClamps),
+		setting("PRICE_ROUNDING", c.PriceRounding),
+		setting("S3_ENDPOINT", c.S3Endpoint),
+		setting("S3_PUBLIC_ENDPOINT", c.S3PublicEndpoint),
+		secret("S3_ACCESS_KEY", c.S3AccessKey),
+		secret("S3_SECRET_KEY", c.S3SecretKey),
+		setting("S3_BUCKET", c.S3Bucket),
+		setting("S3_USE_SSL", strconv.FormatBool(c.S3UseSSL)),
+		setting("MEDIA_BASE_URL", c.MediaBaseURL),
+		setting("MESSAGING_GRPC_ADDR", c.MessagingGRPCAddr),
+		setting("MESSAGING_GRPC_DIAL_TIMEOUT", c.MessagingGRPCDial.String()),
+		setting("MESSAGING_GRPC_TIMEOUT", c.MessagingGRPCTime.String()),
+		setting("INTEGRITY_CHECK_INTERVAL", c.IntegrityCheck.String()),
+		setting("BOOKING_EXPIRY_INTERVAL", c.BookingExpiryTick.String()),
+		setting("BOOKING_PENDING_TTL", c.BookingPendingTTL.String()),
+		setting("ALLOWED_CITIES", strings.Join(c.AllowedCities, ",")),
+		setting("MARKET_GRANDFATHER_ACTIVE", strconv.FormatBool(c.MarketGrandfather)),
+		setting("MARKET_SEARCH_HIDE_OUTSIDE", strconv.FormatBool(c.MarketHideOutside)),
+		setting("LISTING_PUBLISH_RELAXED", strconv.FormatBool(c.PublishRelaxed)),
+		setting("RATE_LIMIT_RPS", strconv.Itoa(c.RateLimitRPS)),
+		setting("RATE_LIMIT_BURST", strconv.Itoa(c.RateLimitBurst)),
+		setting("USER_RATE_LIMIT_RPS", strconv.Itoa(c.UserRateLimitRPS)),
+		setting("USER_RATE_LIMIT_BURST", strconv.Itoa(c.UserRateLimitBurst)),
+		setting("RATE_LIMIT_MODE", c.RateLimitMode),
+		setting("USER_RATE_LIMIT_MODE", c.UserRateLimitMode),
+		setting("CATALOG_SEARCH_DEDUPE", strconv.FormatBool(c.CatalogDedupe)),
+		setting("CATALOG_SEARCH_CACHE_TTL", c.CatalogCacheTTL.String()),
+		setting("CATALOG_SEARCH_CONCURRENCY", strconv.Itoa(c.CatalogConcurrency)),
+		setting("CATALOG_REQUEST_TIMEOUT", c.CatalogTimeout.String()),
+		setting("PHOTO_UPLOAD_TIMEOUT", c.PhotoUploadTimeout.String()),
+		setting("PRICE_SUGGESTION_TIMEOUT", c.MLSuggestTimeout.String()),
+	}
+	settings = append(settings, velocitySettings("VELOCITY_REGISTER", c.RegisterVelocity)...)
+	settings = append(settings, velocitySettings("VELOCITY_BOOKING", c.BookingVelocity)...)
+	settings = append(settings,
+		setting("METRICS_ENABLED", strconv.FormatBool(c.MetricsEnabled)),
+		setting("OTEL_EXPORTER_OTLP_ENDPOINT", c.OTelEndpoint),
+		secret("CALENDAR_FEED_KEY", c.CalendarFeedKey),
+	)
+	for i := range settings {
+		settings[i].Source = source(settings[i].Name)
+	}
+	return settings
+}
+
+func setting(name, value string) Setting {
+	return Setting{Name: name, Value: value}
+}
+
+func secret(name, value string) Setting {
+	return Setting{Name: name, Value: maskSecret(value), Secret: true}
+}
+
+func velocitySettings(prefix string, limits VelocityLimits) []Setting {
+	return []Setting{
+		setting(prefix+"_VERIFY_HOURLY", strconv.Itoa(limits.VerifyHourly)),
+		setting(prefix+"_VERIFY_DAILY", strconv.Itoa(limits.VerifyDaily)),
+		setting(prefix+"_REJECT_HOURLY", strconv.Itoa(limits.RejectHourly)),
+		setting(prefix+"_REJECT_DAILY", strconv.Itoa(limits.RejectDaily)),
+	}
+}
+
+func source(name string) string {
+	if os.Getenv(name) != "" {
+		return SourceEnv
+	}
+	return SourceDefault
+}
+
+// maskSecret keeps the last four characters; shorter secrets are masked
+// entirely so that the mask never reveals the whole value.
+func maskSecret(value string) string {
+	const visible = 4
+	runes := []rune(value)
+	if len(runes) == 0 {
+		return ""
+	}
+	if len(runes) <= visible {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-visible) + string(runes[len(runes)-visible:])
+}
+
+// maskURL masks the password of a connection string and keeps the rest
+// readable; a value that does not parse is masked as a whole.
+func maskURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return maskSecret(raw)
+	}
+	if parsed.User == nil {
+		return raw
+	}
+	password, ok := parsed.User.Password()
+	if !ok {
+		return raw
+	}
+	parsed.User = url.UserPassword(parsed.User.Username(), maskSecret(password))
+	return parsed.String()
+}
+
+func joinDurations(values []time.Duration) string {
+	parts := make([]string, 0, len(values))
+	for _, d := range values {
+		parts = append(parts, d.String())
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,159 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	domainavailability "rentme/internal/domain/availability"
+	"rentme/internal/domain/listings"
+	"rentme/internal/domain/shared/concurrency"
+	domainrange "rentme/internal/domain/shared/daterange"
+)
+
+// defaultCleaningBufferDays matches the calendars the memory repository creates.
+const defaultCleaningBufferDays = 1
+
+// AvailabilityRepository stores one document per listing calendar, keyed by
+// listing id, with the blocks embedded.
+type AvailabilityRepository struct {
+	col *mongo.Collection
+}
+
+func NewAvailabilityRepository(db *mongo.Database) *AvailabilityRepository {
+	return &AvailabilityRepository{col: db.Collection("agg_availability")}
+}
+
+// Calendar loads the calendar, creating an empty one on first access.
+func (r *AvailabilityRepository) Calendar(ctx context.Context, id listings.ListingID) (*domainavailability.AvailabilityCalendar, error) {
+	insert := bson.M{
+		"blocks":               bson.A{},
+		"version":              int64(0),
+		"cleaning_buffer_days": defaultCleaningBufferDays,
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var doc availabilityDocument
+	err := r.col.FindOneAndUpdate(ctx, bson.M{"_id": string(id)}, bson.M{"$setOnInsert": insert}, opts).Decode(&doc)
+	if err != nil {
+		// Two first reads racing on the upsert: the loser sees a duplicate key
+		// and the document now exists.
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+		if err := r.col.FindOne(ctx, bson.M{"_id": string(id)}).Decode(&doc); err != nil {
+			return nil, err
+		}
+	}
+	return doc.toAggregate(), nil
+}
+
+// CalendarsByListingIDs loads all calendars with one $in query. Listings
+// without a document get an empty calendar; unlike Calendar nothing is upserted.
+func (r *AvailabilityRepository) CalendarsByListingIDs(ctx context.Context, ids []listings.ListingID) (map[listings.ListingID]*domainavailability.AvailabilityCalendar, error) {
+	out := make(map[listings.ListingID]*domainavailability.AvailabilityCalendar, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, string(id))
+	}
+	cursor, err := r.col.Find(ctx, bson.M{"_id": bson.M{"$in": keys}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var doc availabilityDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		cal := doc.toAggregate()
+		out[cal.ListingID] = cal
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if _, ok := out[id]; !ok {
+			out[id] = domainavailability.NewCalendar(id, defaultCleaningBufferDays)
+		}
+	}
+	return out, nil
+}
+
+// Save replaces the document if nobody saved it since it was loaded.
+func (r *AvailabilityRepository) Save(ctx context.Context, calendar *domainavailability.AvailabilityCalendar) error {
+	doc := newAvailabilityDocument(calendar)
+	filter := bson.M{"_id": doc.ID, "version": calendar.Version}
+	doc.Version = calendar.Version + 1
+	res, err := r.col.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return concurrency.ErrConcurrentUpdate
+		}
+		return err
+	}
+	if res.MatchedCount == 0 && res.UpsertedCount == 0 {
+		return concurrency.ErrConcurrentUpdate
+	}
+	calendar.Version = doc.Version
+	return nil
+}
+
+type availabilityDocument struct {
+	ID                 string                      `bson:"_id"`
+	Blocks             []availabilityBlockDocument `bson:"blocks"`
+	Version            int64                       `bson:"version"`
+	CleaningBufferDays int                         `bson:"cleaning_buffer_days"`
+}
+
+type availabilityBlockDocument struct {
+	CheckIn   time.Time `bson:"check_in"`
+	CheckOut  time.Time `bson:"check_out"`
+	Reason    string    `bson:"reason"`
+	Reference string    `bson:"reference"`
+	Note      string    `bson:"note,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func newAvailabilityDocument(calendar *domainavailability.AvailabilityCalendar) availabilityDocument {
+	blocks := make([]availabilityBlockDocument, 0, len(calendar.Blocks))
+	for _, block := range calendar.Blocks {
+		blocks = append(blocks, availabilityBlockDocument{
+			CheckIn:   block.Range.CheckIn.UTC(),
+			CheckOut:  block.Range.CheckOut.UTC(),
+			Reason:    string(block.Reason),
+			Reference: block.Reference,
+			Note:      block.Note,
+			CreatedAt: block.CreatedAt.UTC(),
+		})
+	}
+	return availabilityDocument{
+		ID:                 string(calendar.ListingID),
+		Blocks:             blocks,
+		Version:            calendar.Version,
+		CleaningBufferDays: calendar.CleaningBufferDays,
+	}
+}
+
+func (d availabilityDocument) toAggregate() *domainavailability.AvailabilityCalendar {
+	calendar := domainavailability.NewCalendar(listings.ListingID(d.ID), d.CleaningBufferDays)
+	calendar.Version = d.Version
+	calendar.Blocks = make([]domainavailability.Block, 0, len(d.Blocks))
+	for _, block := range d.Blocks {
+		calendar.Blocks = append(calendar.Blocks, domainavailability.Block{
+			Range:     domainrange.DateRange{CheckIn: block.CheckIn.UTC(), CheckOut: block.CheckOut.UTC()},
+			Reason:    domainavailability.BlockReason(block.Reason),
+			Reference: block.Reference,
+			Note:      block.Note,
+			CreatedAt: block.CreatedAt.UTC(),
+		})
+	}
+	return calendar
+}
+
+var _ domainavailability.Repository = (*AvailabilityRepository)(nil)
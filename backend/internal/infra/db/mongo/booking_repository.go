@@ -2,7 +2,6 @@ package mongo
 
 import (
 	"context"
-	"errors"
 	"sort"
 	"time"
 
@@ -13,11 +12,10 @@ import (
 	domainbooking "rentme/internal/domain/booking"
 	"rentme/internal/domain/listings"
 	domainpricing "rentme/internal/domain/pricing"
+	"rentme/internal/domain/shared/concurrency"
 	domainrange "rentme/internal/domain/shared/daterange"
 )
 
-var ErrConcurrentUpdate = errors.New("mongo: concurrent update detected")
-
 type BookingRepository struct {
 	col *mongo.Collection
 }
@@ -43,19 +41,67 @@ func (r *BookingRepository) Save(ctx context.Context, b *domainbooking.Booking)
 	res, err := r.col.UpdateOne(ctx, filter, update, opts)
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
-			return ErrConcurrentUpdate
+			return concurrency.ErrConcurrentUpdate
 		}
 		return err
 	}
 	if res.MatchedCount == 0 && res.UpsertedCount == 0 {
-		return ErrConcurrentUpdate
+		return concurrency.ErrConcurrentUpdate
 	}
 	b.Version = doc.Version
 	return nil
 }
 
-func (r *BookingRepository) ListByGuest(ctx context.Context, guestID string) ([]*domainbooking.Booking, error) {
+// ListByGuest pages with a keyset filter on (created_at, _id), so deep pages
+// cost the same as the first one.
+func (r *BookingRepository) ListByGuest(ctx context.Context, guestID string, page domainbooking.GuestPage) ([]*domainbooking.Booking, string, error) {
+	cursor, err := domainbooking.ParseCursor(page.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
 	filter := bson.M{"guest_id": guestID}
+	if cursor != nil {
+		createdAt := cursor.CreatedAt.UnixMilli()
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": createdAt}},
+			bson.M{"created_at": createdAt, "_id": bson.M{"$lt": string(cursor.ID)}},
+		}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if page.Limit > 0 {
+		opts.SetLimit(int64(page.Limit) + 1)
+	}
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cur.Close(ctx)
+
+	var items []*domainbooking.Booking
+	for cur.Next(ctx) {
+		var doc bookingDocument
+		if err := cur.Decode(&doc); err != nil {
+			return nil, "", err
+		}
+		agg, err := doc.toAggregate()
+		if err != nil {
+			return nil, "", err
+		}
+		items = append(items, agg)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if page.Limit > 0 && len(items) > page.Limit {
+		items = items[:page.Limit]
+		next = domainbooking.CursorFor(items[len(items)-1])
+	}
+	return items, next, nil
+}
+
+func (r *BookingRepository) ListByListing(ctx context.Context, listingID listings.ListingID) ([]*domainbooking.Booking, error) {
+	filter := bson.M{"listing_id": string(listingID)}
 	cur, err := r.col.Find(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -83,9 +129,14 @@ func (r *BookingRepository) ListByGuest(ctx context.Context, guestID string) ([]
 	return items, nil
 }
 
-func (r *BookingRepository) ListByListing(ctx context.Context, listingID listings.ListingID) ([]*domainbooking.Booking, error) {
-	filter := bson.M{"listing_id": string(listingID)}
-	cur, err := r.col.Find(ctx, filter)
+// ListByState returns bookings in the given state created before olderThan, oldest first.
+func (r *BookingRepository) ListByState(ctx context.Context, state domainbooking.BookingState, olderThan time.Time) ([]*domainbooking.Booking, error) {
+	filter := bson.M{"state": string(state)}
+	if !olderThan.IsZero() {
+		filter["created_at"] = bson.M{"$lt": olderThan.UnixMilli()}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cur, err := r.col.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -106,12 +157,112 @@ func (r *BookingRepository) ListByListing(ctx context.Context, listingID listing
 	if err := cur.Err(); err != nil {
 		return nil, err
 	}
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].CreatedAt.After(items[j].CreatedAt)
-	})
 	return items, nil
 }
 
+// ListByListingIDs returns a newest-first page of bookings for the given listings.
+func (r *BookingRepository) ListByListingIDs(ctx context.Context, ids []listings.ListingID, state domainbooking.BookingState, limit, offset int) ([]*domainbooking.Booking, int, error) {
+	if len(ids) == 0 {
+		return nil, 0, nil
+	}
+	listingIDs := make(bson.A, 0, len(ids))
+	for _, id := range ids {
+		listingIDs = append(listingIDs, string(id))
+	}
+	filter := bson.M{"listing_id": bson.M{"$in": listingIDs}}
+	if state != "" {
+		filter["state"] = string(state)
+	}
+	total, err := r.col.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(offset))
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cur.Close(ctx)
+
+	items := make([]*domainbooking.Booking, 0)
+	for cur.Next(ctx) {
+		var doc bookingDocument
+		if err := cur.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		agg, err := doc.toAggregate()
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, agg)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, 0, err
+	}
+	return items, int(total), nil
+}
+
+// List returns a newest-first page of bookings matching the filter.
+func (r *BookingRepository) List(ctx context.Context, filter domainbooking.BookingFilter) ([]*domainbooking.Booking, int, error) {
+	if filter.ListingIDs != nil && len(filter.ListingIDs) == 0 {
+		return []*domainbooking.Booking{}, 0, nil
+	}
+	query := bson.M{}
+	if filter.GuestID != "" {
+		query["guest_id"] = filter.GuestID
+	}
+	if len(filter.ListingIDs) > 0 {
+		listingIDs := make(bson.A, 0, len(filter.ListingIDs))
+		for _, id := range filter.ListingIDs {
+			listingIDs = append(listingIDs, string(id))
+		}
+		query["listing_id"] = bson.M{"$in": listingIDs}
+	}
+	if filter.State != "" {
+		query["state"] = string(filter.State)
+	}
+	total, err := r.col.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(max(filter.Offset, 0)))
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	cur, err := r.col.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cur.Close(ctx)
+
+	items := make([]*domainbooking.Booking, 0)
+	for cur.Next(ctx) {
+		var doc bookingDocument
+		if err := cur.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		agg, err := doc.toAggregate()
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, agg)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, 0, err
+	}
+	return items, int(total), nil
+}
+
 type bookingDocument struct {
 	ID          string                                   `bson:"_id"`
 	ListingID   string                                   `bson:"listing_id"`
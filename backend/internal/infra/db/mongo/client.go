@@ -26,3 +26,7 @@ func New(uri, database string) (*Client, error) {
 func (c *Client) Ping(ctx context.Context) error {
 	return c.DB.Client().Ping(ctx, nil)
 }
+
+func (c *Client) Close(ctx context.Context) error {
+	return c.DB.Client().Disconnect(ctx)
+}
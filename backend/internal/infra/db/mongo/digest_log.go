@@ -0,0 +1,55 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"rentme/internal/app/services/digest"
+)
+
+// DigestLog records sent host digests keyed by period and host, so the
+// unique _id rejects a second claim from any instance.
+type DigestLog struct {
+	col *mongo.Collection
+}
+
+func NewDigestLog(db *mongo.Database) *DigestLog {
+	return &DigestLog{col: db.Collection("app_digest_log")}
+}
+
+func (l *DigestLog) Claim(ctx context.Context, hostID, period string, at time.Time) (bool, error) {
+	doc := digestLogDocument{
+		ID:     digestLogID(hostID, period),
+		HostID: hostID,
+		Period: period,
+		SentAt: at.UTC(),
+	}
+	if _, err := l.col.InsertOne(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (l *DigestLog) Release(ctx context.Context, hostID, period string) error {
+	_, err := l.col.DeleteOne(ctx, bson.M{"_id": digestLogID(hostID, period)})
+	return err
+}
+
+type digestLogDocument struct {
+	ID     string    `bson:"_id"`
+	HostID string    `bson:"host_id"`
+	Period string    `bson:"period"`
+	SentAt time.Time `bson:"sent_at"`
+}
+
+func digestLogID(hostID, period string) string {
+	return period + "|" + hostID
+}
+
+var _ digest.SendLog = (*DigestLog)(nil)
@@ -13,23 +13,26 @@ import (
 
 type IdempotencyStore struct {
 	col *mongo.Collection
+	ttl time.Duration
 }
 
-func NewIdempotencyStore(db *mongo.Database) *IdempotencyStore {
-	col := db.Collection("app_idempotency")
-	ttl := time.Hour * 24 * 7
-	idx := mongo.IndexModel{
-		Keys:    bson.D{{Key: "created_at", Value: 1}},
-		Options: options.Index().SetExpireAfterSeconds(int32(ttl.Seconds())),
-	}
-	_, _ = col.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)})
-	_, _ = col.Indexes().CreateOne(context.Background(), idx)
-	return &IdempotencyStore{col: col}
+// NewIdempotencyStore stamps records with expires_at = now + ttl; the TTL
+// index on expires_at removes them once they lapse.
+func NewIdempotencyStore(db *mongo.Database, ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{col: db.Collection("app_idempotency"), ttl: ttl}
 }
 
 func (s *IdempotencyStore) Get(ctx context.Context, key string) (middleware.IdempotencyRecord, bool, error) {
+	// The TTL monitor runs about once a minute, so lapsed documents are filtered here too.
+	filter := bson.M{
+		"key": key,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$exists": false}},
+			bson.M{"expires_at": bson.M{"$gt": time.Now().UTC()}},
+		},
+	}
 	var doc idempotencyDocument
-	if err := s.col.FindOne(ctx, bson.M{"key": key}).Decode(&doc); err != nil {
+	if err := s.col.FindOne(ctx, filter).Decode(&doc); err != nil {
 		if err == mongo.ErrNoDocuments {
 			return middleware.IdempotencyRecord{}, false, nil
 		}
@@ -39,27 +42,50 @@ func (s *IdempotencyStore) Get(ctx context.Context, key string) (middleware.Idem
 }
 
 func (s *IdempotencyStore) Save(ctx context.Context, rec middleware.IdempotencyRecord) error {
+	now := time.Now().UTC()
+	if rec.ExpiresAt.IsZero() && s.ttl > 0 {
+		rec.ExpiresAt = now.Add(s.ttl)
+	}
 	doc := idempotencyDocument{
-		ID:         rec.Key,
-		Key:        rec.Key,
-		Payload:    rec.Payload,
-		Error:      rec.Error,
-		OccurredAt: rec.OccurredAt,
-		CreatedAt:  time.Now().UTC(),
+		ID:          rec.Key,
+		Key:         rec.Key,
+		Fingerprint: rec.Fingerprint,
+		Payload:     rec.Payload,
+		Error:       rec.Error,
+		OccurredAt:  rec.OccurredAt,
+		CreatedAt:   now,
+	}
+	if !rec.ExpiresAt.IsZero() {
+		expiresAt := rec.ExpiresAt.UTC()
+		doc.ExpiresAt = &expiresAt
 	}
 	_, err := s.col.UpdateByID(ctx, doc.ID, bson.M{"$set": doc}, options.Update().SetUpsert(true))
 	return err
 }
 
 type idempotencyDocument struct {
-	ID         string    `bson:"_id"`
-	Key        string    `bson:"key"`
-	Payload    []byte    `bson:"payload"`
-	Error      string    `bson:"error"`
-	OccurredAt time.Time `bson:"occurred_at"`
-	CreatedAt  time.Time `bson:"created_at"`
+	ID          string     `bson:"_id"`
+	Key         string     `bson:"key"`
+	Fingerprint string     `bson:"fingerprint,omitempty"`
+	Payload     []byte     `bson:"payload"`
+	Error       string     `bson:"error"`
+	OccurredAt  time.Time  `bson:"occurred_at"`
+	CreatedAt   time.Time  `bson:"created_at"`
+	ExpiresAt   *time.Time `bson:"expires_at,omitempty"`
 }
 
 func (d idempotencyDocument) toRecord() middleware.IdempotencyRecord {
-	return middleware.IdempotencyRecord{Key: d.Key, Payload: d.Payload, Error: d.Error, OccurredAt: d.OccurredAt}
+	rec := middleware.IdempotencyRecord{
+		Key:         d.Key,
+		Fingerprint: d.Fingerprint,
+		Payload:     d.Payload,
+		Error:       d.Error,
+		OccurredAt:  d.OccurredAt,
+	}
+	if d.ExpiresAt != nil {
+		rec.ExpiresAt = *d.ExpiresAt
+	}
+	return rec
 }
+
+var _ middleware.IdempotencyStore = (*IdempotencyStore)(nil)
@@ -0,0 +1,188 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"rentme/internal/app/jobs"
+)
+
+// jobRunRetention bounds the run log; the TTL index on finished_at removes
+// older runs.
+const jobRunRetention = 7 * 24 * time.Hour
+
+// JobStore keeps the job queue in app_jobs and the run log in app_job_runs.
+// Leases are taken with findOneAndUpdate so concurrent instances never claim
+// the same task.
+type JobStore struct {
+	tasks *mongo.Collection
+	runs  *mongo.Collection
+}
+
+func NewJobStore(db *mongo.Database) *JobStore {
+	return &JobStore{tasks: db.Collection("app_jobs"), runs: db.Collection("app_job_runs")}
+}
+
+func (s *JobStore) Enqueue(ctx context.Context, task jobs.Task) error {
+	doc := newJobTaskDocument(task)
+	_, err := s.tasks.UpdateByID(ctx, doc.ID, bson.M{"$setOnInsert": doc}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (s *JobStore) Lease(ctx context.Context, owner string, now time.Time, ttl time.Duration, limit int) ([]jobs.Task, error) {
+	filter := bson.M{
+		"run_at":      bson.M{"$lte": now},
+		"lease_until": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"lease_owner": owner, "lease_until": now.Add(ttl)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+	leased := make([]jobs.Task, 0)
+	for limit <= 0 || len(leased) < limit {
+		var doc jobTaskDocument
+		if err := s.tasks.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc); err != nil {
+			if err == mongo.ErrNoDocuments {
+				break
+			}
+			return leased, err
+		}
+		leased = append(leased, doc.toTask())
+	}
+	return leased, nil
+}
+
+func (s *JobStore) Reschedule(ctx context.Context, id, owner string, runAt time.Time, attempts int, lastError string) error {
+	filter := bson.M{"_id": id, "lease_owner": owner}
+	if runAt.IsZero() {
+		_, err := s.tasks.DeleteOne(ctx, filter)
+		return err
+	}
+	update := bson.M{"$set": bson.M{
+		"run_at":      runAt.UTC(),
+		"attempts":    attempts,
+		"last_error":  lastError,
+		"lease_owner": "",
+		"lease_until": time.Time{},
+	}}
+	_, err := s.tasks.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (s *JobStore) RecordRun(ctx context.Context, run jobs.Run) error {
+	_, err := s.runs.InsertOne(ctx, newJobRunDocument(run))
+	return err
+}
+
+func (s *JobStore) ListRuns(ctx context.Context, job string, limit int) ([]jobs.Run, error) {
+	filter := bson.M{}
+	if job != "" {
+		filter["job"] = job
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "finished_at", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cur, err := s.runs.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	result := make([]jobs.Run, 0)
+	for cur.Next(ctx) {
+		var doc jobRunDocument
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		result = append(result, doc.toRun())
+	}
+	return result, cur.Err()
+}
+
+type jobTaskDocument struct {
+	ID         string    `bson:"_id"`
+	Job        string    `bson:"job"`
+	Payload    []byte    `bson:"payload,omitempty"`
+	RunAt      time.Time `bson:"run_at"`
+	Attempts   int       `bson:"attempts"`
+	LeaseOwner string    `bson:"lease_owner"`
+	LeaseUntil time.Time `bson:"lease_until"`
+	LastError  string    `bson:"last_error,omitempty"`
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+func newJobTaskDocument(task jobs.Task) jobTaskDocument {
+	return jobTaskDocument{
+		ID:         task.ID,
+		Job:        task.Job,
+		Payload:    task.Payload,
+		RunAt:      task.RunAt.UTC(),
+		Attempts:   task.Attempts,
+		LeaseOwner: task.LeaseOwner,
+		LeaseUntil: task.LeaseUntil.UTC(),
+		LastError:  task.LastError,
+		CreatedAt:  task.CreatedAt.UTC(),
+	}
+}
+
+func (d jobTaskDocument) toTask() jobs.Task {
+	return jobs.Task{
+		ID:         d.ID,
+		Job:        d.Job,
+		Payload:    d.Payload,
+		RunAt:      d.RunAt,
+		Attempts:   d.Attempts,
+		LeaseOwner: d.LeaseOwner,
+		LeaseUntil: d.LeaseUntil,
+		LastError:  d.LastError,
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+type jobRunDocument struct {
+	ID         string    `bson:"_id"`
+	TaskID     string    `bson:"task_id"`
+	Job        string    `bson:"job"`
+	Owner      string    `bson:"owner"`
+	Attempt    int       `bson:"attempt"`
+	Status     string    `bson:"status"`
+	StartedAt  time.Time `bson:"started_at"`
+	FinishedAt time.Time `bson:"finished_at"`
+	DurationMs int64     `bson:"duration_ms"`
+	Error      string    `bson:"error,omitempty"`
+}
+
+func newJobRunDocument(run jobs.Run) jobRunDocument {
+	return jobRunDocument{
+		ID:         run.ID,
+		TaskID:     run.TaskID,
+		Job:        run.Job,
+		Owner:      run.Owner,
+		Attempt:    run.Attempt,
+		Status:     string(run.Status),
+		StartedAt:  run.StartedAt.UTC(),
+		FinishedAt: run.FinishedAt.UTC(),
+		DurationMs: run.Duration().Milliseconds(),
+		Error:      run.Error,
+	}
+}
+
+func (d jobRunDocument) toRun() jobs.Run {
+	return jobs.Run{
+		ID:         d.ID,
+		TaskID:     d.TaskID,
+		Job:        d.Job,
+		Owner:      d.Owner,
+		Attempt:    d.Attempt,
+		Status:     jobs.RunStatus(d.Status),
+		StartedAt:  d.StartedAt,
+		FinishedAt: d.FinishedAt,
+		Error:      d.Error,
+	}
+}
+
+var _ jobs.Store = (*JobStore)(nil)
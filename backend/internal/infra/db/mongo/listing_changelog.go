@@ -0,0 +1,70 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	domainlistings "rentme/internal/domain/listings"
+)
+
+// The changelog lives in its own collection so that frequently edited
+// listings do not grow their aggregate document without bound.
+const listingChangelogCollection = "listing_changelog"
+
+type changelogDocument struct {
+	ListingID string   `bson:"listing_id"`
+	Version   int64    `bson:"version"`
+	ChangedBy string   `bson:"changed_by"`
+	At        int64    `bson:"at"`
+	Fields    []string `bson:"fields"`
+}
+
+// History returns the saved changelog of a listing, newest first.
+func (r *ListingRepository) History(ctx context.Context, id domainlistings.ListingID) ([]domainlistings.ChangelogEntry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}, {Key: "at", Value: -1}})
+	cur, err := r.changelog.Find(ctx, bson.M{"listing_id": string(id)}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	entries := make([]domainlistings.ChangelogEntry, 0)
+	for cur.Next(ctx) {
+		var doc changelogDocument
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		entries = append(entries, domainlistings.ChangelogEntry{
+			Version:   doc.Version,
+			ChangedBy: doc.ChangedBy,
+			At:        timestampToTime(doc.At),
+			Fields:    doc.Fields,
+		})
+	}
+	return entries, cur.Err()
+}
+
+// saveChangelog writes the entries recorded since the listing was loaded under
+// the version the listing was just saved as.
+func (r *ListingRepository) saveChangelog(ctx context.Context, listing *domainlistings.Listing, version int64) error {
+	if len(listing.Changelog) == 0 {
+		return nil
+	}
+	docs := make([]any, 0, len(listing.Changelog))
+	for _, entry := range listing.Changelog {
+		docs = append(docs, changelogDocument{
+			ListingID: string(listing.ID),
+			Version:   version,
+			ChangedBy: entry.ChangedBy,
+			At:        entry.At.UnixMilli(),
+			Fields:    entry.Fields,
+		})
+	}
+	if _, err := r.changelog.InsertMany(ctx, docs); err != nil {
+		return err
+	}
+	listing.Changelog = nil
+	return nil
+}
@@ -0,0 +1,600 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/domain/shared/concurrency"
+)
+
+// bboxClauses splits the box so that no polygon crosses the antimeridian or
+// spans more than a hemisphere, which GeoJSON queries cannot express.
+func bboxClauses(box domainlistings.BoundingBox) bson.A {
+	if box.West > box.East {
+		return bson.A{
+			bboxPolygon(box.South, box.West, box.North, 180),
+			bboxPolygon(box.South, -180, box.North, box.East),
+		}
+	}
+	if box.East-box.West > 180 {
+		mid := (box.West + box.East) / 2
+		return bson.A{
+			bboxPolygon(box.South, box.West, box.North, mid),
+			bboxPolygon(box.South, mid, box.North, box.East),
+		}
+	}
+	return bson.A{bboxPolygon(box.South, box.West, box.North, box.East)}
+}
+
+func bboxPolygon(south, west, north, east float64) bson.M {
+	ring := bson.A{
+		bson.A{west, south}, bson.A{east, south}, bson.A{east, north}, bson.A{west, north}, bson.A{west, south},
+	}
+	return bson.M{"address.location": bson.M{"$geoWithin": bson.M{
+		"$geometry": bson.M{"type": "Polygon", "coordinates": bson.A{ring}},
+	}}}
+}
+
+// earthRadiusKm converts kilometres to radians for $centerSphere.
+const earthRadiusKm = 6378.1
+
+var ErrListingNotFound = domainlistings.ErrListingNotFound
+
+type ListingRepository struct {
+	col       *mongo.Collection
+	changelog *mongo.Collection
+}
+
+func NewListingRepository(db *mongo.Database) *ListingRepository {
+	return &ListingRepository{
+		col:       db.Collection("agg_listing"),
+		changelog: db.Collection(listingChangelogCollection),
+	}
+}
+
+func (r *ListingRepository) ByID(ctx context.Context, id domainlistings.ListingID) (*domainlistings.Listing, error) {
+	var doc listingDocument
+	if err := r.col.FindOne(ctx, bson.M{"_id": string(id)}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	return doc.toAggregate(), nil
+}
+
+func (r *ListingRepository) Save(ctx context.Context, listing *domainlistings.Listing) error {
+	doc := newListingDocument(listing)
+	filter := bson.M{"_id": doc.ID, "version": listing.Version}
+	doc.Version = listing.Version + 1
+	res, err := r.col.UpdateOne(ctx, filter, bson.M{"$set": doc}, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return concurrency.ErrConcurrentUpdate
+		}
+		return err
+	}
+	if res.MatchedCount == 0 && res.UpsertedCount == 0 {
+		return concurrency.ErrConcurrentUpdate
+	}
+	if err := r.saveChangelog(ctx, listing, doc.Version); err != nil {
+		return err
+	}
+	listing.Version = doc.Version
+	return nil
+}
+
+// Search applies the catalog filters in Mongo. The commute filter needs the
+// domain estimate, so when it is set paging happens after loading matches.
+func (r *ListingRepository) Search(ctx context.Context, params domainlistings.SearchParams) (domainlistings.SearchResult, error) {
+	opts := params.Normalized()
+	filter := listingSearchFilter(opts)
+	findOpts := options.Find().SetSort(listingSort(opts.Sort))
+	paged := opts.MaxTravelMinutes <= 0
+	if paged {
+		findOpts.SetSkip(int64(opts.Offset)).SetLimit(int64(opts.Limit))
+	}
+
+	cur, err := r.col.Find(ctx, filter, findOpts)
+	if err != nil {
+		return domainlistings.SearchResult{}, err
+	}
+	defer cur.Close(ctx)
+
+	var items []*domainlistings.Listing
+	for cur.Next(ctx) {
+		var doc listingDocument
+		if err := cur.Decode(&doc); err != nil {
+			return domainlistings.SearchResult{}, err
+		}
+		listing := doc.toAggregate()
+		if !paged {
+			commute, ok := listing.Commute(opts.TravelMode, opts.CommuteFrom)
+			if !ok || commute.Minutes > opts.MaxTravelMinutes {
+				continue
+			}
+		}
+		items = append(items, listing)
+	}
+	if err := cur.Err(); err != nil {
+		return domainlistings.SearchResult{}, err
+	}
+
+	if !paged {
+		total := len(items)
+		start := min(opts.Offset, total)
+		end := min(start+opts.Limit, total)
+		return domainlistings.SearchResult{Items: items[start:end], Total: total}, nil
+	}
+	total, err := r.col.CountDocuments(ctx, filter)
+	if err != nil {
+		return domainlistings.SearchResult{}, err
+	}
+	return domainlistings.SearchResult{Items: items, Total: int(total)}, nil
+}
+
+// PricePerSqMMedians groups the active listings with a known area by city
+// and rental term in one aggregation; the medians are taken from the groups.
+func (r *ListingRepository) PricePerSqMMedians(ctx context.Context, cities []string) (map[domainlistings.BenchmarkKey]float64, error) {
+	medians := make(map[domainlistings.BenchmarkKey]float64)
+	if len(cities) == 0 {
+		return medians, nil
+	}
+	patterns := make(bson.A, 0, len(cities))
+	for _, city := range cities {
+		patterns = append(patterns, equalFold(city))
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"state":        string(domainlistings.ListingActive),
+			"has_area":     true,
+			"address.city": bson.M{"$in": patterns},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"city": bson.M{"$toLower": bson.M{"$trim": bson.M{"input": "$address.city"}}},
+				"term": "$rental_term_type",
+			},
+			"prices": bson.M{"$push": "$price_per_sq_m"},
+		}}},
+	}
+	cur, err := r.col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		var group struct {
+			ID struct {
+				City string `bson:"city"`
+				Term string `bson:"term"`
+			} `bson:"_id"`
+			Prices []float64 `bson:"prices"`
+		}
+		if err := cur.Decode(&group); err != nil {
+			return nil, err
+		}
+		if median := domainlistings.MedianPricePerSqM(group.Prices); median > 0 {
+			key := domainlistings.BenchmarkKey{City: group.ID.City, Term: domainlistings.RentalTermType(group.ID.Term)}
+			medians[key] = median
+		}
+	}
+	return medians, cur.Err()
+}
+
+func listingSearchFilter(opts domainlistings.SearchParams) bson.M {
+	filter := bson.M{}
+	var and bson.A
+	if opts.OnlyActive {
+		filter["state"] = string(domainlistings.ListingActive)
+	} else if len(opts.States) > 0 {
+		states := make(bson.A, 0, len(opts.States))
+		for _, state := range opts.States {
+			states = append(states, string(state))
+		}
+		filter["state"] = bson.M{"$in": states}
+	} else {
+		filter["state"] = bson.M{"$ne": string(domainlistings.ListingDeleted)}
+	}
+	if opts.Host != "" {
+		filter["host"] = string(opts.Host)
+	}
+	if opts.UnitGroupID != "" {
+		filter["unit_group_id"] = opts.UnitGroupID
+	}
+	if opts.City != "" {
+		filter["address.city"] = equalFold(opts.City)
+	}
+	if len(opts.Cities) > 0 {
+		cities := make(bson.A, 0, len(opts.Cities))
+		for _, city := range opts.Cities {
+			cities = append(cities, equalFold(city))
+		}
+		and = append(and, bson.M{"address.city": bson.M{"$in": cities}})
+	}
+	if opts.Region != "" {
+		filter["address.region"] = equalFold(opts.Region)
+	}
+	if opts.Country != "" {
+		filter["address.country"] = equalFold(opts.Country)
+	}
+	if opts.LocationQuery != "" {
+		pattern := containsFold(opts.LocationQuery)
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"address.city": pattern},
+			bson.M{"address.region": pattern},
+			bson.M{"address.country": pattern},
+			bson.M{"address.line1": pattern},
+			bson.M{"title": pattern},
+		}})
+	}
+	if opts.MinGuests > 0 {
+		filter["guests_limit"] = bson.M{"$gte": opts.MinGuests}
+	}
+	if opts.PriceMinRub > 0 || opts.PriceMaxRub > 0 {
+		price := bson.M{}
+		if opts.PriceMinRub > 0 {
+			price["$gte"] = opts.PriceMinRub
+		}
+		if opts.PriceMaxRub > 0 {
+			price["$lte"] = opts.PriceMaxRub
+		}
+		filter["rate_rub"] = price
+	}
+	if opts.MaxPricePerSqM > 0 {
+		filter["has_area"] = true
+		filter["price_per_sq_m"] = bson.M{"$lte": opts.MaxPricePerSqM}
+	}
+	if !opts.CheckIn.IsZero() {
+		filter["available_from"] = bson.M{"$lte": opts.CheckIn.UnixMilli()}
+	}
+	if len(opts.Amenities) > 0 {
+		filter["search_amenities"] = bson.M{"$all": opts.Amenities}
+	}
+	if len(opts.Tags) > 0 {
+		filter["search_tags"] = bson.M{"$all": opts.Tags}
+	}
+	if len(opts.PropertyTypes) > 0 {
+		filter["search_property_type"] = bson.M{"$in": opts.PropertyTypes}
+	}
+	if len(opts.RentalTerms) > 0 {
+		terms := make(bson.A, 0, len(opts.RentalTerms))
+		for _, term := range opts.RentalTerms {
+			terms = append(terms, string(term))
+		}
+		filter["rental_term_type"] = bson.M{"$in": terms}
+	}
+	if center, radius, ok := opts.GeoFilter(); ok {
+		// Listings without coordinates have no location and never match.
+		filter["address.location"] = bson.M{"$geoWithin": bson.M{
+			"$centerSphere": bson.A{bson.A{center.Lon, center.Lat}, radius / earthRadiusKm},
+		}}
+	}
+	if opts.BBox != nil {
+		and = append(and, bson.M{"$or": bboxClauses(*opts.BBox)})
+	}
+	if len(and) > 0 {
+		filter["$and"] = and
+	}
+	return filter
+}
+
+func listingSort(sort domainlistings.CatalogSort) bson.D {
+	switch sort {
+	case domainlistings.SortByPriceDesc:
+		return bson.D{{Key: "rate_rub", Value: -1}, {Key: "rating", Value: -1}, {Key: "_id", Value: 1}}
+	case domainlistings.SortByRating:
+		return bson.D{{Key: "rating", Value: -1}, {Key: "rate_rub", Value: 1}, {Key: "_id", Value: 1}}
+	case domainlistings.SortByNewest:
+		return bson.D{{Key: "available_from", Value: -1}, {Key: "rate_rub", Value: 1}, {Key: "_id", Value: 1}}
+	case domainlistings.SortByUpdated:
+		return bson.D{{Key: "updated_at", Value: -1}, {Key: "rate_rub", Value: 1}, {Key: "_id", Value: 1}}
+	case domainlistings.SortByPricePerSqM:
+		return bson.D{{Key: "has_area", Value: -1}, {Key: "price_per_sq_m", Value: 1}, {Key: "rate_rub", Value: 1}, {Key: "_id", Value: 1}}
+	default:
+		return bson.D{{Key: "rate_rub", Value: 1}, {Key: "rating", Value: -1}, {Key: "_id", Value: 1}}
+	}
+}
+
+// equalFold matches the whole value case-insensitively; unlike a $regex
+// document it is also accepted inside $in.
+func equalFold(value string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(strings.TrimSpace(value)) + "$", Options: "i"}
+}
+
+func containsFold(value string) primitive.Regex {
+	return primitive.Regex{Pattern: regexp.QuoteMeta(value), Options: "i"}
+}
+
+type listingDocument struct {
+	ID                   string            `bson:"_id"`
+	Host                 string            `bson:"host"`
+	CoHosts              []string          `bson:"co_hosts,omitempty"`
+	UnitGroupID          string            `bson:"unit_group_id,omitempty"`
+	Title                string            `bson:"title"`
+	Description          string            `bson:"description"`
+	PropertyType         string            `bson:"property_type"`
+	Address              addressDocument   `bson:"address"`
+	Amenities            []string          `bson:"amenities"`
+	GuestsLimit          int               `bson:"guests_limit"`
+	MinNights            int               `bson:"min_nights"`
+	MaxNights            int               `bson:"max_nights"`
+	MinMonths            int               `bson:"min_months"`
+	MaxMonths            int               `bson:"max_months"`
+	HouseRules           []string          `bson:"house_rules"`
+	CancellationPolicyID string            `bson:"cancellation_policy_id"`
+	State                string            `bson:"state"`
+	Tags                 []string          `bson:"tags"`
+	Highlights           []string          `bson:"highlights"`
+	RateRub              int64             `bson:"rate_rub"`
+	CleaningFeeRub       int64             `bson:"cleaning_fee_rub,omitempty"`
+	ServiceFeePct        float64           `bson:"service_fee_pct,omitempty"`
+	WeekendPremiumPct    float64           `bson:"weekend_premium_pct,omitempty"`
+	Bedrooms             int               `bson:"bedrooms"`
+	Bathrooms            int               `bson:"bathrooms"`
+	Floor                int               `bson:"floor"`
+	FloorsTotal          int               `bson:"floors_total"`
+	RenovationScore      int               `bson:"renovation_score"`
+	BuildingAgeYears     int               `bson:"building_age_years"`
+	AreaSquareMeters     float64           `bson:"area_sq_m"`
+	TravelMinutes        float64           `bson:"travel_minutes"`
+	TravelMode           string            `bson:"travel_mode"`
+	RentalTermType       string            `bson:"rental_term_type"`
+	VerifiedGuestsOnly   bool              `bson:"verified_guests_only,omitempty"`
+	ThumbnailURL         string            `bson:"thumbnail_url"`
+	Rating               float64           `bson:"rating"`
+	Photos               []string          `bson:"photos"`
+	PhotoTags            map[string]string `bson:"photo_tags,omitempty"`
+	AvailableFrom        int64             `bson:"available_from"`
+	RepublishAt          int64             `bson:"republish_at,omitempty"`
+	DeletedAt            int64             `bson:"deleted_at,omitempty"`
+	CreatedAt            int64             `bson:"created_at"`
+	UpdatedAt            int64             `bson:"updated_at"`
+	Version              int64             `bson:"version"`
+	// Lower-cased copies used by case-insensitive catalog filters.
+	SearchTags         []string `bson:"search_tags"`
+	SearchAmenities    []string `bson:"search_amenities"`
+	SearchPropertyType string   `bson:"search_property_type"`
+	// PricePerSqM is zero and HasArea false when the area is unknown; the
+	// flag keeps those listings last in the price per square meter sort.
+	PricePerSqM float64 `bson:"price_per_sq_m"`
+	HasArea     bool    `bson:"has_area"`
+
+	LongStayDiscountPct     float64 `bson:"long_stay_discount_pct,omitempty"`
+	LongStayThresholdNights int     `bson:"long_stay_threshold_nights,omitempty"`
+
+	PriceHistory []priceChangeDocument `bson:"price_history,omitempty"`
+}
+
+// priceChangeDocument is one entry of the capped price history kept on the
+// listing document.
+type priceChangeDocument struct {
+	OldRateRub int64  `bson:"old_rate_rub"`
+	NewRateRub int64  `bson:"new_rate_rub"`
+	Source     string `bson:"source"`
+	ChangedBy  string `bson:"changed_by,omitempty"`
+	At         int64  `bson:"at"`
+}
+
+type addressDocument struct {
+	Line1    string         `bson:"line1"`
+	Line2    string         `bson:"line2"`
+	City     string         `bson:"city"`
+	Region   string         `bson:"region"`
+	Country  string         `bson:"country"`
+	Lat      float64        `bson:"lat"`
+	Lon      float64        `bson:"lon"`
+	Location *pointDocument `bson:"location,omitempty"`
+}
+
+// pointDocument is a GeoJSON point indexed with 2dsphere.
+type pointDocument struct {
+	Type        string    `bson:"type"`
+	Coordinates []float64 `bson:"coordinates"`
+}
+
+func newListingDocument(l *domainlistings.Listing) listingDocument {
+	address := addressDocument{
+		Line1:   l.Address.Line1,
+		Line2:   l.Address.Line2,
+		City:    l.Address.City,
+		Region:  l.Address.Region,
+		Country: l.Address.Country,
+		Lat:     l.Address.Lat,
+		Lon:     l.Address.Lon,
+	}
+	if l.HasCoordinates() {
+		address.Location = &pointDocument{Type: "Point", Coordinates: []float64{l.Address.Lon, l.Address.Lat}}
+	}
+	coHosts := make([]string, 0, len(l.CoHosts))
+	for _, id := range l.CoHosts {
+		coHosts = append(coHosts, string(id))
+	}
+	photoTags := make(map[string]string, len(l.PhotoTags))
+	for url, tag := range l.PhotoTags {
+		photoTags[url] = string(tag)
+	}
+	priceHistory := make([]priceChangeDocument, 0, len(l.PriceHistory))
+	for _, change := range l.PriceHistory {
+		priceHistory = append(priceHistory, priceChangeDocument{
+			OldRateRub: change.OldRateRub,
+			NewRateRub: change.NewRateRub,
+			Source:     string(change.Source),
+			ChangedBy:  change.ChangedBy,
+			At:         change.At.UnixMilli(),
+		})
+	}
+	pricePerSqM, hasArea := l.PricePerSqM()
+	return listingDocument{
+		ID:                   string(l.ID),
+		Host:                 string(l.Host),
+		CoHosts:              coHosts,
+		UnitGroupID:          l.UnitGroupID,
+		Title:                l.Title,
+		Description:          l.Description,
+		PropertyType:         l.PropertyType,
+		Address:              address,
+		Amenities:            l.Amenities,
+		GuestsLimit:          l.GuestsLimit,
+		MinNights:            l.MinNights,
+		MaxNights:            l.MaxNights,
+		MinMonths:            l.MinMonths,
+		MaxMonths:            l.MaxMonths,
+		HouseRules:           l.HouseRules,
+		CancellationPolicyID: l.CancellationPolicyID,
+		State:                string(l.State),
+		Tags:                 l.Tags,
+		Highlights:           l.Highlights,
+		RateRub:              l.RateRub,
+		CleaningFeeRub:       l.CleaningFeeRub,
+		ServiceFeePct:        l.ServiceFeePct,
+		WeekendPremiumPct:    l.WeekendPremiumPct,
+		Bedrooms:             l.Bedrooms,
+		Bathrooms:            l.Bathrooms,
+		Floor:                l.Floor,
+		FloorsTotal:          l.FloorsTotal,
+		RenovationScore:      l.RenovationScore,
+		BuildingAgeYears:     l.BuildingAgeYears,
+		AreaSquareMeters:     l.AreaSquareMeters,
+		TravelMinutes:        l.TravelMinutes,
+		TravelMode:           l.TravelMode,
+		RentalTermType:       string(l.RentalTermType),
+		VerifiedGuestsOnly:   l.VerifiedGuestsOnly,
+		ThumbnailURL:         l.ThumbnailURL,
+		Rating:               l.Rating,
+		Photos:               l.Photos,
+		PhotoTags:            photoTags,
+		AvailableFrom:        optionalTimestamp(l.AvailableFrom),
+		RepublishAt:          optionalTimestamp(l.RepublishAt),
+		DeletedAt:            optionalTimestamp(l.DeletedAt),
+		CreatedAt:            l.CreatedAt.UnixMilli(),
+		UpdatedAt:            l.UpdatedAt.UnixMilli(),
+		Version:              l.Version,
+		SearchTags:           lowerAll(l.Tags),
+		SearchAmenities:      lowerAll(l.Amenities),
+		SearchPropertyType:   strings.ToLower(strings.TrimSpace(l.PropertyType)),
+		PricePerSqM:          pricePerSqM,
+		HasArea:              hasArea,
+
+		LongStayDiscountPct:     l.LongStayDiscountPct,
+		LongStayThresholdNights: l.LongStayThresholdNights,
+
+		PriceHistory: priceHistory,
+	}
+}
+
+func (d listingDocument) toAggregate() *domainlistings.Listing {
+	coHosts := make([]domainlistings.HostID, 0, len(d.CoHosts))
+	for _, id := range d.CoHosts {
+		coHosts = append(coHosts, domainlistings.HostID(id))
+	}
+	photoTags := make(map[string]domainlistings.PhotoTag, len(d.PhotoTags))
+	for url, tag := range d.PhotoTags {
+		photoTags[url] = domainlistings.PhotoTag(tag)
+	}
+	var priceHistory []domainlistings.PriceChange
+	for _, change := range d.PriceHistory {
+		priceHistory = append(priceHistory, domainlistings.PriceChange{
+			OldRateRub: change.OldRateRub,
+			NewRateRub: change.NewRateRub,
+			Source:     domainlistings.PriceChangeSource(change.Source),
+			ChangedBy:  change.ChangedBy,
+			At:         timestampToTime(change.At),
+		})
+	}
+	return &domainlistings.Listing{
+		ID:           domainlistings.ListingID(d.ID),
+		Host:         domainlistings.HostID(d.Host),
+		CoHosts:      coHosts,
+		UnitGroupID:  d.UnitGroupID,
+		Title:        d.Title,
+		Description:  d.Description,
+		PropertyType: d.PropertyType,
+		Address: domainlistings.Address{
+			Line1:   d.Address.Line1,
+			Line2:   d.Address.Line2,
+			City:    d.Address.City,
+			Region:  d.Address.Region,
+			Country: d.Address.Country,
+			Lat:     d.Address.Lat,
+			Lon:     d.Address.Lon,
+		},
+		Amenities:            d.Amenities,
+		GuestsLimit:          d.GuestsLimit,
+		MinNights:            d.MinNights,
+		MaxNights:            d.MaxNights,
+		MinMonths:            d.MinMonths,
+		MaxMonths:            d.MaxMonths,
+		HouseRules:           d.HouseRules,
+		CancellationPolicyID: d.CancellationPolicyID,
+		State:                domainlistings.ListingState(d.State),
+		Tags:                 d.Tags,
+		Highlights:           d.Highlights,
+		RateRub:              d.RateRub,
+		CleaningFeeRub:       d.CleaningFeeRub,
+		ServiceFeePct:        d.ServiceFeePct,
+		WeekendPremiumPct:    d.WeekendPremiumPct,
+		Bedrooms:             d.Bedrooms,
+		Bathrooms:            d.Bathrooms,
+		Floor:                d.Floor,
+		FloorsTotal:          d.FloorsTotal,
+		RenovationScore:      d.RenovationScore,
+		BuildingAgeYears:     d.BuildingAgeYears,
+		AreaSquareMeters:     d.AreaSquareMeters,
+		TravelMinutes:        d.TravelMinutes,
+		TravelMode:           d.TravelMode,
+		RentalTermType:       domainlistings.RentalTermType(d.RentalTermType),
+		VerifiedGuestsOnly:   d.VerifiedGuestsOnly,
+		ThumbnailURL:         d.ThumbnailURL,
+		Rating:               d.Rating,
+		Photos:               d.Photos,
+		PhotoTags:            photoTags,
+		AvailableFrom:        optionalTime(d.AvailableFrom),
+		RepublishAt:          optionalTime(d.RepublishAt),
+		DeletedAt:            optionalTime(d.DeletedAt),
+		Version:              d.Version,
+		CreatedAt:            timestampToTime(d.CreatedAt),
+		UpdatedAt:            timestampToTime(d.UpdatedAt),
+
+		LongStayDiscountPct:     d.LongStayDiscountPct,
+		LongStayThresholdNights: d.LongStayThresholdNights,
+
+		PriceHistory: priceHistory,
+	}
+}
+
+// optionalTimestamp keeps zero times as 0 so they round-trip as zero.
+func optionalTimestamp(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func optionalTime(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return timestampToTime(ms)
+}
+
+func lowerAll(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+var _ domainlistings.ListingRepository = (*ListingRepository)(nil)
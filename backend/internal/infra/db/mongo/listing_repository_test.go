@@ -0,0 +1,41 @@
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	domainlistings "rentme/internal/domain/listings"
+)
+
+func TestListingSearchFilterComparesRubles(t *testing.T) {
+	cases := []struct {
+		name string
+		opts domainlistings.SearchParams
+		want bson.M
+	}{
+		{"max only", domainlistings.SearchParams{PriceMaxRub: 5000}, bson.M{"$lte": int64(5000)}},
+		{"both bounds", domainlistings.SearchParams{PriceMinRub: 1000, PriceMaxRub: 5000}, bson.M{"$gte": int64(1000), "$lte": int64(5000)}},
+		{"no bounds", domainlistings.SearchParams{}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := listingSearchFilter(tc.opts)["rate_rub"]
+			if tc.want == nil {
+				if ok {
+					t.Fatalf("rate_rub filter = %v, want none", got)
+				}
+				return
+			}
+			price, isMap := got.(bson.M)
+			if !isMap || len(price) != len(tc.want) {
+				t.Fatalf("rate_rub filter = %#v, want %v", got, tc.want)
+			}
+			for op, value := range tc.want {
+				if price[op] != value {
+					t.Fatalf("rate_rub %s = %#v, want %#v", op, price[op], value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const migrationsCollection = "schema_migrations"
+
+var ErrMigratorNotConfigured = errors.New("mongo: migrator missing database")
+
+// Migration is a single versioned schema step. Up must be idempotent so a
+// partially applied migration can be retried.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// Migrator applies migrations in version order and records them in the
+// schema_migrations collection.
+type Migrator struct {
+	DB         *mongo.Database
+	Migrations []Migration
+	Logger     *slog.Logger
+}
+
+// NewMigrator returns a migrator preloaded with the application migrations.
+func NewMigrator(db *mongo.Database, logger *slog.Logger) *Migrator {
+	return &Migrator{DB: db, Migrations: Migrations(), Logger: logger}
+}
+
+// Pending lists migrations that have not been recorded as applied yet.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	if m.DB == nil {
+		return nil, ErrMigratorNotConfigured
+	}
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ordered := append([]Migration(nil), m.Migrations...)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].Version < ordered[j].Version })
+	pending := make([]Migration, 0, len(ordered))
+	for _, migration := range ordered {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		pending = append(pending, migration)
+	}
+	return pending, nil
+}
+
+// Up applies every pending migration and returns how many were executed.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return 0, err
+	}
+	col := m.DB.Collection(migrationsCollection)
+	for i, migration := range pending {
+		if m.Logger != nil {
+			m.Logger.Info("applying mongo migration", "version", migration.Version, "description", migration.Description)
+		}
+		if err := migration.Up(ctx, m.DB); err != nil {
+			return i, fmt.Errorf("mongo: migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+		doc := migrationDocument{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		}
+		if _, err := col.UpdateByID(ctx, doc.Version, bson.M{"$set": doc}, options.Update().SetUpsert(true)); err != nil {
+			return i, err
+		}
+	}
+	return len(pending), nil
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int]struct{}, error) {
+	cur, err := m.DB.Collection(migrationsCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	applied := make(map[int]struct{})
+	for cur.Next(ctx) {
+		var doc migrationDocument
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		applied[doc.Version] = struct{}{}
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+type migrationDocument struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Migrations returns the application schema history. Append new entries with
+// the next version number; never renumber or edit applied ones.
+func Migrations() []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Description: "booking guest and listing indexes",
+			Up: createIndexes("agg_booking",
+				mongo.IndexModel{Keys: bson.D{{Key: "guest_id", Value: 1}, {Key: "created_at", Value: -1}}},
+				mongo.IndexModel{Keys: bson.D{{Key: "listing_id", Value: 1}, {Key: "created_at", Value: -1}}},
+				mongo.IndexModel{Keys: bson.D{{Key: "state", Value: 1}}},
+			),
+		},
+		{
+			Version:     2,
+			Description: "listing search indexes",
+			Up: createIndexes("agg_listing",
+				mongo.IndexModel{Keys: bson.D{{Key: "state", Value: 1}, {Key: "address.city", Value: 1}, {Key: "rate_rub", Value: 1}}},
+				mongo.IndexModel{Keys: bson.D{{Key: "host", Value: 1}, {Key: "updated_at", Value: -1}}},
+				mongo.IndexModel{Keys: bson.D{{Key: "rental_term_type", Value: 1}}},
+			),
+		},
+		{
+			Version:     3,
+			Description: "unique user email",
+			Up: createIndexes("agg_user",
+				mongo.IndexModel{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+			),
+		},
+		{
+			Version:     4,
+			Description: "idempotency key and ttl indexes",
+			Up: createIndexes("app_idempotency",
+				mongo.IndexModel{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "created_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(int32((7 * 24 * time.Hour).Seconds())),
+				},
+			),
+		},
+		{
+			Version:     5,
+			Description: "outbox and inbox indexes",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if err := createIndexes("app_outbox",
+					mongo.IndexModel{Keys: bson.D{{Key: "state", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+				)(ctx, db); err != nil {
+					return err
+				}
+				return createIndexes("app_inbox",
+					mongo.IndexModel{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "consumer", Value: 1}}, Options: options.Index().SetUnique(true)},
+				)(ctx, db)
+			},
+		},
+		{
+			Version:     6,
+			Description: "wishlist listing index",
+			Up: createIndexes("agg_wishlist",
+				mongo.IndexModel{Keys: bson.D{{Key: "listing_ids", Value: 1}}},
+			),
+		},
+		{
+			Version:     7,
+			Description: "backfill booking price unit",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				filter := bson.M{"$or": bson.A{
+					bson.M{"price_unit": bson.M{"$exists": false}},
+					bson.M{"price_unit": ""},
+				}}
+				_, err := db.Collection("agg_booking").UpdateMany(ctx, filter, bson.M{"$set": bson.M{"price_unit": "night"}})
+				return err
+			},
+		},
+		{
+			Version:     8,
+			Description: "booking state and age index for expiry",
+			Up: createIndexes("agg_booking",
+				mongo.IndexModel{Keys: bson.D{{Key: "state", Value: 1}, {Key: "created_at", Value: 1}}},
+			),
+		},
+		{
+			Version:     9,
+			Description: "idempotency expiry follows expires_at",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				// Records now carry their own deadline from IDEMPOTENCY_TTL; the fixed
+				// seven-day index on created_at would cut longer TTLs short.
+				indexes := db.Collection("app_idempotency").Indexes()
+				if _, err := indexes.DropOne(ctx, "created_at_1"); err != nil && !isIndexNotFound(err) {
+					return err
+				}
+				return createIndexes("app_idempotency",
+					mongo.IndexModel{
+						Keys:    bson.D{{Key: "expires_at", Value: 1}},
+						Options: options.Index().SetExpireAfterSeconds(0),
+					},
+				)(ctx, db)
+			},
+		},
+		{
+			Version:     10,
+			Description: "listing geo index for radius search",
+			Up: createIndexes("agg_listing",
+				mongo.IndexModel{Keys: bson.D{{Key: "address.location", Value: "2dsphere"}}},
+			),
+		},
+		{
+			Version:     11,
+			Description: "background job queue and run log",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				if err := createIndexes("app_jobs",
+					mongo.IndexModel{Keys: bson.D{{Key: "run_at", Value: 1}, {Key: "lease_until", Value: 1}}},
+				)(ctx, db); err != nil {
+					return err
+				}
+				return createIndexes("app_job_runs",
+					mongo.IndexModel{Keys: bson.D{{Key: "job", Value: 1}, {Key: "finished_at", Value: -1}}},
+					mongo.IndexModel{
+						Keys:    bson.D{{Key: "finished_at", Value: 1}},
+						Options: options.Index().SetExpireAfterSeconds(int32(jobRunRetention.Seconds())),
+					},
+				)(ctx, db)
+			},
+		},
+		{
+			Version:     12,
+			Description: "listing unit group lookup",
+			Up: createIndexes("agg_listing",
+				mongo.IndexModel{Keys: bson.D{{Key: "host", Value: 1}, {Key: "unit_group_id", Value: 1}}},
+			),
+		},
+		{
+			Version:     13,
+			Description: "photo upload session expiry",
+			Up: createIndexes("app_photo_uploads",
+				mongo.IndexModel{Keys: bson.D{{Key: "expires_at", Value: 1}}},
+			),
+		},
+		{
+			Version:     14,
+			Description: "listing changelog lookup",
+			Up: createIndexes(listingChangelogCollection,
+				mongo.IndexModel{Keys: bson.D{{Key: "listing_id", Value: 1}, {Key: "version", Value: -1}}},
+			),
+		},
+		{
+			Version:     15,
+			Description: "listing price per square meter",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				col := db.Collection("agg_listing")
+				hasArea := bson.M{"$and": bson.A{
+					bson.M{"$gt": bson.A{"$area_sq_m", 0}},
+					bson.M{"$gt": bson.A{"$rate_rub", 0}},
+				}}
+				backfill := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+					"has_area":       hasArea,
+					"price_per_sq_m": bson.M{"$cond": bson.A{hasArea, bson.M{"$divide": bson.A{"$rate_rub", "$area_sq_m"}}, 0}},
+				}}}}
+				if _, err := col.UpdateMany(ctx, bson.M{}, backfill); err != nil {
+					return err
+				}
+				return createIndexes("agg_listing",
+					mongo.IndexModel{Keys: bson.D{{Key: "state", Value: 1}, {Key: "has_area", Value: -1}, {Key: "price_per_sq_m", Value: 1}}},
+				)(ctx, db)
+			},
+		},
+	}
+}
+
+func isIndexNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && (cmdErr.Code == 27 || cmdErr.Name == "IndexNotFound")
+}
+
+func createIndexes(collection string, models ...mongo.IndexModel) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection(collection).Indexes().CreateMany(ctx, models)
+		return err
+	}
+}
@@ -0,0 +1,120 @@
+package mongo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	listingapp "rentme/internal/app/handlers/listings"
+)
+
+// PhotoUploadStore keeps resumable photo upload sessions in
+// app_photo_uploads. Documents are removed by the expiry sweep rather than a
+// TTL index, which would drop them before their parts are deleted.
+type PhotoUploadStore struct {
+	col *mongo.Collection
+}
+
+func NewPhotoUploadStore(db *mongo.Database) *PhotoUploadStore {
+	return &PhotoUploadStore{col: db.Collection("app_photo_uploads")}
+}
+
+func (s *PhotoUploadStore) Create(ctx context.Context, upload listingapp.PhotoUpload) error {
+	_, err := s.col.InsertOne(ctx, newPhotoUploadDocument(upload))
+	return err
+}
+
+func (s *PhotoUploadStore) Get(ctx context.Context, id string) (listingapp.PhotoUpload, error) {
+	var doc photoUploadDocument
+	if err := s.col.FindOne(ctx, bson.M{"_id": id}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return listingapp.PhotoUpload{}, listingapp.ErrPhotoUploadNotFound
+		}
+		return listingapp.PhotoUpload{}, err
+	}
+	return doc.toUpload(), nil
+}
+
+func (s *PhotoUploadStore) SetPart(ctx context.Context, id string, n int, size int64) error {
+	res, err := s.col.UpdateByID(ctx, id, bson.M{"$set": bson.M{"parts." + strconv.Itoa(n): size}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return listingapp.ErrPhotoUploadNotFound
+	}
+	return nil
+}
+
+func (s *PhotoUploadStore) Delete(ctx context.Context, id string) error {
+	_, err := s.col.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (s *PhotoUploadStore) Expired(ctx context.Context, now time.Time, limit int) ([]listingapp.PhotoUpload, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "expires_at", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cur, err := s.col.Find(ctx, bson.M{"expires_at": bson.M{"$lt": now.UTC()}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var uploads []listingapp.PhotoUpload
+	for cur.Next(ctx) {
+		var doc photoUploadDocument
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, doc.toUpload())
+	}
+	return uploads, cur.Err()
+}
+
+type photoUploadDocument struct {
+	ID        string           `bson:"_id"`
+	ListingID string           `bson:"listing_id"`
+	HostID    string           `bson:"host_id"`
+	Parts     map[string]int64 `bson:"parts"`
+	CreatedAt time.Time        `bson:"created_at"`
+	ExpiresAt time.Time        `bson:"expires_at"`
+}
+
+func newPhotoUploadDocument(upload listingapp.PhotoUpload) photoUploadDocument {
+	parts := make(map[string]int64, len(upload.Parts))
+	for n, size := range upload.Parts {
+		parts[strconv.Itoa(n)] = size
+	}
+	return photoUploadDocument{
+		ID:        upload.ID,
+		ListingID: upload.ListingID,
+		HostID:    upload.HostID,
+		Parts:     parts,
+		CreatedAt: upload.CreatedAt.UTC(),
+		ExpiresAt: upload.ExpiresAt.UTC(),
+	}
+}
+
+func (d photoUploadDocument) toUpload() listingapp.PhotoUpload {
+	parts := make(map[int]int64, len(d.Parts))
+	for key, size := range d.Parts {
+		if n, err := strconv.Atoi(key); err == nil {
+			parts[n] = size
+		}
+	}
+	return listingapp.PhotoUpload{
+		ID:        d.ID,
+		ListingID: d.ListingID,
+		HostID:    d.HostID,
+		Parts:     parts,
+		CreatedAt: d.CreatedAt.UTC(),
+		ExpiresAt: d.ExpiresAt.UTC(),
+	}
+}
+
+var _ listingapp.PhotoUploadStore = (*PhotoUploadStore)(nil)
@@ -0,0 +1,123 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	domainlistings "rentme/internal/domain/listings"
+	domainpricing "rentme/internal/domain/pricing"
+)
+
+// clampDefaultsID is the _id of the document holding the default ranges;
+// every other document holds the ranges of one city.
+const clampDefaultsID = "defaults"
+
+// ClampStore keeps admin-edited ML price clamps in app_ml_price_clamps, one
+// document per city so concurrent edits of different cities do not collide.
+type ClampStore struct {
+	col *mongo.Collection
+}
+
+func NewClampStore(db *mongo.Database) *ClampStore {
+	return &ClampStore{col: db.Collection("app_ml_price_clamps")}
+}
+
+func (s *ClampStore) Table(ctx context.Context) (domainpricing.ClampTable, error) {
+	cur, err := s.col.Find(ctx, bson.M{})
+	if err != nil {
+		return domainpricing.ClampTable{}, err
+	}
+	defer cur.Close(ctx)
+	table := domainpricing.ClampTable{Cities: map[string]domainpricing.ClampTerms{}}
+	for cur.Next(ctx) {
+		var doc clampDocument
+		if err := cur.Decode(&doc); err != nil {
+			return domainpricing.ClampTable{}, err
+		}
+		if doc.ID == clampDefaultsID {
+			table.Defaults = doc.toTerms()
+			continue
+		}
+		table.Cities[doc.City] = doc.toTerms()
+	}
+	if err := cur.Err(); err != nil {
+		return domainpricing.ClampTable{}, err
+	}
+	return table.Normalized(), nil
+}
+
+func (s *ClampStore) SaveCity(ctx context.Context, city string, terms domainpricing.ClampTerms) error {
+	city = domainpricing.NormalizeCity(city)
+	if len(terms) == 0 {
+		_, err := s.col.DeleteOne(ctx, bson.M{"_id": clampCityID(city)})
+		return err
+	}
+	doc := newClampDocument(clampCityID(city), city, terms, time.Now().UTC())
+	_, err := s.col.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+// Replace swaps the whole table. It is not atomic: a reader may briefly see
+// the table half written, which the engine's cache smooths over.
+func (s *ClampStore) Replace(ctx context.Context, table domainpricing.ClampTable) error {
+	table = table.Normalized()
+	now := time.Now().UTC()
+	docs := make([]any, 0, len(table.Cities)+1)
+	if len(table.Defaults) > 0 {
+		docs = append(docs, newClampDocument(clampDefaultsID, "", table.Defaults, now))
+	}
+	for city, terms := range table.Cities {
+		docs = append(docs, newClampDocument(clampCityID(city), city, terms, now))
+	}
+	if _, err := s.col.DeleteMany(ctx, bson.M{}); err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	_, err := s.col.InsertMany(ctx, docs)
+	return err
+}
+
+type clampDocument struct {
+	ID        string                        `bson:"_id"`
+	City      string                        `bson:"city,omitempty"`
+	Terms     map[string]clampRangeDocument `bson:"terms"`
+	UpdatedAt time.Time                     `bson:"updated_at"`
+}
+
+type clampRangeDocument struct {
+	MinRub int64 `bson:"min_rub"`
+	MaxRub int64 `bson:"max_rub"`
+}
+
+func newClampDocument(id, city string, terms domainpricing.ClampTerms, at time.Time) clampDocument {
+	doc := clampDocument{
+		ID:        id,
+		City:      city,
+		Terms:     make(map[string]clampRangeDocument, len(terms)),
+		UpdatedAt: at,
+	}
+	for term, rng := range terms {
+		doc.Terms[string(term)] = clampRangeDocument{MinRub: rng.MinRub, MaxRub: rng.MaxRub}
+	}
+	return doc
+}
+
+func (d clampDocument) toTerms() domainpricing.ClampTerms {
+	terms := make(domainpricing.ClampTerms, len(d.Terms))
+	for term, rng := range d.Terms {
+		terms[domainlistings.RentalTermType(term)] = domainpricing.ClampRange{MinRub: rng.MinRub, MaxRub: rng.MaxRub}
+	}
+	return terms
+}
+
+func clampCityID(city string) string {
+	return "city:" + city
+}
+
+var _ domainpricing.ClampStore = (*ClampStore)(nil)
@@ -13,6 +13,7 @@ import (
 	domainlistings "rentme/internal/domain/listings"
 	domainpricing "rentme/internal/domain/pricing"
 	domainreviews "rentme/internal/domain/reviews"
+	domainwishlist "rentme/internal/domain/wishlist"
 )
 
 // Factory wires Mongo transactions into the generic UnitOfWork interface.
@@ -24,6 +25,7 @@ type Factory struct {
 	BookingRepo      domainbooking.Repository
 	PricingSvc       domainpricing.Calculator
 	ReviewsRepo      domainreviews.Repository
+	WishlistsRepo    domainwishlist.Repository
 }
 
 var ErrUnitOfWorkNotConfigured = errors.New("mongo: unit of work factory missing database")
@@ -53,6 +55,7 @@ func (f Factory) Begin(ctx context.Context, opts uow.TxOptions) (uow.UnitOfWork,
 		booking:      f.BookingRepo,
 		pricing:      f.PricingSvc,
 		reviews:      f.ReviewsRepo,
+		wishlists:    f.WishlistsRepo,
 	}, nil
 }
 
@@ -65,6 +68,7 @@ type Unit struct {
 	booking      domainbooking.Repository
 	pricing      domainpricing.Calculator
 	reviews      domainreviews.Repository
+	wishlists    domainwishlist.Repository
 }
 
 func (u *Unit) Listings() domainlistings.ListingRepository {
@@ -87,6 +91,10 @@ func (u *Unit) Reviews() domainreviews.Repository {
 	return u.reviews
 }
 
+func (u *Unit) Wishlists() domainwishlist.Repository {
+	return u.wishlists
+}
+
 func (u *Unit) Commit(ctx context.Context) error {
 	defer u.session.EndSession(ctx)
 	if err := u.session.CommitTransaction(ctx); err != nil {
@@ -0,0 +1,71 @@
+package mongo
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"rentme/internal/domain/listings"
+	domainwishlist "rentme/internal/domain/wishlist"
+)
+
+// WishlistRepository stores one document per guest with the saved listing
+// ids, most recent first.
+type WishlistRepository struct {
+	col *mongo.Collection
+}
+
+func NewWishlistRepository(db *mongo.Database) *WishlistRepository {
+	return &WishlistRepository{col: db.Collection("agg_wishlist")}
+}
+
+// ByGuest returns the guest wishlist, or an empty one when nothing was saved yet.
+func (r *WishlistRepository) ByGuest(ctx context.Context, guestID string) (*domainwishlist.Wishlist, error) {
+	id := strings.TrimSpace(guestID)
+	var doc wishlistDocument
+	if err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domainwishlist.New(id)
+		}
+		return nil, err
+	}
+	return doc.toAggregate(), nil
+}
+
+// Save replaces the wishlist snapshot; the last writer wins.
+func (r *WishlistRepository) Save(ctx context.Context, wishlist *domainwishlist.Wishlist) error {
+	if wishlist == nil || strings.TrimSpace(wishlist.GuestID) == "" {
+		return domainwishlist.ErrGuestRequired
+	}
+	doc := newWishlistDocument(wishlist, time.Now().UTC())
+	_, err := r.col.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+type wishlistDocument struct {
+	ID         string    `bson:"_id"`
+	ListingIDs []string  `bson:"listing_ids"`
+	UpdatedAt  time.Time `bson:"updated_at"`
+}
+
+func newWishlistDocument(wishlist *domainwishlist.Wishlist, now time.Time) wishlistDocument {
+	ids := make([]string, 0, len(wishlist.ListingIDs))
+	for _, id := range wishlist.ListingIDs {
+		ids = append(ids, string(id))
+	}
+	return wishlistDocument{ID: wishlist.GuestID, ListingIDs: ids, UpdatedAt: now}
+}
+
+func (d wishlistDocument) toAggregate() *domainwishlist.Wishlist {
+	ids := make([]listings.ListingID, 0, len(d.ListingIDs))
+	for _, id := range d.ListingIDs {
+		ids = append(ids, listings.ListingID(id))
+	}
+	return &domainwishlist.Wishlist{GuestID: d.ID, ListingIDs: ids}
+}
+
+var _ domainwishlist.Repository = (*WishlistRepository)(nil)
@@ -0,0 +1,26 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"rentme/internal/domain/listings"
+	domainwishlist "rentme/internal/domain/wishlist"
+)
+
+func TestWishlistDocumentKeepsOrder(t *testing.T) {
+	wishlist := &domainwishlist.Wishlist{GuestID: "guest-1", ListingIDs: []listings.ListingID{"listing-3", "listing-1", "listing-2"}}
+	doc := newWishlistDocument(wishlist, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+	if doc.ID != "guest-1" {
+		t.Fatalf("_id = %q, want the guest id", doc.ID)
+	}
+	back := doc.toAggregate()
+	if back.GuestID != wishlist.GuestID || len(back.ListingIDs) != len(wishlist.ListingIDs) {
+		t.Fatalf("round trip = %+v, want %+v", back, wishlist)
+	}
+	for i, id := range wishlist.ListingIDs {
+		if back.ListingIDs[i] != id {
+			t.Fatalf("position %d = %s, want %s", i, back.ListingIDs[i], id)
+		}
+	}
+}
@@ -5,29 +5,84 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	gin "github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
 
+	"rentme/internal/app/commands"
 	"rentme/internal/app/dto"
+	adminapp "rentme/internal/app/handlers/admin"
+	bookingapp "rentme/internal/app/handlers/booking"
+	reviewsapp "rentme/internal/app/handlers/reviews"
+	"rentme/internal/app/middleware"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/services/identity"
+	"rentme/internal/app/services/trust"
+	"rentme/internal/app/uow"
 	domainauth "rentme/internal/domain/auth"
+	domainbooking "rentme/internal/domain/booking"
+	domainguestblock "rentme/internal/domain/guestblock"
+	domainlistings "rentme/internal/domain/listings"
+	domainpricing "rentme/internal/domain/pricing"
+	domainreviews "rentme/internal/domain/reviews"
 	domainuser "rentme/internal/domain/user"
+	"rentme/internal/infra/config"
 	"rentme/internal/infra/pricing"
 )
 
 type AdminHTTP interface {
 	ListUsers(c *gin.Context)
+	GetUser(c *gin.Context)
 	MLMetrics(c *gin.Context)
 	BlockUser(c *gin.Context)
 	UnblockUser(c *gin.Context)
+	SetUserRoles(c *gin.Context)
+	UserVerification(c *gin.Context)
+	SetUserVerification(c *gin.Context)
+	RemoveGuestBlock(c *gin.Context)
+	ListListings(c *gin.Context)
+	GetListing(c *gin.Context)
+	SuspendListing(c *gin.Context)
+	ReactivateListing(c *gin.Context)
+	BookingIntegrityReports(c *gin.Context)
+	JobRuns(c *gin.Context)
+	CheckListingIntegrity(c *gin.Context)
+	ListBookings(c *gin.Context)
+	GetBooking(c *gin.Context)
+	ForceConfirmBooking(c *gin.Context)
+	CancelBooking(c *gin.Context)
+	ListListingReviews(c *gin.Context)
+	ModerateReview(c *gin.Context)
+	UpdateMarkets(c *gin.Context)
+	ExportPriceClamps(c *gin.Context)
+	ImportPriceClamps(c *gin.Context)
+	CityPriceClamps(c *gin.Context)
+	UpdateCityPriceClamps(c *gin.Context)
+	Config(c *gin.Context)
+	ListRateLimits(c *gin.Context)
+	UpdateRateLimit(c *gin.Context)
 }
 
 type AdminHandler struct {
+	Commands commands.Bus
+	Queries  queries.Bus
 	Users    domainuser.Repository
 	Sessions domainauth.SessionStore
-	Metrics  *pricing.MetricsClient
-	Logger   *slog.Logger
+	Metrics  *pricing.MetricsCache
+	// MLBreaker guards ML price quotes; its state is shown with the ML metrics.
+	MLBreaker *pricing.CircuitBreaker
+	Velocity  *trust.Service
+	Identity  *identity.Service
+	// GuestBlocks feeds the blocks shown on the user detail.
+	GuestBlocks domainguestblock.Repository
+	// Settings is the effective configuration with secrets already masked.
+	Settings []config.Setting
+	// RateLimits are the rules the server applies, switchable at runtime.
+	RateLimits *middleware.RateLimitRules
+	Logger     *slog.Logger
 }
 
 func (h AdminHandler) ListUsers(c *gin.Context) {
@@ -36,7 +91,7 @@ func (h AdminHandler) ListUsers(c *gin.Context) {
 		return
 	}
 	if h.Users == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "user repository unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "user repository unavailable")
 		return
 	}
 
@@ -51,7 +106,7 @@ func (h AdminHandler) ListUsers(c *gin.Context) {
 		if h.Logger != nil {
 			h.Logger.Error("list users failed", "error", err)
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "cannot list users"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot list users")
 		return
 	}
 
@@ -65,6 +120,88 @@ func (h AdminHandler) ListUsers(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// GetUser returns the profile together with recent velocity decisions.
+func (h AdminHandler) GetUser(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	user, err := h.loadUserByID(c)
+	if err != nil {
+		return
+	}
+	verdicts, err := h.Velocity.Decisions(c.Request.Context(), string(user.ID), 20)
+	if err != nil && h.Logger != nil {
+		h.Logger.Warn("load velocity decisions failed", "user_id", user.ID, "error", err)
+	}
+	decisions := make([]dto.VelocityDecision, 0, len(verdicts))
+	for _, v := range verdicts {
+		decisions = append(decisions, dto.VelocityDecision{
+			Action:   string(v.Action),
+			Decision: string(v.Decision),
+			Reason:   v.Reason,
+			IP:       v.IP,
+			Device:   v.Device,
+			At:       v.At,
+		})
+	}
+	detail := dto.AdminUserDetail{
+		User:               dto.MapUserProfile(user),
+		VerificationReason: user.VerificationReason,
+		VelocityDecisions:  decisions,
+		BlockedGuests:      []dto.GuestBlock{},
+		BlockedBy:          []dto.GuestBlock{},
+	}
+	if h.GuestBlocks != nil {
+		blocked, err := h.GuestBlocks.ByHost(c.Request.Context(), domainlistings.HostID(user.ID))
+		if err == nil {
+			var blockedBy []*domainguestblock.Block
+			if blockedBy, err = h.GuestBlocks.ByGuest(c.Request.Context(), string(user.ID)); err == nil {
+				detail.BlockedGuests = dto.MapGuestBlocks(blocked)
+				detail.BlockedBy = dto.MapGuestBlocks(blockedBy)
+			}
+		}
+		if err != nil && h.Logger != nil {
+			h.Logger.Warn("load guest blocks failed", "user_id", user.ID, "error", err)
+		}
+	}
+	c.JSON(http.StatusOK, detail)
+}
+
+// RemoveGuestBlock lifts a block the host :id placed on a guest.
+func (h AdminHandler) RemoveGuestBlock(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	cmd := bookingapp.UnblockGuestCommand{
+		HostID:    strings.TrimSpace(c.Param("id")),
+		GuestID:   strings.TrimSpace(c.Param("guestId")),
+		RemovedBy: principal.ID,
+	}
+	result, err := commands.Dispatch[bookingapp.UnblockGuestCommand, *dto.GuestBlock](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		var status int
+		switch {
+		case errors.Is(err, domainguestblock.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, domainguestblock.ErrHostRequired), errors.Is(err, domainguestblock.ErrGuestRequired):
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+		if h.Logger != nil {
+			h.Logger.Warn("guest block removal failed", "status", status, "host_id", cmd.HostID, "guest_id", cmd.GuestID, "error", err)
+		}
+		respondError(c, status, errorCode(status, err), err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 func (h AdminHandler) BlockUser(c *gin.Context) {
 	if _, ok := requireRole(c, "admin"); !ok {
 		return
@@ -82,7 +219,7 @@ func (h AdminHandler) BlockUser(c *gin.Context) {
 		if h.Logger != nil {
 			h.Logger.Error("user block failed", "user_id", user.ID, "error", err)
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "cannot update user"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot update user")
 		return
 	}
 	if h.Sessions != nil {
@@ -111,7 +248,7 @@ func (h AdminHandler) UnblockUser(c *gin.Context) {
 		if h.Logger != nil {
 			h.Logger.Error("user unblock failed", "user_id", user.ID, "error", err)
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "cannot update user"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot update user")
 		return
 	}
 	if h.Logger != nil {
@@ -120,15 +257,146 @@ func (h AdminHandler) UnblockUser(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.MapUserProfile(user))
 }
 
+type adminUserRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+// SetUserRoles replaces the roles of a user. The user's sessions are dropped
+// so every client signs in again under the new roles; the last admin cannot
+// lose the admin role.
+func (h AdminHandler) SetUserRoles(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	var req adminUserRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	if len(req.Roles) == 0 {
+		respondError(c, http.StatusBadRequest, ErrCodeValidation, "roles are required")
+		return
+	}
+	roles, err := domainuser.ParseAssignableRoles(req.Roles)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	user, err := h.loadUserByID(c)
+	if err != nil {
+		return
+	}
+	previous := dto.MapUserProfile(user).Roles
+	if user.HasRole(domainuser.RoleAdmin) && !slices.Contains(roles, domainuser.RoleAdmin) {
+		_, admins, err := h.Users.List(c.Request.Context(), domainuser.ListParams{Role: domainuser.RoleAdmin, Limit: 1})
+		if err != nil {
+			if h.Logger != nil {
+				h.Logger.Error("count admins failed", "error", err)
+			}
+			respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot update user")
+			return
+		}
+		if admins <= 1 {
+			respondError(c, http.StatusConflict, ErrCodeConflict, domainuser.ErrLastAdmin.Error())
+			return
+		}
+	}
+	if err := user.AssignRoles(roles, time.Now()); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	if err := h.Users.Save(c.Request.Context(), user); err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("user roles update failed", "user_id", user.ID, "error", err)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot update user")
+		return
+	}
+	if h.Sessions != nil {
+		if err := h.Sessions.DeleteByUser(c.Request.Context(), user.ID); err != nil && h.Logger != nil {
+			h.Logger.Warn("session invalidation after role change failed", "user_id", user.ID, "error", err)
+		}
+	}
+	profile := dto.MapUserProfile(user)
+	if h.Logger != nil {
+		h.Logger.Info("user roles set by admin", "user_id", user.ID, "admin_id", principal.ID, "previous_roles", previous, "roles", profile.Roles)
+	}
+	c.JSON(http.StatusOK, profile)
+}
+
+// UserVerification returns the identity verification of the user.
+func (h AdminHandler) UserVerification(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	user, err := h.loadUserByID(c)
+	if err != nil {
+		return
+	}
+	c.JSON(http.StatusOK, dto.MapUserVerification(user))
+}
+
+type adminUserVerificationRequest struct {
+	Status string `json:"status"`
+	Method string `json:"method"`
+}
+
+// SetUserVerification records the outcome of a manual identity check.
+func (h AdminHandler) SetUserVerification(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Identity == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "verification unavailable")
+		return
+	}
+	var req adminUserVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	status, err := domainuser.ParseVerificationStatus(req.Status)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	id := strings.TrimSpace(c.Param("id"))
+	user, err := h.Identity.Set(c.Request.Context(), domainuser.ID(id), status, req.Method)
+	if err != nil {
+		if errors.Is(err, domainuser.ErrNotFound) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "user not found")
+			return
+		}
+		if h.Logger != nil {
+			h.Logger.Error("user verification update failed", "user_id", id, "error", err)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot update user")
+		return
+	}
+	if h.Logger != nil {
+		h.Logger.Info("user verification set by admin", "user_id", user.ID, "admin_id", principal.ID, "status", status)
+	}
+	c.JSON(http.StatusOK, dto.MapUserVerification(user))
+}
+
 func (h AdminHandler) MLMetrics(c *gin.Context) {
 	if _, ok := requireRole(c, "admin"); !ok {
 		return
 	}
 	if h.Metrics == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "ml metrics unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "ml metrics unavailable")
 		return
 	}
-	result, err := h.Metrics.Fetch(c.Request.Context())
+	var breaker *pricing.BreakerSnapshot
+	if h.MLBreaker != nil {
+		snapshot := h.MLBreaker.Snapshot()
+		breaker = &snapshot
+	}
+	// Served from a short cache; fetched_at, age_seconds and stale tell the
+	// dashboard how fresh the numbers are.
+	result, err := h.Metrics.Get(c.Request.Context())
 	if err != nil {
 		if h.Logger != nil {
 			h.Logger.Error("ml metrics fetch failed", "error", err)
@@ -137,32 +405,609 @@ func (h AdminHandler) MLMetrics(c *gin.Context) {
 		if errors.Is(err, context.DeadlineExceeded) {
 			status = http.StatusGatewayTimeout
 		}
-		c.JSON(status, gin.H{"error": err.Error()})
+		// The breaker matters most when the service is down, so its state is
+		// reported even without metrics.
+		var details map[string]string
+		if breaker != nil {
+			details = map[string]string{"circuit_breaker": string(breaker.State)}
+		}
+		respondErrorDetails(c, status, errorCode(status, err), err.Error(), details)
+		return
+	}
+	c.JSON(http.StatusOK, adminMLMetricsResponse{MetricsSnapshot: result, CircuitBreaker: breaker})
+}
+
+type adminMLMetricsResponse struct {
+	pricing.MetricsSnapshot
+	CircuitBreaker *pricing.BreakerSnapshot `json:"circuit_breaker,omitempty"`
+}
+
+type adminConfigResponse struct {
+	Settings []config.Setting `json:"settings"`
+}
+
+// Config shows the effective configuration. Every read is logged with the
+// admin who made it.
+func (h AdminHandler) Config(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Settings == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "configuration unavailable")
+		return
+	}
+	if h.Logger != nil {
+		h.Logger.Info("configuration viewed by admin", "admin_id", principal.ID, "ip", c.ClientIP())
+	}
+	c.JSON(http.StatusOK, adminConfigResponse{Settings: h.Settings})
+}
+
+type adminSuspendListingRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ListListings browses listings of every host, filtered by status, city and
+// host.
+func (h AdminHandler) ListListings(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	query := adminapp.AdminListListingsQuery{
+		Status: c.Query("status"),
+		City:   c.Query("city"),
+		HostID: c.Query("host_id"),
+		Limit:  parseIntWithDefault(c.Query("limit"), 20),
+		Offset: parseIntWithDefault(c.Query("offset"), 0),
+	}
+	result, err := queries.Ask[adminapp.AdminListListingsQuery, dto.HostListingCatalog](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("admin listings query failed", "error", err)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot load listings")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetListing returns any listing as its host sees it.
+func (h AdminHandler) GetListing(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	query := adminapp.AdminGetListingQuery{ListingID: strings.TrimSpace(c.Param("id"))}
+	result, err := queries.Ask[adminapp.AdminGetListingQuery, dto.HostListingDetail](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		h.handleListingError(c, err, query.ListingID)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h AdminHandler) SuspendListing(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	var req adminSuspendListingRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+			return
+		}
+	}
+	cmd := adminapp.AdminSuspendListingCommand{
+		AdminID:   principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+		Reason:    req.Reason,
+	}
+	result, err := commands.Dispatch[adminapp.AdminSuspendListingCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleListingError(c, err, cmd.ListingID)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h AdminHandler) ReactivateListing(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	cmd := adminapp.AdminReactivateListingCommand{
+		AdminID:   principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+	}
+	result, err := commands.Dispatch[adminapp.AdminReactivateListingCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleListingError(c, err, cmd.ListingID)
 		return
 	}
 	c.JSON(http.StatusOK, result)
 }
 
+func (h AdminHandler) handleListingError(c *gin.Context, err error, listingID string) {
+	var status int
+	switch {
+	case errors.Is(err, adminapp.ErrListingNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, adminapp.ErrListingNotSuspended),
+		errors.Is(err, domainlistings.ErrInvalidState):
+		status = http.StatusConflict
+	case isValidationError(err):
+		status = http.StatusBadRequest
+	case errors.Is(err, uow.ErrUnitOfWorkMissing):
+		status = http.StatusServiceUnavailable
+	default:
+		status = http.StatusInternalServerError
+	}
+	if h.Logger != nil {
+		h.Logger.Warn("admin listing action failed", "status", status, "listing_id", listingID, "error", err)
+	}
+	respondError(c, status, errorCode(status, err), err.Error())
+}
+
+func (h AdminHandler) BookingIntegrityReports(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	query := adminapp.ListBookingIntegrityReportsQuery{
+		ListingID: strings.TrimSpace(c.Query("listing_id")),
+		Limit:     parseIntWithDefault(c.Query("limit"), 10),
+	}
+	result, err := queries.Ask[adminapp.ListBookingIntegrityReportsQuery, dto.BookingIntegrityReportList](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("integrity reports query failed", "error", err)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot load integrity reports")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// JobRuns lists recent background job runs with status, duration and error.
+func (h AdminHandler) JobRuns(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	query := adminapp.ListJobRunsQuery{
+		Job:   strings.TrimSpace(c.Query("job")),
+		Limit: parseIntWithDefault(c.Query("limit"), 50),
+	}
+	result, err := queries.Ask[adminapp.ListJobRunsQuery, dto.JobRunList](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("job runs query failed", "error", err)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot load job runs")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h AdminHandler) CheckListingIntegrity(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	cmd := adminapp.RunBookingIntegrityCheckCommand{
+		AdminID:   principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+	}
+	result, err := commands.Dispatch[adminapp.RunBookingIntegrityCheckCommand, *dto.BookingIntegrityReport](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleListingError(c, err, cmd.ListingID)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ListBookings searches bookings of every guest and host.
+func (h AdminHandler) ListBookings(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	query := bookingapp.AdminListBookingsQuery{
+		GuestID:   c.Query("guest_id"),
+		HostID:    c.Query("host_id"),
+		ListingID: c.Query("listing_id"),
+		Status:    c.Query("status"),
+		Limit:     parseIntWithDefault(c.Query("limit"), 20),
+		Offset:    parseIntWithDefault(c.Query("offset"), 0),
+	}
+	result, err := queries.Ask[bookingapp.AdminListBookingsQuery, dto.HostBookingCollection](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("admin bookings query failed", "error", err)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot load bookings")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetBooking returns any booking with its listing snapshot and guest id.
+func (h AdminHandler) GetBooking(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	query := bookingapp.GetBookingQuery{
+		BookingID: strings.TrimSpace(c.Param("id")),
+		ViewerID:  principal.ID,
+		Admin:     true,
+	}
+	result, err := queries.Ask[bookingapp.GetBookingQuery, dto.BookingDetail](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		h.handleBookingError(c, err, query.BookingID)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ForceConfirmBooking confirms a booking for support without the host
+// ownership check.
+func (h AdminHandler) ForceConfirmBooking(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	cmd := bookingapp.AdminForceConfirmBookingCommand{
+		AdminID:   principal.ID,
+		BookingID: strings.TrimSpace(c.Param("id")),
+	}
+	result, err := commands.Dispatch[bookingapp.AdminForceConfirmBookingCommand, *bookingapp.HostBookingActionResult](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleBookingError(c, err, cmd.BookingID)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+type adminCancelBookingRequest struct {
+	Initiator string `json:"initiator"`
+	RefundRub *int64 `json:"refund_rub"`
+	Reason    string `json:"reason"`
+}
+
+// CancelBooking cancels a booking on behalf of either party or the platform.
+// Without refund_rub the cancellation policy decides the refund.
+func (h AdminHandler) CancelBooking(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	var req adminCancelBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "reason is required")
+		return
+	}
+	cmd := bookingapp.AdminCancelBookingCommand{
+		AdminID:   principal.ID,
+		BookingID: strings.TrimSpace(c.Param("id")),
+		Initiator: req.Initiator,
+		RefundRub: req.RefundRub,
+		Reason:    req.Reason,
+	}
+	result, err := commands.Dispatch[bookingapp.AdminCancelBookingCommand, *dto.AdminBookingCancellation](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleBookingError(c, err, cmd.BookingID)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h AdminHandler) handleBookingError(c *gin.Context, err error, bookingID string) {
+	if respondConcurrentUpdate(c, err) {
+		return
+	}
+	var status int
+	switch {
+	case errors.Is(err, domainbooking.ErrBookingNotFound),
+		errors.Is(err, mongo.ErrNoDocuments):
+		status = http.StatusNotFound
+	case errors.Is(err, domainbooking.ErrInvalidState):
+		status = http.StatusConflict
+	case errors.Is(err, domainbooking.ErrInvalidInitiator),
+		errors.Is(err, domainbooking.ErrRefundOutOfRange):
+		status = http.StatusBadRequest
+	case errors.Is(err, uow.ErrUnitOfWorkMissing):
+		status = http.StatusServiceUnavailable
+	default:
+		status = http.StatusInternalServerError
+	}
+	if h.Logger != nil {
+		h.Logger.Warn("admin booking action failed", "status", status, "booking_id", bookingID, "error", err)
+	}
+	respondError(c, status, errorCode(status, err), err.Error())
+}
+
+// ListListingReviews lists every review of a listing, moderated ones included.
+func (h AdminHandler) ListListingReviews(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	query := reviewsapp.AdminListListingReviewsQuery{
+		ListingID: strings.TrimSpace(c.Param("id")),
+		Limit:     parseIntWithDefault(c.Query("limit"), 20),
+		Offset:    parseIntWithDefault(c.Query("offset"), 0),
+	}
+	result, err := queries.Ask[reviewsapp.AdminListListingReviewsQuery, dto.AdminReviewCollection](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		if errors.Is(err, reviewsapp.ErrListingNotFound) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "listing not found")
+			return
+		}
+		if h.Logger != nil {
+			h.Logger.Error("admin listing reviews query failed", "listing_id", query.ListingID, "error", err)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot load reviews")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+type adminModerateReviewRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ModerateReview hides a review from public view. The review is kept so the
+// decision can be audited.
+func (h AdminHandler) ModerateReview(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	var req adminModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	cmd := reviewsapp.ModerateReviewCommand{
+		AdminID:  principal.ID,
+		ReviewID: strings.TrimSpace(c.Param("id")),
+		Reason:   req.Reason,
+	}
+	result, err := commands.Dispatch[reviewsapp.ModerateReviewCommand, dto.AdminReview](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		var status int
+		switch {
+		case errors.Is(err, domainreviews.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, domainreviews.ErrModerationReason):
+			status = http.StatusBadRequest
+		case errors.Is(err, uow.ErrUnitOfWorkMissing):
+			status = http.StatusServiceUnavailable
+		default:
+			status = http.StatusInternalServerError
+		}
+		if h.Logger != nil {
+			h.Logger.Warn("review moderation failed", "status", status, "review_id", cmd.ReviewID, "error", err)
+		}
+		respondError(c, status, errorCode(status, err), err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+type adminUpdateMarketsRequest struct {
+	Cities            []string `json:"cities"`
+	GrandfatherActive *bool    `json:"grandfather_active"`
+}
+
+func (h AdminHandler) UpdateMarkets(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	var req adminUpdateMarketsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	cmd := adminapp.AdminUpdateMarketsCommand{
+		AdminID:           principal.ID,
+		Cities:            req.Cities,
+		GrandfatherActive: req.GrandfatherActive == nil || *req.GrandfatherActive,
+	}
+	result, err := commands.Dispatch[adminapp.AdminUpdateMarketsCommand, *dto.MarketSettings](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, uow.ErrUnitOfWorkMissing) {
+			status = http.StatusServiceUnavailable
+		}
+		if h.Logger != nil {
+			h.Logger.Error("markets update failed", "status", status, "error", err)
+		}
+		respondError(c, status, errorCode(status, err), err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h AdminHandler) ExportPriceClamps(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	result, err := queries.Ask[adminapp.ExportPriceClampsQuery, dto.PriceClampTable](c.Request.Context(), h.Queries, adminapp.ExportPriceClampsQuery{})
+	if err != nil {
+		h.respondPriceClampsError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ImportPriceClamps takes a table in the export format and replaces every
+// stored range with it.
+func (h AdminHandler) ImportPriceClamps(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	var table domainpricing.ClampTable
+	if err := c.ShouldBindJSON(&table); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	cmd := adminapp.AdminImportPriceClampsCommand{AdminID: principal.ID, Table: table}
+	result, err := commands.Dispatch[adminapp.AdminImportPriceClampsCommand, *dto.PriceClampTable](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.respondPriceClampsError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h AdminHandler) CityPriceClamps(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	query := adminapp.CityPriceClampsQuery{City: c.Param("city")}
+	result, err := queries.Ask[adminapp.CityPriceClampsQuery, dto.CityPriceClamps](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		h.respondPriceClampsError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateCityPriceClamps takes the city's ranges keyed by rental term, e.g.
+// {"short_term": {"min_rub": 3000, "max_rub": 35000}}.
+func (h AdminHandler) UpdateCityPriceClamps(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	var terms domainpricing.ClampTerms
+	if err := c.ShouldBindJSON(&terms); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	cmd := adminapp.AdminUpdateCityClampsCommand{
+		AdminID: principal.ID,
+		City:    c.Param("city"),
+		Terms:   terms,
+	}
+	result, err := commands.Dispatch[adminapp.AdminUpdateCityClampsCommand, *dto.CityPriceClamps](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.respondPriceClampsError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h AdminHandler) respondPriceClampsError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case isValidationError(err):
+		status = http.StatusBadRequest
+	case errors.Is(err, uow.ErrUnitOfWorkMissing):
+		status = http.StatusServiceUnavailable
+	}
+	if status >= http.StatusInternalServerError && h.Logger != nil {
+		h.Logger.Error("price clamps request failed", "status", status, "error", err)
+	}
+	respondError(c, status, errorCode(status, err), err.Error())
+}
+
 func (h AdminHandler) loadUserByID(c *gin.Context) (*domainuser.User, error) {
 	if h.Users == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "user repository unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "user repository unavailable")
 		return nil, errors.New("user repository unavailable")
 	}
 	id := strings.TrimSpace(c.Param("id"))
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "user id is required")
 		return nil, errors.New("user id is required")
 	}
 	user, err := h.Users.ByID(c.Request.Context(), domainuser.ID(id))
 	if err != nil {
 		if errors.Is(err, domainuser.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "user not found")
 			return nil, err
 		}
 		if h.Logger != nil {
 			h.Logger.Error("load user failed", "user_id", id, "error", err)
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "cannot load user"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot load user")
 		return nil, err
 	}
 	return user, nil
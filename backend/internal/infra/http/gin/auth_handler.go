@@ -10,6 +10,7 @@ import (
 
 	"rentme/internal/app/dto"
 	authsvc "rentme/internal/app/services/auth"
+	"rentme/internal/app/services/trust"
 	domainuser "rentme/internal/domain/user"
 )
 
@@ -17,12 +18,15 @@ type AuthHTTP interface {
 	Register(c *gin.Context)
 	Login(c *gin.Context)
 	Logout(c *gin.Context)
+	Refresh(c *gin.Context)
 	Me(c *gin.Context)
+	ChangePassword(c *gin.Context)
 }
 
 type AuthHandler struct {
-	Service *authsvc.Service
-	Logger  *slog.Logger
+	Service  *authsvc.Service
+	Velocity *trust.Service
+	Logger   *slog.Logger
 }
 
 type registerRequest struct {
@@ -37,14 +41,27 @@ type loginRequest struct {
 	Password string `json:"password"`
 }
 
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
 func (h AuthHandler) Register(c *gin.Context) {
 	if h.Service == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "auth service unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "auth service unavailable")
 		return
 	}
 	var req registerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid request")
+		return
+	}
+	verdict, ok := checkVelocity(c, h.Velocity, trust.Attempt{Action: trust.ActionRegister}, h.Logger)
+	if !ok {
 		return
 	}
 	result, err := h.Service.Register(c.Request.Context(), authsvc.RegisterParams{
@@ -57,17 +74,26 @@ func (h AuthHandler) Register(c *gin.Context) {
 		h.respondAuthError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, dto.NewAuthResponse(result.User, result.Token))
+	if verdict.Decision == trust.DecisionVerify {
+		if flagged, err := h.Velocity.Flag(c.Request.Context(), result.User.ID, verdict); err != nil {
+			if h.Logger != nil {
+				h.Logger.Warn("flag user for verification failed", "user_id", result.User.ID, "error", err)
+			}
+		} else {
+			result.User = flagged
+		}
+	}
+	c.JSON(http.StatusCreated, dto.NewAuthResponse(result.User, result.Token, result.RefreshToken))
 }
 
 func (h AuthHandler) Login(c *gin.Context) {
 	if h.Service == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "auth service unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "auth service unavailable")
 		return
 	}
 	var req loginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid request")
 		return
 	}
 	result, err := h.Service.Login(c.Request.Context(), authsvc.LoginParams{
@@ -78,12 +104,12 @@ func (h AuthHandler) Login(c *gin.Context) {
 		h.respondAuthError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, dto.NewAuthResponse(result.User, result.Token))
+	c.JSON(http.StatusOK, dto.NewAuthResponse(result.User, result.Token, result.RefreshToken))
 }
 
 func (h AuthHandler) Logout(c *gin.Context) {
 	if h.Service == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "auth service unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "auth service unavailable")
 		return
 	}
 	token := bearerTokenFromContext(c)
@@ -91,16 +117,49 @@ func (h AuthHandler) Logout(c *gin.Context) {
 		if h.Logger != nil {
 			h.Logger.Warn("logout failed", "error", err)
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "logout failed"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "logout failed")
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
+func (h AuthHandler) Refresh(c *gin.Context) {
+	if h.Service == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "auth service unavailable")
+		return
+	}
+	var req refreshRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid request")
+			return
+		}
+	}
+	var (
+		result *authsvc.AuthResult
+		err    error
+	)
+	// A refresh token in the body renews an expired session; without one the
+	// still valid bearer token is swapped for a new session.
+	if refresh := strings.TrimSpace(req.RefreshToken); refresh != "" {
+		result, err = h.Service.Refresh(c.Request.Context(), refresh)
+	} else if token := extractBearerToken(c.GetHeader("Authorization")); token != "" {
+		result, err = h.Service.RefreshSession(c.Request.Context(), token)
+	} else {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "refresh_token or a bearer token is required")
+		return
+	}
+	if err != nil {
+		h.respondAuthError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewAuthResponse(result.User, result.Token, result.RefreshToken))
+}
+
 func (h AuthHandler) Me(c *gin.Context) {
 	principal, ok := currentPrincipal(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "auth required"})
+		respondError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "auth required")
 		return
 	}
 	profile := dto.UserProfile{
@@ -114,23 +173,50 @@ func (h AuthHandler) Me(c *gin.Context) {
 	c.JSON(http.StatusOK, profile)
 }
 
+func (h AuthHandler) ChangePassword(c *gin.Context) {
+	principal, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Service == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "auth service unavailable")
+		return
+	}
+	var req changePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid request")
+		return
+	}
+	if err := h.Service.ChangePassword(c.Request.Context(), principal.ID, req.CurrentPassword, req.NewPassword); err != nil {
+		h.respondAuthError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
 func (h AuthHandler) respondAuthError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, authsvc.ErrInvalidCredentials):
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Неверный email или пароль"})
+		respondError(c, http.StatusUnauthorized, ErrCodeInvalidCredentials, "Неверный email или пароль")
 	case errors.Is(err, authsvc.ErrUserBlocked):
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Аккаунт заблокирован"})
+		respondError(c, http.StatusUnauthorized, ErrCodeUserBlocked, "Аккаунт заблокирован")
+	case errors.Is(err, authsvc.ErrInvalidRefresh):
+		respondError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "Сессия истекла, войдите снова")
+	case errors.Is(err, authsvc.ErrWrongPassword):
+		respondError(c, http.StatusUnauthorized, ErrCodeInvalidCredentials, "Неверный текущий пароль")
+	case errors.Is(err, domainuser.ErrNotFound):
+		respondError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "auth required")
 	case errors.Is(err, authsvc.ErrPasswordTooShort),
 		errors.Is(err, domainuser.ErrEmailRequired),
 		errors.Is(err, domainuser.ErrNameRequired):
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
 	case errors.Is(err, domainuser.ErrEmailAlreadyUsed):
-		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		respondError(c, http.StatusConflict, ErrCodeEmailTaken, err.Error())
 	default:
 		if h.Logger != nil {
 			h.Logger.Error("auth operation failed", "error", err)
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "internal error")
 	}
 }
 
@@ -0,0 +1,50 @@
+package ginserver
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	authsvc "rentme/internal/app/services/auth"
+	"rentme/internal/infra/storage/memory"
+)
+
+type plainPasswords struct{}
+
+func (plainPasswords) Hash(password string) (string, error) { return "plain:" + password, nil }
+
+func (plainPasswords) Compare(hash, password string) error {
+	if hash != "plain:"+password {
+		return authsvc.ErrInvalidCredentials
+	}
+	return nil
+}
+
+type sequentialTokens struct{ n int }
+
+func (g *sequentialTokens) NewToken() (string, error) {
+	g.n++
+	return fmt.Sprintf("token-%d", g.n), nil
+}
+
+func TestRegisterTakenEmailIsConflict(t *testing.T) {
+	service := &authsvc.Service{
+		Users:     memory.NewUserRepository(),
+		Sessions:  memory.NewSessionStore(),
+		Passwords: plainPasswords{},
+		Tokens:    &sequentialTokens{},
+	}
+	server := newTestServer(t, Handlers{Auth: AuthHandler{Service: service}}, nil)
+
+	first := serve(server, http.MethodPost, "/api/v1/auth/register", `{"email":"anna@example.com","name":"Anna","password":"password1"}`)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first registration status = %d: %s", first.Code, first.Body)
+	}
+	second := serve(server, http.MethodPost, "/api/v1/auth/register", `{"email":"Anna@Example.com","name":"Anna","password":"password2"}`)
+	if second.Code != http.StatusConflict {
+		t.Fatalf("second registration status = %d, want %d: %s", second.Code, http.StatusConflict, second.Body)
+	}
+	if apiErr := decodeAPIError(t, second.Body.Bytes()); apiErr.Code != ErrCodeEmailTaken {
+		t.Fatalf("code = %q, want %q", apiErr.Code, ErrCodeEmailTaken)
+	}
+}
@@ -95,11 +95,11 @@ func currentPrincipal(c *gin.Context) (principal, bool) {
 func requireRole(c *gin.Context, role string) (principal, bool) {
 	p, ok := currentPrincipal(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "auth required"})
+		respondError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "auth required")
 		return principal{}, false
 	}
 	if role != "" && !p.HasRole(role) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		respondError(c, http.StatusForbidden, ErrCodeForbidden, "insufficient permissions")
 		return principal{}, false
 	}
 	return p, true
@@ -2,7 +2,6 @@ package ginserver
 
 import (
 	"net/http"
-	"time"
 
 	gin "github.com/gin-gonic/gin"
 
@@ -17,12 +16,13 @@ type AvailabilityHandler struct {
 
 func (h AvailabilityHandler) Calendar(c *gin.Context) {
 	listingID := c.Param("id")
-	from, _ := time.Parse(time.RFC3339, c.Query("from"))
-	to, _ := time.Parse(time.RFC3339, c.Query("to"))
+	// Missing bounds default to a 45 day window; the query clamps long spans.
+	from, _ := parseFlexibleTime(c.Query("from"))
+	to, _ := parseFlexibleTime(c.Query("to"))
 	query := availabilityapp.GetCalendarQuery{ListingID: listingID, From: from, To: to}
 	result, err := queries.Ask[availabilityapp.GetCalendarQuery, dto.Calendar](c.Request.Context(), h.Queries, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, err.Error())
 		return
 	}
 	c.JSON(http.StatusOK, result)
@@ -1,18 +1,32 @@
 package ginserver
 
 import (
+	"errors"
+	"log/slog"
 	"net/http"
 	"time"
 
 	gin "github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
 
 	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
 	BookingApp "rentme/internal/app/handlers/booking"
+	"rentme/internal/app/middleware"
+	"rentme/internal/app/queries"
+	"rentme/internal/app/services/trust"
+	domainavailability "rentme/internal/domain/availability"
+	domainbooking "rentme/internal/domain/booking"
+	"rentme/internal/domain/shared/daterange"
+	domainuser "rentme/internal/domain/user"
 )
 
 type BookingHandler struct {
 	Commands commands.Bus
+	Queries  queries.Bus
+	Velocity *trust.Service
+	Logger   *slog.Logger
 }
 
 type createBookingRequest struct {
@@ -29,14 +43,23 @@ func (h BookingHandler) Create(c *gin.Context) {
 		return
 	}
 	if h.Commands == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "commands unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
 		return
 	}
 	var req createBookingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
 		return
 	}
+	verdict, ok := checkVelocity(c, h.Velocity, trust.Attempt{Action: trust.ActionBooking, UserID: user.ID}, h.Logger)
+	if !ok {
+		return
+	}
+	if verdict.Decision == trust.DecisionVerify {
+		if _, err := h.Velocity.Flag(c.Request.Context(), domainuser.ID(user.ID), verdict); err != nil && h.Logger != nil {
+			h.Logger.Warn("flag user for verification failed", "user_id", user.ID, "error", err)
+		}
+	}
 	cmd := BookingApp.RequestBookingCommand{
 		CommandID:       generateCommandID(),
 		ListingID:       req.ListingID,
@@ -49,16 +72,128 @@ func (h BookingHandler) Create(c *gin.Context) {
 	}
 	result, err := commands.Dispatch[BookingApp.RequestBookingCommand, *BookingApp.RequestBookingResult](c.Request.Context(), h.Commands, cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if errors.Is(err, middleware.ErrIdempotencyConflict) {
+			respondError(c, http.StatusConflict, ErrCodeIdempotencyConflict, err.Error())
+			return
+		}
+		if errors.Is(err, BookingApp.ErrGuestNotVerified) {
+			respondError(c, http.StatusForbidden, ErrCodeGuestNotVerified, err.Error())
+			return
+		}
+		if respondDatesUnavailable(c, err) || respondConcurrentUpdate(c, err) {
+			return
+		}
+		if errors.Is(err, domainavailability.ErrOverlappingRange) {
+			respondError(c, http.StatusConflict, ErrCodeBookingConflict, "listing is not available for the requested dates")
+			return
+		}
+		respondError(c, http.StatusBadRequest, errorCode(http.StatusBadRequest, err), err.Error())
+		return
+	}
+	c.Header("Location", "/api/v1/bookings/"+result.BookingID)
+	c.JSON(http.StatusCreated, result)
+}
+
+// Get returns one booking to its guest, the listing host or an admin.
+func (h BookingHandler) Get(c *gin.Context) {
+	user, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	query := BookingApp.GetBookingQuery{
+		BookingID: c.Param("id"),
+		ViewerID:  user.ID,
+		Admin:     user.HasRole("admin"),
+	}
+	result, err := queries.Ask[BookingApp.GetBookingQuery, dto.BookingDetail](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		if errors.Is(err, domainbooking.ErrBookingNotFound) || errors.Is(err, mongo.ErrNoDocuments) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "booking not found")
+			return
+		}
+		if h.Logger != nil {
+			h.Logger.Error("get booking failed", "booking_id", query.BookingID, "error", err)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot load booking")
 		return
 	}
-	c.JSON(http.StatusAccepted, result)
+	c.JSON(http.StatusOK, result)
+}
+
+// Quote prices a stay on the listing without requesting it. Signing in is
+// optional; it only matters for listings that accept verified guests only.
+func (h BookingHandler) Quote(c *gin.Context) {
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	checkIn, ok := parseFlexibleTime(c.Query("check_in"))
+	if !ok {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "check_in must be a date or an RFC 3339 timestamp")
+		return
+	}
+	var checkOut time.Time
+	if raw := c.Query("check_out"); raw != "" {
+		if checkOut, ok = parseFlexibleTime(raw); !ok {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "check_out must be a date or an RFC 3339 timestamp")
+			return
+		}
+	}
+	query := BookingApp.QuoteBookingQuery{
+		ListingID: c.Param("id"),
+		CheckIn:   checkIn,
+		CheckOut:  checkOut,
+		Guests:    parseIntWithDefault(c.Query("guests"), 1),
+		Months:    parseIntWithDefault(c.Query("months"), 0),
+	}
+	if viewer, ok := currentPrincipal(c); ok {
+		query.GuestID = viewer.ID
+	}
+	result, err := queries.Ask[BookingApp.QuoteBookingQuery, dto.BookingQuote](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		if errors.Is(err, BookingApp.ErrListingNotFound) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "listing not found")
+			return
+		}
+		if isValidationError(err) {
+			respondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+		if errors.Is(err, BookingApp.ErrCheckOutRequired) || errors.Is(err, daterange.ErrInvalidRange) || errors.Is(err, daterange.ErrNotDateAligned) {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+			return
+		}
+		if h.Logger != nil {
+			h.Logger.Error("quote booking failed", "listing_id", query.ListingID, "error", err)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot quote booking")
+		return
+	}
+	c.JSON(http.StatusOK, result)
 }
 
 func (h BookingHandler) Accept(c *gin.Context) {
 	c.Status(http.StatusNotImplemented)
 }
 
+// respondDatesUnavailable answers 409 with the taken range when err reports a
+// calendar conflict and tells the caller whether it did.
+func respondDatesUnavailable(c *gin.Context, err error) bool {
+	var unavailable *BookingApp.DatesUnavailableError
+	if !errors.As(err, &unavailable) {
+		return false
+	}
+	respondErrorDetails(c, http.StatusConflict, ErrCodeBookingConflict, "listing is not available for the requested dates", map[string]string{
+		"conflict_check_in":  unavailable.Conflict.CheckIn.Format(time.DateOnly),
+		"conflict_check_out": unavailable.Conflict.CheckOut.Format(time.DateOnly),
+	})
+	return true
+}
+
 func generateCommandID() string {
 	return uuid.NewString()
 }
@@ -0,0 +1,129 @@
+package ginserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"rentme/internal/app/commands"
+	BookingApp "rentme/internal/app/handlers/booking"
+	"rentme/internal/app/middleware"
+	domainavailability "rentme/internal/domain/availability"
+	domainlistings "rentme/internal/domain/listings"
+	domainrange "rentme/internal/domain/shared/daterange"
+	"rentme/internal/infra/storage/memory"
+)
+
+// bookingServer serves the real request handler over a short-term listing
+// that needs three nights and already has a confirmed stay from taken.
+func bookingServer(t *testing.T, taken domainrange.DateRange, availableFrom time.Time) http.Handler {
+	t.Helper()
+	ctx := context.Background()
+	listings := memory.NewListingRepository()
+	if err := listings.Save(ctx, &domainlistings.Listing{
+		ID:             "listing-1",
+		Host:           "host-1",
+		Title:          "Loft",
+		State:          domainlistings.ListingActive,
+		RentalTermType: domainlistings.RentalTermShort,
+		GuestsLimit:    2,
+		MinNights:      3,
+		MaxNights:      14,
+		RateRub:        5000,
+		AvailableFrom:  availableFrom,
+	}); err != nil {
+		t.Fatalf("save listing: %v", err)
+	}
+	availability := memory.NewAvailabilityRepository()
+	calendar := domainavailability.NewCalendar("listing-1", 0)
+	if err := calendar.Reserve(taken, "booking-confirmed", time.Now()); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if err := availability.Save(ctx, calendar); err != nil {
+		t.Fatalf("save calendar: %v", err)
+	}
+	factory := memory.Factory{
+		ListingsRepo:     listings,
+		AvailabilityRepo: availability,
+		BookingRepo:      memory.NewBookingRepository(),
+		ReviewsRepo:      memory.NewReviewsRepository(),
+		WishlistsRepo:    memory.NewWishlistRepository(),
+	}
+	bus := commands.NewInMemoryBus()
+	commands.RegisterHandler(bus, BookingApp.RequestBookingCommand{}.Key(), &BookingApp.RequestBookingHandler{})
+	booking := BookingHandler{Commands: middleware.ChainCommands(bus, middleware.Transaction(factory, nil))}
+	return newTestServer(t, Handlers{Booking: booking}, &principal{ID: "guest-1", Roles: []string{"guest"}})
+}
+
+func bookingBody(checkIn, checkOut time.Time) string {
+	return fmt.Sprintf(`{"listing_id":"listing-1","check_in":%q,"check_out":%q,"guests":2}`,
+		checkIn.Format(time.RFC3339), checkOut.Format(time.RFC3339))
+}
+
+func decodeAPIError(t *testing.T, body []byte) APIError {
+	t.Helper()
+	var apiErr APIError
+	if err := json.Unmarshal(body, &apiErr); err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	return apiErr
+}
+
+func TestCreateBookingOverlappingConfirmedStayIsConflict(t *testing.T) {
+	day := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 1, 0)
+	taken := domainrange.DateRange{CheckIn: day.AddDate(0, 0, 5), CheckOut: day.AddDate(0, 0, 10)}
+	server := bookingServer(t, taken, time.Time{})
+
+	rec := serve(server, http.MethodPost, "/api/v1/bookings", bookingBody(day.AddDate(0, 0, 3), day.AddDate(0, 0, 7)))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusConflict, rec.Body)
+	}
+	apiErr := decodeAPIError(t, rec.Body.Bytes())
+	if apiErr.Code != ErrCodeBookingConflict {
+		t.Fatalf("code = %q, want %q", apiErr.Code, ErrCodeBookingConflict)
+	}
+	if apiErr.Details["conflict_check_in"] != taken.CheckIn.Format(time.DateOnly) ||
+		apiErr.Details["conflict_check_out"] != taken.CheckOut.Format(time.DateOnly) {
+		t.Fatalf("details = %v, want the taken range %s", apiErr.Details, taken)
+	}
+
+	// The nights right after the confirmed stay are still free.
+	rec = serve(server, http.MethodPost, "/api/v1/bookings", bookingBody(taken.CheckOut, taken.CheckOut.AddDate(0, 0, 3)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("free dates status = %d: %s", rec.Code, rec.Body)
+	}
+	rec = serve(server, http.MethodPost, "/api/v1/bookings", bookingBody(taken.CheckOut, taken.CheckOut.AddDate(0, 0, 3)))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("rebooking the same dates status = %d, want %d: %s", rec.Code, http.StatusConflict, rec.Body)
+	}
+}
+
+func TestCreateBookingStayBoundsAreValidationErrors(t *testing.T) {
+	day := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 1, 0)
+	taken := domainrange.DateRange{CheckIn: day.AddDate(0, 2, 0), CheckOut: day.AddDate(0, 2, 3)}
+	server := bookingServer(t, taken, day.AddDate(0, 0, 2))
+
+	cases := []struct {
+		name     string
+		checkIn  time.Time
+		checkOut time.Time
+	}{
+		{"below min nights", day.AddDate(0, 0, 5), day.AddDate(0, 0, 7)},
+		{"above max nights", day.AddDate(0, 0, 5), day.AddDate(0, 0, 20)},
+		{"before available from", day, day.AddDate(0, 0, 4)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(server, http.MethodPost, "/api/v1/bookings", bookingBody(tc.checkIn, tc.checkOut))
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body)
+			}
+			if apiErr := decodeAPIError(t, rec.Body.Bytes()); apiErr.Code != ErrCodeValidation {
+				t.Fatalf("code = %q, want %q", apiErr.Code, ErrCodeValidation)
+			}
+		})
+	}
+}
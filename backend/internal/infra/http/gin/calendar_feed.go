@@ -0,0 +1,107 @@
+package ginserver
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/app/dto"
+	availabilityapp "rentme/internal/app/handlers/availability"
+	listingapp "rentme/internal/app/handlers/listings"
+	"rentme/internal/app/queries"
+	domainavailability "rentme/internal/domain/availability"
+)
+
+// calendarFeedLookback keeps recent stays in the feed so channel managers do
+// not drop them the moment they end.
+const calendarFeedLookback = 30 * 24 * time.Hour
+
+// FeedSigner signs capability URLs handed to third-party tools.
+type FeedSigner interface {
+	Sign(value string) string
+	Verify(value, token string) bool
+}
+
+type calendarFeedResponse struct {
+	URL string `json:"url"`
+}
+
+// CalendarFeedURL returns the signed iCal URL of the listing for the host to
+// paste into a channel manager.
+func (h HostListingHandler) CalendarFeedURL(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Queries == nil || h.CalendarFeed == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("calendar feed unavailable"))
+		return
+	}
+	listingID := c.Param("id")
+	query := listingapp.GetHostListingQuery{HostID: principal.ID, ListingID: listingID}
+	if _, err := queries.Ask[listingapp.GetHostListingQuery, dto.HostListingDetail](c.Request.Context(), h.Queries, query); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	url := "/api/v1/host/listings/" + listingID + "/calendar.ics?token=" + h.CalendarFeed.Sign(listingID)
+	c.JSON(http.StatusOK, calendarFeedResponse{URL: url})
+}
+
+// CalendarICS serves the listing calendar as an iCal feed. It is public; the
+// token signed for the listing id stands in for authentication.
+func (h HostListingHandler) CalendarICS(c *gin.Context) {
+	listingID := c.Param("id")
+	if h.CalendarFeed == nil || !h.CalendarFeed.Verify(listingID, c.Query("token")) {
+		// Same answer for unknown listings and bad tokens, so ids cannot be probed.
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "calendar not found")
+		return
+	}
+	if h.Queries == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("queries bus unavailable"))
+		return
+	}
+	now := time.Now().UTC()
+	from := now.Add(-calendarFeedLookback)
+	query := availabilityapp.GetCalendarQuery{
+		ListingID: listingID,
+		From:      from,
+		To:        from.AddDate(0, 0, availabilityapp.MaxCalendarWindowDays),
+	}
+	calendar, err := queries.Ask[availabilityapp.GetCalendarQuery, dto.Calendar](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+		return
+	}
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(serializeCalendarICS(calendar, now)))
+}
+
+func serializeCalendarICS(calendar dto.Calendar, now time.Time) string {
+	cal := ics.NewCalendar()
+	cal.SetMethod(ics.MethodPublish)
+	cal.SetProductId("-//rentme//calendar feed//EN")
+	cal.SetXWRCalName("rentme " + calendar.ListingID)
+	for _, block := range calendar.Blocks {
+		event := cal.AddEvent(block.Reference + "@rentme")
+		event.SetDtStampTime(now)
+		event.SetStartAt(block.From)
+		event.SetEndAt(block.To)
+		event.SetSummary(calendarFeedSummary(block.Reason))
+	}
+	return cal.Serialize()
+}
+
+// calendarFeedSummary keeps guest details out of the feed; channel managers
+// only need to know the nights are taken.
+func calendarFeedSummary(reason string) string {
+	switch reason {
+	case string(domainavailability.ReasonBooking):
+		return "Reserved"
+	case string(domainavailability.ReasonCleaning):
+		return "Cleaning"
+	default:
+		return "Not available"
+	}
+}
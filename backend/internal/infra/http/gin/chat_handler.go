@@ -1,21 +1,30 @@
 package ginserver
 
 import (
+	"context"
 	"errors"
 	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	gin "github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"rentme/internal/app/dto"
+	"rentme/internal/app/policies"
 	"rentme/internal/app/uow"
 	domainbooking "rentme/internal/domain/booking"
+	domainguestblock "rentme/internal/domain/guestblock"
+	domaininbox "rentme/internal/domain/inbox"
 	domainlistings "rentme/internal/domain/listings"
+	domainuser "rentme/internal/domain/user"
 	"rentme/internal/infra/messaging"
 )
 
@@ -28,15 +37,26 @@ type ChatHTTP interface {
 	CreateBookingConversation(c *gin.Context)
 	CreateDirectConversation(c *gin.Context)
 	MarkRead(c *gin.Context)
+	Assign(c *gin.Context)
+	ListNotes(c *gin.Context)
+	AddNote(c *gin.Context)
 }
 
-// ChatHandler bridges HTTP with messaging gRPC client.
+// ChatHandler bridges HTTP with messaging gRPC client. Assignments and notes
+// of the host team inbox are kept outside the messaging service.
 type ChatHandler struct {
-	Messaging  *messaging.Client
-	UoWFactory uow.UoWFactory
-	Logger     *slog.Logger
+	Messaging   *messaging.Client
+	UoWFactory  uow.UoWFactory
+	Assignments domaininbox.AssignmentRepository
+	Notes       domaininbox.NoteRepository
+	GuestBlocks domainguestblock.Repository
+	Notifier    policies.Notifier
+	Users       domainuser.Repository
+	Logger      *slog.Logger
 }
 
+var errNotHostTeam = errors.New("not a member of the listing host team")
+
 // ListMyConversations returns conversations for the current user (or all for admins).
 func (h ChatHandler) ListMyConversations(c *gin.Context) {
 	principal, ok := requireRole(c, "")
@@ -44,7 +64,7 @@ func (h ChatHandler) ListMyConversations(c *gin.Context) {
 		return
 	}
 	if h.Messaging == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "messaging unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
 		return
 	}
 	targetUser := principal.ID
@@ -60,6 +80,15 @@ func (h ChatHandler) ListMyConversations(c *gin.Context) {
 	limit := parsePositiveIntStrict(c.Query("limit"), 20)
 	cursor := c.Query("cursor")
 
+	if assigned := strings.TrimSpace(c.Query("assigned")); assigned != "" {
+		if assigned != "me" {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "assigned supports only \"me\"")
+			return
+		}
+		h.listAssignedConversations(c, principal.ID, limit, cursor)
+		return
+	}
+
 	conversations, next, err := h.Messaging.ListConversations(c.Request.Context(), targetUser, limit, cursor, includeAll)
 	if err != nil {
 		h.respondMessagingError(c, err, "list conversations", "user_id", targetUser)
@@ -70,21 +99,194 @@ func (h ChatHandler) ListMyConversations(c *gin.Context) {
 		NextCursor: next,
 	}
 	for _, conv := range conversations {
-		collection.Items = append(collection.Items, dto.Conversation{
-			ID:                conv.ID,
-			ListingID:         conv.ListingID,
-			Participants:      append([]string(nil), conv.Participants...),
-			CreatedAt:         conv.CreatedAt,
-			LastMessageAt:     conv.LastMessageAt,
-			LastMessageID:     conv.LastMessageID,
-			LastMessageSender: conv.LastSenderID,
-			LastMessageText:   conv.LastMessageText,
-			HasUnread:         conv.HasUnread,
-		})
+		collection.Items = append(collection.Items, mapConversation(conv))
 	}
+	h.attachPeers(c.Request.Context(), collection.Items, principal.ID)
+	h.attachListings(c.Request.Context(), collection.Items)
 	c.JSON(http.StatusOK, collection)
 }
 
+// assignedConversationLookups bounds the conversations of one page of the
+// "assigned to me" view loaded from messaging at once.
+const assignedConversationLookups = 8
+
+// listAssignedConversations serves the "assigned to me" view of the host team
+// inbox, a page of limit assignments after cursor.
+func (h ChatHandler) listAssignedConversations(c *gin.Context, userID string, limit int, cursor string) {
+	if h.Assignments == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "inbox unavailable")
+		return
+	}
+	assignments, err := h.Assignments.ByAssignee(c.Request.Context(), domainlistings.HostID(userID))
+	if err != nil {
+		h.logError("list assignments failed", err)
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot list assigned conversations")
+		return
+	}
+	page, next, err := pageAssignments(assignments, cursor, limit)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	conversations, failed, err := loadAssignedConversations(c.Request.Context(), h.Messaging.GetConversation, page)
+	if err != nil {
+		h.respondMessagingError(c, err, "load assigned conversation", "conversation_id", failed, "user_id", userID)
+		return
+	}
+	collection := dto.ConversationList{Items: make([]dto.Conversation, 0, len(conversations)), NextCursor: next}
+	for _, conv := range conversations {
+		collection.Items = append(collection.Items, mapConversation(conv))
+	}
+	h.attachPeers(c.Request.Context(), collection.Items, userID)
+	h.attachListings(c.Request.Context(), collection.Items)
+	c.JSON(http.StatusOK, collection)
+}
+
+var errAssignmentCursor = errors.New("invalid cursor")
+
+// pageAssignments returns up to limit assignments after cursor and the cursor
+// of the next page, empty on the last one. A cursor names the last assignment
+// of a page by its time and conversation, so it survives assignments added or
+// removed in between.
+func pageAssignments(assignments []*domaininbox.Assignment, cursor string, limit int) ([]*domaininbox.Assignment, string, error) {
+	start := 0
+	if cursor != "" {
+		rawAt, conversationID, ok := strings.Cut(cursor, "_")
+		nanos, err := strconv.ParseInt(rawAt, 10, 64)
+		if !ok || err != nil || conversationID == "" {
+			return nil, "", errAssignmentCursor
+		}
+		at := time.Unix(0, nanos)
+		for start < len(assignments) && !assignmentAfter(assignments[start], at, conversationID) {
+			start++
+		}
+	}
+	end := start + limit
+	if end >= len(assignments) {
+		return assignments[start:], "", nil
+	}
+	last := assignments[end-1]
+	return assignments[start:end], strconv.FormatInt(last.AssignedAt.UnixNano(), 10) + "_" + last.ConversationID, nil
+}
+
+// assignmentAfter reports whether a comes after the assignment of
+// conversationID at at in the ByAssignee order.
+func assignmentAfter(a *domaininbox.Assignment, at time.Time, conversationID string) bool {
+	if !a.AssignedAt.Equal(at) {
+		return a.AssignedAt.Before(at)
+	}
+	return a.ConversationID > conversationID
+}
+
+// loadAssignedConversations fetches the conversations of page concurrently,
+// keeping the page order and leaving out conversations messaging no longer
+// has. On failure it returns the id of the conversation that failed.
+func loadAssignedConversations(ctx context.Context, get func(context.Context, string) (messaging.Conversation, error), page []*domaininbox.Assignment) ([]messaging.Conversation, string, error) {
+	found := make([]*messaging.Conversation, len(page))
+	var (
+		mu     sync.Mutex
+		failed string
+	)
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(assignedConversationLookups)
+	for i, assignment := range page {
+		group.Go(func() error {
+			conv, err := get(groupCtx, assignment.ConversationID)
+			if err != nil {
+				if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+					return nil
+				}
+				mu.Lock()
+				if failed == "" {
+					failed = assignment.ConversationID
+				}
+				mu.Unlock()
+				return err
+			}
+			found[i] = &conv
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, failed, err
+	}
+	conversations := make([]messaging.Conversation, 0, len(found))
+	for _, conv := range found {
+		if conv != nil {
+			conversations = append(conversations, *conv)
+		}
+	}
+	return conversations, "", nil
+}
+
+// attachPeers adds the name and identity badge of the other participants.
+// Users that cannot be loaded are listed by id only.
+func (h ChatHandler) attachPeers(ctx context.Context, items []dto.Conversation, viewerID string) {
+	if h.Users == nil {
+		return
+	}
+	cache := make(map[string]dto.ConversationPeer)
+	for i := range items {
+		for _, participant := range items[i].Participants {
+			if participant == viewerID {
+				continue
+			}
+			peer, ok := cache[participant]
+			if !ok {
+				peer = dto.ConversationPeer{ID: participant}
+				user, err := h.Users.ByID(ctx, domainuser.ID(participant))
+				if err == nil {
+					peer.Name = user.Name
+					peer.Verified = user.IdentityVerified()
+				} else if !errors.Is(err, domainuser.ErrNotFound) {
+					h.logError("load chat peer failed", err)
+				}
+				cache[participant] = peer
+			}
+			items[i].Peers = append(items[i].Peers, peer)
+		}
+	}
+}
+
+// attachListings adds the title, thumbnail and city of the listing each
+// conversation is about. Every listing is loaded once in a single read-only
+// unit of work; conversations whose listing cannot be loaded get no snapshot.
+func (h ChatHandler) attachListings(ctx context.Context, items []dto.Conversation) {
+	if h.UoWFactory == nil {
+		return
+	}
+	snapshots := make(map[string]*dto.ListingSnapshot)
+	for _, item := range items {
+		if item.ListingID != "" {
+			snapshots[item.ListingID] = nil
+		}
+	}
+	if len(snapshots) == 0 {
+		return
+	}
+	unit, err := h.UoWFactory.Begin(ctx, uow.TxOptions{ReadOnly: true})
+	if err != nil {
+		h.logError("load chat listings failed", err)
+		return
+	}
+	defer unit.Rollback(ctx)
+
+	for id := range snapshots {
+		listing, err := unit.Listings().ByID(ctx, domainlistings.ListingID(id))
+		if err != nil {
+			continue
+		}
+		snapshots[id] = &dto.ListingSnapshot{
+			Title:        listing.Title,
+			ThumbnailURL: dto.MediaURL(listing.ThumbnailURL),
+			City:         listing.Address.City,
+		}
+	}
+	for i := range items {
+		items[i].Listing = snapshots[items[i].ListingID]
+	}
+}
+
 // ListMessages returns messages for a conversation if the user is a participant or admin.
 func (h ChatHandler) ListMessages(c *gin.Context) {
 	principal, ok := requireRole(c, "")
@@ -92,12 +294,12 @@ func (h ChatHandler) ListMessages(c *gin.Context) {
 		return
 	}
 	if h.Messaging == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "messaging unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
 		return
 	}
 	conversationID := c.Param("id")
 	if conversationID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "conversation id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "conversation id is required")
 		return
 	}
 
@@ -107,8 +309,11 @@ func (h ChatHandler) ListMessages(c *gin.Context) {
 		return
 	}
 	if !principal.HasRole("admin") && !contains(conversation.Participants, principal.ID) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "not a chat participant"})
-		return
+		// Co-hosts read threads of the listings they help with.
+		if _, err := h.hostTeamListing(c.Request.Context(), conversation.ListingID, principal.ID); err != nil {
+			respondError(c, http.StatusForbidden, ErrCodeForbidden, "not a chat participant")
+			return
+		}
 	}
 	limit := parsePositiveIntStrict(c.Query("limit"), 50)
 	cursor := c.Query("cursor")
@@ -124,11 +329,12 @@ func (h ChatHandler) ListMessages(c *gin.Context) {
 	}
 	for _, msg := range messages {
 		collection.Items = append(collection.Items, dto.ChatMessage{
-			ID:             msg.ID,
-			ConversationID: msg.ConversationID,
-			SenderID:       msg.SenderID,
-			Text:           msg.Text,
-			CreatedAt:      msg.CreatedAt,
+			ID:              msg.ID,
+			ConversationID:  msg.ConversationID,
+			SenderID:        msg.SenderID,
+			Text:            msg.Text,
+			ClientMessageID: msg.ClientMessageID,
+			CreatedAt:       msg.CreatedAt,
 		})
 	}
 	c.JSON(http.StatusOK, collection)
@@ -141,24 +347,25 @@ func (h ChatHandler) SendMessage(c *gin.Context) {
 		return
 	}
 	if h.Messaging == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "messaging unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
 		return
 	}
 	conversationID := c.Param("id")
 	if conversationID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "conversation id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "conversation id is required")
 		return
 	}
 	var req struct {
-		Text string `json:"text"`
+		Text            string `json:"text"`
+		ClientMessageID string `json:"client_message_id"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid payload")
 		return
 	}
 	req.Text = strings.TrimSpace(req.Text)
 	if req.Text == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "text is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "text is required")
 		return
 	}
 
@@ -168,20 +375,21 @@ func (h ChatHandler) SendMessage(c *gin.Context) {
 		return
 	}
 	if !principal.HasRole("admin") && !contains(conversation.Participants, principal.ID) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "not a chat participant"})
+		respondError(c, http.StatusForbidden, ErrCodeForbidden, "not a chat participant")
 		return
 	}
-	message, err := h.Messaging.SendMessage(c.Request.Context(), conversationID, principal.ID, req.Text)
+	message, err := h.Messaging.SendMessage(c.Request.Context(), conversationID, principal.ID, req.Text, strings.TrimSpace(req.ClientMessageID))
 	if err != nil {
 		h.respondMessagingError(c, err, "send message", "conversation_id", conversationID, "user_id", principal.ID)
 		return
 	}
 	c.JSON(http.StatusCreated, dto.ChatMessage{
-		ID:             message.ID,
-		ConversationID: message.ConversationID,
-		SenderID:       message.SenderID,
-		Text:           message.Text,
-		CreatedAt:      message.CreatedAt,
+		ID:              message.ID,
+		ConversationID:  message.ConversationID,
+		SenderID:        message.SenderID,
+		Text:            message.Text,
+		ClientMessageID: message.ClientMessageID,
+		CreatedAt:       message.CreatedAt,
 	})
 }
 
@@ -192,34 +400,45 @@ func (h ChatHandler) CreateListingConversation(c *gin.Context) {
 		return
 	}
 	if h.Messaging == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "messaging unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
 		return
 	}
 	listingID := strings.TrimSpace(c.Param("id"))
 	if listingID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "listing id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "listing id is required")
 		return
 	}
 	if h.UoWFactory == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "listings unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "listings unavailable")
 		return
 	}
 	unit, err := h.UoWFactory.Begin(c.Request.Context(), uow.TxOptions{ReadOnly: true})
 	if err != nil {
 		h.logError("begin uow failed", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "cannot load listing"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot load listing")
 		return
 	}
 	defer unit.Rollback(c.Request.Context())
 
 	listing, err := unit.Listings().ByID(c.Request.Context(), domainlistings.ListingID(listingID))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "listing not found"})
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "listing not found")
 		return
 	}
 	hostID := string(listing.Host)
 	if hostID == principal.ID {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot start chat with yourself"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "cannot start chat with yourself")
+		return
+	}
+	blocked, err := domainguestblock.IsBlocked(c.Request.Context(), h.GuestBlocks, listing.Host, principal.ID)
+	if err != nil {
+		h.logError("guest block lookup failed", err)
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot create conversation")
+		return
+	}
+	if blocked {
+		// Deliberately vague: the guest is not told that the host blocked them.
+		respondError(c, http.StatusForbidden, ErrCodeForbidden, "conversation is not available for this listing")
 		return
 	}
 	conversation, err := h.Messaging.GetOrCreateConversationForListing(c.Request.Context(), listingID, principal.ID, hostID)
@@ -258,45 +477,45 @@ func (h ChatHandler) CreateBookingConversation(c *gin.Context) {
 		return
 	}
 	if h.Messaging == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "messaging unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
 		return
 	}
 	bookingID := strings.TrimSpace(c.Param("id"))
 	if bookingID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "booking id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "booking id is required")
 		return
 	}
 	if h.UoWFactory == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "bookings unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "bookings unavailable")
 		return
 	}
 	unit, err := h.UoWFactory.Begin(c.Request.Context(), uow.TxOptions{ReadOnly: true})
 	if err != nil {
 		h.logError("begin uow failed", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "cannot load booking"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot load booking")
 		return
 	}
 	defer unit.Rollback(c.Request.Context())
 
 	booking, err := unit.Booking().ByID(c.Request.Context(), domainbooking.BookingID(bookingID))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "booking not found")
 		return
 	}
 	listing, err := unit.Listings().ByID(c.Request.Context(), booking.ListingID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "listing not found"})
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "listing not found")
 		return
 	}
 
 	hostID := string(listing.Host)
 	guestID := booking.GuestID
 	if principal.ID != hostID && principal.ID != guestID && !principal.HasRole("admin") {
-		c.JSON(http.StatusForbidden, gin.H{"error": "not a booking participant"})
+		respondError(c, http.StatusForbidden, ErrCodeForbidden, "not a booking participant")
 		return
 	}
 	if hostID == "" || guestID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "booking participants missing"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "booking participants missing")
 		return
 	}
 
@@ -338,27 +557,27 @@ func (h ChatHandler) CreateDirectConversation(c *gin.Context) {
 		return
 	}
 	if !principal.HasRole("admin") {
-		c.JSON(http.StatusForbidden, gin.H{"error": "admin only"})
+		respondError(c, http.StatusForbidden, ErrCodeForbidden, "admin only")
 		return
 	}
 	if h.Messaging == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "messaging unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
 		return
 	}
 	var req struct {
 		UserID string `json:"user_id"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid payload")
 		return
 	}
 	req.UserID = strings.TrimSpace(req.UserID)
 	if req.UserID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "user_id is required")
 		return
 	}
 	if req.UserID == principal.ID {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot chat with yourself"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "cannot chat with yourself")
 		return
 	}
 	conversation, err := h.Messaging.GetOrCreateConversationForListing(c.Request.Context(), "", principal.ID, req.UserID)
@@ -387,19 +606,19 @@ func (h ChatHandler) MarkRead(c *gin.Context) {
 		return
 	}
 	if h.Messaging == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "messaging unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
 		return
 	}
 	conversationID := strings.TrimSpace(c.Param("id"))
 	if conversationID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "conversation id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "conversation id is required")
 		return
 	}
 	var req struct {
 		LastReadMessageID string `json:"last_read_message_id"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid payload")
 		return
 	}
 
@@ -409,7 +628,7 @@ func (h ChatHandler) MarkRead(c *gin.Context) {
 		return
 	}
 	if !principal.HasRole("admin") && !contains(conversation.Participants, principal.ID) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "not a chat participant"})
+		respondError(c, http.StatusForbidden, ErrCodeForbidden, "not a chat participant")
 		return
 	}
 
@@ -425,6 +644,249 @@ func (h ChatHandler) MarkRead(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"read_at": readAt})
 }
 
+type assignConversationRequest struct {
+	AssigneeID string `json:"assignee_id"`
+}
+
+// Assign hands a listing conversation to a member of the host team. An empty
+// assignee clears the assignment.
+func (h ChatHandler) Assign(c *gin.Context) {
+	principal, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Messaging == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
+		return
+	}
+	if h.Assignments == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "inbox unavailable")
+		return
+	}
+	conversationID := strings.TrimSpace(c.Param("id"))
+	if conversationID == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "conversation id is required")
+		return
+	}
+	var req assignConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid payload")
+		return
+	}
+	listing, ok := h.loadTeamConversation(c, conversationID, principal.ID)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	assigneeID := domainlistings.HostID(strings.TrimSpace(req.AssigneeID))
+	if assigneeID == "" {
+		if err := h.Assignments.Delete(ctx, conversationID); err != nil {
+			h.logError("clear assignment failed", err)
+			respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot update assignment")
+			return
+		}
+		c.JSON(http.StatusOK, dto.ConversationAssignment{ConversationID: conversationID, ListingID: string(listing.ID)})
+		return
+	}
+	if !listing.IsHostTeamMember(assigneeID) {
+		respondError(c, http.StatusBadRequest, ErrCodeValidation, "assignee must be a member of the host team")
+		return
+	}
+	previous, err := h.Assignments.ByConversation(ctx, conversationID)
+	if err != nil {
+		h.logError("load assignment failed", err)
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot update assignment")
+		return
+	}
+	assignment, err := domaininbox.NewAssignment(conversationID, listing.ID, assigneeID, principal.ID, time.Now())
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	if err := h.Assignments.Save(ctx, assignment); err != nil {
+		h.logError("save assignment failed", err)
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot update assignment")
+		return
+	}
+	changed := previous == nil || previous.AssigneeID != assigneeID
+	if changed && h.Notifier != nil {
+		data := map[string]any{
+			"conversation_id": conversationID,
+			"listing_id":      string(listing.ID),
+			"assigned_by":     principal.ID,
+		}
+		if err := h.Notifier.Send(ctx, string(assigneeID), "chat.assigned", data); err != nil && h.Logger != nil {
+			h.Logger.Warn("assignment notification failed", "conversation_id", conversationID, "assignee_id", assigneeID, "error", err)
+		}
+	}
+	if h.Logger != nil {
+		h.Logger.Info("conversation assigned", "conversation_id", conversationID, "listing_id", listing.ID, "assignee_id", assigneeID, "assigned_by", principal.ID)
+	}
+	c.JSON(http.StatusOK, mapAssignment(assignment))
+}
+
+// ListNotes returns internal notes of a conversation to the host team.
+func (h ChatHandler) ListNotes(c *gin.Context) {
+	principal, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Messaging == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
+		return
+	}
+	if h.Notes == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "inbox unavailable")
+		return
+	}
+	conversationID := strings.TrimSpace(c.Param("id"))
+	if conversationID == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "conversation id is required")
+		return
+	}
+	if _, ok := h.loadTeamConversation(c, conversationID, principal.ID); !ok {
+		return
+	}
+	notes, err := h.Notes.ListByConversation(c.Request.Context(), conversationID)
+	if err != nil {
+		h.logError("list notes failed", err)
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot list notes")
+		return
+	}
+	collection := dto.InboxNoteList{Items: make([]dto.InboxNote, 0, len(notes))}
+	for _, note := range notes {
+		collection.Items = append(collection.Items, mapNote(note))
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// AddNote stores an internal note; it is never delivered to the guest.
+func (h ChatHandler) AddNote(c *gin.Context) {
+	principal, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Messaging == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
+		return
+	}
+	if h.Notes == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "inbox unavailable")
+		return
+	}
+	conversationID := strings.TrimSpace(c.Param("id"))
+	if conversationID == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "conversation id is required")
+		return
+	}
+	var req struct {
+		Text string `json:"text"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid payload")
+		return
+	}
+	listing, ok := h.loadTeamConversation(c, conversationID, principal.ID)
+	if !ok {
+		return
+	}
+	note, err := domaininbox.NewNote(uuid.NewString(), conversationID, listing.ID, principal.ID, req.Text, time.Now())
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	if err := h.Notes.Append(c.Request.Context(), note); err != nil {
+		h.logError("save note failed", err)
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot save note")
+		return
+	}
+	c.JSON(http.StatusCreated, mapNote(note))
+}
+
+// loadTeamConversation resolves the listing behind a conversation and checks
+// that the user belongs to its host team. It writes the error response itself.
+func (h ChatHandler) loadTeamConversation(c *gin.Context, conversationID, userID string) (*domainlistings.Listing, bool) {
+	conversation, err := h.Messaging.GetConversation(c.Request.Context(), conversationID)
+	if err != nil {
+		h.respondMessagingError(c, err, "load conversation", "conversation_id", conversationID, "user_id", userID)
+		return nil, false
+	}
+	if strings.TrimSpace(conversation.ListingID) == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "conversation is not linked to a listing")
+		return nil, false
+	}
+	listing, err := h.hostTeamListing(c.Request.Context(), conversation.ListingID, userID)
+	if err != nil {
+		if errors.Is(err, errNotHostTeam) {
+			respondError(c, http.StatusForbidden, ErrCodeForbidden, errNotHostTeam.Error())
+			return nil, false
+		}
+		h.logError("load listing failed", err)
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "listing not found")
+		return nil, false
+	}
+	return listing, true
+}
+
+func (h ChatHandler) hostTeamListing(ctx context.Context, listingID, userID string) (*domainlistings.Listing, error) {
+	if strings.TrimSpace(listingID) == "" {
+		return nil, errNotHostTeam
+	}
+	if h.UoWFactory == nil {
+		return nil, errors.New("listings unavailable")
+	}
+	unit, err := h.UoWFactory.Begin(ctx, uow.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer unit.Rollback(ctx)
+
+	listing, err := unit.Listings().ByID(ctx, domainlistings.ListingID(listingID))
+	if err != nil {
+		return nil, err
+	}
+	if !listing.IsHostTeamMember(domainlistings.HostID(userID)) {
+		return nil, errNotHostTeam
+	}
+	return listing, nil
+}
+
+func mapConversation(conv messaging.Conversation) dto.Conversation {
+	return dto.Conversation{
+		ID:                conv.ID,
+		ListingID:         conv.ListingID,
+		Participants:      append([]string(nil), conv.Participants...),
+		CreatedAt:         conv.CreatedAt,
+		LastMessageAt:     conv.LastMessageAt,
+		LastMessageID:     conv.LastMessageID,
+		LastMessageSender: conv.LastSenderID,
+		LastMessageText:   conv.LastMessageText,
+		HasUnread:         conv.HasUnread,
+		UnreadCount:       conv.UnreadCount,
+	}
+}
+
+func mapAssignment(assignment *domaininbox.Assignment) dto.ConversationAssignment {
+	return dto.ConversationAssignment{
+		ConversationID: assignment.ConversationID,
+		ListingID:      string(assignment.ListingID),
+		AssigneeID:     string(assignment.AssigneeID),
+		AssignedBy:     assignment.AssignedBy,
+		AssignedAt:     assignment.AssignedAt,
+	}
+}
+
+func mapNote(note *domaininbox.Note) dto.InboxNote {
+	return dto.InboxNote{
+		ID:             note.ID,
+		ConversationID: note.ConversationID,
+		AuthorID:       note.AuthorID,
+		Text:           note.Text,
+		CreatedAt:      note.CreatedAt,
+	}
+}
+
 func (h ChatHandler) respondMessagingError(c *gin.Context, err error, action string, attrs ...any) {
 	code := codes.Unknown
 	st, ok := status.FromError(err)
@@ -437,20 +899,20 @@ func (h ChatHandler) respondMessagingError(c *gin.Context, err error, action str
 	if ok {
 		switch code {
 		case codes.NotFound:
-			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "not found")
 			return
 		case codes.InvalidArgument:
-			c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, st.Message())
 			return
 		case codes.Unauthenticated, codes.PermissionDenied:
-			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			respondError(c, http.StatusForbidden, ErrCodeForbidden, "forbidden")
 			return
 		case codes.Unavailable, codes.DeadlineExceeded:
-			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "messaging unavailable"})
+			respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "messaging unavailable")
 			return
 		}
 	}
-	c.JSON(http.StatusBadGateway, gin.H{"error": "messaging unavailable"})
+	respondError(c, http.StatusBadGateway, ErrCodeUpstream, "messaging unavailable")
 }
 
 func (h ChatHandler) logError(msg string, err error) {
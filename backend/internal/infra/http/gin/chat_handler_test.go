@@ -0,0 +1,145 @@
+package ginserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	domaininbox "rentme/internal/domain/inbox"
+	"rentme/internal/infra/messaging"
+)
+
+// assignmentsNewestFirst lists n assignments in ByAssignee order; the last
+// two share an instant.
+func assignmentsNewestFirst(n int) []*domaininbox.Assignment {
+	base := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	out := make([]*domaininbox.Assignment, 0, n)
+	for i := 0; i < n; i++ {
+		at := base.Add(-time.Duration(i) * time.Minute)
+		if i == n-1 {
+			at = out[i-1].AssignedAt
+		}
+		out = append(out, &domaininbox.Assignment{ConversationID: fmt.Sprintf("conv-%02d", i), AssignedAt: at})
+	}
+	return out
+}
+
+func TestPageAssignmentsWalksEveryAssignmentOnce(t *testing.T) {
+	assignments := assignmentsNewestFirst(7)
+	var seen []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > len(assignments) {
+			t.Fatalf("paging does not end")
+		}
+		page, next, err := pageAssignments(assignments, cursor, 3)
+		if err != nil {
+			t.Fatalf("page %d: %v", pages, err)
+		}
+		for _, a := range page {
+			seen = append(seen, a.ConversationID)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if len(seen) != len(assignments) {
+		t.Fatalf("seen %v, want all %d assignments", seen, len(assignments))
+	}
+	for i, id := range seen {
+		if id != assignments[i].ConversationID {
+			t.Fatalf("position %d = %s, want %s", i, id, assignments[i].ConversationID)
+		}
+	}
+}
+
+func TestPageAssignmentsCursorSurvivesRemoval(t *testing.T) {
+	assignments := assignmentsNewestFirst(6)
+	_, next, err := pageAssignments(assignments, "", 2)
+	if err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	// The last assignment of the first page is reassigned before the next call.
+	remaining := append([]*domaininbox.Assignment{assignments[0]}, assignments[2:]...)
+	page, _, err := pageAssignments(remaining, next, 2)
+	if err != nil {
+		t.Fatalf("second page: %v", err)
+	}
+	if len(page) != 2 || page[0].ConversationID != "conv-02" {
+		t.Fatalf("second page starts at %v, want conv-02", page)
+	}
+}
+
+func TestPageAssignmentsRejectsGarbageCursor(t *testing.T) {
+	for _, cursor := range []string{"abc", "123", "x_conv-1", "123_"} {
+		if _, _, err := pageAssignments(assignmentsNewestFirst(3), cursor, 2); !errors.Is(err, errAssignmentCursor) {
+			t.Errorf("cursor %q: err = %v, want errAssignmentCursor", cursor, err)
+		}
+	}
+}
+
+func TestLoadAssignedConversationsKeepsOrderAndSkipsMissing(t *testing.T) {
+	page := assignmentsNewestFirst(20)
+	var (
+		mu       sync.Mutex
+		inFlight int
+		peak     int
+	)
+	get := func(_ context.Context, id string) (messaging.Conversation, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if id == "conv-05" {
+			return messaging.Conversation{}, status.Error(codes.NotFound, "gone")
+		}
+		return messaging.Conversation{ID: id}, nil
+	}
+
+	conversations, _, err := loadAssignedConversations(context.Background(), get, page)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(conversations) != len(page)-1 {
+		t.Fatalf("conversations = %d, want %d", len(conversations), len(page)-1)
+	}
+	for i, conv := range conversations {
+		want := page[i].ConversationID
+		if i >= 5 {
+			want = page[i+1].ConversationID
+		}
+		if conv.ID != want {
+			t.Fatalf("position %d = %s, want %s", i, conv.ID, want)
+		}
+	}
+	if peak > assignedConversationLookups {
+		t.Fatalf("peak lookups in flight = %d, want at most %d", peak, assignedConversationLookups)
+	}
+}
+
+func TestLoadAssignedConversationsReportsFailure(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "down")
+	get := func(_ context.Context, id string) (messaging.Conversation, error) {
+		if id == "conv-01" {
+			return messaging.Conversation{}, unavailable
+		}
+		return messaging.Conversation{ID: id}, nil
+	}
+	_, failed, err := loadAssignedConversations(context.Background(), get, assignmentsNewestFirst(3))
+	if !errors.Is(err, unavailable) || failed != "conv-01" {
+		t.Fatalf("err = %v, failed = %q; want the messaging error for conv-01", err, failed)
+	}
+}
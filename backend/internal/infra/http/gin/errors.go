@@ -0,0 +1,123 @@
+package ginserver
+
+import (
+	"errors"
+	"net/http"
+
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/app/middleware"
+	domainavailability "rentme/internal/domain/availability"
+	domainbooking "rentme/internal/domain/booking"
+	"rentme/internal/domain/shared/concurrency"
+)
+
+// Machine-readable error codes returned in APIError.Code.
+const (
+	ErrCodeBadRequest          = "BAD_REQUEST"
+	ErrCodeValidation          = "VALIDATION_FAILED"
+	ErrCodeUnauthorized        = "UNAUTHORIZED"
+	ErrCodeInvalidCredentials  = "INVALID_CREDENTIALS"
+	ErrCodeUserBlocked         = "USER_BLOCKED"
+	ErrCodeForbidden           = "FORBIDDEN"
+	ErrCodeNotFound            = "NOT_FOUND"
+	ErrCodeConflict            = "CONFLICT"
+	ErrCodeBookingConflict     = "BOOKING_CONFLICT"
+	ErrCodeConcurrentUpdate    = "CONCURRENT_UPDATE"
+	ErrCodeGuestNotVerified    = "GUEST_NOT_VERIFIED"
+	ErrCodeEmailTaken          = "EMAIL_TAKEN"
+	ErrCodeIdempotencyConflict = "IDEMPOTENCY_CONFLICT"
+	ErrCodeRateLimited         = "RATE_LIMITED"
+	ErrCodeNotImplemented      = "NOT_IMPLEMENTED"
+	ErrCodeUpstream            = "UPSTREAM_ERROR"
+	ErrCodeUnavailable         = "SERVICE_UNAVAILABLE"
+	ErrCodeTimeout             = "REQUEST_TIMEOUT"
+	ErrCodeInternal            = "INTERNAL_ERROR"
+)
+
+// APIError is the body of every error response. The message stays under the
+// "error" key so clients reading the old shape keep working.
+type APIError struct {
+	Code    string            `json:"code"`
+	Message string            `json:"error"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+func respondError(c *gin.Context, status int, code, message string) {
+	respondErrorDetails(c, status, code, message, nil)
+}
+
+func respondErrorDetails(c *gin.Context, status int, code, message string, details map[string]string) {
+	if code == "" {
+		code = errorCodeForStatus(status)
+	}
+	c.JSON(status, APIError{Code: code, Message: message, Details: details})
+}
+
+// concurrentUpdateRetryAfter is the Retry-After hint, in seconds, sent when a
+// request lost an optimistic-locking race.
+const concurrentUpdateRetryAfter = "1"
+
+// respondConcurrentUpdate answers 409 when err is a lost optimistic-locking
+// race. The other request usually finished already, so the client should
+// reload and retry.
+func respondConcurrentUpdate(c *gin.Context, err error) bool {
+	if !errors.Is(err, concurrency.ErrConcurrentUpdate) {
+		return false
+	}
+	c.Header("Retry-After", concurrentUpdateRetryAfter)
+	respondErrorDetails(c, http.StatusConflict, ErrCodeConcurrentUpdate, "the resource was changed by another request, reload and retry", map[string]string{
+		"retryable": "true",
+	})
+	return true
+}
+
+// abortWithError stops the handler chain, for use in middleware.
+func abortWithError(c *gin.Context, status int, code, message string) {
+	respondError(c, status, code, message)
+	c.Abort()
+}
+
+// errorCode picks a specific code for well-known errors and falls back to the status.
+func errorCode(status int, err error) string {
+	switch {
+	case err == nil:
+	case errors.Is(err, middleware.ErrIdempotencyConflict):
+		return ErrCodeIdempotencyConflict
+	case errors.Is(err, domainavailability.ErrOverlappingRange):
+		return ErrCodeBookingConflict
+	case status == http.StatusConflict && errors.Is(err, domainbooking.ErrInvalidState):
+		return ErrCodeBookingConflict
+	case status == http.StatusBadRequest && isValidationError(err):
+		return ErrCodeValidation
+	}
+	return errorCodeForStatus(status)
+}
+
+// errorCodeForStatus is the fallback code when a handler maps errors to a status only.
+func errorCodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrCodeForbidden
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusTooManyRequests:
+		return ErrCodeRateLimited
+	case http.StatusNotImplemented:
+		return ErrCodeNotImplemented
+	case http.StatusBadGateway:
+		return ErrCodeUpstream
+	case http.StatusServiceUnavailable:
+		return ErrCodeUnavailable
+	case http.StatusGatewayTimeout:
+		return ErrCodeTimeout
+	default:
+		return ErrCodeInternal
+	}
+}
@@ -14,6 +14,7 @@ import (
 	bookingapp "rentme/internal/app/handlers/booking"
 	"rentme/internal/app/queries"
 	domainbooking "rentme/internal/domain/booking"
+	domainguestblock "rentme/internal/domain/guestblock"
 )
 
 type HostBookingHandler struct {
@@ -36,9 +37,18 @@ func (h HostBookingHandler) List(c *gin.Context) {
 		return
 	}
 
+	limit := parseIntWithDefault(c.Query("limit"), 20)
+	page := parseIntWithDefault(c.Query("page"), 1)
+	offset := parseInt(c.Query("offset"))
+	if offset == 0 && page > 1 {
+		offset = (page - 1) * limit
+	}
+
 	query := bookingapp.ListHostBookingsQuery{
 		HostID: host.ID,
 		Status: c.Query("status"),
+		Limit:  limit,
+		Offset: offset,
 	}
 	result, err := queries.Ask[bookingapp.ListHostBookingsQuery, dto.HostBookingCollection](c.Request.Context(), h.Queries, query)
 	if err != nil {
@@ -101,10 +111,170 @@ func (h HostBookingHandler) Decline(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// CheckIn records the guest's arrival; it opens shortly before the check-in date.
+func (h HostBookingHandler) CheckIn(c *gin.Context) {
+	host, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	cmd := bookingapp.CheckInBookingCommand{
+		HostID:    host.ID,
+		BookingID: strings.TrimSpace(c.Param("id")),
+	}
+	result, err := commands.Dispatch[bookingapp.CheckInBookingCommand, *bookingapp.HostBookingActionResult](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleStayError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CheckOut ends the stay, after which the guest may leave a review.
+func (h HostBookingHandler) CheckOut(c *gin.Context) {
+	host, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	cmd := bookingapp.CheckOutBookingCommand{
+		HostID:    host.ID,
+		BookingID: strings.TrimSpace(c.Param("id")),
+	}
+	result, err := commands.Dispatch[bookingapp.CheckOutBookingCommand, *bookingapp.HostBookingActionResult](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleStayError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h HostBookingHandler) NoShow(c *gin.Context) {
+	host, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	cmd := bookingapp.MarkNoShowCommand{
+		HostID:    host.ID,
+		BookingID: strings.TrimSpace(c.Param("id")),
+	}
+	result, err := commands.Dispatch[bookingapp.MarkNoShowCommand, *bookingapp.HostBookingActionResult](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleStayError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// handleStayError answers 409 when the stay is not in a state or at a time
+// that allows the transition.
+func (h HostBookingHandler) handleStayError(c *gin.Context, err error) {
+	if errors.Is(err, domainbooking.ErrInvalidState) ||
+		errors.Is(err, domainbooking.ErrCheckInTooEarly) ||
+		errors.Is(err, domainbooking.ErrNoShowTooEarly) {
+		h.respondWithError(c, http.StatusConflict, err)
+		return
+	}
+	h.handleError(c, err)
+}
+
+type blockGuestRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ListBlockedGuests lists the guests the host refuses to host again.
+func (h HostBookingHandler) ListBlockedGuests(c *gin.Context) {
+	host, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Queries == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("queries bus unavailable"))
+		return
+	}
+	result, err := queries.Ask[bookingapp.ListBlockedGuestsQuery, dto.GuestBlockCollection](c.Request.Context(), h.Queries, bookingapp.ListBlockedGuestsQuery{HostID: host.ID})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// BlockGuest stops the guest from booking or starting a chat about any
+// listing of the host. The reason is optional and never shown to the guest.
+func (h HostBookingHandler) BlockGuest(c *gin.Context) {
+	host, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+	var req blockGuestRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+	cmd := bookingapp.BlockGuestCommand{
+		HostID:  host.ID,
+		GuestID: strings.TrimSpace(c.Param("guestId")),
+		Reason:  req.Reason,
+	}
+	result, err := commands.Dispatch[bookingapp.BlockGuestCommand, *dto.GuestBlock](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// UnblockGuest lifts a block the host placed earlier.
+func (h HostBookingHandler) UnblockGuest(c *gin.Context) {
+	host, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+	cmd := bookingapp.UnblockGuestCommand{
+		HostID:  host.ID,
+		GuestID: strings.TrimSpace(c.Param("guestId")),
+	}
+	result, err := commands.Dispatch[bookingapp.UnblockGuestCommand, *dto.GuestBlock](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 func (h HostBookingHandler) handleError(c *gin.Context, err error) {
+	if respondConcurrentUpdate(c, err) {
+		return
+	}
 	switch {
 	case errors.Is(err, bookingapp.ErrBookingNotOwned),
 		errors.Is(err, domainbooking.ErrBookingNotFound),
+		errors.Is(err, bookingapp.ErrBlockedGuestNotFound),
+		errors.Is(err, domainguestblock.ErrNotFound),
 		errors.Is(err, mongo.ErrNoDocuments):
 		h.respondWithError(c, http.StatusNotFound, err)
 	case isHostBookingValidationError(err):
@@ -122,7 +292,7 @@ func (h HostBookingHandler) respondWithError(c *gin.Context, status int, err err
 		}
 		h.Logger.Error("host booking request failed", fields...)
 	}
-	c.JSON(status, gin.H{"error": err.Error()})
+	respondError(c, status, errorCode(status, err), err.Error())
 }
 
 func isHostBookingValidationError(err error) bool {
@@ -130,7 +300,10 @@ func isHostBookingValidationError(err error) bool {
 	case errors.Is(err, domainbooking.ErrInvalidState),
 		errors.Is(err, domainbooking.ErrPaymentHoldRequired),
 		errors.Is(err, domainbooking.ErrInvalidGuests),
-		errors.Is(err, domainbooking.ErrCheckInInPast):
+		errors.Is(err, domainbooking.ErrCheckInInPast),
+		errors.Is(err, domainguestblock.ErrGuestRequired),
+		errors.Is(err, domainguestblock.ErrSelfBlock),
+		errors.Is(err, domainguestblock.ErrReasonTooLong):
 		return true
 	}
 	return false
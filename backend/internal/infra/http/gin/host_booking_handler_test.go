@@ -0,0 +1,33 @@
+package ginserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"rentme/internal/app/commands"
+	bookingapp "rentme/internal/app/handlers/booking"
+	"rentme/internal/domain/shared/concurrency"
+)
+
+func TestHostConfirmLostRaceIsRetryableConflict(t *testing.T) {
+	bus := commands.NewInMemoryBus()
+	commands.RegisterHandler[bookingapp.ConfirmHostBookingCommand, *bookingapp.HostBookingActionResult](bus, bookingapp.ConfirmHostBookingCommand{}.Key(),
+		commands.HandlerFunc[bookingapp.ConfirmHostBookingCommand, *bookingapp.HostBookingActionResult](func(context.Context, bookingapp.ConfirmHostBookingCommand) (*bookingapp.HostBookingActionResult, error) {
+			return nil, fmt.Errorf("save booking: %w", concurrency.ErrConcurrentUpdate)
+		}))
+	server := newTestServer(t, Handlers{HostBooking: HostBookingHandler{Commands: bus}}, &principal{ID: "host-1", Roles: []string{"host"}})
+
+	rec := serve(server, http.MethodPost, "/api/v1/host/bookings/booking-1/confirm", "")
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusConflict, rec.Body)
+	}
+	if got := rec.Header().Get("Retry-After"); got != concurrentUpdateRetryAfter {
+		t.Fatalf("Retry-After = %q, want %q", got, concurrentUpdateRetryAfter)
+	}
+	apiErr := decodeAPIError(t, rec.Body.Bytes())
+	if apiErr.Code != ErrCodeConcurrentUpdate || apiErr.Details["retryable"] != "true" {
+		t.Fatalf("error = %+v, want a retryable %s", apiErr, ErrCodeConcurrentUpdate)
+	}
+}
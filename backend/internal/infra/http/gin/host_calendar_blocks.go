@@ -0,0 +1,72 @@
+package ginserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	listingapp "rentme/internal/app/handlers/listings"
+)
+
+type bulkBlockRequest struct {
+	Ranges []bulkBlockRange `json:"ranges"`
+}
+
+type bulkBlockRange struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+}
+
+// BulkBlockCalendar closes several date ranges of the listing calendar at
+// once. When any range is rejected nothing is blocked and the response lists
+// the problem of every rejected range under details, keyed "ranges[i]".
+func (h HostListingHandler) BulkBlockCalendar(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	var req bulkBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	ranges := make([]listingapp.BlockRangeInput, 0, len(req.Ranges))
+	for _, r := range req.Ranges {
+		ranges = append(ranges, listingapp.BlockRangeInput{From: r.From, To: r.To, Reason: r.Reason})
+	}
+
+	cmd := listingapp.BulkBlockCalendarCommand{
+		HostID:    principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+		Ranges:    ranges,
+	}
+	result, err := commands.Dispatch[listingapp.BulkBlockCalendarCommand, []dto.HostCalendarBlock](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		var bulkErr *listingapp.BulkBlockError
+		switch {
+		case errors.As(err, &bulkErr):
+			details := make(map[string]string, len(bulkErr.Ranges))
+			for _, rejected := range bulkErr.Ranges {
+				details[fmt.Sprintf("ranges[%d]", rejected.Index)] = rejected.Err.Error()
+			}
+			respondErrorDetails(c, http.StatusUnprocessableEntity, ErrCodeValidation, err.Error(), details)
+		case errors.Is(err, listingapp.ErrNoBlockRanges), errors.Is(err, listingapp.ErrTooManyBlockRanges):
+			h.respondWithError(c, http.StatusBadRequest, err)
+		default:
+			h.handleError(c, err)
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
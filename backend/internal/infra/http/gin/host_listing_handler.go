@@ -18,15 +18,28 @@ import (
 	"rentme/internal/app/dto"
 	listingapp "rentme/internal/app/handlers/listings"
 	"rentme/internal/app/queries"
+	domainbooking "rentme/internal/domain/booking"
 	domainlistings "rentme/internal/domain/listings"
+	domainmarkets "rentme/internal/domain/markets"
+	domainpricing "rentme/internal/domain/pricing"
+	"rentme/internal/domain/shared/daterange"
 )
 
 const maxListingPhotoSizeBytes int64 = 10 * 1024 * 1024
 
 type HostListingHandler struct {
-	Commands commands.Bus
-	Queries  queries.Bus
-	Logger   *slog.Logger
+	Commands     commands.Bus
+	Queries      queries.Bus
+	CalendarFeed FeedSigner
+	// Media maps photo URLs clients send back to their stored form; nil
+	// keeps them as sent.
+	Media  MediaURLMapper
+	Logger *slog.Logger
+}
+
+// MediaURLMapper undoes the rewriting of stored media URLs for clients.
+type MediaURLMapper interface {
+	Stored(raw string) string
 }
 
 func (h HostListingHandler) List(c *gin.Context) {
@@ -83,6 +96,8 @@ func (h HostListingHandler) Create(c *gin.Context) {
 		h.respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
+	payload.ThumbnailURL = h.storedURL(payload.ThumbnailURL)
+	payload.Photos = h.storedURLs(payload.Photos)
 
 	cmd := listingapp.CreateHostListingCommand{HostID: hostID, Payload: payload}
 	result, err := commands.Dispatch[listingapp.CreateHostListingCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
@@ -117,6 +132,82 @@ func (h HostListingHandler) Get(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// History lists which listing attributes each update changed, newest first.
+func (h HostListingHandler) History(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Queries == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("queries bus unavailable"))
+		return
+	}
+
+	query := listingapp.HostListingHistoryQuery{
+		HostID:    principal.ID,
+		ListingID: c.Param("id"),
+	}
+	result, err := queries.Ask[listingapp.HostListingHistoryQuery, []dto.ListingChangelogEntry](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// PriceHistory lists the rate changes of a listing, newest first.
+func (h HostListingHandler) PriceHistory(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Queries == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("queries bus unavailable"))
+		return
+	}
+
+	query := listingapp.HostListingPriceHistoryQuery{
+		HostID:    principal.ID,
+		ListingID: c.Param("id"),
+	}
+	result, err := queries.Ask[listingapp.HostListingPriceHistoryQuery, []dto.ListingPriceChange](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ValidateAddress previews the address step of the listing form without
+// saving anything.
+func (h HostListingHandler) ValidateAddress(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Queries == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("queries bus unavailable"))
+		return
+	}
+
+	var req hostListingAddress
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	query := listingapp.ValidateHostListingAddressQuery{
+		HostID:  principal.ID,
+		Address: req.toDomain(),
+	}
+	result, err := queries.Ask[listingapp.ValidateHostListingAddressQuery, dto.HostListingAddressValidation](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 func (h HostListingHandler) Update(c *gin.Context) {
 	principal, ok := requireRole(c, "host")
 	if !ok {
@@ -139,6 +230,8 @@ func (h HostListingHandler) Update(c *gin.Context) {
 		h.respondWithError(c, http.StatusBadRequest, err)
 		return
 	}
+	payload.ThumbnailURL = h.storedURL(payload.ThumbnailURL)
+	payload.Photos = h.storedURLs(payload.Photos)
 
 	cmd := listingapp.UpdateHostListingCommand{
 		HostID:    hostID,
@@ -153,6 +246,29 @@ func (h HostListingHandler) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+func (h HostListingHandler) Duplicate(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	cmd := listingapp.DuplicateHostListingCommand{
+		HostID:          principal.ID,
+		SourceListingID: c.Param("id"),
+	}
+	result, err := commands.Dispatch[listingapp.DuplicateHostListingCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.Header("Location", fmt.Sprintf("/api/v1/host/listings/%s", result.ID))
+	c.JSON(http.StatusCreated, result)
+}
+
 func (h HostListingHandler) Publish(c *gin.Context) {
 	principal, ok := requireRole(c, "host")
 	if !ok {
@@ -187,9 +303,23 @@ func (h HostListingHandler) Unpublish(c *gin.Context) {
 		return
 	}
 
+	var payload unpublishRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+	republishAt, ok := parseFlexibleTime(payload.RepublishAt)
+	if payload.RepublishAt != "" && !ok {
+		h.respondWithError(c, http.StatusBadRequest, errors.New("republish_at must be a valid date"))
+		return
+	}
+
 	cmd := listingapp.UnpublishHostListingCommand{
-		HostID:    hostID,
-		ListingID: c.Param("id"),
+		HostID:      hostID,
+		ListingID:   c.Param("id"),
+		RepublishAt: republishAt,
 	}
 	result, err := commands.Dispatch[listingapp.UnpublishHostListingCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
 	if err != nil {
@@ -199,6 +329,87 @@ func (h HostListingHandler) Unpublish(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// Delete soft-deletes a draft or unpublished listing. Open bookings block it
+// with 409 and the conflicting booking ids.
+func (h HostListingHandler) Delete(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	cmd := listingapp.DeleteHostListingCommand{
+		HostID:    principal.ID,
+		ListingID: c.Param("id"),
+	}
+	result, err := commands.Dispatch[listingapp.DeleteHostListingCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		var active *listingapp.ActiveBookingsError
+		if errors.As(err, &active) {
+			respondErrorDetails(c, http.StatusConflict, ErrCodeConflict, listingapp.ErrListingHasActiveBookings.Error(), map[string]string{
+				"booking_ids": strings.Join(active.BookingIDs, ","),
+			})
+			return
+		}
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Restore brings a deleted listing back as a draft within the retention window.
+func (h HostListingHandler) Restore(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	cmd := listingapp.RestoreHostListingCommand{
+		HostID:    principal.ID,
+		ListingID: c.Param("id"),
+	}
+	result, err := commands.Dispatch[listingapp.RestoreHostListingCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+type unpublishRequest struct {
+	RepublishAt string `json:"republish_at"`
+}
+
+// CancelRepublish drops the scheduled republish of an unpublished listing.
+func (h HostListingHandler) CancelRepublish(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	cmd := listingapp.CancelRepublishHostListingCommand{
+		HostID:    principal.ID,
+		ListingID: c.Param("id"),
+	}
+	result, err := commands.Dispatch[listingapp.CancelRepublishHostListingCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 func (h HostListingHandler) PriceSuggestion(c *gin.Context) {
 	principal, ok := requireRole(c, "host")
 	if !ok {
@@ -239,6 +450,47 @@ func (h HostListingHandler) PriceSuggestion(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// ApplyPriceSuggestion sets the listing rate to the recommendation for the
+// same stay parameters as PriceSuggestion.
+func (h HostListingHandler) ApplyPriceSuggestion(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	var payload priceSuggestionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	checkIn, checkOut, err := parseRange(payload.CheckIn, payload.CheckOut)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := listingapp.ApplyPriceSuggestionCommand{
+		HostID:    principal.ID,
+		ListingID: c.Param("id"),
+		CheckIn:   checkIn,
+		CheckOut:  checkOut,
+		Guests:    payload.Guests,
+	}
+	result, err := commands.Dispatch[listingapp.ApplyPriceSuggestionCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 func (h HostListingHandler) UploadPhoto(c *gin.Context) {
 	principal, ok := requireRole(c, "host")
 	if !ok {
@@ -303,6 +555,7 @@ func (h HostListingHandler) UploadPhoto(c *gin.Context) {
 		ObjectKey:   objectKey,
 		ContentType: contentType,
 		Reader:      bytes.NewReader(data),
+		Tag:         c.PostForm("tag"),
 	}
 	result, err := commands.Dispatch[listingapp.UploadHostListingPhotoCommand, *dto.HostListingPhotoUploadResult](c.Request.Context(), h.Commands, cmd)
 	if err != nil {
@@ -313,11 +566,282 @@ func (h HostListingHandler) UploadPhoto(c *gin.Context) {
 	c.JSON(http.StatusCreated, result)
 }
 
+// PhotoUploadURL hands out a presigned URL for uploading a photo directly to
+// storage; the client then registers the returned key with RegisterPhoto.
+func (h HostListingHandler) PhotoUploadURL(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Queries == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("queries bus unavailable"))
+		return
+	}
+	listingID := strings.TrimSpace(c.Param("id"))
+	if listingID == "" {
+		h.respondWithError(c, http.StatusBadRequest, errors.New("listing id is required"))
+		return
+	}
+	contentType := strings.ToLower(strings.TrimSpace(c.Query("content_type")))
+	if !isAllowedImageType(contentType) {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("unsupported content type: %s", contentType))
+		return
+	}
+
+	query := listingapp.HostListingPhotoUploadURLQuery{
+		HostID:      principal.ID,
+		ListingID:   listingID,
+		ObjectKey:   buildPhotoObjectKey(listingID, "", contentType),
+		ContentType: contentType,
+	}
+	result, err := queries.Ask[listingapp.HostListingPhotoUploadURLQuery, dto.HostListingPhotoUploadURL](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+type registerPhotoRequest struct {
+	Key string `json:"key" binding:"required"`
+	Tag string `json:"tag"`
+}
+
+// RegisterPhoto adds a photo uploaded through PhotoUploadURL to the listing.
+func (h HostListingHandler) RegisterPhoto(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+	listingID := strings.TrimSpace(c.Param("id"))
+	if listingID == "" {
+		h.respondWithError(c, http.StatusBadRequest, errors.New("listing id is required"))
+		return
+	}
+	var req registerPhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	key := strings.TrimSpace(req.Key)
+	if !ownsPhotoObjectKey(listingID, key) {
+		h.respondWithError(c, http.StatusBadRequest, errors.New("key does not belong to this listing"))
+		return
+	}
+
+	cmd := listingapp.RegisterHostListingPhotoCommand{
+		HostID:    principal.ID,
+		ListingID: listingID,
+		ObjectKey: key,
+		Tag:       req.Tag,
+	}
+	result, err := commands.Dispatch[listingapp.RegisterHostListingPhotoCommand, *dto.HostListingPhotoUploadResult](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+type deletePhotoRequest struct {
+	URL   string `json:"url"`
+	Index *int   `json:"index"`
+}
+
+// reorderPhotosRequest takes the new gallery order as photos; order is
+// accepted as an alias.
+type reorderPhotosRequest struct {
+	Photos []string `json:"photos"`
+	Order  []string `json:"order"`
+}
+
+type tagPhotoRequest struct {
+	URL   string `json:"url"`
+	Index *int   `json:"index"`
+	Tag   string `json:"tag"`
+}
+
+func (h HostListingHandler) DeletePhoto(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+	var req deletePhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	if strings.TrimSpace(req.URL) == "" && req.Index == nil {
+		h.respondWithError(c, http.StatusBadRequest, errors.New("url or index is required"))
+		return
+	}
+	cmd := listingapp.DeleteHostListingPhotoCommand{
+		HostID:    principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+		URL:       h.storedURL(req.URL),
+		Index:     req.Index,
+	}
+	result, err := commands.Dispatch[listingapp.DeleteHostListingPhotoCommand, *dto.HostListingPhotoUploadResult](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h HostListingHandler) ReorderPhotos(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+	var req reorderPhotosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	cmd := listingapp.ReorderHostListingPhotosCommand{
+		HostID:    principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+		Photos:    req.Photos,
+	}
+	if cmd.Photos == nil {
+		cmd.Photos = req.Order
+	}
+	cmd.Photos = h.storedURLs(cmd.Photos)
+	result, err := commands.Dispatch[listingapp.ReorderHostListingPhotosCommand, *dto.HostListingPhotoUploadResult](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h HostListingHandler) TagPhoto(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+	var req tagPhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	if strings.TrimSpace(req.URL) == "" && req.Index == nil {
+		h.respondWithError(c, http.StatusBadRequest, errors.New("url or index is required"))
+		return
+	}
+	cmd := listingapp.TagHostListingPhotoCommand{
+		HostID:    principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+		URL:       h.storedURL(req.URL),
+		Index:     req.Index,
+		Tag:       req.Tag,
+	}
+	result, err := commands.Dispatch[listingapp.TagHostListingPhotoCommand, *dto.HostListingPhotoUploadResult](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+type coHostsRequest struct {
+	CoHosts []string `json:"co_hosts"`
+}
+
+// SetCoHosts replaces the co-host team that shares the listing inbox.
+func (h HostListingHandler) SetCoHosts(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+	var req coHostsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	cmd := listingapp.SetHostListingCoHostsCommand{
+		HostID:    principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+		CoHostIDs: req.CoHosts,
+	}
+	result, err := commands.Dispatch[listingapp.SetHostListingCoHostsCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+type unitGroupRequest struct {
+	UnitGroupID string `json:"unit_group_id"`
+}
+
+// SetUnitGroup links the listing to the host's other listings of the same
+// physical unit; an empty unit_group_id unlinks it.
+func (h HostListingHandler) SetUnitGroup(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+	var req unitGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	cmd := listingapp.SetHostListingUnitGroupCommand{
+		HostID:      principal.ID,
+		ListingID:   strings.TrimSpace(c.Param("id")),
+		UnitGroupID: req.UnitGroupID,
+	}
+	result, err := commands.Dispatch[listingapp.SetHostListingUnitGroupCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 func (h HostListingHandler) handleError(c *gin.Context, err error) {
-	if errors.Is(err, listingapp.ErrListingNotOwned) {
+	if respondConcurrentUpdate(c, err) {
+		return
+	}
+	if errors.Is(err, listingapp.ErrListingNotOwned) || errors.Is(err, domainlistings.ErrPhotoNotFound) {
 		h.respondWithError(c, http.StatusNotFound, err)
 		return
 	}
+	if errors.Is(err, listingapp.ErrRepublishUnavailable) {
+		h.respondWithError(c, http.StatusServiceUnavailable, err)
+		return
+	}
+	if errors.Is(err, domainlistings.ErrRestoreExpired) {
+		h.respondWithError(c, http.StatusConflict, err)
+		return
+	}
 	if isValidationError(err) {
 		h.respondWithError(c, http.StatusBadRequest, err)
 		return
@@ -333,7 +857,7 @@ func (h HostListingHandler) respondWithError(c *gin.Context, status int, err err
 		}
 		h.Logger.Error("host listing request failed", fields...)
 	}
-	c.JSON(status, gin.H{"error": err.Error()})
+	respondError(c, status, errorCode(status, err), err.Error())
 }
 
 func parseRange(checkInRaw, checkOutRaw string) (time.Time, time.Time, error) {
@@ -381,8 +905,19 @@ func buildPhotoObjectKey(listingID, filename, contentType string) string {
 	if ext == "" {
 		ext = ".img"
 	}
-	safeListing := sanitizePathToken(listingID)
-	return fmt.Sprintf("listings/%s/%s%s", safeListing, uuid.NewString(), ext)
+	return fmt.Sprintf("%s%s%s", photoObjectPrefix(listingID), uuid.NewString(), ext)
+}
+
+// photoObjectPrefix is the key prefix of every photo object of a listing.
+func photoObjectPrefix(listingID string) string {
+	return fmt.Sprintf("listings/%s/", sanitizePathToken(listingID))
+}
+
+// ownsPhotoObjectKey rejects keys outside the listing's prefix so a host
+// cannot register someone else's upload.
+func ownsPhotoObjectKey(listingID, key string) bool {
+	rest, ok := strings.CutPrefix(key, photoObjectPrefix(listingID))
+	return ok && rest != "" && !strings.Contains(rest, "/") && !strings.Contains(rest, "..")
 }
 
 func sanitizePathToken(value string) string {
@@ -408,6 +943,24 @@ func sanitizePathToken(value string) string {
 	return result
 }
 
+func (h HostListingHandler) storedURL(raw string) string {
+	if h.Media == nil || raw == "" {
+		return raw
+	}
+	return h.Media.Stored(raw)
+}
+
+func (h HostListingHandler) storedURLs(raw []string) []string {
+	if h.Media == nil || raw == nil {
+		return raw
+	}
+	out := make([]string, 0, len(raw))
+	for _, url := range raw {
+		out = append(out, h.Media.Stored(url))
+	}
+	return out
+}
+
 func buildHostListingPayload(req hostListingRequest) (listingapp.HostListingPayload, error) {
 	availableFrom := time.Time{}
 	if req.AvailableFrom != "" {
@@ -420,18 +973,7 @@ func buildHostListingPayload(req hostListingRequest) (listingapp.HostListingPayl
 
 	rate := req.RateRub
 
-	address := domainlistings.Address{
-		Line1:   strings.TrimSpace(req.Address.Line1),
-		Line2:   strings.TrimSpace(req.Address.Line2),
-		City:    strings.TrimSpace(req.Address.City),
-		Region:  strings.TrimSpace(req.Address.Region),
-		Country: strings.TrimSpace(req.Address.Country),
-		Lat:     req.Address.Lat,
-		Lon:     req.Address.Lon,
-	}
-	if address.Region == "" {
-		address.Region = address.Country
-	}
+	address := req.Address.toDomain()
 
 	travelMinutes := req.TravelMinutes
 	if travelMinutes < 0 {
@@ -445,17 +987,9 @@ func buildHostListingPayload(req hostListingRequest) (listingapp.HostListingPayl
 		travelMode = "transit"
 	}
 
-	var rentalTerm domainlistings.RentalTermType
-	if strings.TrimSpace(req.RentalTerm) != "" {
-		value := strings.ToLower(strings.TrimSpace(req.RentalTerm))
-		switch value {
-		case string(domainlistings.RentalTermShort):
-			rentalTerm = domainlistings.RentalTermShort
-		case string(domainlistings.RentalTermLong):
-			rentalTerm = domainlistings.RentalTermLong
-		default:
-			return listingapp.HostListingPayload{}, fmt.Errorf("rental_term must be %q or %q", domainlistings.RentalTermShort, domainlistings.RentalTermLong)
-		}
+	rentalTerm, err := parseRentalTerm(req.RentalTerm)
+	if err != nil {
+		return listingapp.HostListingPayload{}, err
 	}
 
 	payload := listingapp.HostListingPayload{
@@ -472,7 +1006,12 @@ func buildHostListingPayload(req hostListingRequest) (listingapp.HostListingPayl
 		GuestsLimit:          req.GuestsLimit,
 		MinNights:            req.MinNights,
 		MaxNights:            req.MaxNights,
+		MinMonths:            req.MinMonths,
+		MaxMonths:            req.MaxMonths,
 		RateRub:              rate,
+		CleaningFeeRub:       req.CleaningFeeRub,
+		ServiceFeePct:        req.ServiceFeePct,
+		WeekendPremiumPct:    req.WeekendPremiumPct,
 		Bedrooms:             req.Bedrooms,
 		Bathrooms:            req.Bathrooms,
 		Floor:                req.Floor,
@@ -483,12 +1022,31 @@ func buildHostListingPayload(req hostListingRequest) (listingapp.HostListingPayl
 		TravelMinutes:        travelMinutes,
 		TravelMode:           travelMode,
 		RentalTermType:       rentalTerm,
+		VerifiedGuestsOnly:   req.VerifiedGuestsOnly,
 		AvailableFrom:        availableFrom,
 		Photos:               cleanStrings(req.Photos),
+
+		LongStayDiscountPct:     req.LongStayDiscountPct,
+		LongStayThresholdNights: req.LongStayThresholdNights,
 	}
 	return payload, nil
 }
 
+// parseRentalTerm accepts an empty value, which leaves the term unchanged.
+func parseRentalTerm(raw string) (domainlistings.RentalTermType, error) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	switch value {
+	case "":
+		return "", nil
+	case string(domainlistings.RentalTermShort):
+		return domainlistings.RentalTermShort, nil
+	case string(domainlistings.RentalTermLong):
+		return domainlistings.RentalTermLong, nil
+	default:
+		return "", fmt.Errorf("rental_term must be %q or %q", domainlistings.RentalTermShort, domainlistings.RentalTermLong)
+	}
+}
+
 func cleanStrings(values []string) []string {
 	if len(values) == 0 {
 		return nil
@@ -517,15 +1075,46 @@ func isValidationError(err error) bool {
 	case errors.Is(err, domainlistings.ErrTitleRequired),
 		errors.Is(err, domainlistings.ErrGuestsLimit),
 		errors.Is(err, domainlistings.ErrNightsRange),
+		errors.Is(err, domainlistings.ErrMonthsRange),
 		errors.Is(err, domainlistings.ErrRate),
+		errors.Is(err, domainlistings.ErrCleaningFee),
+		errors.Is(err, domainlistings.ErrServiceFee),
+		errors.Is(err, domainlistings.ErrWeekendPremium),
+		errors.Is(err, domainlistings.ErrLongStay),
+		errors.Is(err, domainlistings.ErrPhotoRequired),
+		errors.Is(err, domainlistings.ErrDescriptionLen),
+		errors.Is(err, domainlistings.ErrRateRequired),
 		errors.Is(err, domainlistings.ErrInvalidFloor),
 		errors.Is(err, domainlistings.ErrFloorsTotal),
 		errors.Is(err, domainlistings.ErrRenovationScore),
 		errors.Is(err, domainlistings.ErrBuildingAge),
 		errors.Is(err, domainlistings.ErrRentalTerm),
 		errors.Is(err, domainlistings.ErrAddressRequired),
+		errors.Is(err, domainlistings.ErrAddressCoordinates),
+		errors.Is(err, domainlistings.ErrAddressField),
+		errors.Is(err, domainlistings.ErrUnitGroupID),
 		errors.Is(err, domainlistings.ErrInvalidState),
-		errors.Is(err, domainlistings.ErrPhotoURL):
+		errors.Is(err, domainlistings.ErrPhotoURL),
+		errors.Is(err, domainlistings.ErrPhotoOrder),
+		errors.Is(err, domainlistings.ErrPhotoTag),
+		errors.Is(err, domainlistings.ErrTravelMinutes),
+		errors.Is(err, domainlistings.ErrCoHostIsOwner),
+		errors.Is(err, domainlistings.ErrRepublishAt),
+		errors.Is(err, listingapp.ErrCoHostNotHost),
+		errors.Is(err, listingapp.ErrNoListingFields),
+		errors.Is(err, domainmarkets.ErrCityNotSupported),
+		errors.Is(err, domainbooking.ErrInvalidGuests),
+		errors.Is(err, domainbooking.ErrGuestsExceedLimit),
+		errors.Is(err, domainbooking.ErrBelowMinNights),
+		errors.Is(err, domainbooking.ErrExceedsMaxNights),
+		errors.Is(err, domainbooking.ErrBeforeAvailableFrom),
+		errors.Is(err, domainbooking.ErrMonthsRange),
+		errors.Is(err, domainbooking.ErrMonthsNotAllowed),
+		errors.Is(err, domainpricing.ErrInvalidClampRange),
+		errors.Is(err, domainpricing.ErrUnknownClampTerm),
+		errors.Is(err, domainpricing.ErrClampCityRequired),
+		errors.Is(err, daterange.ErrInvalidRange),
+		errors.Is(err, daterange.ErrNotDateAligned):
 		return true
 	}
 	return false
@@ -545,7 +1134,12 @@ type hostListingRequest struct {
 	GuestsLimit          int                `json:"guests_limit"`
 	MinNights            int                `json:"min_nights"`
 	MaxNights            int                `json:"max_nights"`
+	MinMonths            int                `json:"min_months"`
+	MaxMonths            int                `json:"max_months"`
 	RateRub              int64              `json:"rate_rub"`
+	CleaningFeeRub       int64              `json:"cleaning_fee_rub"`
+	ServiceFeePct        float64            `json:"service_fee_pct"`
+	WeekendPremiumPct    float64            `json:"weekend_premium_pct"`
 	Bedrooms             int                `json:"bedrooms"`
 	Bathrooms            int                `json:"bathrooms"`
 	Floor                int                `json:"floor"`
@@ -558,6 +1152,10 @@ type hostListingRequest struct {
 	RentalTerm           string             `json:"rental_term"`
 	TravelMinutes        float64            `json:"travel_minutes"`
 	TravelMode           string             `json:"travel_mode"`
+	VerifiedGuestsOnly   bool               `json:"verified_guests_only"`
+
+	LongStayDiscountPct     float64 `json:"long_stay_discount_pct"`
+	LongStayThresholdNights int     `json:"long_stay_threshold_nights"`
 }
 
 type hostListingAddress struct {
@@ -570,6 +1168,19 @@ type hostListingAddress struct {
 	Lon     float64 `json:"lon"`
 }
 
+// toDomain leaves trimming and defaults to domainlistings.NormalizeAddress.
+func (a hostListingAddress) toDomain() domainlistings.Address {
+	return domainlistings.Address{
+		Line1:   a.Line1,
+		Line2:   a.Line2,
+		City:    a.City,
+		Region:  a.Region,
+		Country: a.Country,
+		Lat:     a.Lat,
+		Lon:     a.Lon,
+	}
+}
+
 type priceSuggestionRequest struct {
 	CheckIn  string `json:"check_in"`
 	CheckOut string `json:"check_out"`
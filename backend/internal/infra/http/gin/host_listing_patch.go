@@ -0,0 +1,182 @@
+package ginserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	listingapp "rentme/internal/app/handlers/listings"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+// hostListingPatchRequest mirrors hostListingRequest with optional fields; a
+// field left out of the body keeps its stored value. The address is replaced
+// as a whole when present.
+type hostListingPatchRequest struct {
+	Title                *string             `json:"title"`
+	Description          *string             `json:"description"`
+	PropertyType         *string             `json:"property_type"`
+	Address              *hostListingAddress `json:"address"`
+	Amenities            *[]string           `json:"amenities"`
+	HouseRules           *[]string           `json:"house_rules"`
+	Tags                 *[]string           `json:"tags"`
+	Highlights           *[]string           `json:"highlights"`
+	ThumbnailURL         *string             `json:"thumbnail_url"`
+	CancellationPolicyID *string             `json:"cancellation_policy_id"`
+	GuestsLimit          *int                `json:"guests_limit"`
+	MinNights            *int                `json:"min_nights"`
+	MaxNights            *int                `json:"max_nights"`
+	MinMonths            *int                `json:"min_months"`
+	MaxMonths            *int                `json:"max_months"`
+	RateRub              *int64              `json:"rate_rub"`
+	CleaningFeeRub       *int64              `json:"cleaning_fee_rub"`
+	ServiceFeePct        *float64            `json:"service_fee_pct"`
+	WeekendPremiumPct    *float64            `json:"weekend_premium_pct"`
+	Bedrooms             *int                `json:"bedrooms"`
+	Bathrooms            *int                `json:"bathrooms"`
+	Floor                *int                `json:"floor"`
+	FloorsTotal          *int                `json:"floors_total"`
+	RenovationScore      *int                `json:"renovation_score"`
+	BuildingAgeYears     *int                `json:"building_age_years"`
+	AreaSquareMeters     *float64            `json:"area_sq_m"`
+	AvailableFrom        *string             `json:"available_from"`
+	Photos               *[]string           `json:"photos"`
+	RentalTerm           *string             `json:"rental_term"`
+	TravelMinutes        *float64            `json:"travel_minutes"`
+	TravelMode           *string             `json:"travel_mode"`
+	VerifiedGuestsOnly   *bool               `json:"verified_guests_only"`
+
+	LongStayDiscountPct     *float64 `json:"long_stay_discount_pct"`
+	LongStayThresholdNights *int     `json:"long_stay_threshold_nights"`
+}
+
+// Patch changes only the fields present in the body. Unknown fields are
+// rejected so that a misspelled name is not silently ignored.
+func (h HostListingHandler) Patch(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	var req hostListingPatchRequest
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	fields, err := buildHostListingPatch(req)
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, err)
+		return
+	}
+	if fields.ThumbnailURL != nil {
+		thumbnail := h.storedURL(*fields.ThumbnailURL)
+		fields.ThumbnailURL = &thumbnail
+	}
+	if fields.Photos != nil {
+		photos := h.storedURLs(*fields.Photos)
+		fields.Photos = &photos
+	}
+
+	cmd := listingapp.UpdateHostListingFieldsCommand{
+		HostID:    principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+		Fields:    fields,
+	}
+	result, err := commands.Dispatch[listingapp.UpdateHostListingFieldsCommand, *dto.HostListingDetail](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// buildHostListingPatch applies the same cleanup as buildHostListingPayload to
+// the fields that are present.
+func buildHostListingPatch(req hostListingPatchRequest) (domainlistings.ListingPatch, error) {
+	patch := domainlistings.ListingPatch{
+		Title:                req.Title,
+		Description:          req.Description,
+		PropertyType:         req.PropertyType,
+		ThumbnailURL:         req.ThumbnailURL,
+		CancellationPolicyID: req.CancellationPolicyID,
+		GuestsLimit:          req.GuestsLimit,
+		MinNights:            req.MinNights,
+		MaxNights:            req.MaxNights,
+		MinMonths:            req.MinMonths,
+		MaxMonths:            req.MaxMonths,
+		RateRub:              req.RateRub,
+		CleaningFeeRub:       req.CleaningFeeRub,
+		ServiceFeePct:        req.ServiceFeePct,
+		WeekendPremiumPct:    req.WeekendPremiumPct,
+		Bedrooms:             req.Bedrooms,
+		Bathrooms:            req.Bathrooms,
+		Floor:                req.Floor,
+		FloorsTotal:          req.FloorsTotal,
+		RenovationScore:      req.RenovationScore,
+		BuildingAgeYears:     req.BuildingAgeYears,
+		AreaSquareMeters:     req.AreaSquareMeters,
+		TravelMinutes:        req.TravelMinutes,
+		VerifiedGuestsOnly:   req.VerifiedGuestsOnly,
+		Amenities:            cleanStringsPtr(req.Amenities),
+		HouseRules:           cleanStringsPtr(req.HouseRules),
+		Tags:                 cleanStringsPtr(req.Tags),
+		Highlights:           cleanStringsPtr(req.Highlights),
+		Photos:               cleanStringsPtr(req.Photos),
+
+		LongStayDiscountPct:     req.LongStayDiscountPct,
+		LongStayThresholdNights: req.LongStayThresholdNights,
+	}
+	if req.Address != nil {
+		address := req.Address.toDomain()
+		patch.Address = &address
+	}
+	if req.AvailableFrom != nil {
+		parsed, ok := parseFlexibleTime(*req.AvailableFrom)
+		if !ok {
+			return domainlistings.ListingPatch{}, errors.New("available_from must be a valid date")
+		}
+		patch.AvailableFrom = &parsed
+	}
+	if req.RentalTerm != nil {
+		term, err := parseRentalTerm(*req.RentalTerm)
+		if err != nil {
+			return domainlistings.ListingPatch{}, err
+		}
+		if term == "" {
+			return domainlistings.ListingPatch{}, fmt.Errorf("rental_term must be %q or %q", domainlistings.RentalTermShort, domainlistings.RentalTermLong)
+		}
+		patch.RentalTermType = &term
+	}
+	if req.TravelMode != nil {
+		mode := strings.TrimSpace(strings.ToLower(*req.TravelMode))
+		if mode == "" {
+			mode = "car"
+		}
+		if mode == "public" {
+			mode = "transit"
+		}
+		patch.TravelMode = &mode
+	}
+	return patch, nil
+}
+
+func cleanStringsPtr(values *[]string) *[]string {
+	if values == nil {
+		return nil
+	}
+	cleaned := cleanStrings(*values)
+	return &cleaned
+}
@@ -1,6 +1,7 @@
 package ginserver
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,6 +12,8 @@ import (
 	"rentme/internal/app/dto"
 	listingapp "rentme/internal/app/handlers/listings"
 	"rentme/internal/app/queries"
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/domain/shared/daterange"
 )
 
 // ListingHandler wires listing queries to HTTP.
@@ -18,24 +21,91 @@ type ListingHandler struct {
 	Queries queries.Bus
 }
 
-// Catalog responds with a filtered collection of listings.
+// Catalog responds with a filtered collection of listings in the v1 shape.
 func (h ListingHandler) Catalog(c *gin.Context) {
+	if result, ok := h.searchCatalog(c); ok {
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// CatalogV2 serves the same search as Catalog with v2 listing cards.
+func (h ListingHandler) CatalogV2(c *gin.Context) {
+	if result, ok := h.searchCatalog(c); ok {
+		c.JSON(http.StatusOK, dto.MapListingCatalogV2(result))
+	}
+}
+
+// searchCatalog parses catalog filters and runs the search; it writes the
+// error response itself and reports whether a result is available.
+func (h ListingHandler) searchCatalog(c *gin.Context) (dto.ListingCatalog, bool) {
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "listing handler unavailable")
+		return dto.ListingCatalog{}, false
+	}
+	query, ok := parseCatalogQuery(c)
+	if !ok {
+		return dto.ListingCatalog{}, false
+	}
+	if viewer, ok := currentPrincipal(c); ok {
+		query.ViewerID = viewer.ID
+	}
+	result, err := queries.Ask[listingapp.SearchCatalogQuery, dto.ListingCatalog](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+		return dto.ListingCatalog{}, false
+	}
+	return result, true
+}
+
+// MapClusters groups the catalog matches inside bbox=west,south,east,north
+// into clusters sized for zoom.
+func (h ListingHandler) MapClusters(c *gin.Context) {
 	if h.Queries == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "listing handler unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "listing handler unavailable")
+		return
+	}
+	box, err := parseBBox(c.Query("bbox"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	zoom, err := strconv.Atoi(strings.TrimSpace(c.Query("zoom")))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "zoom must be an integer")
 		return
 	}
+	filters, ok := parseCatalogQuery(c)
+	if !ok {
+		return
+	}
+	query := listingapp.GetMapClustersQuery{Filters: filters, BBox: box, Zoom: zoom}
+	result, err := queries.Ask[listingapp.GetMapClustersQuery, dto.ListingMapClusters](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		if errors.Is(err, listingapp.ErrMapViewport) {
+			respondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+			return
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// parseCatalogQuery reads the catalog filters shared by the list and map
+// views; on invalid input it writes a 400 and returns false.
+func parseCatalogQuery(c *gin.Context) (listingapp.SearchCatalogQuery, bool) {
 	location := c.Query("location")
 	checkInRaw := c.Query("check_in")
 	checkOutRaw := c.Query("check_out")
 	checkIn, _ := parseFlexibleTime(checkInRaw)
 	checkOut, _ := parseFlexibleTime(checkOutRaw)
 	if (checkInRaw != "" || checkOutRaw != "") && (checkIn.IsZero() || checkOut.IsZero()) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "both check_in and check_out must be valid dates"})
-		return
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "both check_in and check_out must be valid dates")
+		return listingapp.SearchCatalogQuery{}, false
 	}
 	if !checkIn.IsZero() && !checkOut.IsZero() && !checkOut.After(checkIn) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "check_out must be after check_in"})
-		return
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "check_out must be after check_in")
+		return listingapp.SearchCatalogQuery{}, false
 	}
 	guests := parseInt(c.Query("guests"))
 	if guests == 0 {
@@ -62,41 +132,69 @@ func (h ListingHandler) Catalog(c *gin.Context) {
 	}
 	propertyTypes := mergeSlices(splitCSV(c.Query("type")), splitCSV(c.Query("types")))
 	rentalTerms := mergeSlices(splitCSV(c.Query("rental_term")), splitCSV(c.Query("rental_terms")))
-
-	query := listingapp.SearchCatalogQuery{
-		City:          c.Query("city"),
-		Region:        c.Query("region"),
-		Country:       c.Query("country"),
-		Location:      location,
-		Tags:          splitCSV(c.Query("tags")),
-		Amenities:     splitCSV(c.Query("amenities")),
-		MinGuests:     guests,
-		PriceMinRub:   priceMin,
-		PriceMaxRub:   priceMax,
-		PropertyTypes: propertyTypes,
-		RentalTerms:   rentalTerms,
-		Limit:         limit,
-		Offset:        offset,
-		Sort:          c.Query("sort"),
-		CheckIn:       checkIn,
-		CheckOut:      checkOut,
+	maxTravel, err := parseOptionalFloat(c.Query("max_travel_minutes"))
+	if err != nil || maxTravel < 0 {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "max_travel_minutes must be a non-negative number")
+		return listingapp.SearchCatalogQuery{}, false
+	}
+	maxPricePerSqM, err := parseOptionalFloat(c.Query("max_price_per_sq_m"))
+	if err != nil || maxPricePerSqM < 0 {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "max_price_per_sq_m must be a non-negative number")
+		return listingapp.SearchCatalogQuery{}, false
+	}
+	travelMode := strings.TrimSpace(c.Query("travel_mode"))
+	if travelMode != "" && domainlistings.NormalizeTravelMode(travelMode) == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "travel_mode must be walk, bike, transit or car")
+		return listingapp.SearchCatalogQuery{}, false
+	}
+	commuteFrom, err := parsePointOfInterest(c.Query("poi_lat"), c.Query("poi_lon"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return listingapp.SearchCatalogQuery{}, false
 	}
-	result, err := queries.Ask[listingapp.SearchCatalogQuery, dto.ListingCatalog](c.Request.Context(), h.Queries, query)
+	geoCenter, radiusKm, err := parseGeoRadius(c.Query("lat"), c.Query("lon"), c.Query("radius_km"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return listingapp.SearchCatalogQuery{}, false
 	}
-	c.JSON(http.StatusOK, result)
+
+	query := listingapp.SearchCatalogQuery{
+		City:             c.Query("city"),
+		Region:           c.Query("region"),
+		Country:          c.Query("country"),
+		Location:         location,
+		Tags:             splitCSV(c.Query("tags")),
+		Amenities:        splitCSV(c.Query("amenities")),
+		MinGuests:        guests,
+		PriceMinRub:      priceMin,
+		PriceMaxRub:      priceMax,
+		MaxPricePerSqM:   maxPricePerSqM,
+		PropertyTypes:    propertyTypes,
+		RentalTerms:      rentalTerms,
+		Limit:            limit,
+		Offset:           offset,
+		Sort:             c.Query("sort"),
+		CheckIn:          checkIn,
+		CheckOut:         checkOut,
+		MaxTravelMinutes: maxTravel,
+		TravelMode:       travelMode,
+		CommuteFrom:      commuteFrom,
+		Lat:              geoCenter.Lat,
+		Lon:              geoCenter.Lon,
+		RadiusKm:         radiusKm,
+		AvailableOnly:    parseBool(c.Query("available_only")),
+	}
+	return query, true
 }
 
 func (h ListingHandler) Overview(c *gin.Context) {
 	if h.Queries == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "listing handler unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "listing handler unavailable")
 		return
 	}
 	listingID := c.Param("id")
 	if listingID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "listing id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "listing id is required")
 		return
 	}
 	windowFrom, windowTo := resolveWindow(c.Query("from"), c.Query("to"))
@@ -107,7 +205,47 @@ func (h ListingHandler) Overview(c *gin.Context) {
 	}
 	result, err := queries.Ask[listingapp.GetOverviewQuery, dto.ListingOverview](c.Request.Context(), h.Queries, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h ListingHandler) Prices(c *gin.Context) {
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "listing handler unavailable")
+		return
+	}
+	listingID := c.Param("id")
+	if listingID == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "listing id is required")
+		return
+	}
+	from, ok := parseFlexibleTime(c.Query("from"))
+	if !ok {
+		from = time.Now().UTC()
+	}
+	from = truncateToDay(from)
+	to, ok := parseFlexibleTime(c.Query("to"))
+	if !ok {
+		to = from.AddDate(0, 0, 30)
+	}
+	query := listingapp.GetPriceCalendarQuery{
+		ListingID: listingID,
+		From:      from,
+		To:        truncateToDay(to),
+		Guests:    parseIntWithDefault(c.Query("guests"), 1),
+	}
+	result, err := queries.Ask[listingapp.GetPriceCalendarQuery, dto.PriceCalendar](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		switch {
+		case errors.Is(err, listingapp.ErrPriceCalendarRange):
+			respondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		case errors.Is(err, listingapp.ErrListingNotFound):
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "listing not found")
+		default:
+			respondError(c, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+		}
 		return
 	}
 	c.JSON(http.StatusOK, result)
@@ -138,6 +276,10 @@ func parseFlexibleTime(raw string) (time.Time, bool) {
 		return time.Time{}, false
 	}
 	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		// A midnight written with an offset is a date in that zone; keep the day.
+		if daterange.IsDateAligned(t) {
+			return daterange.DateOf(t), true
+		}
 		return t.UTC(), true
 	}
 	if t, err := time.Parse("2006-01-02", raw); err == nil {
@@ -181,6 +323,70 @@ func parseInt(raw string) int {
 	return value
 }
 
+// parseBool reads a query flag; anything strconv does not accept counts as false.
+func parseBool(raw string) bool {
+	value, _ := strconv.ParseBool(strings.TrimSpace(raw))
+	return value
+}
+
+func parseOptionalFloat(raw string) (float64, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(raw, 64)
+}
+
+// parsePointOfInterest reads an optional commute origin; both coordinates must be given together.
+func parsePointOfInterest(latRaw, lonRaw string) (*domainlistings.GeoPoint, error) {
+	if strings.TrimSpace(latRaw) == "" && strings.TrimSpace(lonRaw) == "" {
+		return nil, nil
+	}
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(latRaw), 64)
+	lon, lonErr := strconv.ParseFloat(strings.TrimSpace(lonRaw), 64)
+	if latErr != nil || lonErr != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return nil, errors.New("poi_lat and poi_lon must be valid coordinates")
+	}
+	return &domainlistings.GeoPoint{Lat: lat, Lon: lon}, nil
+}
+
+// parseGeoRadius reads the lat/lon/radius_km filter. All three are required
+// together; radius_km is capped at domainlistings.MaxSearchRadiusKm.
+func parseGeoRadius(latRaw, lonRaw, radiusRaw string) (domainlistings.GeoPoint, float64, error) {
+	if strings.TrimSpace(latRaw) == "" && strings.TrimSpace(lonRaw) == "" && strings.TrimSpace(radiusRaw) == "" {
+		return domainlistings.GeoPoint{}, 0, nil
+	}
+	center, err := parsePointOfInterest(latRaw, lonRaw)
+	if err != nil || center == nil {
+		return domainlistings.GeoPoint{}, 0, errors.New("lat and lon must be valid coordinates")
+	}
+	radius, err := strconv.ParseFloat(strings.TrimSpace(radiusRaw), 64)
+	if err != nil || radius <= 0 {
+		return domainlistings.GeoPoint{}, 0, errors.New("radius_km must be a positive number")
+	}
+	if radius > domainlistings.MaxSearchRadiusKm {
+		radius = domainlistings.MaxSearchRadiusKm
+	}
+	return *center, radius, nil
+}
+
+// parseBBox reads "west,south,east,north", the order map libraries emit.
+func parseBBox(raw string) (domainlistings.BoundingBox, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return domainlistings.BoundingBox{}, errors.New("bbox must be west,south,east,north")
+	}
+	values := make([]float64, 4)
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return domainlistings.BoundingBox{}, errors.New("bbox must be west,south,east,north")
+		}
+		values[i] = value
+	}
+	return domainlistings.BoundingBox{West: values[0], South: values[1], East: values[2], North: values[3]}, nil
+}
+
 func parseIntWithDefault(raw string, fallback int) int {
 	value := parseInt(raw)
 	if value == 0 {
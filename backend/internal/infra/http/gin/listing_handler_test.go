@@ -0,0 +1,100 @@
+package ginserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/app/dto"
+	listingapp "rentme/internal/app/handlers/listings"
+	"rentme/internal/app/queries"
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/infra/storage/memory"
+)
+
+func TestCatalogQueryAcceptsTimeOfDay(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/listings?check_in=2026-05-01T14:00:00Z&check_out=2026-05-03T11:00:00Z", nil)
+
+	query, ok := parseCatalogQuery(c)
+	if !ok {
+		t.Fatalf("catalog query rejected: %d %s", rec.Code, rec.Body)
+	}
+	if query.CheckIn.Hour() != 14 || query.CheckOut.Hour() != 11 {
+		t.Fatalf("range = [%s, %s), want the times as sent", query.CheckIn, query.CheckOut)
+	}
+}
+
+// priceFilterServer serves the catalog over nightly listings at 4800, 5000
+// and 5200 RUB.
+func priceFilterServer(t *testing.T) http.Handler {
+	t.Helper()
+	listings := memory.NewListingRepository()
+	for id, rate := range map[domainlistings.ListingID]int64{"listing-4800": 4800, "listing-5000": 5000, "listing-5200": 5200} {
+		listing := &domainlistings.Listing{
+			ID:             id,
+			Host:           "host-1",
+			Title:          string(id),
+			GuestsLimit:    2,
+			State:          domainlistings.ListingActive,
+			RateRub:        rate,
+			RentalTermType: domainlistings.RentalTermShort,
+		}
+		if err := listings.Save(context.Background(), listing); err != nil {
+			t.Fatalf("save listing: %v", err)
+		}
+	}
+	factory := memory.Factory{
+		ListingsRepo:     listings,
+		AvailabilityRepo: memory.NewAvailabilityRepository(),
+		BookingRepo:      memory.NewBookingRepository(),
+		ReviewsRepo:      memory.NewReviewsRepository(),
+		WishlistsRepo:    memory.NewWishlistRepository(),
+	}
+	queryBus := queries.NewInMemoryBus()
+	queries.RegisterHandler(queryBus, listingapp.SearchCatalogQuery{}.Key(), &listingapp.SearchCatalogHandler{UoWFactory: factory})
+	return newTestServer(t, Handlers{Listing: ListingHandler{Queries: queryBus}}, nil)
+}
+
+func TestCatalogPriceFilterInRubles(t *testing.T) {
+	server := priceFilterServer(t)
+	cases := []struct {
+		name    string
+		query   string
+		wantIDs map[string]bool
+		min     int64
+		max     int64
+	}{
+		{"max", "price_max=5000", map[string]bool{"listing-4800": true, "listing-5000": true}, 0, 5000},
+		{"min and max", "price_min=4900&price_max=5000", map[string]bool{"listing-5000": true}, 4900, 5000},
+		{"rub aliases", "price_min_rub=5100", map[string]bool{"listing-5200": true}, 5100, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(server, http.MethodGet, "/api/v1/listings?"+tc.query, "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+			}
+			var catalog dto.ListingCatalog
+			if err := json.Unmarshal(rec.Body.Bytes(), &catalog); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(catalog.Items) != len(tc.wantIDs) {
+				t.Fatalf("items = %+v, want %v", catalog.Items, tc.wantIDs)
+			}
+			for _, item := range catalog.Items {
+				if !tc.wantIDs[item.ID] {
+					t.Fatalf("unexpected listing %s in %v", item.ID, tc.wantIDs)
+				}
+			}
+			if catalog.Filters.PriceMinRub != tc.min || catalog.Filters.PriceMaxRub != tc.max {
+				t.Fatalf("filters echo min %d max %d, want %d and %d", catalog.Filters.PriceMinRub, catalog.Filters.PriceMaxRub, tc.min, tc.max)
+			}
+		})
+	}
+}
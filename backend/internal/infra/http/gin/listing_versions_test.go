@@ -0,0 +1,152 @@
+package ginserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	listingapp "rentme/internal/app/handlers/listings"
+	"rentme/internal/app/queries"
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/infra/storage/memory"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// catalogFixtures are the listings both API versions are snapshotted from: a
+// nightly flat with photos and a monthly rent without them.
+func catalogFixtures() []*domainlistings.Listing {
+	available := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	return []*domainlistings.Listing{
+		{
+			ID:               "listing-loft",
+			Host:             "host-1",
+			Title:            "Лофт у Патриарших",
+			PropertyType:     "apartment",
+			Address:          domainlistings.Address{Line1: "Малая Бронная, 20", City: "Москва", Region: "Москва", Country: "RU"},
+			Amenities:        []string{"wifi", "kitchen"},
+			GuestsLimit:      3,
+			MinNights:        2,
+			MaxNights:        30,
+			State:            domainlistings.ListingActive,
+			Tags:             []string{"center"},
+			Highlights:       []string{"Вид на пруды"},
+			RateRub:          7450,
+			Bedrooms:         1,
+			Bathrooms:        1,
+			AreaSquareMeters: 48,
+			RentalTermType:   domainlistings.RentalTermShort,
+			ThumbnailURL:     "https://media.example/loft/1.jpg",
+			Photos:           []string{"https://media.example/loft/1.jpg", "https://media.example/loft/2.jpg"},
+			Rating:           4.8,
+			AvailableFrom:    available,
+			CreatedAt:        available.AddDate(0, -1, 0),
+		},
+		{
+			ID:               "listing-flat",
+			Host:             "host-2",
+			Title:            "Flat near the park",
+			PropertyType:     "apartment",
+			Address:          domainlistings.Address{Line1: "Nevsky 1", City: "Санкт-Петербург", Region: "Санкт-Петербург", Country: "RU"},
+			GuestsLimit:      2,
+			MinMonths:        1,
+			MaxMonths:        12,
+			State:            domainlistings.ListingActive,
+			RateRub:          61200,
+			Bedrooms:         2,
+			Bathrooms:        1,
+			AreaSquareMeters: 64,
+			RentalTermType:   domainlistings.RentalTermLong,
+			Rating:           4.5,
+			AvailableFrom:    available,
+			CreatedAt:        available.AddDate(0, -2, 0),
+		},
+	}
+}
+
+func catalogVersionsServer(t *testing.T) http.Handler {
+	t.Helper()
+	listings := memory.NewListingRepository()
+	for _, listing := range catalogFixtures() {
+		if err := listings.Save(context.Background(), listing); err != nil {
+			t.Fatalf("save listing: %v", err)
+		}
+	}
+	factory := memory.Factory{
+		ListingsRepo:     listings,
+		AvailabilityRepo: memory.NewAvailabilityRepository(),
+		BookingRepo:      memory.NewBookingRepository(),
+		ReviewsRepo:      memory.NewReviewsRepository(),
+		WishlistsRepo:    memory.NewWishlistRepository(),
+	}
+	queryBus := queries.NewInMemoryBus()
+	queries.RegisterHandler(queryBus, listingapp.SearchCatalogQuery{}.Key(), &listingapp.SearchCatalogHandler{UoWFactory: factory})
+	return newTestServer(t, Handlers{Listing: ListingHandler{Queries: queryBus}}, nil)
+}
+
+// assertGolden compares body with testdata/name, indented; -update rewrites it.
+func assertGolden(t *testing.T, name string, body []byte) {
+	t.Helper()
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, body, "", "  "); err != nil {
+		t.Fatalf("indent %s: %v", body, err)
+	}
+	pretty.WriteByte('\n')
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.WriteFile(path, pretty.Bytes(), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s (run with -update to create it): %v", path, err)
+	}
+	if !bytes.Equal(pretty.Bytes(), want) {
+		t.Fatalf("%s differs from the response; run with -update if the change is intended\ngot:\n%s", path, pretty.Bytes())
+	}
+}
+
+func TestCatalogVersionsMatchGoldenFiles(t *testing.T) {
+	server := catalogVersionsServer(t)
+	cases := []struct {
+		name       string
+		target     string
+		golden     string
+		deprecated bool
+	}{
+		{"v1", "/api/v1/listings?sort=price_asc", "catalog_v1.golden.json", true},
+		{"v2", "/api/v2/listings?sort=price_asc", "catalog_v2.golden.json", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(server, http.MethodGet, tc.target, "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+			}
+			assertGolden(t, tc.golden, rec.Body.Bytes())
+
+			headers := map[string]string{
+				"Deprecation": "true",
+				"Sunset":      "Thu, 01 Apr 2027 00:00:00 GMT",
+				"Link":        `</api/v2/listings>; rel="successor-version"`,
+			}
+			for name, value := range headers {
+				got := rec.Header().Get(name)
+				if tc.deprecated && got != value {
+					t.Errorf("%s = %q, want %q", name, got, value)
+				}
+				if !tc.deprecated && got != "" {
+					t.Errorf("%s = %q on the current version, want none", name, got)
+				}
+			}
+		})
+	}
+}
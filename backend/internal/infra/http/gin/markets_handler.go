@@ -0,0 +1,40 @@
+package ginserver
+
+import (
+	"log/slog"
+	"net/http"
+
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/app/dto"
+	marketsapp "rentme/internal/app/handlers/markets"
+	"rentme/internal/app/queries"
+)
+
+type MarketsHTTP interface {
+	List(c *gin.Context)
+}
+
+// MarketsHandler exposes the supported cities for the city selector.
+type MarketsHandler struct {
+	Queries queries.Bus
+	Logger  *slog.Logger
+}
+
+func (h MarketsHandler) List(c *gin.Context) {
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	result, err := queries.Ask[marketsapp.ListMarketsQuery, dto.MarketSettings](c.Request.Context(), h.Queries, marketsapp.ListMarketsQuery{})
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("markets query failed", "error", err)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "cannot load markets")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+var _ MarketsHTTP = (*MarketsHandler)(nil)
@@ -0,0 +1,141 @@
+package ginserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	listingapp "rentme/internal/app/handlers/listings"
+	meapp "rentme/internal/app/handlers/me"
+	"rentme/internal/app/middleware"
+	"rentme/internal/app/queries"
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/infra/storage/memory"
+)
+
+// favoriteStores holds an active listing-1 and a draft listing-2 and an empty
+// wishlist.
+func favoriteStores(t *testing.T) memory.Factory {
+	t.Helper()
+	listings := memory.NewListingRepository()
+	for _, listing := range []*domainlistings.Listing{
+		{ID: "listing-1", Title: "Loft", State: domainlistings.ListingActive},
+		{ID: "listing-2", Title: "Draft", State: domainlistings.ListingDraft},
+	} {
+		if err := listings.Save(context.Background(), listing); err != nil {
+			t.Fatalf("save listing: %v", err)
+		}
+	}
+	return memory.Factory{
+		ListingsRepo:     listings,
+		AvailabilityRepo: memory.NewAvailabilityRepository(),
+		BookingRepo:      memory.NewBookingRepository(),
+		ReviewsRepo:      memory.NewReviewsRepository(),
+		WishlistsRepo:    memory.NewWishlistRepository(),
+	}
+}
+
+// favoritesServer serves the wishlist handlers over factory for guest-1.
+func favoritesServer(t *testing.T, factory memory.Factory) http.Handler {
+	t.Helper()
+	commandBus := commands.NewInMemoryBus()
+	commands.RegisterHandler(commandBus, meapp.AddToWishlistCommand{}.Key(), &meapp.AddToWishlistHandler{})
+	commands.RegisterHandler(commandBus, meapp.RemoveFromWishlistCommand{}.Key(), &meapp.RemoveFromWishlistHandler{})
+	queryBus := queries.NewInMemoryBus()
+	queries.RegisterHandler(queryBus, meapp.ListWishlistQuery{}.Key(), &meapp.ListWishlistHandler{UoWFactory: factory})
+
+	me := MeHandler{
+		Commands: middleware.ChainCommands(commandBus, middleware.Transaction(factory, nil)),
+		Queries:  queryBus,
+	}
+	return newTestServer(t, Handlers{Me: me}, &principal{ID: "guest-1", Roles: []string{"guest"}})
+}
+
+func decodeWishlist(t *testing.T, body []byte) dto.WishlistCollection {
+	t.Helper()
+	var list dto.WishlistCollection
+	if err := json.Unmarshal(body, &list); err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	return list
+}
+
+func TestFavoritesAndWishlistShareOneList(t *testing.T) {
+	server := favoritesServer(t, favoriteStores(t))
+
+	if rec := serve(server, http.MethodPost, "/api/v1/me/wishlist/listing-1", ""); rec.Code != http.StatusOK {
+		t.Fatalf("add status = %d: %s", rec.Code, rec.Body)
+	}
+	rec := serve(server, http.MethodGet, "/api/v1/me/favorites", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d: %s", rec.Code, rec.Body)
+	}
+	if list := decodeWishlist(t, rec.Body.Bytes()); list.Total != 1 || list.Items[0].ID != "listing-1" {
+		t.Fatalf("favorites = %+v, want the listing saved to the wishlist", list)
+	}
+
+	if rec := serve(server, http.MethodDelete, "/api/v1/me/favorites/listing-1", ""); rec.Code != http.StatusOK {
+		t.Fatalf("remove status = %d: %s", rec.Code, rec.Body)
+	}
+	rec = serve(server, http.MethodGet, "/api/v1/me/wishlist", "")
+	if list := decodeWishlist(t, rec.Body.Bytes()); list.Total != 0 {
+		t.Fatalf("wishlist after removing the favorite = %+v, want empty", list)
+	}
+}
+
+func TestAddFavoriteUnknownOrInactiveListingIsNotFound(t *testing.T) {
+	server := favoritesServer(t, favoriteStores(t))
+	for _, listingID := range []string{"missing", "listing-2"} {
+		if rec := serve(server, http.MethodPost, "/api/v1/me/favorites/"+listingID, ""); rec.Code != http.StatusNotFound {
+			t.Errorf("%s: status = %d, want %d: %s", listingID, rec.Code, http.StatusNotFound, rec.Body)
+		}
+	}
+}
+
+func TestFavoritesRequireSignIn(t *testing.T) {
+	server := newTestServer(t, Handlers{Me: MeHandler{Commands: commands.NewInMemoryBus(), Queries: queries.NewInMemoryBus()}}, nil)
+	if rec := serve(server, http.MethodGet, "/api/v1/me/favorites", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestCatalogMarksTheViewerWishlist(t *testing.T) {
+	factory := favoriteStores(t)
+	if rec := serve(favoritesServer(t, factory), http.MethodPost, "/api/v1/me/wishlist/listing-1", ""); rec.Code != http.StatusOK {
+		t.Fatalf("add status = %d: %s", rec.Code, rec.Body)
+	}
+	queryBus := queries.NewInMemoryBus()
+	queries.RegisterHandler(queryBus, listingapp.SearchCatalogQuery{}.Key(), &listingapp.SearchCatalogHandler{UoWFactory: factory})
+	handlers := Handlers{Listing: ListingHandler{Queries: queryBus}}
+
+	cases := []struct {
+		name   string
+		caller *principal
+		want   *bool
+	}{
+		{"anonymous", nil, nil},
+		{"saved it", &principal{ID: "guest-1", Roles: []string{"guest"}}, boolPtr(true)},
+		{"someone else", &principal{ID: "guest-2", Roles: []string{"guest"}}, boolPtr(false)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(newTestServer(t, handlers, tc.caller), http.MethodGet, "/api/v1/listings", "")
+			var catalog dto.ListingCatalog
+			if err := json.Unmarshal(rec.Body.Bytes(), &catalog); err != nil || rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, err = %v: %s", rec.Code, err, rec.Body)
+			}
+			if len(catalog.Items) != 1 {
+				t.Fatalf("items = %+v, want the active listing", catalog.Items)
+			}
+			got := catalog.Items[0].IsFavorite
+			if (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+				t.Fatalf("is_favorite = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func boolPtr(v bool) *bool { return &v }
@@ -1,23 +1,44 @@
 package ginserver
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	gin "github.com/gin-gonic/gin"
 
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"rentme/internal/app/commands"
 	"rentme/internal/app/dto"
+	bookingapp "rentme/internal/app/handlers/booking"
 	meapp "rentme/internal/app/handlers/me"
 	"rentme/internal/app/queries"
+	authsvc "rentme/internal/app/services/auth"
+	"rentme/internal/app/uow"
+	domainavailability "rentme/internal/domain/availability"
+	domainbooking "rentme/internal/domain/booking"
+	"rentme/internal/domain/shared/daterange"
 )
 
 type MeHTTP interface {
 	ListBookings(c *gin.Context)
+	ModifyBooking(c *gin.Context)
+	ListWishlist(c *gin.Context)
+	AddToWishlist(c *gin.Context)
+	RemoveFromWishlist(c *gin.Context)
+	UpdateNotifications(c *gin.Context)
+	UpdateProfile(c *gin.Context)
+	ChangePassword(c *gin.Context)
 }
 
 type MeHandler struct {
-	Queries queries.Bus
-	Logger  *slog.Logger
+	Commands commands.Bus
+	Queries  queries.Bus
+	Auth     *authsvc.Service
+	Logger   *slog.Logger
 }
 
 func (h MeHandler) ListBookings(c *gin.Context) {
@@ -26,19 +47,202 @@ func (h MeHandler) ListBookings(c *gin.Context) {
 		return
 	}
 	if h.Queries == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "queries unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
 		return
 	}
-	query := meapp.ListGuestBookingsQuery{GuestID: user.ID}
+	query := meapp.ListGuestBookingsQuery{
+		GuestID: user.ID,
+		Limit:   parseInt(c.Query("limit")),
+		Cursor:  c.Query("cursor"),
+	}
 	result, err := queries.Ask[meapp.ListGuestBookingsQuery, dto.GuestBookingCollection](c.Request.Context(), h.Queries, query)
 	if err != nil {
+		if errors.Is(err, domainbooking.ErrInvalidCursor) {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+			return
+		}
 		if h.Logger != nil {
 			h.Logger.Error("me bookings query failed", "error", err, "user_id", user.ID)
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load bookings"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to load bookings")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+type modifyBookingRequest struct {
+	CheckOut time.Time `json:"check_out" binding:"required"`
+}
+
+// ModifyBooking moves the check-out of one of the current user's bookings.
+func (h MeHandler) ModifyBooking(c *gin.Context) {
+	user, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	var req modifyBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	cmd := bookingapp.ModifyBookingCommand{
+		GuestID:     user.ID,
+		BookingID:   strings.TrimSpace(c.Param("id")),
+		NewCheckOut: req.CheckOut,
+	}
+	result, err := commands.Dispatch[bookingapp.ModifyBookingCommand, *bookingapp.ModifyBookingResult](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		if respondDatesUnavailable(c, err) || respondConcurrentUpdate(c, err) {
+			return
+		}
+		var status int
+		switch {
+		case errors.Is(err, bookingapp.ErrBookingNotGuests),
+			errors.Is(err, domainbooking.ErrBookingNotFound),
+			errors.Is(err, mongo.ErrNoDocuments):
+			status = http.StatusNotFound
+		case errors.Is(err, domainbooking.ErrInvalidState),
+			errors.Is(err, domainavailability.ErrOverlappingRange):
+			status = http.StatusConflict
+		case errors.Is(err, uow.ErrUnitOfWorkMissing):
+			status = http.StatusServiceUnavailable
+		case isValidationError(err),
+			errors.Is(err, bookingapp.ErrWholeMonths),
+			errors.Is(err, daterange.ErrInvalidRange),
+			errors.Is(err, daterange.ErrNotDateAligned):
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+		if h.Logger != nil {
+			h.Logger.Warn("modify booking failed", "status", status, "error", err, "user_id", user.ID, "booking_id", cmd.BookingID)
+		}
+		respondError(c, status, errorCode(status, err), err.Error())
 		return
 	}
 	c.JSON(http.StatusOK, result)
 }
 
+func (h MeHandler) ListWishlist(c *gin.Context) {
+	user, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Queries == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "queries unavailable")
+		return
+	}
+	query := meapp.ListWishlistQuery{GuestID: user.ID}
+	result, err := queries.Ask[meapp.ListWishlistQuery, dto.WishlistCollection](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("me wishlist query failed", "error", err, "user_id", user.ID)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to load wishlist")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h MeHandler) AddToWishlist(c *gin.Context) {
+	user, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	listingID := strings.TrimSpace(c.Param("listing_id"))
+	if listingID == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "listing id is required")
+		return
+	}
+	cmd := meapp.AddToWishlistCommand{GuestID: user.ID, ListingID: listingID}
+	result, err := commands.Dispatch[meapp.AddToWishlistCommand, *dto.WishlistEntry](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleWishlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h MeHandler) RemoveFromWishlist(c *gin.Context) {
+	user, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	listingID := strings.TrimSpace(c.Param("listing_id"))
+	if listingID == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "listing id is required")
+		return
+	}
+	cmd := meapp.RemoveFromWishlistCommand{GuestID: user.ID, ListingID: listingID}
+	result, err := commands.Dispatch[meapp.RemoveFromWishlistCommand, *dto.WishlistEntry](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleWishlistError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+type notificationsRequest struct {
+	WeeklyDigest *bool `json:"weekly_digest"`
+}
+
+// UpdateNotifications stores the notification opt-outs of the current user.
+func (h MeHandler) UpdateNotifications(c *gin.Context) {
+	user, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "commands unavailable")
+		return
+	}
+	var req notificationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	if req.WeeklyDigest == nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "weekly_digest is required")
+		return
+	}
+	cmd := meapp.UpdateNotificationsCommand{UserID: user.ID, WeeklyDigest: *req.WeeklyDigest}
+	result, err := commands.Dispatch[meapp.UpdateNotificationsCommand, *dto.UserProfile](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("update notifications failed", "error", err, "user_id", user.ID)
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to update notifications")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h MeHandler) handleWishlistError(c *gin.Context, err error) {
+	var status int
+	switch {
+	case errors.Is(err, meapp.ErrWishlistListingNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, uow.ErrUnitOfWorkMissing):
+		status = http.StatusServiceUnavailable
+	default:
+		status = http.StatusInternalServerError
+	}
+	if h.Logger != nil {
+		h.Logger.Warn("wishlist update failed", "status", status, "error", err)
+	}
+	respondError(c, status, errorCode(status, err), err.Error())
+}
+
 var _ MeHTTP = (*MeHandler)(nil)
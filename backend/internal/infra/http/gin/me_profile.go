@@ -0,0 +1,82 @@
+package ginserver
+
+import (
+	"errors"
+	"net/http"
+
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/app/dto"
+	authsvc "rentme/internal/app/services/auth"
+	domainuser "rentme/internal/domain/user"
+)
+
+type updateProfileRequest struct {
+	Name string `json:"name"`
+}
+
+// UpdateProfile renames the current user.
+func (h MeHandler) UpdateProfile(c *gin.Context) {
+	principal, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Auth == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "auth service unavailable")
+		return
+	}
+	var req updateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid request")
+		return
+	}
+	user, err := h.Auth.UpdateProfile(c.Request.Context(), principal.ID, req.Name)
+	if err != nil {
+		h.handleAccountError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MapUserProfile(user))
+}
+
+// ChangePassword replaces the current user's password. Other sessions are
+// signed out; the one making the request stays valid.
+func (h MeHandler) ChangePassword(c *gin.Context) {
+	principal, ok := requireRole(c, "")
+	if !ok {
+		return
+	}
+	if h.Auth == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "auth service unavailable")
+		return
+	}
+	var req changePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "invalid request")
+		return
+	}
+	err := h.Auth.ChangePasswordKeepingSession(c.Request.Context(), principal.ID, bearerTokenFromContext(c), req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		h.handleAccountError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h MeHandler) handleAccountError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, authsvc.ErrWrongPassword):
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidCredentials, "Неверный текущий пароль")
+	case errors.Is(err, authsvc.ErrPasswordTooShort),
+		errors.Is(err, domainuser.ErrNameRequired):
+		respondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+	case errors.Is(err, authsvc.ErrUserBlocked):
+		respondError(c, http.StatusUnauthorized, ErrCodeUserBlocked, "Аккаунт заблокирован")
+	case errors.Is(err, domainuser.ErrNotFound):
+		respondError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "auth required")
+	default:
+		if h.Logger != nil {
+			h.Logger.Error("account update failed", "error", err, "path", c.FullPath())
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "internal error")
+	}
+}
@@ -0,0 +1,162 @@
+package ginserver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	listingapp "rentme/internal/app/handlers/listings"
+	"rentme/internal/app/queries"
+)
+
+type completePhotoUploadRequest struct {
+	Tag string `json:"tag"`
+}
+
+// CreatePhotoUpload opens a resumable upload. The client sends the photo in
+// parts with PutPhotoUploadPart and finishes with CompletePhotoUpload; after a
+// dropped connection GetPhotoUpload tells which parts still need sending.
+func (h HostListingHandler) CreatePhotoUpload(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+
+	cmd := listingapp.CreateHostListingPhotoUploadCommand{
+		HostID:    principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+	}
+	result, err := commands.Dispatch[listingapp.CreateHostListingPhotoUploadCommand, *dto.HostListingPhotoUpload](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handlePhotoUploadError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h HostListingHandler) GetPhotoUpload(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Queries == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("queries bus unavailable"))
+		return
+	}
+
+	query := listingapp.HostListingPhotoUploadQuery{
+		HostID:    principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+		UploadID:  strings.TrimSpace(c.Param("uploadId")),
+	}
+	result, err := queries.Ask[listingapp.HostListingPhotoUploadQuery, dto.HostListingPhotoUpload](c.Request.Context(), h.Queries, query)
+	if err != nil {
+		h.handlePhotoUploadError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// PutPhotoUploadPart takes the raw bytes of one part as the request body.
+func (h HostListingHandler) PutPhotoUploadPart(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+	part, err := strconv.Atoi(c.Param("n"))
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, errors.New("part number must be an integer"))
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, listingapp.MaxPhotoUploadBytes+1))
+	if err != nil {
+		h.respondWithError(c, http.StatusBadRequest, fmt.Errorf("cannot read part: %w", err))
+		return
+	}
+	if int64(len(data)) > listingapp.MaxPhotoUploadBytes {
+		h.respondWithError(c, http.StatusBadRequest, listingapp.ErrPhotoUploadTooLarge)
+		return
+	}
+
+	cmd := listingapp.PutHostListingPhotoUploadPartCommand{
+		HostID:    principal.ID,
+		ListingID: strings.TrimSpace(c.Param("id")),
+		UploadID:  strings.TrimSpace(c.Param("uploadId")),
+		Part:      part,
+		Reader:    bytes.NewReader(data),
+		Size:      int64(len(data)),
+	}
+	result, err := commands.Dispatch[listingapp.PutHostListingPhotoUploadPartCommand, *dto.HostListingPhotoUpload](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handlePhotoUploadError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h HostListingHandler) CompletePhotoUpload(c *gin.Context) {
+	principal, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		h.respondWithError(c, http.StatusServiceUnavailable, errors.New("commands bus unavailable"))
+		return
+	}
+	var req completePhotoUploadRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.respondWithError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	listingID := strings.TrimSpace(c.Param("id"))
+	cmd := listingapp.CompleteHostListingPhotoUploadCommand{
+		HostID:       principal.ID,
+		ListingID:    listingID,
+		UploadID:     strings.TrimSpace(c.Param("uploadId")),
+		ObjectPrefix: photoObjectPrefix(listingID),
+		Tag:          req.Tag,
+	}
+	result, err := commands.Dispatch[listingapp.CompleteHostListingPhotoUploadCommand, *dto.HostListingPhotoUploadResult](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handlePhotoUploadError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h HostListingHandler) handlePhotoUploadError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, listingapp.ErrPhotoUploadNotFound):
+		h.respondWithError(c, http.StatusNotFound, err)
+	case errors.Is(err, listingapp.ErrPhotoUploadsUnavailable):
+		h.respondWithError(c, http.StatusServiceUnavailable, err)
+	case errors.Is(err, listingapp.ErrPhotoUploadPart),
+		errors.Is(err, listingapp.ErrPhotoUploadEmptyPart),
+		errors.Is(err, listingapp.ErrPhotoUploadTooLarge),
+		errors.Is(err, listingapp.ErrPhotoUploadIncomplete),
+		errors.Is(err, listingapp.ErrPhotoUploadContentType):
+		h.respondWithError(c, http.StatusBadRequest, err)
+	default:
+		h.handleError(c, err)
+	}
+}
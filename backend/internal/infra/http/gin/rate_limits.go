@@ -0,0 +1,104 @@
+package ginserver
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/app/middleware"
+	"rentme/internal/infra/config"
+)
+
+// Rate limit rule names, as shown and switched in the admin view.
+const (
+	RateLimitRuleIP   = "ip"
+	RateLimitRuleUser = "user"
+)
+
+// rateLimitTopOffenders is how many offenders per rule the admin view lists by default.
+const rateLimitTopOffenders = 20
+
+// NewRateLimitRules builds the per-IP and per-user rules from cfg. An invalid
+// configured mode falls back to enforce, so a typo never silently disables a limit.
+func NewRateLimitRules(cfg config.Config, logger *slog.Logger) *middleware.RateLimitRules {
+	mode := func(env, raw string) middleware.RateLimitMode {
+		mode, err := middleware.ParseRateLimitMode(raw)
+		if err != nil {
+			if logger != nil {
+				logger.Warn("invalid rate limit mode, enforcing", "env", env, "value", raw, "error", err)
+			}
+			return middleware.RateLimitEnforce
+		}
+		return mode
+	}
+	return middleware.NewRateLimitRules(
+		middleware.NewRateLimitRule(RateLimitRuleIP, cfg.RateLimitRPS, cfg.RateLimitBurst, mode("RATE_LIMIT_MODE", cfg.RateLimitMode), func(c *gin.Context) string {
+			return c.ClientIP()
+		}),
+		middleware.NewRateLimitRule(RateLimitRuleUser, cfg.UserRateLimitRPS, cfg.UserRateLimitBurst, mode("USER_RATE_LIMIT_MODE", cfg.UserRateLimitMode), func(c *gin.Context) string {
+			if p, ok := currentPrincipal(c); ok {
+				return p.ID
+			}
+			return ""
+		}),
+	)
+}
+
+type adminRateLimitsResponse struct {
+	Rules []middleware.RateLimitRuleStatus `json:"rules"`
+}
+
+type adminUpdateRateLimitRequest struct {
+	Mode string `json:"mode" binding:"required"`
+}
+
+// ListRateLimits lists the rate limit rules with their mode and the keys that
+// exceeded them most, which is what a limit is tuned on while in shadow.
+func (h AdminHandler) ListRateLimits(c *gin.Context) {
+	if _, ok := requireRole(c, "admin"); !ok {
+		return
+	}
+	if h.RateLimits == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "rate limits unavailable")
+		return
+	}
+	top := parseIntWithDefault(c.Query("limit"), rateLimitTopOffenders)
+	c.JSON(http.StatusOK, adminRateLimitsResponse{Rules: h.RateLimits.Statuses(top)})
+}
+
+// UpdateRateLimit switches a rule between shadow and enforce without a restart.
+func (h AdminHandler) UpdateRateLimit(c *gin.Context) {
+	principal, ok := requireRole(c, "admin")
+	if !ok {
+		return
+	}
+	if h.RateLimits == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "rate limits unavailable")
+		return
+	}
+	var req adminUpdateRateLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	mode, err := middleware.ParseRateLimitMode(req.Mode)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	rule := c.Param("rule")
+	if err := h.RateLimits.SetMode(rule, mode); err != nil {
+		if errors.Is(err, middleware.ErrUnknownRateLimitRule) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+			return
+		}
+		respondError(c, http.StatusInternalServerError, errorCode(http.StatusInternalServerError, err), err.Error())
+		return
+	}
+	if h.Logger != nil {
+		h.Logger.Info("rate limit mode switched by admin", "admin_id", principal.ID, "rule", rule, "mode", mode)
+	}
+	c.JSON(http.StatusOK, h.RateLimits.Rule(rule).Status(rateLimitTopOffenders))
+}
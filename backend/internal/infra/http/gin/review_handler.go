@@ -40,17 +40,17 @@ func (h ReviewsHandler) Submit(c *gin.Context) {
 		return
 	}
 	if h.Commands == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "reviews: commands unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "reviews: commands unavailable")
 		return
 	}
 	bookingID := c.Param("id")
 	if bookingID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "booking id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "booking id is required")
 		return
 	}
 	var req submitReviewRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
 		return
 	}
 
@@ -90,7 +90,7 @@ func (h ReviewsHandler) handleSubmitError(c *gin.Context, err error) {
 	if h.Logger != nil {
 		h.Logger.Warn("review submit failed", "status", status, "error", err)
 	}
-	c.JSON(status, gin.H{"error": err.Error()})
+	respondError(c, status, errorCode(status, err), err.Error())
 }
 
 func (h ReviewsHandler) Update(c *gin.Context) {
@@ -99,17 +99,17 @@ func (h ReviewsHandler) Update(c *gin.Context) {
 		return
 	}
 	if h.Commands == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "reviews: commands unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "reviews: commands unavailable")
 		return
 	}
 	reviewID := c.Param("id")
 	if reviewID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "review id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "review id is required")
 		return
 	}
 	var req updateReviewRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
 		return
 	}
 	cmd := reviewsapp.UpdateReviewCommand{
@@ -144,17 +144,78 @@ func (h ReviewsHandler) handleUpdateError(c *gin.Context, err error) {
 	if h.Logger != nil {
 		h.Logger.Warn("review update failed", "status", status, "error", err)
 	}
-	c.JSON(status, gin.H{"error": err.Error()})
+	respondError(c, status, errorCode(status, err), err.Error())
+}
+
+type replyReviewRequest struct {
+	Text string `json:"text"`
+}
+
+func (h ReviewsHandler) Reply(c *gin.Context) {
+	host, ok := requireRole(c, "host")
+	if !ok {
+		return
+	}
+	if h.Commands == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "reviews: commands unavailable")
+		return
+	}
+	reviewID := c.Param("review_id")
+	if reviewID == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "review id is required")
+		return
+	}
+	var req replyReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	cmd := reviewsapp.ReplyToReviewCommand{
+		ListingID: c.Param("id"),
+		ReviewID:  reviewID,
+		HostID:    host.ID,
+		Text:      req.Text,
+		Now:       time.Now().UTC(),
+	}
+	review, err := commands.Dispatch[reviewsapp.ReplyToReviewCommand, dto.Review](c.Request.Context(), h.Commands, cmd)
+	if err != nil {
+		h.handleReplyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+func (h ReviewsHandler) handleReplyError(c *gin.Context, err error) {
+	var status int
+	switch {
+	case errors.Is(err, domainreviews.ErrReplyRequired),
+		errors.Is(err, domainreviews.ErrReplyTooLong):
+		status = http.StatusBadRequest
+	case errors.Is(err, reviewsapp.ErrReviewNotHosted):
+		status = http.StatusForbidden
+	case errors.Is(err, domainreviews.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domainreviews.ErrReplyToDraft):
+		status = http.StatusConflict
+	case errors.Is(err, uow.ErrUnitOfWorkMissing):
+		status = http.StatusServiceUnavailable
+	default:
+		status = http.StatusInternalServerError
+	}
+	if h.Logger != nil {
+		h.Logger.Warn("review reply failed", "status", status, "error", err)
+	}
+	respondError(c, status, errorCode(status, err), err.Error())
 }
 
 func (h ReviewsHandler) ListByListing(c *gin.Context) {
 	if h.Queries == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "reviews: queries unavailable"})
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "reviews: queries unavailable")
 		return
 	}
 	listingID := c.Param("id")
 	if listingID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "listing id is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "listing id is required")
 		return
 	}
 	limit := parsePositiveInt(c.Query("limit"), 20)
@@ -165,13 +226,13 @@ func (h ReviewsHandler) ListByListing(c *gin.Context) {
 		Limit:     limit,
 		Offset:    offset,
 	}
-	result, err := queries.Ask[reviewsapp.ListListingReviewsQuery, dto.ReviewCollection](c.Request.Context(), h.Queries, query)
+	result, err := queries.Ask[reviewsapp.ListListingReviewsQuery, dto.PublicReviewCollection](c.Request.Context(), h.Queries, query)
 	if err != nil {
 		if errors.Is(err, reviewsapp.ErrListingNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "listing not found"})
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "listing not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, err.Error())
 		return
 	}
 	c.JSON(http.StatusOK, result)
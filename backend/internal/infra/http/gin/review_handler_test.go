@@ -0,0 +1,58 @@
+package ginserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/dto"
+	reviewsapp "rentme/internal/app/handlers/reviews"
+	domainreviews "rentme/internal/domain/reviews"
+)
+
+func replyBus(err error) *commands.InMemoryBus {
+	bus := commands.NewInMemoryBus()
+	commands.RegisterHandler[reviewsapp.ReplyToReviewCommand, dto.Review](bus, reviewsapp.ReplyToReviewCommand{}.Key(),
+		commands.HandlerFunc[reviewsapp.ReplyToReviewCommand, dto.Review](func(context.Context, reviewsapp.ReplyToReviewCommand) (dto.Review, error) {
+			return dto.Review{}, err
+		}))
+	return bus
+}
+
+func TestReviewReplyIsAHostRoute(t *testing.T) {
+	handlers := Handlers{Reviews: ReviewsHandler{Commands: replyBus(nil)}}
+	target := "/api/v1/host/listings/listing-1/reviews/review-1/reply"
+
+	guest := newTestServer(t, handlers, &principal{ID: "guest-1", Roles: []string{"guest"}})
+	if rec := serve(guest, http.MethodPost, target, `{"text":"thanks"}`); rec.Code != http.StatusForbidden {
+		t.Fatalf("guest reply status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	host := newTestServer(t, handlers, &principal{ID: "host-1", Roles: []string{"host"}})
+	if rec := serve(host, http.MethodPost, target, `{"text":"thanks"}`); rec.Code != http.StatusOK {
+		t.Fatalf("host reply status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+}
+
+func TestReviewReplyErrorStatus(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"draft", domainreviews.ErrReplyToDraft, http.StatusConflict},
+		{"empty", domainreviews.ErrReplyRequired, http.StatusBadRequest},
+		{"not hosted", reviewsapp.ErrReviewNotHosted, http.StatusForbidden},
+		{"missing", domainreviews.ErrNotFound, http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handlers := Handlers{Reviews: ReviewsHandler{Commands: replyBus(tc.err)}}
+			server := newTestServer(t, handlers, &principal{ID: "host-1", Roles: []string{"host"}})
+			rec := serve(server, http.MethodPost, "/api/v1/host/listings/listing-1/reviews/review-1/reply", `{"text":"thanks"}`)
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tc.want, rec.Body)
+			}
+		})
+	}
+}
@@ -7,14 +7,18 @@ import (
 
 	"github.com/gin-contrib/cors"
 	gin "github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"rentme/internal/app/middleware"
 	"rentme/internal/infra/config"
 	"rentme/internal/infra/obs"
 )
 
 type BookingHTTP interface {
 	Create(c *gin.Context)
+	Get(c *gin.Context)
 	Accept(c *gin.Context)
+	Quote(c *gin.Context)
 }
 
 type AvailabilityHTTP interface {
@@ -23,30 +27,63 @@ type AvailabilityHTTP interface {
 
 type ListingHTTP interface {
 	Catalog(c *gin.Context)
+	CatalogV2(c *gin.Context)
+	MapClusters(c *gin.Context)
 	Overview(c *gin.Context)
+	Prices(c *gin.Context)
 }
 
 type ReviewsHTTP interface {
 	Submit(c *gin.Context)
 	ListByListing(c *gin.Context)
 	Update(c *gin.Context)
+	Reply(c *gin.Context)
 }
 
 type HostListingHTTP interface {
 	List(c *gin.Context)
 	Create(c *gin.Context)
 	Get(c *gin.Context)
+	History(c *gin.Context)
+	PriceHistory(c *gin.Context)
+	BulkBlockCalendar(c *gin.Context)
 	Update(c *gin.Context)
+	Patch(c *gin.Context)
+	Duplicate(c *gin.Context)
+	ValidateAddress(c *gin.Context)
 	Publish(c *gin.Context)
 	Unpublish(c *gin.Context)
+	CancelRepublish(c *gin.Context)
+	Delete(c *gin.Context)
+	Restore(c *gin.Context)
 	PriceSuggestion(c *gin.Context)
+	ApplyPriceSuggestion(c *gin.Context)
 	UploadPhoto(c *gin.Context)
+	PhotoUploadURL(c *gin.Context)
+	RegisterPhoto(c *gin.Context)
+	CreatePhotoUpload(c *gin.Context)
+	GetPhotoUpload(c *gin.Context)
+	PutPhotoUploadPart(c *gin.Context)
+	CompletePhotoUpload(c *gin.Context)
+	DeletePhoto(c *gin.Context)
+	ReorderPhotos(c *gin.Context)
+	TagPhoto(c *gin.Context)
+	SetCoHosts(c *gin.Context)
+	SetUnitGroup(c *gin.Context)
+	CalendarFeedURL(c *gin.Context)
+	CalendarICS(c *gin.Context)
 }
 
 type HostBookingHTTP interface {
 	List(c *gin.Context)
 	Confirm(c *gin.Context)
 	Decline(c *gin.Context)
+	CheckIn(c *gin.Context)
+	CheckOut(c *gin.Context)
+	NoShow(c *gin.Context)
+	ListBlockedGuests(c *gin.Context)
+	BlockGuest(c *gin.Context)
+	UnblockGuest(c *gin.Context)
 }
 
 type Handlers struct {
@@ -60,7 +97,11 @@ type Handlers struct {
 	Reviews        ReviewsHTTP
 	Me             MeHTTP
 	Admin          AdminHTTP
+	Markets        MarketsHTTP
 	AuthMiddleware gin.HandlerFunc
+	// RateLimits are shared with the admin handler so modes switched there
+	// apply here; nil builds the rules from cfg.
+	RateLimits *middleware.RateLimitRules
 }
 
 func NewServer(cfg config.Config, obsMW obs.Middleware, health obs.HealthHandlers, h Handlers) *http.Server {
@@ -71,8 +112,15 @@ func NewServer(cfg config.Config, obsMW obs.Middleware, health obs.HealthHandler
 
 	router := gin.New()
 	router.Use(gin.Recovery())
+	if obsMW.Tracing.Enabled() {
+		router.Use(obsMW.Tracing.GinMiddleware())
+	}
 	router.Use(obsMW.RequestID())
 	router.Use(obsMW.LoggerMiddleware())
+	if cfg.MetricsEnabled && obsMW.Metrics != nil {
+		router.Use(obsMW.Metrics.HTTPMiddleware())
+		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	}
 	router.MaxMultipartMemory = 16 << 20 // 16 MiB guardrail for uploads
 	router.Use(cors.New(cors.Config{
 		AllowOrigins: []string{"*"},
@@ -82,11 +130,29 @@ func NewServer(cfg config.Config, obsMW obs.Middleware, health obs.HealthHandler
 			"Content-Length",
 			"Content-Type",
 			"X-Request-ID",
+			"Retry-After",
+			"Deprecation",
+			"Sunset",
+			"Link",
+			"Warning",
+			"X-RateLimit-Limit",
+			"X-RateLimit-Remaining",
+			"X-RateLimit-Reset",
 		},
 		MaxAge: 12 * time.Hour,
 	}))
+	rateLimits := h.RateLimits
+	if rateLimits == nil {
+		rateLimits = NewRateLimitRules(cfg, obsMW.Logger)
+	}
+	var rateLimitObserver middleware.RateLimitObserver
+	if cfg.MetricsEnabled && obsMW.Metrics != nil {
+		rateLimitObserver = obsMW.Metrics
+	}
+	router.Use(rateLimits.Rule(RateLimitRuleIP).Handler(rateLimitObserver))
 	if h.AuthMiddleware != nil {
 		router.Use(h.AuthMiddleware)
+		router.Use(rateLimits.Rule(RateLimitRuleUser).Handler(rateLimitObserver))
 	}
 
 	registerSwaggerRoutes(router)
@@ -94,16 +160,49 @@ func NewServer(cfg config.Config, obsMW obs.Middleware, health obs.HealthHandler
 	router.GET("/livez", health.Livez)
 	router.GET("/readyz", health.Readyz)
 
+	// One limiter shared by every catalog search route, so switching between
+	// the list and the map does not double a client's allowance.
+	catalogLimit := middleware.ConcurrencyLimiterByKey(cfg.CatalogConcurrency, func(c *gin.Context) string {
+		if p, ok := currentPrincipal(c); ok {
+			return p.ID
+		}
+		return "ip:" + c.ClientIP()
+	})
+
+	// Route-level timeouts run after auth, so a timed-out request can be
+	// logged against the caller that made it.
+	requestTimeout := func(d time.Duration) gin.HandlerFunc {
+		limit := middleware.RequestTimeout(d)
+		return func(c *gin.Context) {
+			limit(c)
+			if c.Writer.Status() != http.StatusGatewayTimeout || obsMW.Logger == nil {
+				return
+			}
+			principalID := ""
+			if p, ok := currentPrincipal(c); ok {
+				principalID = p.ID
+			}
+			obsMW.Logger.Warn("http request timed out", "method", c.Request.Method, "path", c.FullPath(), "timeout", d, "principal_id", principalID, "request_id", c.GetString("request_id"))
+		}
+	}
+	catalogTimeout := requestTimeout(cfg.CatalogTimeout)
+	photoUploadTimeout := requestTimeout(cfg.PhotoUploadTimeout)
+	priceSuggestionTimeout := requestTimeout(cfg.MLSuggestTimeout)
+
 	api := router.Group("/api/v1")
 	if h.Auth != nil {
 		api.POST("/auth/register", h.Auth.Register)
 		api.POST("/auth/login", h.Auth.Login)
 		api.POST("/auth/logout", h.Auth.Logout)
+		api.POST("/auth/refresh", h.Auth.Refresh)
 		api.GET("/auth/me", h.Auth.Me)
+		api.POST("/auth/password", h.Auth.ChangePassword)
 	}
 	if h.Booking != nil {
 		api.POST("/bookings", h.Booking.Create)
+		api.GET("/bookings/:id", h.Booking.Get)
 		api.POST("/bookings/:id/accept", h.Booking.Accept)
+		api.GET("/listings/:id/quote", h.Booking.Quote)
 	}
 	if h.Reviews != nil {
 		api.POST("/bookings/:id/review", h.Reviews.Submit)
@@ -111,11 +210,16 @@ func NewServer(cfg config.Config, obsMW obs.Middleware, health obs.HealthHandler
 		api.GET("/listings/:id/reviews", h.Reviews.ListByListing)
 	}
 	if h.Availability != nil {
-		api.GET("/listings/:id/calendar", h.Availability.Calendar)
+		api.GET("/listings/:id/calendar", catalogTimeout, h.Availability.Calendar)
 	}
 	if h.Listing != nil {
-		api.GET("/listings", h.Listing.Catalog)
+		api.GET("/listings", deprecated("/api/v2/listings", v1CatalogSunset), catalogTimeout, catalogLimit, h.Listing.Catalog)
+		api.GET("/listings/map-clusters", catalogTimeout, catalogLimit, h.Listing.MapClusters)
 		api.GET("/listings/:id/overview", h.Listing.Overview)
+		api.GET("/listings/:id/prices", h.Listing.Prices)
+	}
+	if h.Markets != nil {
+		api.GET("/markets", h.Markets.List)
 	}
 	if h.Chat != nil {
 		api.POST("/chats", h.Chat.CreateDirectConversation)
@@ -123,36 +227,117 @@ func NewServer(cfg config.Config, obsMW obs.Middleware, health obs.HealthHandler
 		api.GET("/chats/:id/messages", h.Chat.ListMessages)
 		api.POST("/chats/:id/messages", h.Chat.SendMessage)
 		api.POST("/chats/:id/read", h.Chat.MarkRead)
+		api.PUT("/chats/:id/assignee", h.Chat.Assign)
+		api.GET("/chats/:id/notes", h.Chat.ListNotes)
+		api.POST("/chats/:id/notes", h.Chat.AddNote)
 		api.POST("/listings/:id/chat", h.Chat.CreateListingConversation)
 		api.POST("/bookings/:id/chat", h.Chat.CreateBookingConversation)
 	}
+	hostGroup := api.Group("/host/listings")
+	if h.Reviews != nil {
+		// Shares the :id wildcard with the host listing routes; gin rejects a differently named parameter here.
+		hostGroup.POST("/:id/reviews/:review_id/reply", h.Reviews.Reply)
+	}
 	if h.HostListing != nil {
-		hostGroup := api.Group("/host/listings")
 		hostGroup.GET("", h.HostListing.List)
 		hostGroup.POST("", h.HostListing.Create)
+		hostGroup.POST("/validate-address", h.HostListing.ValidateAddress)
 		hostGroup.GET("/:id", h.HostListing.Get)
 		hostGroup.PUT("/:id", h.HostListing.Update)
+		hostGroup.PATCH("/:id", h.HostListing.Patch)
+		hostGroup.DELETE("/:id", h.HostListing.Delete)
+		hostGroup.POST("/:id/restore", h.HostListing.Restore)
+		hostGroup.GET("/:id/history", h.HostListing.History)
+		hostGroup.GET("/:id/price-history", h.HostListing.PriceHistory)
+		hostGroup.POST("/:id/duplicate", h.HostListing.Duplicate)
 		hostGroup.POST("/:id/publish", h.HostListing.Publish)
 		hostGroup.POST("/:id/unpublish", h.HostListing.Unpublish)
-		hostGroup.POST("/:id/price-suggestion", h.HostListing.PriceSuggestion)
-		hostGroup.POST("/:id/photos", h.HostListing.UploadPhoto)
+		hostGroup.DELETE("/:id/republish", h.HostListing.CancelRepublish)
+		hostGroup.POST("/:id/price-suggestion", priceSuggestionTimeout, h.HostListing.PriceSuggestion)
+		hostGroup.POST("/:id/price-suggestion/apply", priceSuggestionTimeout, h.HostListing.ApplyPriceSuggestion)
+		hostGroup.POST("/:id/photos", photoUploadTimeout, h.HostListing.UploadPhoto)
+		hostGroup.GET("/:id/photos/upload-url", h.HostListing.PhotoUploadURL)
+		hostGroup.PATCH("/:id/photos", h.HostListing.RegisterPhoto)
+		hostGroup.POST("/:id/photos/uploads", h.HostListing.CreatePhotoUpload)
+		hostGroup.GET("/:id/photos/uploads/:uploadId", h.HostListing.GetPhotoUpload)
+		hostGroup.PUT("/:id/photos/uploads/:uploadId/parts/:n", photoUploadTimeout, h.HostListing.PutPhotoUploadPart)
+		hostGroup.POST("/:id/photos/uploads/:uploadId/complete", photoUploadTimeout, h.HostListing.CompletePhotoUpload)
+		hostGroup.DELETE("/:id/photos", h.HostListing.DeletePhoto)
+		hostGroup.PUT("/:id/photos/order", h.HostListing.ReorderPhotos)
+		hostGroup.PUT("/:id/photos/tag", h.HostListing.TagPhoto)
+		hostGroup.PUT("/:id/cohosts", h.HostListing.SetCoHosts)
+		hostGroup.PUT("/:id/unit-group", h.HostListing.SetUnitGroup)
+		hostGroup.GET("/:id/calendar-feed", h.HostListing.CalendarFeedURL)
+		hostGroup.GET("/:id/calendar.ics", h.HostListing.CalendarICS)
+		hostGroup.POST("/:id/calendar/bulk-blocks", h.HostListing.BulkBlockCalendar)
 	}
 	if h.HostBooking != nil {
 		hostBookingGroup := api.Group("/host/bookings")
 		hostBookingGroup.GET("", h.HostBooking.List)
 		hostBookingGroup.POST("/:id/confirm", h.HostBooking.Confirm)
 		hostBookingGroup.POST("/:id/decline", h.HostBooking.Decline)
+		hostBookingGroup.POST("/:id/check-in", h.HostBooking.CheckIn)
+		hostBookingGroup.POST("/:id/check-out", h.HostBooking.CheckOut)
+		hostBookingGroup.POST("/:id/no-show", h.HostBooking.NoShow)
+		blockedGuestsGroup := api.Group("/host/blocked-guests")
+		blockedGuestsGroup.GET("", h.HostBooking.ListBlockedGuests)
+		blockedGuestsGroup.POST("/:guestId", h.HostBooking.BlockGuest)
+		blockedGuestsGroup.DELETE("/:guestId", h.HostBooking.UnblockGuest)
 	}
 	if h.Me != nil {
 		meGroup := api.Group("/me")
 		meGroup.GET("/bookings", h.Me.ListBookings)
+		meGroup.PATCH("/bookings/:id", h.Me.ModifyBooking)
+		meGroup.GET("/wishlist", h.Me.ListWishlist)
+		meGroup.POST("/wishlist/:listing_id", h.Me.AddToWishlist)
+		meGroup.DELETE("/wishlist/:listing_id", h.Me.RemoveFromWishlist)
+		// Favorites are the wishlist under the name the catalog uses.
+		meGroup.GET("/favorites", h.Me.ListWishlist)
+		meGroup.POST("/favorites/:listing_id", h.Me.AddToWishlist)
+		meGroup.DELETE("/favorites/:listing_id", h.Me.RemoveFromWishlist)
+		meGroup.PUT("/notifications", h.Me.UpdateNotifications)
+		meGroup.PUT("/profile", h.Me.UpdateProfile)
+		meGroup.POST("/password", h.Me.ChangePassword)
 	}
 	if h.Admin != nil {
 		adminGroup := api.Group("/admin")
 		adminGroup.GET("/users", h.Admin.ListUsers)
+		adminGroup.GET("/users/:id", h.Admin.GetUser)
 		adminGroup.POST("/users/:id/block", h.Admin.BlockUser)
 		adminGroup.POST("/users/:id/unblock", h.Admin.UnblockUser)
+		adminGroup.PUT("/users/:id/roles", h.Admin.SetUserRoles)
+		adminGroup.GET("/users/:id/verification", h.Admin.UserVerification)
+		adminGroup.PUT("/users/:id/verification", h.Admin.SetUserVerification)
+		adminGroup.DELETE("/users/:id/blocked-guests/:guestId", h.Admin.RemoveGuestBlock)
 		adminGroup.GET("/ml/metrics", h.Admin.MLMetrics)
+		adminGroup.GET("/ml/clamps", h.Admin.ExportPriceClamps)
+		adminGroup.PUT("/ml/clamps", h.Admin.ImportPriceClamps)
+		adminGroup.GET("/ml/clamps/:city", h.Admin.CityPriceClamps)
+		adminGroup.PUT("/ml/clamps/:city", h.Admin.UpdateCityPriceClamps)
+		adminGroup.GET("/listings", h.Admin.ListListings)
+		adminGroup.GET("/listings/:id", h.Admin.GetListing)
+		adminGroup.POST("/listings/:id/suspend", h.Admin.SuspendListing)
+		adminGroup.POST("/listings/:id/reactivate", h.Admin.ReactivateListing)
+		adminGroup.POST("/listings/:id/integrity-check", h.Admin.CheckListingIntegrity)
+		adminGroup.GET("/listings/:id/reviews", h.Admin.ListListingReviews)
+		adminGroup.DELETE("/reviews/:id", h.Admin.ModerateReview)
+		adminGroup.GET("/bookings", h.Admin.ListBookings)
+		adminGroup.GET("/bookings/:id", h.Admin.GetBooking)
+		adminGroup.POST("/bookings/:id/force-confirm", h.Admin.ForceConfirmBooking)
+		adminGroup.POST("/bookings/:id/cancel", h.Admin.CancelBooking)
+		adminGroup.GET("/integrity/bookings", h.Admin.BookingIntegrityReports)
+		adminGroup.GET("/jobs/runs", h.Admin.JobRuns)
+		adminGroup.PUT("/markets", h.Admin.UpdateMarkets)
+		adminGroup.GET("/config", h.Admin.Config)
+		adminGroup.GET("/rate-limits", h.Admin.ListRateLimits)
+		adminGroup.PUT("/rate-limits/:rule", h.Admin.UpdateRateLimit)
+	}
+
+	// v2 only carries routes whose response shape changed; everything else
+	// stays on v1.
+	apiV2 := router.Group("/api/v2")
+	if h.Listing != nil {
+		apiV2.GET("/listings", catalogTimeout, catalogLimit, h.Listing.CatalogV2)
 	}
 
 	return &http.Server{Addr: cfg.HTTPAddr, Handler: router}
@@ -0,0 +1,40 @@
+package ginserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/infra/config"
+	"rentme/internal/infra/obs"
+)
+
+// newTestServer builds the real router around h. A non-nil caller is signed
+// in on every request.
+func newTestServer(t *testing.T, h Handlers, caller *principal) http.Handler {
+	t.Helper()
+	if caller != nil {
+		signedIn := *caller
+		h.AuthMiddleware = func(c *gin.Context) {
+			setPrincipal(c, signedIn)
+			c.Next()
+		}
+	}
+	return NewServer(config.Config{Env: "test"}, obs.Middleware{}, obs.HealthHandlers{}, h).Handler
+}
+
+func serve(handler http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if body != "" {
+		req = httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req = httptest.NewRequest(method, target, nil)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
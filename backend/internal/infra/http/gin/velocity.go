@@ -0,0 +1,35 @@
+package ginserver
+
+import (
+	"log/slog"
+	"net/http"
+
+	gin "github.com/gin-gonic/gin"
+
+	"rentme/internal/app/services/trust"
+)
+
+// deviceFingerprintHeader carries the client-side device fingerprint used by velocity checks.
+const deviceFingerprintHeader = "X-Device-Fingerprint"
+
+// checkVelocity consults the velocity service and answers 429 on reject. It
+// fails open when the check itself errors.
+func checkVelocity(c *gin.Context, svc *trust.Service, attempt trust.Attempt, logger *slog.Logger) (trust.Verdict, bool) {
+	if svc == nil {
+		return trust.Verdict{Decision: trust.DecisionAllow}, true
+	}
+	attempt.IP = c.ClientIP()
+	attempt.Device = c.GetHeader(deviceFingerprintHeader)
+	verdict, err := svc.Check(c.Request.Context(), attempt)
+	if err != nil {
+		if logger != nil {
+			logger.Warn("velocity check failed", "action", attempt.Action, "error", err)
+		}
+		return trust.Verdict{Decision: trust.DecisionAllow}, true
+	}
+	if verdict.Decision == trust.DecisionReject {
+		respondError(c, http.StatusTooManyRequests, ErrCodeRateLimited, "too many attempts, try again later")
+		return verdict, false
+	}
+	return verdict, true
+}
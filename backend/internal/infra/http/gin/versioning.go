@@ -0,0 +1,23 @@
+package ginserver
+
+import (
+	"net/http"
+	"time"
+
+	gin "github.com/gin-gonic/gin"
+)
+
+// v1CatalogSunset is when the v1 catalog card shape stops being served.
+var v1CatalogSunset = time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+// deprecated marks a v1 route whose shape has a v2 successor. Clients get the
+// Deprecation header (RFC 9745), the Sunset date (RFC 8594) and a Link to the
+// replacement route.
+func deprecated(successor string, sunset time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+		c.Header("Link", "<"+successor+">; rel=\"successor-version\"")
+		c.Next()
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type Store struct {
@@ -15,9 +14,7 @@ type Store struct {
 }
 
 func NewStore(db *mongo.Database, consumer string) *Store {
-	col := db.Collection("app_inbox")
-	_, _ = col.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "consumer", Value: 1}}, Options: options.Index().SetUnique(true)})
-	return &Store{col: col, consumer: consumer}
+	return &Store{col: db.Collection("app_inbox"), consumer: consumer}
 }
 
 func (s *Store) Seen(ctx context.Context, eventID string) (bool, error) {
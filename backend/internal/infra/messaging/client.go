@@ -3,11 +3,18 @@ package messaging
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 
 	pb "messaging-service/proto"
 )
@@ -19,10 +26,29 @@ type Config struct {
 	CallTimeout time.Duration
 }
 
+// ErrUnavailable is returned by Ping while messaging-service cannot be reached.
+var ErrUnavailable = errors.New("messaging: service unavailable")
+
+// retryServiceConfig retries calls that fail with UNAVAILABLE, which covers
+// the window in which messaging-service is restarting or not up yet.
+const retryServiceConfig = `{
+	"methodConfig": [{
+		"name": [{"service": "messaging.v1.MessagingService"}],
+		"retryPolicy": {
+			"maxAttempts": 4,
+			"initialBackoff": "0.1s",
+			"maxBackoff": "1s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]
+}`
+
 // Client wraps the messaging-service gRPC API.
 type Client struct {
 	conn        *grpc.ClientConn
 	svc         pb.MessagingServiceClient
+	dialTimeout time.Duration
 	callTimeout time.Duration
 	logger      *slog.Logger
 }
@@ -38,18 +64,23 @@ type Conversation struct {
 	LastSenderID  string
 	LastMessageText string
 	HasUnread     bool
+	UnreadCount   int
 }
 
 // Message models a chat message used by the HTTP layer.
 type Message struct {
-	ID             string
-	ConversationID string
-	SenderID       string
-	Text           string
-	CreatedAt      time.Time
+	ID              string
+	ConversationID  string
+	SenderID        string
+	Text            string
+	ClientMessageID string
+	CreatedAt       time.Time
 }
 
-// NewClient dials messaging-service and returns a typed client.
+// NewClient returns a typed client for messaging-service. The connection is
+// established lazily and re-established after failures, so the client is
+// usable even when messaging-service starts after the backend; calls made
+// while it is down fail with codes.Unavailable.
 func NewClient(ctx context.Context, cfg Config, logger *slog.Logger) (*Client, error) {
 	if cfg.Addr == "" {
 		return nil, errors.New("messaging: address required")
@@ -62,22 +93,82 @@ func NewClient(ctx context.Context, cfg Config, logger *slog.Logger) (*Client, e
 	if callTimeout <= 0 {
 		callTimeout = 5 * time.Second
 	}
-	dialCtx, cancel := context.WithTimeout(ctx, dialTimeout)
-	defer cancel()
-
-	conn, err := grpc.DialContext(dialCtx, cfg.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(
+		cfg.Addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultServiceConfig(retryServiceConfig),
+		// Spans go to the global provider, so this is a no-op without tracing.
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
 	if err != nil {
 		return nil, err
 	}
-	if logger != nil {
-		logger.Info("messaging grpc connected", "addr", cfg.Addr)
-	}
-	return &Client{
+	client := &Client{
 		conn:        conn,
 		svc:         pb.NewMessagingServiceClient(conn),
+		dialTimeout: dialTimeout,
 		callTimeout: callTimeout,
 		logger:      logger,
-	}, nil
+	}
+	// Start connecting right away; the first call should not pay for the dial.
+	conn.Connect()
+	if err := client.Ping(ctx); err != nil {
+		if logger != nil {
+			logger.Warn("messaging grpc not reachable yet; will keep reconnecting", "addr", cfg.Addr, "error", err)
+		}
+	} else if logger != nil {
+		logger.Info("messaging grpc connected", "addr", cfg.Addr)
+	}
+	return client, nil
+}
+
+// Ping reports whether the connection to messaging-service is ready, waiting
+// up to the dial timeout for a connection attempt in progress.
+func (c *Client) Ping(ctx context.Context) error {
+	if c == nil || c.conn == nil {
+		return ErrUnavailable
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, c.dialTimeout)
+	defer cancel()
+	for {
+		state := c.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Idle:
+			c.conn.Connect()
+		case connectivity.Shutdown:
+			return fmt.Errorf("%w: connection closed", ErrUnavailable)
+		}
+		if !c.conn.WaitForStateChange(waitCtx, state) {
+			return fmt.Errorf("%w: connection %s", ErrUnavailable, strings.ToLower(state.String()))
+		}
+	}
+}
+
+// Health asks messaging-service for its gRPC health status once the
+// connection is ready. A server without the health service counts as
+// healthy: the connection itself already proved it is up.
+func (c *Client) Health(ctx context.Context) error {
+	if err := c.Ping(ctx); err != nil {
+		return err
+	}
+	callCtx, cancel := context.WithTimeout(ctx, c.callTimeout)
+	defer cancel()
+	resp, err := healthpb.NewHealthClient(c.conn).Check(callCtx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%w: health status %s", ErrUnavailable, resp.GetStatus())
+	}
+	return nil
 }
 
 // Close releases the gRPC connection.
@@ -136,6 +227,27 @@ func (c *Client) ListConversations(ctx context.Context, userID string, limit int
 	return items, resp.GetNextCursor(), nil
 }
 
+// UnreadCount sums unread messages over the user's conversations, reading at
+// most maxPages pages.
+func (c *Client) UnreadCount(ctx context.Context, userID string, maxPages int) (int, error) {
+	total := 0
+	cursor := ""
+	for page := 0; maxPages <= 0 || page < maxPages; page++ {
+		items, next, err := c.ListConversations(ctx, userID, 50, cursor, false)
+		if err != nil {
+			return 0, err
+		}
+		for _, conv := range items {
+			total += conv.UnreadCount
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	return total, nil
+}
+
 // MarkConversationRead updates read position for a user.
 func (c *Client) MarkConversationRead(ctx context.Context, conversationID, userID, lastReadMessageID string) (time.Time, error) {
 	req := &pb.MarkConversationReadRequest{
@@ -155,12 +267,14 @@ func (c *Client) MarkConversationRead(ctx context.Context, conversationID, userI
 	return resp.AsTime(), nil
 }
 
-// SendMessage posts a message to a conversation.
-func (c *Client) SendMessage(ctx context.Context, conversationID, senderID, text string) (Message, error) {
+// SendMessage posts a message to a conversation. A repeated clientMessageID
+// returns the originally stored message.
+func (c *Client) SendMessage(ctx context.Context, conversationID, senderID, text, clientMessageID string) (Message, error) {
 	req := &pb.SendMessageRequest{
-		ConversationId: conversationID,
-		SenderId:       senderID,
-		Text:           text,
+		ConversationId:  conversationID,
+		SenderId:        senderID,
+		Text:            text,
+		ClientMessageId: clientMessageID,
 	}
 	callCtx, cancel := c.wrapCall(ctx)
 	defer cancel()
@@ -224,6 +338,7 @@ func mapConversation(conv *pb.Conversation) Conversation {
 		LastSenderID:    conv.GetLastMessageSenderId(),
 		LastMessageText: conv.GetLastMessageText(),
 		HasUnread:       conv.GetHasUnread(),
+		UnreadCount:     int(conv.GetUnreadCount()),
 	}
 }
 
@@ -236,10 +351,11 @@ func mapMessage(msg *pb.Message) Message {
 		createdAt = msg.CreatedAt.AsTime()
 	}
 	return Message{
-		ID:             msg.GetId(),
-		ConversationID: msg.GetConversationId(),
-		SenderID:       msg.GetSenderId(),
-		Text:           msg.GetText(),
-		CreatedAt:      createdAt,
+		ID:              msg.GetId(),
+		ConversationID:  msg.GetConversationId(),
+		SenderID:        msg.GetSenderId(),
+		Text:            msg.GetText(),
+		ClientMessageID: msg.GetClientMessageId(),
+		CreatedAt:       createdAt,
 	}
 }
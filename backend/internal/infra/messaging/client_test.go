@@ -0,0 +1,109 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	pb "messaging-service/proto"
+)
+
+type fakeMessaging struct {
+	pb.UnimplementedMessagingServiceServer
+}
+
+func (fakeMessaging) ListConversations(context.Context, *pb.ListConversationsRequest) (*pb.ListConversationsResponse, error) {
+	return &pb.ListConversationsResponse{
+		Conversations: []*pb.Conversation{{Id: "conv-1", ListingId: "listing-1"}},
+	}, nil
+}
+
+// freeAddr returns a local address nothing listens on yet.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+	return addr
+}
+
+func startFakeMessaging(t *testing.T, addr string) *grpc.Server {
+	t.Helper()
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("listen %s: %v", addr, err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterMessagingServiceServer(server, fakeMessaging{})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return server
+}
+
+func TestClientRecoversWhenServiceStartsLater(t *testing.T) {
+	addr := freeAddr(t)
+	ctx := context.Background()
+	client, err := NewClient(ctx, Config{Addr: addr, DialTimeout: 200 * time.Millisecond, CallTimeout: time.Second}, nil)
+	if err != nil {
+		t.Fatalf("client must be built while the service is down: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	if err := client.Ping(ctx); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("ping while down = %v, want ErrUnavailable", err)
+	}
+	if _, _, err := client.ListConversations(ctx, "guest-1", 10, "", false); status.Code(err) != codes.Unavailable {
+		t.Fatalf("call while down = %v, want codes.Unavailable", err)
+	}
+
+	startFakeMessaging(t, addr)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		err := client.Ping(ctx)
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("client did not reconnect: %v", err)
+		}
+	}
+	if err := client.Health(ctx); err != nil {
+		t.Fatalf("health after recovery: %v", err)
+	}
+	items, _, err := client.ListConversations(ctx, "guest-1", 10, "", false)
+	if err != nil {
+		t.Fatalf("call after recovery: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "conv-1" {
+		t.Fatalf("conversations = %+v, want conv-1", items)
+	}
+}
+
+func TestPingReportsClosedClient(t *testing.T) {
+	if err := (*Client)(nil).Ping(context.Background()); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("nil client ping = %v, want ErrUnavailable", err)
+	}
+	client, err := NewClient(context.Background(), Config{Addr: freeAddr(t), DialTimeout: 50 * time.Millisecond}, nil)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("closed client ping = %v, want ErrUnavailable", err)
+	}
+}
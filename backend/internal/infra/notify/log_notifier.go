@@ -0,0 +1,23 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+
+	"rentme/internal/app/policies"
+)
+
+// LogNotifier writes notifications to the application log until a real
+// delivery channel is wired in.
+type LogNotifier struct {
+	Logger *slog.Logger
+}
+
+func (n LogNotifier) Send(ctx context.Context, to string, template string, data any) error {
+	if n.Logger != nil {
+		n.Logger.Warn("notification", "to", to, "template", template, "data", data)
+	}
+	return nil
+}
+
+var _ policies.Notifier = LogNotifier{}
@@ -0,0 +1,193 @@
+package obs
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"rentme/internal/app/commands"
+	"rentme/internal/app/outbox"
+	"rentme/internal/app/queries"
+)
+
+// Metrics holds the Prometheus collectors of the application.
+type Metrics struct {
+	commandTotal    *prometheus.CounterVec
+	commandDuration *prometheus.HistogramVec
+	queryTotal      *prometheus.CounterVec
+	queryDuration   *prometheus.HistogramVec
+	httpDuration    *prometheus.HistogramVec
+	outboxFlush     *prometheus.HistogramVec
+	outboxFailures  *prometheus.CounterVec
+	catalogSearch   *prometheus.CounterVec
+	rateLimited     *prometheus.CounterVec
+}
+
+// NewMetrics creates the collectors and registers them. A nil registerer
+// means prometheus.DefaultRegisterer, which promhttp.Handler serves.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	m := &Metrics{
+		commandTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rentme",
+			Name:      "command_dispatch_total",
+			Help:      "Commands dispatched through the bus.",
+		}, []string{"command", "result"}),
+		commandDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "rentme",
+			Name:      "command_dispatch_duration_seconds",
+			Help:      "Command dispatch latency.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"command"}),
+		queryTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rentme",
+			Name:      "query_ask_total",
+			Help:      "Queries asked through the bus.",
+		}, []string{"query", "result"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "rentme",
+			Name:      "query_ask_duration_seconds",
+			Help:      "Query latency.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"query"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "rentme",
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		outboxFlush: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "rentme",
+			Name:      "outbox_flush_duration_seconds",
+			Help:      "Outbox flush latency.",
+			Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"result"}),
+		outboxFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rentme",
+			Name:      "outbox_flush_failures_total",
+			Help:      "Outbox flushes that failed after their command committed, by command.",
+		}, []string{"command"}),
+		catalogSearch: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rentme",
+			Name:      "catalog_search_total",
+			Help:      "Catalog searches by how they were answered: computed, shared with an identical in-flight search, or cached.",
+		}, []string{"outcome"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "rentme",
+			Name:      "rate_limit_exceeded_total",
+			Help:      "Requests over a rate limit rule, by rule and by whether the rule enforced or only shadowed the limit.",
+		}, []string{"rule", "mode"}),
+	}
+	reg.MustRegister(m.commandTotal, m.commandDuration, m.queryTotal, m.queryDuration, m.httpDuration, m.outboxFlush, m.outboxFailures, m.catalogSearch, m.rateLimited)
+	return m
+}
+
+// HTTPMiddleware records request latency labelled with the route template so
+// path parameters do not blow up cardinality.
+func (m *Metrics) HTTPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.httpDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveOutboxFlushFailure counts one failed flush of the command's outbox batch.
+func (m *Metrics) ObserveOutboxFlushFailure(command string) {
+	m.outboxFailures.WithLabelValues(command).Inc()
+}
+
+// ObserveCatalogSearch counts one catalog search answered with outcome.
+func (m *Metrics) ObserveCatalogSearch(outcome string) {
+	m.catalogSearch.WithLabelValues(outcome).Inc()
+}
+
+// ObserveRateLimitExceeded counts one request over the rule's limit.
+func (m *Metrics) ObserveRateLimitExceeded(rule string, mode string) {
+	m.rateLimited.WithLabelValues(rule, mode).Inc()
+}
+
+// InstrumentedBus decorates the command and query buses with a span named
+// after the command or query key and, when Metrics is set, dispatch counters
+// and latency histograms.
+type InstrumentedBus struct {
+	Commands commands.Bus
+	Queries  queries.Bus
+	Metrics  *Metrics
+}
+
+func (b InstrumentedBus) Dispatch(ctx context.Context, cmd commands.Command) (any, error) {
+	key := cmd.Key()
+	ctx, span := StartSpan(ctx, key, trace.WithAttributes(attribute.String("command.key", key)))
+	start := time.Now()
+	res, err := b.Commands.Dispatch(ctx, cmd)
+	EndSpan(span, err)
+	if b.Metrics != nil {
+		b.Metrics.commandDuration.WithLabelValues(key).Observe(time.Since(start).Seconds())
+		b.Metrics.commandTotal.WithLabelValues(key, resultLabel(err)).Inc()
+	}
+	return res, err
+}
+
+func (b InstrumentedBus) Ask(ctx context.Context, q queries.Query) (any, error) {
+	key := q.Key()
+	ctx, span := StartSpan(ctx, key, trace.WithAttributes(attribute.String("query.key", key)))
+	start := time.Now()
+	res, err := b.Queries.Ask(ctx, q)
+	EndSpan(span, err)
+	if b.Metrics != nil {
+		b.Metrics.queryDuration.WithLabelValues(key).Observe(time.Since(start).Seconds())
+		b.Metrics.queryTotal.WithLabelValues(key, resultLabel(err)).Inc()
+	}
+	return res, err
+}
+
+// InstrumentedOutbox measures how long flushing buffered events takes.
+type InstrumentedOutbox struct {
+	Outbox  outbox.Outbox
+	Metrics *Metrics
+}
+
+func (o InstrumentedOutbox) Add(ctx context.Context, record outbox.EventRecord) error {
+	return o.Outbox.Add(ctx, record)
+}
+
+// Discard forwards to the wrapped outbox when it stages records itself.
+func (o InstrumentedOutbox) Discard(ctx context.Context) error {
+	if discarder, ok := o.Outbox.(outbox.Discarder); ok {
+		return discarder.Discard(ctx)
+	}
+	return nil
+}
+
+func (o InstrumentedOutbox) Flush(ctx context.Context) error {
+	start := time.Now()
+	err := o.Outbox.Flush(ctx)
+	o.Metrics.outboxFlush.WithLabelValues(resultLabel(err)).Observe(time.Since(start).Seconds())
+	return err
+}
+
+func resultLabel(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+var (
+	_ commands.Bus     = InstrumentedBus{}
+	_ queries.Bus      = InstrumentedBus{}
+	_ outbox.Outbox    = InstrumentedOutbox{}
+	_ outbox.Discarder = InstrumentedOutbox{}
+)
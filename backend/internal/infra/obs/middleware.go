@@ -2,6 +2,7 @@ package obs
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,7 +11,9 @@ import (
 )
 
 type Middleware struct {
-	Logger *slog.Logger
+	Logger  *slog.Logger
+	Metrics *Metrics
+	Tracing *Tracing
 }
 
 func (m Middleware) RequestID() gin.HandlerFunc {
@@ -35,7 +38,12 @@ func (m Middleware) LoggerMiddleware() gin.HandlerFunc {
 		if log == nil {
 			return
 		}
-		log.Info("http", "method", c.Request.Method, "path", c.FullPath(), "status", c.Writer.Status(), "duration", time.Since(start), "request_id", c.GetString("request_id"))
+		status := c.Writer.Status()
+		if status == http.StatusTooManyRequests {
+			log.Warn("http rate limited", "method", c.Request.Method, "path", c.FullPath(), "status", status, "client_ip", c.ClientIP(), "retry_after", c.Writer.Header().Get("Retry-After"), "request_id", c.GetString("request_id"))
+			return
+		}
+		log.Info("http", "method", c.Request.Method, "path", c.FullPath(), "status", status, "duration", time.Since(start), "request_id", c.GetString("request_id"))
 	}
 }
 
@@ -0,0 +1,92 @@
+package obs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation scope used by application spans.
+const TracerName = "rentme"
+
+// Tracing owns the OpenTelemetry tracer provider. The zero value (and a nil
+// pointer) is a no-op, which is what SetupTracing returns without an endpoint.
+type Tracing struct {
+	service  string
+	provider *sdktrace.TracerProvider
+}
+
+// SetupTracing exports spans over OTLP/gRPC to endpoint and installs the
+// provider globally. Endpoints without a scheme are dialled without TLS.
+func SetupTracing(ctx context.Context, endpoint, service string) (*Tracing, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return &Tracing{service: service}, nil
+	}
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpointURL(endpoint)}
+	if !strings.Contains(endpoint, "://") {
+		opts = []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure()}
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(service)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return &Tracing{service: service, provider: provider}, nil
+}
+
+// Enabled reports whether spans are exported.
+func (t *Tracing) Enabled() bool {
+	return t != nil && t.provider != nil
+}
+
+// GinMiddleware starts a server span per request named after the route.
+func (t *Tracing) GinMiddleware() gin.HandlerFunc {
+	return otelgin.Middleware(t.service)
+}
+
+// Shutdown flushes pending spans and stops the exporter.
+func (t *Tracing) Shutdown(ctx context.Context) error {
+	if !t.Enabled() {
+		return nil
+	}
+	return t.provider.Shutdown(ctx)
+}
+
+// StartSpan starts a child span on the global provider; it is a no-op span
+// when tracing is not configured.
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// EndSpan records err on the span, if any, and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
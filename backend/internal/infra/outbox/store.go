@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -23,10 +24,7 @@ type Store struct {
 }
 
 func NewStore(db *mongo.Database) *Store {
-	col := db.Collection("app_outbox")
-	idx := mongo.IndexModel{Keys: bson.D{{Key: "state", Value: 1}, {Key: "next_attempt_at", Value: 1}}}
-	_, _ = col.Indexes().CreateOne(context.Background(), idx)
-	return &Store{col: col}
+	return &Store{col: db.Collection("app_outbox")}
 }
 
 func (s *Store) Add(ctx context.Context, record appoutbox.EventRecord) error {
@@ -82,6 +80,36 @@ func (s *Store) Claim(ctx context.Context, workerID string) (*EventDocument, err
 	return &doc, nil
 }
 
+// FetchPending claims up to limit due records for the dispatcher.
+func (s *Store) FetchPending(ctx context.Context, limit int, _ time.Time) ([]appoutbox.PendingRecord, error) {
+	if limit <= 0 {
+		limit = 1
+	}
+	workerID := uuid.NewString()
+	var out []appoutbox.PendingRecord
+	for len(out) < limit {
+		doc, err := s.Claim(ctx, workerID)
+		if err != nil {
+			return out, err
+		}
+		if doc == nil {
+			break
+		}
+		out = append(out, appoutbox.PendingRecord{
+			EventRecord: appoutbox.EventRecord{
+				ID:         doc.ID,
+				Name:       doc.Name,
+				Payload:    doc.Payload,
+				OccurredAt: doc.OccurredAt,
+				Aggregate:  doc.Aggregate,
+				Headers:    doc.Headers,
+			},
+			Attempts: doc.Attempts,
+		})
+	}
+	return out, nil
+}
+
 func (s *Store) MarkSent(ctx context.Context, id string) error {
 	_, err := s.col.UpdateByID(ctx, id, bson.M{"$set": bson.M{"state": stateSent, "sent_at": time.Now().UTC()}})
 	return err
@@ -99,3 +127,8 @@ func (s *Store) MarkFailed(ctx context.Context, id string, next time.Time, errMs
 	_, err := s.col.UpdateByID(ctx, id, update)
 	return err
 }
+
+var (
+	_ appoutbox.Outbox       = (*Store)(nil)
+	_ appoutbox.Dispatchable = (*Store)(nil)
+)
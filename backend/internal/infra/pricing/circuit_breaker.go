@@ -0,0 +1,250 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	domainpricing "rentme/internal/domain/pricing"
+)
+
+const (
+	defaultBreakerFailures = 5
+	defaultBreakerWindow   = time.Minute
+	defaultBreakerCooldown = 30 * time.Second
+)
+
+// ErrMLUnavailable wraps failures to reach the ML service or read its answer,
+// the errors the circuit breaker counts.
+var ErrMLUnavailable = errors.New("pricing: ml service unavailable")
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState string
+
+const (
+	// BreakerClosed lets every call through.
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen short-circuits every call until the cool-down ends.
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets one trial call through after the cool-down; its
+	// outcome closes or reopens the circuit.
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// CircuitBreaker opens after Failures consecutive failures within Window and
+// stays open for Cooldown.
+type CircuitBreaker struct {
+	Failures int
+	Window   time.Duration
+	Cooldown time.Duration
+	Now      func() time.Time
+
+	mu           sync.Mutex
+	state        BreakerState
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	trial        bool
+	opens        int
+	lastError    string
+	lastChange   time.Time
+}
+
+// BreakerSnapshot is the breaker state shown to admins.
+type BreakerSnapshot struct {
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	FailureThreshold    int          `json:"failure_threshold"`
+	CooldownSeconds     float64      `json:"cooldown_seconds"`
+	Opens               int          `json:"opens"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+	LastError           string       `json:"last_error,omitempty"`
+	LastChange          *time.Time   `json:"last_change,omitempty"`
+}
+
+// Allow reports whether a call may go through. Once the cool-down is over it
+// moves to half-open and allows a single trial call.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.currentLocked() {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown() {
+			return false
+		}
+		b.transitionLocked(BreakerHalfOpen)
+		b.trial = true
+		return true
+	case BreakerHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Success records a call that reached the service and closes the circuit.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.firstFailure = time.Time{}
+	b.trial = false
+	if b.currentLocked() != BreakerClosed {
+		b.transitionLocked(BreakerClosed)
+	}
+}
+
+// Failure records a call that could not reach the service. A failed trial
+// reopens the circuit at once.
+func (b *CircuitBreaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	if err != nil {
+		b.lastError = err.Error()
+	}
+	if b.currentLocked() == BreakerHalfOpen {
+		b.trial = false
+		b.openLocked(now)
+		return
+	}
+	if b.failures == 0 || now.Sub(b.firstFailure) > b.window() {
+		b.failures = 0
+		b.firstFailure = now
+	}
+	b.failures++
+	if b.currentLocked() == BreakerClosed && b.failures >= b.threshold() {
+		b.openLocked(now)
+	}
+}
+
+// Snapshot returns the breaker state; an open breaker whose cool-down is over
+// shows as half-open.
+func (b *CircuitBreaker) Snapshot() BreakerSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state := b.currentLocked()
+	if state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cooldown() {
+		state = BreakerHalfOpen
+	}
+	snapshot := BreakerSnapshot{
+		State:               state,
+		ConsecutiveFailures: b.failures,
+		FailureThreshold:    b.threshold(),
+		CooldownSeconds:     b.cooldown().Seconds(),
+		Opens:               b.opens,
+		LastError:           b.lastError,
+	}
+	if !b.openedAt.IsZero() {
+		openedAt := b.openedAt
+		snapshot.OpenedAt = &openedAt
+	}
+	if !b.lastChange.IsZero() {
+		lastChange := b.lastChange
+		snapshot.LastChange = &lastChange
+	}
+	return snapshot
+}
+
+// release ends a trial call without an outcome, so the next call may retry.
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+func (b *CircuitBreaker) openLocked(now time.Time) {
+	b.openedAt = now
+	b.opens++
+	b.transitionLocked(BreakerOpen)
+}
+
+func (b *CircuitBreaker) transitionLocked(state BreakerState) {
+	b.state = state
+	b.lastChange = b.now()
+}
+
+func (b *CircuitBreaker) currentLocked() BreakerState {
+	if b.state == "" {
+		return BreakerClosed
+	}
+	return b.state
+}
+
+func (b *CircuitBreaker) threshold() int {
+	if b.Failures > 0 {
+		return b.Failures
+	}
+	return defaultBreakerFailures
+}
+
+func (b *CircuitBreaker) window() time.Duration {
+	if b.Window > 0 {
+		return b.Window
+	}
+	return defaultBreakerWindow
+}
+
+func (b *CircuitBreaker) cooldown() time.Duration {
+	if b.Cooldown > 0 {
+		return b.Cooldown
+	}
+	return defaultBreakerCooldown
+}
+
+func (b *CircuitBreaker) now() time.Time {
+	if b.Now != nil {
+		return b.Now()
+	}
+	return time.Now()
+}
+
+// BreakerCalculator quotes with Primary behind Breaker and answers from
+// Fallback, tagged SourceFallback, while the circuit is open or when Primary
+// cannot reach its service. Other Primary errors are returned as they are.
+type BreakerCalculator struct {
+	Primary  domainpricing.Calculator
+	Fallback domainpricing.Calculator
+	Breaker  *CircuitBreaker
+	Logger   *slog.Logger
+}
+
+// Quote implements domainpricing.Calculator.
+func (c *BreakerCalculator) Quote(ctx context.Context, input domainpricing.QuoteInput) (domainpricing.PriceBreakdown, error) {
+	if !c.Breaker.Allow() {
+		return c.fallback(ctx, input)
+	}
+	breakdown, err := c.Primary.Quote(ctx, input)
+	switch {
+	case err == nil:
+		c.Breaker.Success()
+		return breakdown, nil
+	case errors.Is(err, ErrMLUnavailable) && ctx.Err() == nil:
+		c.Breaker.Failure(err)
+		if c.Logger != nil {
+			c.Logger.Warn("ml pricing unavailable; quoting with fallback", "listing_id", input.ListingID, "breaker", c.Breaker.Snapshot().State, "error", err)
+		}
+		return c.fallback(ctx, input)
+	default:
+		// Nothing was learned about the service; a trial call is given back.
+		c.Breaker.release()
+		return breakdown, err
+	}
+}
+
+func (c *BreakerCalculator) fallback(ctx context.Context, input domainpricing.QuoteInput) (domainpricing.PriceBreakdown, error) {
+	breakdown, err := c.Fallback.Quote(ctx, input)
+	if err != nil {
+		return breakdown, err
+	}
+	breakdown.Source = domainpricing.SourceFallback
+	return breakdown, nil
+}
+
+var _ domainpricing.Calculator = (*BreakerCalculator)(nil)
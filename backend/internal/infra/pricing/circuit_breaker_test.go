@@ -0,0 +1,167 @@
+package pricing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	domainlistings "rentme/internal/domain/listings"
+	domainpricing "rentme/internal/domain/pricing"
+	"rentme/internal/domain/shared/money"
+)
+
+// flakyML answers 503 while failing is set and a fixed price otherwise.
+type flakyML struct {
+	failing atomic.Bool
+	hits    atomic.Int32
+}
+
+func (s *flakyML) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.hits.Add(1)
+	if s.failing.Load() {
+		http.Error(w, "model reloading", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"recommended_price": 61000}`))
+}
+
+type fixedCalculator struct{ nightly int64 }
+
+func (c fixedCalculator) Quote(context.Context, domainpricing.QuoteInput) (domainpricing.PriceBreakdown, error) {
+	breakdown := domainpricing.PriceBreakdown{Unit: "month", Nights: 1, Nightly: money.Must(c.nightly, "RUB")}
+	return breakdown, breakdown.RecalculateTotal()
+}
+
+type noListings struct {
+	domainlistings.ListingRepository
+}
+
+type breakerClock struct{ now time.Time }
+
+func (c *breakerClock) Now() time.Time { return c.now }
+
+func breakerFixture(t *testing.T) (*flakyML, *breakerClock, *BreakerCalculator) {
+	t.Helper()
+	ml := &flakyML{}
+	server := httptest.NewServer(ml)
+	t.Cleanup(server.Close)
+	clock := &breakerClock{now: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)}
+	calculator := &BreakerCalculator{
+		Primary:  &MLPricingEngine{Client: server.Client(), Endpoint: server.URL, Listings: noListings{}},
+		Fallback: fixedCalculator{nightly: 50000},
+		Breaker:  &CircuitBreaker{Failures: 3, Window: time.Minute, Cooldown: 30 * time.Second, Now: clock.Now},
+	}
+	return ml, clock, calculator
+}
+
+func breakerQuote(t *testing.T, calculator *BreakerCalculator) domainpricing.PriceBreakdown {
+	t.Helper()
+	listing := &domainlistings.Listing{
+		ID:             "listing-1",
+		Address:        domainlistings.Address{City: "Москва"},
+		RentalTermType: domainlistings.RentalTermLong,
+		RateRub:        55000,
+	}
+	breakdown, err := calculator.Quote(context.Background(), domainpricing.QuoteInput{ListingID: listing.ID, Listing: listing, Months: 1})
+	if err != nil {
+		t.Fatalf("quote: %v", err)
+	}
+	return breakdown
+}
+
+func TestBreakerCalculatorOpensHalfOpensAndCloses(t *testing.T) {
+	ml, clock, calculator := breakerFixture(t)
+	ml.failing.Store(true)
+
+	for i := 1; i <= 3; i++ {
+		if got := breakerQuote(t, calculator).Source; got != domainpricing.SourceFallback {
+			t.Fatalf("failing call %d source = %q, want fallback", i, got)
+		}
+	}
+	if state := calculator.Breaker.Snapshot().State; state != BreakerOpen {
+		t.Fatalf("state after 3 failures = %s, want open", state)
+	}
+
+	// Open: answers from the fallback without calling the service.
+	if got := breakerQuote(t, calculator).Source; got != domainpricing.SourceFallback {
+		t.Fatalf("open source = %q, want fallback", got)
+	}
+	if hits := ml.hits.Load(); hits != 3 {
+		t.Fatalf("ml hits while open = %d, want 3", hits)
+	}
+
+	// Half-open: one trial call, which fails and reopens the circuit.
+	clock.now = clock.now.Add(31 * time.Second)
+	if state := calculator.Breaker.Snapshot().State; state != BreakerHalfOpen {
+		t.Fatalf("state after cool-down = %s, want half_open", state)
+	}
+	if got := breakerQuote(t, calculator).Source; got != domainpricing.SourceFallback {
+		t.Fatalf("failed trial source = %q, want fallback", got)
+	}
+	snapshot := calculator.Breaker.Snapshot()
+	if snapshot.State != BreakerOpen || snapshot.Opens != 2 || ml.hits.Load() != 4 {
+		t.Fatalf("after failed trial: state %s, opens %d, hits %d", snapshot.State, snapshot.Opens, ml.hits.Load())
+	}
+
+	// The service recovers: the next trial closes the circuit.
+	ml.failing.Store(false)
+	clock.now = clock.now.Add(31 * time.Second)
+	if got := breakerQuote(t, calculator).Source; got != domainpricing.SourceML {
+		t.Fatalf("successful trial source = %q, want ml", got)
+	}
+	snapshot = calculator.Breaker.Snapshot()
+	if snapshot.State != BreakerClosed || snapshot.ConsecutiveFailures != 0 {
+		t.Fatalf("after successful trial: state %s, failures %d", snapshot.State, snapshot.ConsecutiveFailures)
+	}
+	if got := breakerQuote(t, calculator).Source; got != domainpricing.SourceML || ml.hits.Load() != 6 {
+		t.Fatalf("closed: source %q, hits %d", got, ml.hits.Load())
+	}
+}
+
+func TestCircuitBreakerForgetsFailuresOutsideWindow(t *testing.T) {
+	clock := &breakerClock{now: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := &CircuitBreaker{Failures: 3, Window: time.Minute, Cooldown: 30 * time.Second, Now: clock.Now}
+
+	breaker.Failure(ErrMLUnavailable)
+	breaker.Failure(ErrMLUnavailable)
+	clock.now = clock.now.Add(2 * time.Minute)
+	breaker.Failure(ErrMLUnavailable)
+	if state := breaker.Snapshot().State; state != BreakerClosed {
+		t.Fatalf("state = %s, want closed: the first failures are outside the window", state)
+	}
+	breaker.Failure(ErrMLUnavailable)
+	breaker.Failure(ErrMLUnavailable)
+	if state := breaker.Snapshot().State; state != BreakerOpen {
+		t.Fatalf("state = %s, want open", state)
+	}
+}
+
+func TestCircuitBreakerHalfOpenAllowsOneTrial(t *testing.T) {
+	clock := &breakerClock{now: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := &CircuitBreaker{Failures: 1, Cooldown: 30 * time.Second, Now: clock.Now}
+	breaker.Failure(ErrMLUnavailable)
+	if breaker.Allow() {
+		t.Fatal("open breaker allowed a call")
+	}
+
+	clock.now = clock.now.Add(30 * time.Second)
+	if !breaker.Allow() {
+		t.Fatal("half-open breaker refused the trial call")
+	}
+	if breaker.Allow() {
+		t.Fatal("half-open breaker allowed a second call during the trial")
+	}
+	// A trial that ends without an outcome lets the next caller try.
+	breaker.release()
+	if !breaker.Allow() {
+		t.Fatal("released trial was not given to the next caller")
+	}
+	breaker.Success()
+	if !breaker.Allow() || !breaker.Allow() {
+		t.Fatal("closed breaker refused calls")
+	}
+}
@@ -6,26 +6,17 @@ import (
 	"strings"
 
 	domainlistings "rentme/internal/domain/listings"
+	domainpricing "rentme/internal/domain/pricing"
 )
 
-type ClampRange struct {
-	MinRub int64 `json:"min_rub"`
-	MaxRub int64 `json:"max_rub"`
-}
-
-type ClampConfig struct {
-	Defaults map[domainlistings.RentalTermType]ClampRange                       `json:"defaults"`
-	Cities   map[string]map[domainlistings.RentalTermType]ClampRange            `json:"cities"`
-}
-
-func DefaultClampConfig() ClampConfig {
-	defaults := map[domainlistings.RentalTermType]ClampRange{
+func DefaultClampConfig() domainpricing.ClampTable {
+	defaults := domainpricing.ClampTerms{
 		domainlistings.RentalTermShort: {MinRub: 3_000, MaxRub: 30_000},
 		domainlistings.RentalTermLong:  {MinRub: 25_000, MaxRub: 250_000},
 	}
-	return ClampConfig{
+	return domainpricing.ClampTable{
 		Defaults: defaults,
-		Cities: map[string]map[domainlistings.RentalTermType]ClampRange{
+		Cities: map[string]domainpricing.ClampTerms{
 			"Москва": {
 				domainlistings.RentalTermShort: {MinRub: 3_000, MaxRub: 35_000},
 				domainlistings.RentalTermLong:  {MinRub: 25_000, MaxRub: 300_000},
@@ -38,12 +29,12 @@ func DefaultClampConfig() ClampConfig {
 	}
 }
 
-func LoadClampConfig(raw string, logger *slog.Logger) ClampConfig {
+func LoadClampConfig(raw string, logger *slog.Logger) domainpricing.ClampTable {
 	if strings.TrimSpace(raw) == "" {
 		return DefaultClampConfig()
 	}
 
-	var cfg ClampConfig
+	var cfg domainpricing.ClampTable
 	if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
 		if logger != nil {
 			logger.Warn("invalid ML_PRICE_CLAMPS JSON, using defaults", "error", err)
@@ -54,49 +45,12 @@ func LoadClampConfig(raw string, logger *slog.Logger) ClampConfig {
 	if cfg.Defaults == nil {
 		cfg.Defaults = DefaultClampConfig().Defaults
 	}
-	if cfg.Cities == nil {
-		cfg.Cities = map[string]map[domainlistings.RentalTermType]ClampRange{}
-	}
-
-	normalizedCities := make(map[string]map[domainlistings.RentalTermType]ClampRange, len(cfg.Cities))
-	for city, terms := range cfg.Cities {
-		key := NormalizeCity(city)
-		if key == "" {
-			continue
-		}
-		if terms == nil {
-			continue
-		}
-		normalizedCities[key] = terms
-	}
-	cfg.Cities = normalizedCities
-	return cfg
-}
-
-func NormalizeCity(raw string) string {
-	trimmed := strings.TrimSpace(raw)
-	if trimmed == "" {
-		return ""
-	}
-	switch strings.ToLower(trimmed) {
-	case "moscow", "москва":
-		return "Москва"
-	case "krasnodar", "краснодар":
-		return "Краснодар"
-	default:
-		return trimmed
-	}
+	return cfg.Normalized()
 }
 
-func applyClamps(amount int64, cfg ClampConfig, city string, term domainlistings.RentalTermType) (final int64, min int64, max int64, clamped bool) {
+func applyClamps(amount int64, cfg domainpricing.ClampTable, city string, term domainlistings.RentalTermType) (final int64, min int64, max int64, clamped bool) {
 	final = amount
-	term = normalizeClampTerm(term)
-	city = NormalizeCity(city)
-
-	rng, ok := cfg.Cities[city][term]
-	if !ok {
-		rng, ok = cfg.Defaults[term]
-	}
+	rng, ok := cfg.Range(city, term)
 	if !ok {
 		return final, 0, 0, false
 	}
@@ -114,13 +68,3 @@ func applyClamps(amount int64, cfg ClampConfig, city string, term domainlistings
 	}
 	return final, min, max, clamped
 }
-
-func normalizeClampTerm(term domainlistings.RentalTermType) domainlistings.RentalTermType {
-	switch term {
-	case domainlistings.RentalTermShort, domainlistings.RentalTermLong:
-		return term
-	default:
-		return domainlistings.RentalTermLong
-	}
-}
-
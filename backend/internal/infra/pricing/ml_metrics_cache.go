@@ -0,0 +1,148 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMetricsCacheTTL     = 45 * time.Second
+	defaultMetricsFetchTimeout = 15 * time.Second
+)
+
+var ErrMetricsCacheNotConfigured = errors.New("ml metrics: cache source not configured")
+
+// MetricsFetcher is the upstream the cache reads from; MetricsClient in production.
+type MetricsFetcher interface {
+	Fetch(ctx context.Context) (*MLMetrics, error)
+}
+
+// MetricsSnapshot is a cached metrics response with its freshness. Stale is
+// set when the data is older than the cache TTL, i.e. a refresh is running or
+// the last one failed.
+type MetricsSnapshot struct {
+	MLMetrics
+	FetchedAt  time.Time `json:"fetched_at"`
+	AgeSeconds int64     `json:"age_seconds"`
+	Stale      bool      `json:"stale"`
+}
+
+// MetricsCache keeps the last good ML metrics for TTL and refreshes them in
+// the background once they expire, serving the old snapshot meanwhile. At
+// most one upstream fetch runs at a time; callers without any snapshot wait
+// for it.
+type MetricsCache struct {
+	Source MetricsFetcher
+	TTL    time.Duration
+	// FetchTimeout bounds background refreshes, which outlive the request.
+	FetchTimeout time.Duration
+	Now          func() time.Time
+	Logger       *slog.Logger
+
+	mu        sync.Mutex
+	metrics   *MLMetrics
+	fetchedAt time.Time
+	inflight  *metricsFetch
+}
+
+type metricsFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// Get returns the cached snapshot, fetching it first when nothing was cached
+// yet. Errors are only returned when there is no snapshot to fall back to.
+func (c *MetricsCache) Get(ctx context.Context) (MetricsSnapshot, error) {
+	if c == nil || c.Source == nil {
+		return MetricsSnapshot{}, ErrMetricsCacheNotConfigured
+	}
+	c.mu.Lock()
+	if c.metrics != nil {
+		snapshot := c.snapshotLocked()
+		if snapshot.Stale {
+			c.startLocked()
+		}
+		c.mu.Unlock()
+		return snapshot, nil
+	}
+	fetch := c.startLocked()
+	c.mu.Unlock()
+
+	select {
+	case <-fetch.done:
+	case <-ctx.Done():
+		return MetricsSnapshot{}, ctx.Err()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.metrics == nil {
+		return MetricsSnapshot{}, fetch.err
+	}
+	return c.snapshotLocked(), nil
+}
+
+// startLocked begins an upstream fetch unless one is already in flight.
+func (c *MetricsCache) startLocked() *metricsFetch {
+	if c.inflight != nil {
+		return c.inflight
+	}
+	fetch := &metricsFetch{done: make(chan struct{})}
+	c.inflight = fetch
+	go c.refresh(fetch)
+	return fetch
+}
+
+func (c *MetricsCache) refresh(fetch *metricsFetch) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.fetchTimeout())
+	defer cancel()
+	metrics, err := c.Source.Fetch(ctx)
+
+	c.mu.Lock()
+	if err == nil {
+		c.metrics = metrics
+		c.fetchedAt = c.now()
+	} else if c.metrics != nil && c.Logger != nil {
+		c.Logger.Warn("ml metrics refresh failed, serving stale snapshot", "error", err, "fetched_at", c.fetchedAt)
+	}
+	fetch.err = err
+	c.inflight = nil
+	c.mu.Unlock()
+	close(fetch.done)
+}
+
+func (c *MetricsCache) snapshotLocked() MetricsSnapshot {
+	age := c.now().Sub(c.fetchedAt)
+	if age < 0 {
+		age = 0
+	}
+	return MetricsSnapshot{
+		MLMetrics:  *c.metrics,
+		FetchedAt:  c.fetchedAt,
+		AgeSeconds: int64(age / time.Second),
+		Stale:      age >= c.ttl(),
+	}
+}
+
+func (c *MetricsCache) ttl() time.Duration {
+	if c.TTL <= 0 {
+		return defaultMetricsCacheTTL
+	}
+	return c.TTL
+}
+
+func (c *MetricsCache) fetchTimeout() time.Duration {
+	if c.FetchTimeout <= 0 {
+		return defaultMetricsFetchTimeout
+	}
+	return c.FetchTimeout
+}
+
+func (c *MetricsCache) now() time.Time {
+	if c.Now != nil {
+		return c.Now().UTC()
+	}
+	return time.Now().UTC()
+}
@@ -11,21 +11,37 @@ import (
 	"math"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	domainlistings "rentme/internal/domain/listings"
 	domainpricing "rentme/internal/domain/pricing"
-	domainrange "rentme/internal/domain/shared/daterange"
 	"rentme/internal/domain/shared/money"
+	"rentme/internal/infra/obs"
 )
 
+// defaultClampCacheTTL bounds how long an admin clamp change takes to reach
+// quotes.
+const defaultClampCacheTTL = 30 * time.Second
+
 // MLPricingEngine delegates price suggestions to an external ML service.
+// Recommendations are clamped with the admin-edited ranges from ClampStore
+// laid over Clamps, the configured ranges.
 type MLPricingEngine struct {
-	Client   *http.Client
-	Endpoint string
-	Listings domainlistings.ListingRepository
-	Logger   *slog.Logger
-	Clamps   ClampConfig
+	Client        *http.Client
+	Endpoint      string
+	Listings      domainlistings.ListingRepository
+	Logger        *slog.Logger
+	Clamps        domainpricing.ClampTable
+	ClampStore    domainpricing.ClampStore
+	ClampCacheTTL time.Duration
+
+	clampMu       sync.Mutex
+	clampCached   domainpricing.ClampTable
+	clampLoadedAt time.Time
 }
 
 type mlPredictRequest struct {
@@ -41,6 +57,9 @@ type mlPredictRequest struct {
 	BuildingAgeYears int     `json:"building_age_years"`
 	CurrentPrice     float64 `json:"current_price,omitempty"`
 	RentalTerm       string  `json:"rental_term,omitempty"`
+	// WeekendPremiumPct is informational: the recommendation is the base
+	// rate and the premium is added on top here.
+	WeekendPremiumPct float64 `json:"weekend_premium_pct,omitempty"`
 }
 
 type mlPredictResponse struct {
@@ -52,6 +71,13 @@ type mlPredictResponse struct {
 
 // Quote calls the ML pricing service and maps its response to domain pricing.
 func (e *MLPricingEngine) Quote(ctx context.Context, input domainpricing.QuoteInput) (domainpricing.PriceBreakdown, error) {
+	ctx, span := obs.StartSpan(ctx, "pricing.ml.quote", trace.WithAttributes(attribute.String("listing.id", string(input.ListingID))))
+	breakdown, err := e.quote(ctx, input)
+	obs.EndSpan(span, err)
+	return breakdown, err
+}
+
+func (e *MLPricingEngine) quote(ctx context.Context, input domainpricing.QuoteInput) (domainpricing.PriceBreakdown, error) {
 	var zero domainpricing.PriceBreakdown
 
 	if e == nil || e.Client == nil {
@@ -95,65 +121,49 @@ func (e *MLPricingEngine) Quote(ctx context.Context, input domainpricing.QuoteIn
 		travelMode = "transit"
 	}
 	reqPayload := mlPredictRequest{
-		ListingID:        string(listing.ID),
-		City:             NormalizeCity(listing.Address.City),
-		Minutes:          travelMinutes,
-		Way:              travelMode,
-		Rooms:            listing.Bedrooms,
-		TotalArea:        listing.AreaSquareMeters,
-		Storey:           listing.Floor,
-		Storeys:          listing.FloorsTotal,
-		Renovation:       listing.RenovationScore,
-		BuildingAgeYears: listing.BuildingAgeYears,
-		CurrentPrice:     float64(listing.RateRub),
-		RentalTerm:       string(rentalTerm),
-	}
-
-	body, err := json.Marshal(reqPayload)
-	if err != nil {
-		return zero, err
-	}
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodPost, e.Endpoint, bytes.NewReader(body))
-	if err != nil {
-		return zero, err
+		ListingID:         string(listing.ID),
+		City:              domainpricing.NormalizeCity(listing.Address.City),
+		Minutes:           travelMinutes,
+		Way:               travelMode,
+		Rooms:             listing.Bedrooms,
+		TotalArea:         listing.AreaSquareMeters,
+		Storey:            listing.Floor,
+		Storeys:           listing.FloorsTotal,
+		Renovation:        listing.RenovationScore,
+		BuildingAgeYears:  listing.BuildingAgeYears,
+		CurrentPrice:      float64(listing.RateRub),
+		RentalTerm:        string(rentalTerm),
+		WeekendPremiumPct: listing.WeekendPremiumPct,
 	}
-	request.Header.Set("Content-Type", "application/json")
 
-	resp, err := e.Client.Do(request)
+	mlResp, err := e.predict(ctx, reqPayload)
 	if err != nil {
 		e.logError("ml pricing request failed", listing.ID, err)
-		return zero, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= http.StatusBadRequest {
-		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-		err := fmt.Errorf("ml pricing returned status %d: %s", resp.StatusCode, string(snippet))
-		e.logError("ml pricing returned error", listing.ID, err)
-		return zero, err
-	}
-
-	var mlResp mlPredictResponse
-	if err := json.NewDecoder(resp.Body).Decode(&mlResp); err != nil {
-		e.logError("ml pricing decode failed", listing.ID, err)
-		return zero, err
+		return zero, fmt.Errorf("%w: %v", ErrMLUnavailable, err)
 	}
 
 	cityRaw := listing.Address.City
-	cityNormalized := NormalizeCity(cityRaw)
+	cityNormalized := domainpricing.NormalizeCity(cityRaw)
 	recommendedRaw := int64(math.Round(mlResp.RecommendedPrice))
-	recommendedFinal, clampMin, clampMax, clamped := applyClamps(recommendedRaw, e.clamps(), cityNormalized, rentalTerm)
-	nights := nightsBetween(input.Range)
-	if nights < 1 {
-		nights = 1
-	}
+	recommendedFinal, clampMin, clampMax, clamped := applyClamps(recommendedRaw, e.clamps(ctx), cityNormalized, rentalTerm)
+	// The service prices the unit the listing is rented by, so a long-term
+	// recommendation is a monthly rent and is multiplied by months.
+	input.RentalTerm = rentalTerm
+	unit, units := input.Units()
 
 	breakdown := domainpricing.PriceBreakdown{
-		Nights:  nights,
+		Unit:    unit,
+		Nights:  units,
 		Nightly: money.Must(recommendedFinal, "RUB"),
+		Source:  domainpricing.SourceML,
+	}
+	if err := breakdown.ApplyWeekendPremium(listing, input.Range); err != nil {
+		return zero, err
 	}
-	if err := breakdown.RecalculateTotal(); err != nil {
+	if err := breakdown.ApplyLongStayDiscount(listing); err != nil {
+		return zero, err
+	}
+	if err := breakdown.ApplyListingFees(listing); err != nil {
 		return zero, err
 	}
 
@@ -174,6 +184,69 @@ func (e *MLPricingEngine) Quote(ctx context.Context, input domainpricing.QuoteIn
 	return breakdown, nil
 }
 
+// probeRequest is a plausible listing the service must be able to price; it
+// is not tied to any stored listing.
+var probeRequest = mlPredictRequest{
+	City:             "Москва",
+	Minutes:          20,
+	Way:              "transit",
+	Rooms:            2,
+	TotalArea:        54,
+	Storey:           5,
+	Storeys:          12,
+	Renovation:       3,
+	BuildingAgeYears: 15,
+	RentalTerm:       string(domainlistings.RentalTermLong),
+}
+
+// Probe sends a synthetic prediction request and checks the service answers
+// with a usable price.
+func (e *MLPricingEngine) Probe(ctx context.Context) error {
+	if e == nil || e.Client == nil {
+		return errors.New("pricing: http client not configured")
+	}
+	if e.Endpoint == "" {
+		return errors.New("pricing: ml endpoint not configured")
+	}
+	resp, err := e.predict(ctx, probeRequest)
+	if err != nil {
+		return err
+	}
+	if resp.RecommendedPrice <= 0 || math.IsNaN(resp.RecommendedPrice) {
+		return fmt.Errorf("ml pricing returned unusable price %v", resp.RecommendedPrice)
+	}
+	return nil
+}
+
+// predict posts one request to the ML service and decodes its answer.
+func (e *MLPricingEngine) predict(ctx context.Context, payload mlPredictRequest) (mlPredictResponse, error) {
+	var out mlPredictResponse
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return out, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, e.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return out, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.Client.Do(request)
+	if err != nil {
+		return out, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return out, fmt.Errorf("ml pricing returned status %d: %s", resp.StatusCode, string(snippet))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return out, fmt.Errorf("ml pricing decode: %w", err)
+	}
+	return out, nil
+}
+
 func (e *MLPricingEngine) logError(msg string, listingID domainlistings.ListingID, err error) {
 	if e.Logger == nil {
 		return
@@ -181,21 +254,40 @@ func (e *MLPricingEngine) logError(msg string, listingID domainlistings.ListingI
 	e.Logger.Error(msg, "listing_id", listingID, "error", err)
 }
 
-func nightsBetween(dr domainrange.DateRange) int {
-	if dr.CheckIn.IsZero() || dr.CheckOut.IsZero() {
-		return 0
-	}
-	return int(dr.CheckOut.Sub(dr.CheckIn) / (24 * time.Hour))
-}
-
 var _ domainpricing.Calculator = (*MLPricingEngine)(nil)
 
-func (e *MLPricingEngine) clamps() ClampConfig {
+func (e *MLPricingEngine) clamps(ctx context.Context) domainpricing.ClampTable {
 	if e == nil {
 		return DefaultClampConfig()
 	}
-	if e.Clamps.Defaults == nil && e.Clamps.Cities == nil {
-		return DefaultClampConfig()
+	configured := e.Clamps
+	if configured.Empty() {
+		configured = DefaultClampConfig()
+	}
+	if e.ClampStore == nil {
+		return configured
+	}
+
+	e.clampMu.Lock()
+	defer e.clampMu.Unlock()
+	ttl := e.ClampCacheTTL
+	if ttl <= 0 {
+		ttl = defaultClampCacheTTL
+	}
+	if !e.clampLoadedAt.IsZero() && time.Since(e.clampLoadedAt) < ttl {
+		return e.clampCached
+	}
+	stored, err := e.ClampStore.Table(ctx)
+	if err != nil {
+		if e.Logger != nil {
+			e.Logger.Warn("ml price clamps load failed; using last known clamps", "error", err)
+		}
+		if e.clampLoadedAt.IsZero() {
+			return configured
+		}
+		return e.clampCached
 	}
-	return e.Clamps
+	e.clampCached = stored.Over(configured)
+	e.clampLoadedAt = time.Now()
+	return e.clampCached
 }
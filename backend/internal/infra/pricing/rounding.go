@@ -0,0 +1,41 @@
+package pricing
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	domainpricing "rentme/internal/domain/pricing"
+)
+
+// LoadRoundingPolicy parses PRICE_ROUNDING JSON on top of the defaults, e.g.
+// {"currencies":{"EUR":{"scale":100,"step":1}},"markets":{"Москва":{"RUB":{"monthly_display_step":1000}}}}.
+func LoadRoundingPolicy(raw string, logger *slog.Logger) domainpricing.RoundingPolicy {
+	policy := domainpricing.DefaultRoundingPolicy()
+	if strings.TrimSpace(raw) == "" {
+		return policy
+	}
+	var parsed domainpricing.RoundingPolicy
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		if logger != nil {
+			logger.Warn("invalid PRICE_ROUNDING JSON, using defaults", "error", err)
+		}
+		return policy
+	}
+	for currency, rule := range parsed.Currencies {
+		policy.Currencies[strings.ToUpper(strings.TrimSpace(currency))] = rule
+	}
+	policy.Markets = make(map[string]map[string]domainpricing.RoundingRule, len(parsed.Markets))
+	for market, rules := range parsed.Markets {
+		market = strings.TrimSpace(market)
+		if market == "" || rules == nil {
+			continue
+		}
+		normalized := make(map[string]domainpricing.RoundingRule, len(rules))
+		for currency, rule := range rules {
+			normalized[strings.ToUpper(strings.TrimSpace(currency))] = rule
+		}
+		policy.Markets[market] = normalized
+	}
+	return policy
+}
@@ -0,0 +1,26 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// URLSigner issues HMAC-SHA256 tokens for capability URLs such as calendar
+// feeds, so the URL alone grants access but cannot be guessed for other ids.
+type URLSigner struct {
+	Key []byte
+}
+
+func (s URLSigner) Sign(value string) string {
+	mac := hmac.New(sha256.New, s.Key)
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s URLSigner) Verify(value, token string) bool {
+	if len(s.Key) == 0 || token == "" {
+		return false
+	}
+	return hmac.Equal([]byte(s.Sign(value)), []byte(token))
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"maps"
+	"slices"
+
+	domainavailability "rentme/internal/domain/availability"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/domain/shared/events"
+)
+
+// The repositories below hand out and keep copies, like a database would, so
+// an aggregate changed by one caller is invisible to others until Save and a
+// stale copy fails the version check. Copies never carry pending events.
+
+func cloneListing(l *domainlistings.Listing) *domainlistings.Listing {
+	c := *l
+	c.EventRecorder = events.EventRecorder{}
+	c.CoHosts = slices.Clone(l.CoHosts)
+	c.Amenities = slices.Clone(l.Amenities)
+	c.HouseRules = slices.Clone(l.HouseRules)
+	c.Tags = slices.Clone(l.Tags)
+	c.Highlights = slices.Clone(l.Highlights)
+	c.Photos = slices.Clone(l.Photos)
+	c.PhotoTags = maps.Clone(l.PhotoTags)
+	c.Changelog = slices.Clone(l.Changelog)
+	c.PriceHistory = slices.Clone(l.PriceHistory)
+	return &c
+}
+
+func cloneListings(items []*domainlistings.Listing) []*domainlistings.Listing {
+	result := make([]*domainlistings.Listing, len(items))
+	for i, item := range items {
+		result[i] = cloneListing(item)
+	}
+	return result
+}
+
+func cloneBooking(b *domainbooking.Booking) *domainbooking.Booking {
+	c := *b
+	c.EventRecorder = events.EventRecorder{}
+	c.Price.Fees = slices.Clone(b.Price.Fees)
+	c.Price.Taxes = slices.Clone(b.Price.Taxes)
+	c.Price.Discounts = slices.Clone(b.Price.Discounts)
+	c.Price.Adjustments = slices.Clone(b.Price.Adjustments)
+	return &c
+}
+
+func cloneBookings(items []*domainbooking.Booking) []*domainbooking.Booking {
+	result := make([]*domainbooking.Booking, len(items))
+	for i, item := range items {
+		result[i] = cloneBooking(item)
+	}
+	return result
+}
+
+func cloneCalendar(cal *domainavailability.AvailabilityCalendar) *domainavailability.AvailabilityCalendar {
+	c := *cal
+	c.EventRecorder = events.EventRecorder{}
+	c.Blocks = slices.Clone(cal.Blocks)
+	return &c
+}
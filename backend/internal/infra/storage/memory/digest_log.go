@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"rentme/internal/app/services/digest"
+)
+
+// DigestLog records sent host digests per period in memory.
+type DigestLog struct {
+	mu   sync.Mutex
+	sent map[string]time.Time
+}
+
+func NewDigestLog() *DigestLog {
+	return &DigestLog{sent: make(map[string]time.Time)}
+}
+
+func (l *DigestLog) Claim(ctx context.Context, hostID, period string, at time.Time) (bool, error) {
+	key := digestLogKey(hostID, period)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.sent[key]; ok {
+		return false, nil
+	}
+	l.sent[key] = at.UTC()
+	return true, nil
+}
+
+func (l *DigestLog) Release(ctx context.Context, hostID, period string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.sent, digestLogKey(hostID, period))
+	return nil
+}
+
+func digestLogKey(hostID, period string) string {
+	return period + "|" + hostID
+}
+
+var _ digest.SendLog = (*DigestLog)(nil)
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	domainguestblock "rentme/internal/domain/guestblock"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+type guestBlockKey struct {
+	host  domainlistings.HostID
+	guest string
+}
+
+// GuestBlockRepository keeps host guest blocks in memory.
+type GuestBlockRepository struct {
+	mu    sync.RWMutex
+	items map[guestBlockKey]domainguestblock.Block
+}
+
+// NewGuestBlockRepository builds an empty block store.
+func NewGuestBlockRepository() *GuestBlockRepository {
+	return &GuestBlockRepository{items: make(map[guestBlockKey]domainguestblock.Block)}
+}
+
+func (r *GuestBlockRepository) Get(ctx context.Context, hostID domainlistings.HostID, guestID string) (*domainguestblock.Block, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	block, ok := r.items[guestBlockKey{host: hostID, guest: strings.TrimSpace(guestID)}]
+	if !ok {
+		return nil, domainguestblock.ErrNotFound
+	}
+	return &block, nil
+}
+
+// ByHost lists the guests the host blocked, most recent first.
+func (r *GuestBlockRepository) ByHost(ctx context.Context, hostID domainlistings.HostID) ([]*domainguestblock.Block, error) {
+	return r.filter(func(b domainguestblock.Block) bool { return b.HostID == hostID }), nil
+}
+
+// ByGuest lists the hosts that blocked the guest, most recent first.
+func (r *GuestBlockRepository) ByGuest(ctx context.Context, guestID string) ([]*domainguestblock.Block, error) {
+	guestID = strings.TrimSpace(guestID)
+	return r.filter(func(b domainguestblock.Block) bool { return b.GuestID == guestID }), nil
+}
+
+// Save stores the block, keeping the original creation time when the host
+// only updates the reason.
+func (r *GuestBlockRepository) Save(ctx context.Context, block *domainguestblock.Block) error {
+	if block == nil || strings.TrimSpace(string(block.HostID)) == "" {
+		return domainguestblock.ErrHostRequired
+	}
+	if strings.TrimSpace(block.GuestID) == "" {
+		return domainguestblock.ErrGuestRequired
+	}
+	key := guestBlockKey{host: block.HostID, guest: block.GuestID}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *block
+	if existing, ok := r.items[key]; ok {
+		stored.CreatedAt = existing.CreatedAt
+		block.CreatedAt = existing.CreatedAt
+	}
+	r.items[key] = stored
+	return nil
+}
+
+func (r *GuestBlockRepository) Delete(ctx context.Context, hostID domainlistings.HostID, guestID string) error {
+	key := guestBlockKey{host: hostID, guest: strings.TrimSpace(guestID)}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.items[key]; !ok {
+		return domainguestblock.ErrNotFound
+	}
+	delete(r.items, key)
+	return nil
+}
+
+func (r *GuestBlockRepository) filter(keep func(domainguestblock.Block) bool) []*domainguestblock.Block {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*domainguestblock.Block, 0)
+	for _, block := range r.items {
+		if !keep(block) {
+			continue
+		}
+		item := block
+		result = append(result, &item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result
+}
+
+var _ domainguestblock.Repository = (*GuestBlockRepository)(nil)
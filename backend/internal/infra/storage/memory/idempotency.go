@@ -3,32 +3,92 @@ package memory
 import (
 	"context"
 	"sync"
+	"time"
 
 	"rentme/internal/app/middleware"
 )
 
-// IdempotencyStore stores results in memory.
+// IdempotencyStore stores results in memory for a limited time.
 type IdempotencyStore struct {
 	mu    sync.RWMutex
 	items map[string]middleware.IdempotencyRecord
+	ttl   time.Duration
+	now   func() time.Time
+	swept time.Time
 }
 
-func NewIdempotencyStore() *IdempotencyStore {
-	return &IdempotencyStore{items: make(map[string]middleware.IdempotencyRecord)}
+// idempotencySweepEvery bounds how often Save scans the whole map for expired records.
+const idempotencySweepEvery = time.Minute
+
+// NewIdempotencyStore keeps records for ttl; a non-positive ttl keeps them
+// forever. A nil clock defaults to time.Now.
+func NewIdempotencyStore(ttl time.Duration, now func() time.Time) *IdempotencyStore {
+	if now == nil {
+		now = time.Now
+	}
+	return &IdempotencyStore{
+		items: make(map[string]middleware.IdempotencyRecord),
+		ttl:   ttl,
+		now:   now,
+	}
 }
 
 func (s *IdempotencyStore) Get(ctx context.Context, key string) (middleware.IdempotencyRecord, bool, error) {
+	now := s.now()
 	s.mu.RLock()
-	defer s.mu.RUnlock()
 	rec, ok := s.items[key]
-	return rec, ok, nil
+	s.mu.RUnlock()
+	if !ok {
+		return middleware.IdempotencyRecord{}, false, nil
+	}
+	if rec.Expired(now) {
+		s.mu.Lock()
+		if current, ok := s.items[key]; ok && current.Expired(now) {
+			delete(s.items, key)
+		}
+		s.mu.Unlock()
+		return middleware.IdempotencyRecord{}, false, nil
+	}
+	return rec, true, nil
 }
 
 func (s *IdempotencyStore) Save(ctx context.Context, rec middleware.IdempotencyRecord) error {
+	now := s.now()
+	if rec.ExpiresAt.IsZero() && s.ttl > 0 {
+		rec.ExpiresAt = now.Add(s.ttl)
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if now.Sub(s.swept) >= idempotencySweepEvery {
+		s.evictLocked(now, time.Time{})
+	}
 	s.items[rec.Key] = rec
 	return nil
 }
 
+// Cleanup removes expired records and, when maxAge is positive, records that
+// occurred more than maxAge ago. It returns how many records were removed.
+func (s *IdempotencyStore) Cleanup(ctx context.Context, maxAge time.Duration) int {
+	now := s.now()
+	var cutoff time.Time
+	if maxAge > 0 {
+		cutoff = now.Add(-maxAge)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.evictLocked(now, cutoff)
+}
+
+func (s *IdempotencyStore) evictLocked(now, occurredBefore time.Time) int {
+	removed := 0
+	for key, existing := range s.items {
+		if existing.Expired(now) || (!occurredBefore.IsZero() && existing.OccurredAt.Before(occurredBefore)) {
+			delete(s.items, key)
+			removed++
+		}
+	}
+	s.swept = now
+	return removed
+}
+
 var _ middleware.IdempotencyStore = (*IdempotencyStore)(nil)
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	domaininbox "rentme/internal/domain/inbox"
+	domainlistings "rentme/internal/domain/listings"
+)
+
+// InboxAssignmentRepository keeps conversation assignments in memory.
+type InboxAssignmentRepository struct {
+	mu    sync.RWMutex
+	items map[string]domaininbox.Assignment
+}
+
+// NewInboxAssignmentRepository builds an empty assignment store.
+func NewInboxAssignmentRepository() *InboxAssignmentRepository {
+	return &InboxAssignmentRepository{items: make(map[string]domaininbox.Assignment)}
+}
+
+// ByConversation returns the current assignment or nil when the conversation is unassigned.
+func (r *InboxAssignmentRepository) ByConversation(ctx context.Context, conversationID string) (*domaininbox.Assignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	assignment, ok := r.items[strings.TrimSpace(conversationID)]
+	if !ok {
+		return nil, nil
+	}
+	return &assignment, nil
+}
+
+// ByAssignee lists the conversations assigned to the user, most recent first.
+func (r *InboxAssignmentRepository) ByAssignee(ctx context.Context, assigneeID domainlistings.HostID) ([]*domaininbox.Assignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*domaininbox.Assignment, 0)
+	for _, assignment := range r.items {
+		if assignment.AssigneeID != assigneeID {
+			continue
+		}
+		item := assignment
+		result = append(result, &item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].AssignedAt.Equal(result[j].AssignedAt) {
+			return result[i].AssignedAt.After(result[j].AssignedAt)
+		}
+		return result[i].ConversationID < result[j].ConversationID
+	})
+	return result, nil
+}
+
+// Save replaces the assignment of the conversation.
+func (r *InboxAssignmentRepository) Save(ctx context.Context, assignment *domaininbox.Assignment) error {
+	if assignment == nil || strings.TrimSpace(assignment.ConversationID) == "" {
+		return domaininbox.ErrConversationRequired
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items[assignment.ConversationID] = *assignment
+	return nil
+}
+
+// Delete clears the assignment of the conversation.
+func (r *InboxAssignmentRepository) Delete(ctx context.Context, conversationID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.items, strings.TrimSpace(conversationID))
+	return nil
+}
+
+// InboxNoteRepository keeps internal conversation notes in memory.
+type InboxNoteRepository struct {
+	mu    sync.RWMutex
+	items map[string][]domaininbox.Note
+}
+
+// NewInboxNoteRepository builds an empty note store.
+func NewInboxNoteRepository() *InboxNoteRepository {
+	return &InboxNoteRepository{items: make(map[string][]domaininbox.Note)}
+}
+
+// ListByConversation returns notes in the order they were written.
+func (r *InboxNoteRepository) ListByConversation(ctx context.Context, conversationID string) ([]*domaininbox.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	notes := r.items[strings.TrimSpace(conversationID)]
+	result := make([]*domaininbox.Note, 0, len(notes))
+	for i := range notes {
+		note := notes[i]
+		result = append(result, &note)
+	}
+	return result, nil
+}
+
+// Append stores a new note.
+func (r *InboxNoteRepository) Append(ctx context.Context, note *domaininbox.Note) error {
+	if note == nil || strings.TrimSpace(note.ConversationID) == "" {
+		return domaininbox.ErrConversationRequired
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items[note.ConversationID] = append(r.items[note.ConversationID], *note)
+	return nil
+}
+
+var (
+	_ domaininbox.AssignmentRepository = (*InboxAssignmentRepository)(nil)
+	_ domaininbox.NoteRepository       = (*InboxNoteRepository)(nil)
+)
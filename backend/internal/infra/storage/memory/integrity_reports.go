@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"rentme/internal/app/dto"
+)
+
+const maxIntegrityReports = 50
+
+// IntegrityReportStore keeps the most recent booking integrity reports in memory.
+type IntegrityReportStore struct {
+	mu      sync.RWMutex
+	reports []dto.BookingIntegrityReport
+}
+
+func NewIntegrityReportStore() *IntegrityReportStore {
+	return &IntegrityReportStore{}
+}
+
+// Save prepends the report and drops the oldest ones beyond the retention limit.
+func (s *IntegrityReportStore) Save(ctx context.Context, report dto.BookingIntegrityReport) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports = append([]dto.BookingIntegrityReport{report}, s.reports...)
+	if len(s.reports) > maxIntegrityReports {
+		s.reports = s.reports[:maxIntegrityReports]
+	}
+	return nil
+}
+
+// List returns reports newest first, optionally restricted to a listing.
+func (s *IntegrityReportStore) List(ctx context.Context, listingID string, limit int) ([]dto.BookingIntegrityReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]dto.BookingIntegrityReport, 0)
+	for _, report := range s.reports {
+		if listingID != "" && !reportCoversListing(report, listingID) {
+			continue
+		}
+		result = append(result, report)
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func reportCoversListing(report dto.BookingIntegrityReport, listingID string) bool {
+	if report.ListingID == listingID {
+		return true
+	}
+	for _, finding := range report.Findings {
+		if finding.ListingID == listingID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"rentme/internal/app/jobs"
+)
+
+const maxJobRuns = 200
+
+// JobStore keeps the job queue and the most recent runs in process memory.
+type JobStore struct {
+	mu    sync.Mutex
+	tasks map[string]jobs.Task
+	runs  []jobs.Run
+}
+
+func NewJobStore() *JobStore {
+	return &JobStore{tasks: make(map[string]jobs.Task)}
+}
+
+func (s *JobStore) Enqueue(ctx context.Context, task jobs.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.tasks[task.ID]; exists {
+		return nil
+	}
+	s.tasks[task.ID] = task
+	return nil
+}
+
+// Lease hands out due tasks ordered by run time.
+func (s *JobStore) Lease(ctx context.Context, owner string, now time.Time, ttl time.Duration, limit int) ([]jobs.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	due := make([]jobs.Task, 0)
+	for _, task := range s.tasks {
+		if task.RunAt.After(now) || task.LeaseUntil.After(now) {
+			continue
+		}
+		due = append(due, task)
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].RunAt.Before(due[j].RunAt) })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	for i := range due {
+		due[i].LeaseOwner = owner
+		due[i].LeaseUntil = now.Add(ttl)
+		s.tasks[due[i].ID] = due[i]
+	}
+	return due, nil
+}
+
+func (s *JobStore) Reschedule(ctx context.Context, id, owner string, runAt time.Time, attempts int, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.tasks[id]
+	if !ok || task.LeaseOwner != owner {
+		return nil
+	}
+	if runAt.IsZero() {
+		delete(s.tasks, id)
+		return nil
+	}
+	task.RunAt = runAt
+	task.Attempts = attempts
+	task.LastError = lastError
+	task.LeaseOwner = ""
+	task.LeaseUntil = time.Time{}
+	s.tasks[id] = task
+	return nil
+}
+
+// RecordRun prepends the run and drops the oldest ones beyond the retention limit.
+func (s *JobStore) RecordRun(ctx context.Context, run jobs.Run) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs = append([]jobs.Run{run}, s.runs...)
+	if len(s.runs) > maxJobRuns {
+		s.runs = s.runs[:maxJobRuns]
+	}
+	return nil
+}
+
+// ListRuns returns runs newest first, optionally restricted to a job.
+func (s *JobStore) ListRuns(ctx context.Context, job string, limit int) ([]jobs.Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]jobs.Run, 0)
+	for _, run := range s.runs {
+		if job != "" && run.Job != job {
+			continue
+		}
+		result = append(result, run)
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+var _ jobs.Store = (*JobStore)(nil)
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	domainmarkets "rentme/internal/domain/markets"
+)
+
+// MarketRepository keeps the supported markets in memory.
+type MarketRepository struct {
+	mu       sync.RWMutex
+	settings domainmarkets.Settings
+}
+
+// NewMarketRepository seeds the store with the configured markets.
+func NewMarketRepository(initial domainmarkets.Settings) *MarketRepository {
+	return &MarketRepository{settings: cloneMarketSettings(initial)}
+}
+
+func (r *MarketRepository) Current(ctx context.Context) (domainmarkets.Settings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return cloneMarketSettings(r.settings), nil
+}
+
+func (r *MarketRepository) Save(ctx context.Context, settings domainmarkets.Settings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.settings = cloneMarketSettings(settings)
+	return nil
+}
+
+func cloneMarketSettings(settings domainmarkets.Settings) domainmarkets.Settings {
+	settings.Cities = append([]string(nil), settings.Cities...)
+	return settings
+}
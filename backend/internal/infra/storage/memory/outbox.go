@@ -3,32 +3,162 @@ package memory
 import (
 	"context"
 	"sync"
+	"time"
 
 	appoutbox "rentme/internal/app/outbox"
 )
 
-// Outbox is a no-op implementation that merely keeps events in memory until flushed.
+type outboxState int
+
+const (
+	outboxPending outboxState = iota
+	outboxClaimed
+	outboxSent
+	// outboxStaged records belong to a batch whose command has not committed.
+	outboxStaged
+)
+
+type outboxEntry struct {
+	record      appoutbox.EventRecord
+	batch       string
+	state       outboxState
+	attempts    int
+	nextAttempt time.Time
+	lastError   string
+}
+
+// Outbox keeps events in memory. Records added within a batch stay hidden
+// from the dispatcher until the batch is flushed and are dropped when it is
+// discarded. Without a dispatcher flushed records are dropped; once
+// EnableDispatch is called they stay until they are sent.
 type Outbox struct {
-	mu      sync.Mutex
-	records []appoutbox.EventRecord
+	mu       sync.Mutex
+	records  []*outboxEntry
+	dispatch bool
 }
 
 func NewOutbox() *Outbox {
 	return &Outbox{}
 }
 
+// EnableDispatch keeps unsent records across Flush so a dispatcher can publish them.
+func (o *Outbox) EnableDispatch() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.dispatch = true
+}
+
 func (o *Outbox) Add(ctx context.Context, record appoutbox.EventRecord) error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
-	o.records = append(o.records, record)
+	entry := &outboxEntry{record: record, state: outboxPending}
+	if batch, ok := appoutbox.BatchFromContext(ctx); ok {
+		entry.batch = batch
+		entry.state = outboxStaged
+	}
+	o.records = append(o.records, entry)
 	return nil
 }
 
+// Flush releases the batch in ctx to the dispatcher. Without a dispatcher
+// nothing publishes records, so every released record is dropped.
 func (o *Outbox) Flush(ctx context.Context) error {
 	o.mu.Lock()
 	defer o.mu.Unlock()
-	o.records = nil
+	batch, _ := appoutbox.BatchFromContext(ctx)
+	for _, entry := range o.records {
+		if entry.state == outboxStaged && entry.batch == batch {
+			entry.state = outboxPending
+		}
+		if !o.dispatch && entry.state == outboxPending {
+			entry.state = outboxSent
+		}
+	}
+	o.compactLocked()
+	return nil
+}
+
+// Discard drops the records of the batch in ctx.
+func (o *Outbox) Discard(ctx context.Context) error {
+	batch, ok := appoutbox.BatchFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, entry := range o.records {
+		if entry.state == outboxStaged && entry.batch == batch {
+			entry.state = outboxSent
+		}
+	}
+	o.compactLocked()
+	return nil
+}
+
+// FetchPending claims up to limit records that are due at now, oldest first.
+func (o *Outbox) FetchPending(ctx context.Context, limit int, now time.Time) ([]appoutbox.PendingRecord, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var out []appoutbox.PendingRecord
+	for _, entry := range o.records {
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+		if entry.state != outboxPending || entry.nextAttempt.After(now) {
+			continue
+		}
+		entry.state = outboxClaimed
+		out = append(out, appoutbox.PendingRecord{EventRecord: entry.record, Attempts: entry.attempts})
+	}
+	return out, nil
+}
+
+func (o *Outbox) MarkSent(ctx context.Context, id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if entry := o.findLocked(id); entry != nil {
+		entry.state = outboxSent
+	}
+	o.compactLocked()
 	return nil
 }
 
-var _ appoutbox.Outbox = (*Outbox)(nil)
+func (o *Outbox) MarkFailed(ctx context.Context, id string, next time.Time, errMsg string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if entry := o.findLocked(id); entry != nil {
+		entry.state = outboxPending
+		entry.attempts++
+		entry.nextAttempt = next
+		entry.lastError = errMsg
+	}
+	return nil
+}
+
+func (o *Outbox) findLocked(id string) *outboxEntry {
+	for _, entry := range o.records {
+		if entry.record.ID == id {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (o *Outbox) compactLocked() {
+	kept := o.records[:0]
+	for _, entry := range o.records {
+		if entry.state != outboxSent {
+			kept = append(kept, entry)
+		}
+	}
+	for i := len(kept); i < len(o.records); i++ {
+		o.records[i] = nil
+	}
+	o.records = kept
+}
+
+var (
+	_ appoutbox.Outbox       = (*Outbox)(nil)
+	_ appoutbox.Discarder    = (*Outbox)(nil)
+	_ appoutbox.Dispatchable = (*Outbox)(nil)
+)
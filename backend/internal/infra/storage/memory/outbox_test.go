@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appoutbox "rentme/internal/app/outbox"
+)
+
+func TestOutboxHidesBatchUntilFlush(t *testing.T) {
+	box := NewOutbox()
+	box.EnableDispatch()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	committed := appoutbox.WithBatch(context.Background(), "batch-1")
+	failed := appoutbox.WithBatch(context.Background(), "batch-2")
+
+	for ctx, id := range map[context.Context]string{committed: "evt-1", failed: "evt-2"} {
+		if err := box.Add(ctx, appoutbox.EventRecord{ID: id}); err != nil {
+			t.Fatalf("add %s: %v", id, err)
+		}
+	}
+	if pending, _ := box.FetchPending(context.Background(), 10, now); len(pending) != 0 {
+		t.Fatalf("staged records were fetched: %+v", pending)
+	}
+
+	if err := box.Discard(failed); err != nil {
+		t.Fatalf("discard: %v", err)
+	}
+	if err := box.Flush(committed); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	pending, err := box.FetchPending(context.Background(), 10, now)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != "evt-1" {
+		t.Fatalf("pending = %+v, want only the flushed evt-1", pending)
+	}
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	listingapp "rentme/internal/app/handlers/listings"
+)
+
+// PhotoUploadStore keeps resumable photo upload sessions in memory.
+type PhotoUploadStore struct {
+	mu      sync.Mutex
+	uploads map[string]listingapp.PhotoUpload
+}
+
+func NewPhotoUploadStore() *PhotoUploadStore {
+	return &PhotoUploadStore{uploads: make(map[string]listingapp.PhotoUpload)}
+}
+
+func (s *PhotoUploadStore) Create(ctx context.Context, upload listingapp.PhotoUpload) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uploads[upload.ID] = copyPhotoUpload(upload)
+	return nil
+}
+
+func (s *PhotoUploadStore) Get(ctx context.Context, id string) (listingapp.PhotoUpload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	upload, ok := s.uploads[id]
+	if !ok {
+		return listingapp.PhotoUpload{}, listingapp.ErrPhotoUploadNotFound
+	}
+	return copyPhotoUpload(upload), nil
+}
+
+func (s *PhotoUploadStore) SetPart(ctx context.Context, id string, n int, size int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	upload, ok := s.uploads[id]
+	if !ok {
+		return listingapp.ErrPhotoUploadNotFound
+	}
+	upload.Parts[n] = size
+	return nil
+}
+
+func (s *PhotoUploadStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.uploads, id)
+	return nil
+}
+
+func (s *PhotoUploadStore) Expired(ctx context.Context, now time.Time, limit int) ([]listingapp.PhotoUpload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var expired []listingapp.PhotoUpload
+	for _, upload := range s.uploads {
+		if upload.ExpiresAt.Before(now) {
+			expired = append(expired, copyPhotoUpload(upload))
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].ExpiresAt.Before(expired[j].ExpiresAt) })
+	if limit > 0 && len(expired) > limit {
+		expired = expired[:limit]
+	}
+	return expired, nil
+}
+
+func copyPhotoUpload(upload listingapp.PhotoUpload) listingapp.PhotoUpload {
+	upload.Parts = maps.Clone(upload.Parts)
+	if upload.Parts == nil {
+		upload.Parts = map[int]int64{}
+	}
+	return upload
+}
+
+var _ listingapp.PhotoUploadStore = (*PhotoUploadStore)(nil)
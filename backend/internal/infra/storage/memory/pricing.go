@@ -3,7 +3,6 @@ package memory
 import (
 	"context"
 	"errors"
-	"time"
 
 	"rentme/internal/app/policies"
 	domainlistings "rentme/internal/domain/listings"
@@ -12,9 +11,13 @@ import (
 	"rentme/internal/domain/shared/money"
 )
 
-// PricingEngine is a deterministic calculator used for local demos.
+// PricingEngine is a deterministic calculator used for local demos. It quotes
+// the listing's own rate and fees per night or per month and falls back to the
+// base rates and CleaningFee for listings without their own. Only nightly
+// stays carry the cleaning fee and the listing's weekend premium.
 type PricingEngine struct {
 	BaseNightly money.Money
+	BaseMonthly money.Money
 	CleaningFee money.Money
 }
 
@@ -22,39 +25,51 @@ type PricingEngine struct {
 func NewPricingEngine() *PricingEngine {
 	return &PricingEngine{
 		BaseNightly: money.Must(15000, "RUB"),
+		BaseMonthly: money.Must(65000, "RUB"),
 		CleaningFee: money.Must(1500, "RUB"),
 	}
 }
 
 func (p *PricingEngine) Quote(ctx context.Context, input domainpricing.QuoteInput) (domainpricing.PriceBreakdown, error) {
-	nights := nightsBetween(input.Range)
-	if nights < 1 {
-		nights = 1
-	}
+	unit, units := input.Units()
 	breakdown := domainpricing.PriceBreakdown{
-		Nights:  nights,
-		Nightly: p.BaseNightly,
-		Fees: []domainpricing.Fee{{
-			Name:   "cleaning_fee",
+		Unit:    unit,
+		Nights:  units,
+		Nightly: p.unitPrice(input.Listing, unit),
+	}
+	if unit == domainpricing.UnitNight && (input.Listing == nil || input.Listing.CleaningFeeRub <= 0) {
+		breakdown.Fees = []domainpricing.Fee{{
+			Name:   domainpricing.FeeCleaning,
 			Amount: p.CleaningFee,
-		}},
+		}}
+	}
+	if err := breakdown.ApplyWeekendPremium(input.Listing, input.Range); err != nil {
+		return domainpricing.PriceBreakdown{}, err
+	}
+	if err := breakdown.ApplyLongStayDiscount(input.Listing); err != nil {
+		return domainpricing.PriceBreakdown{}, err
 	}
-	if err := breakdown.RecalculateTotal(); err != nil {
+	if err := breakdown.ApplyListingFees(input.Listing); err != nil {
 		return domainpricing.PriceBreakdown{}, err
 	}
 	return breakdown, nil
 }
 
-func nightsBetween(dr domainrange.DateRange) int {
-	if dr.CheckIn.IsZero() || dr.CheckOut.IsZero() {
-		return 0
+func (p *PricingEngine) unitPrice(listing *domainlistings.Listing, unit string) money.Money {
+	if listing != nil && listing.RateRub > 0 {
+		return money.Must(listing.RateRub, "RUB")
 	}
-	return int(dr.CheckOut.Sub(dr.CheckIn) / (24 * time.Hour))
+	if unit == domainpricing.UnitMonth {
+		return p.BaseMonthly
+	}
+	return p.BaseNightly
 }
 
-// PricingPortAdapter bridges domain calculator into the application policy port.
+// PricingPortAdapter bridges domain calculator into the application policy port
+// and rounds quoted totals with the market's rounding rule.
 type PricingPortAdapter struct {
 	Calculator domainpricing.Calculator
+	Rounding   domainpricing.RoundingPolicy
 }
 
 var ErrPricingCalculatorMissing = errors.New("pricing: calculator missing")
@@ -67,13 +82,21 @@ func (p PricingPortAdapter) Quote(ctx context.Context, listing *domainlistings.L
 	if listing == nil {
 		return domainpricing.PriceBreakdown{}, ErrListingRequired
 	}
-	return p.Calculator.Quote(ctx, domainpricing.QuoteInput{
+	breakdown, err := p.Calculator.Quote(ctx, domainpricing.QuoteInput{
 		ListingID:  listing.ID,
 		Listing:    listing,
 		RentalTerm: listing.RentalTermType,
 		Range:      dr,
+		Months:     domainpricing.MonthsIn(dr),
 		Guests:     guests,
 	})
+	if err != nil {
+		return domainpricing.PriceBreakdown{}, err
+	}
+	if err := breakdown.ApplyRounding(p.Rounding.Rule(listing.Address.City, breakdown.Nightly.Currency)); err != nil {
+		return domainpricing.PriceBreakdown{}, err
+	}
+	return breakdown, nil
 }
 
 var (
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	domainpricing "rentme/internal/domain/pricing"
+)
+
+// ClampStore keeps admin-edited ML price clamps in memory.
+type ClampStore struct {
+	mu    sync.RWMutex
+	table domainpricing.ClampTable
+}
+
+func NewClampStore() *ClampStore {
+	return &ClampStore{}
+}
+
+func (s *ClampStore) Table(ctx context.Context) (domainpricing.ClampTable, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.table.Normalized(), nil
+}
+
+func (s *ClampStore) SaveCity(ctx context.Context, city string, terms domainpricing.ClampTerms) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	table := s.table.Normalized()
+	table.Cities[domainpricing.NormalizeCity(city)] = terms
+	s.table = table.Normalized()
+	return nil
+}
+
+func (s *ClampStore) Replace(ctx context.Context, table domainpricing.ClampTable) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.table = table.Normalized()
+	return nil
+}
+
+var _ domainpricing.ClampStore = (*ClampStore)(nil)
@@ -6,30 +6,34 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	domainavailability "rentme/internal/domain/availability"
 	domainbooking "rentme/internal/domain/booking"
 	domainlistings "rentme/internal/domain/listings"
 	domainreviews "rentme/internal/domain/reviews"
+	"rentme/internal/domain/shared/concurrency"
 )
 
 var (
 	// ErrListingNotFound is returned when a listing cannot be located in memory.
-	ErrListingNotFound = errors.New("memory: listing not found")
+	ErrListingNotFound = domainlistings.ErrListingNotFound
 	// ErrBookingNotFound is returned when a booking does not exist.
 	ErrBookingNotFound = domainbooking.ErrBookingNotFound
 )
 
 // ListingRepository is an in-memory implementation for demo purposes.
 type ListingRepository struct {
-	mu    sync.RWMutex
-	items map[domainlistings.ListingID]*domainlistings.Listing
+	mu      sync.RWMutex
+	items   map[domainlistings.ListingID]*domainlistings.Listing
+	history map[domainlistings.ListingID][]domainlistings.ChangelogEntry
 }
 
 // NewListingRepository builds an empty repository.
 func NewListingRepository() *ListingRepository {
 	return &ListingRepository{
-		items: make(map[domainlistings.ListingID]*domainlistings.Listing),
+		items:   make(map[domainlistings.ListingID]*domainlistings.Listing),
+		history: make(map[domainlistings.ListingID][]domainlistings.ChangelogEntry),
 	}
 }
 
@@ -41,17 +45,38 @@ func (r *ListingRepository) ByID(ctx context.Context, id domainlistings.ListingI
 	if !ok {
 		return nil, ErrListingNotFound
 	}
-	return listing, nil
+	return cloneListing(listing), nil
 }
 
-// Save stores/updates a listing entry.
+// Save stores a copy of the listing. Like the Mongo repository it rejects a
+// listing saved by someone else since it was loaded, bumps the version and
+// moves recorded changelog entries to the listing history.
 func (r *ListingRepository) Save(ctx context.Context, listing *domainlistings.Listing) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.items[listing.ID] = listing
+	if stored, ok := r.items[listing.ID]; ok && stored.Version != listing.Version {
+		return concurrency.ErrConcurrentUpdate
+	}
+	listing.Version++
+	for _, entry := range listing.Changelog {
+		entry.Version = listing.Version
+		entry.Fields = append([]string(nil), entry.Fields...)
+		r.history[listing.ID] = append(r.history[listing.ID], entry)
+	}
+	listing.Changelog = nil
+	r.items[listing.ID] = cloneListing(listing)
 	return nil
 }
 
+// History returns the saved changelog of a listing, newest first.
+func (r *ListingRepository) History(ctx context.Context, id domainlistings.ListingID) ([]domainlistings.ChangelogEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entries := append([]domainlistings.ChangelogEntry{}, r.history[id]...)
+	domainlistings.SortChangelogNewestFirst(entries)
+	return entries, nil
+}
+
 // Search returns listings that satisfy provided filters.
 func (r *ListingRepository) Search(ctx context.Context, params domainlistings.SearchParams) (domainlistings.SearchResult, error) {
 	r.mu.RLock()
@@ -74,12 +99,21 @@ func (r *ListingRepository) Search(ctx context.Context, params domainlistings.Se
 		if opts.Host != "" && listing.Host != opts.Host {
 			continue
 		}
+		if opts.UnitGroupID != "" && listing.UnitGroupID != opts.UnitGroupID {
+			continue
+		}
 		if len(opts.States) > 0 && !stateIncluded(listing.State, opts.States) {
 			continue
 		}
+		if len(opts.States) == 0 && listing.State == domainlistings.ListingDeleted {
+			continue
+		}
 		if opts.City != "" && !strings.EqualFold(listing.Address.City, opts.City) {
 			continue
 		}
+		if len(opts.Cities) > 0 && !cityIncluded(listing.Address.City, opts.Cities) {
+			continue
+		}
 		if opts.Region != "" && !strings.EqualFold(listing.Address.Region, opts.Region) {
 			continue
 		}
@@ -100,6 +134,11 @@ func (r *ListingRepository) Search(ctx context.Context, params domainlistings.Se
 		if opts.PriceMaxRub > 0 && listing.RateRub > opts.PriceMaxRub {
 			continue
 		}
+		if opts.MaxPricePerSqM > 0 {
+			if price, ok := listing.PricePerSqM(); !ok || price > opts.MaxPricePerSqM {
+				continue
+			}
+		}
 		if !opts.CheckIn.IsZero() && listing.AvailableFrom.After(opts.CheckIn) {
 			continue
 		}
@@ -115,6 +154,18 @@ func (r *ListingRepository) Search(ctx context.Context, params domainlistings.Se
 		if len(opts.RentalTerms) > 0 && !rentalTermMatches(listing.RentalTermType, opts.RentalTerms) {
 			continue
 		}
+		if opts.MaxTravelMinutes > 0 {
+			commute, ok := listing.Commute(opts.TravelMode, opts.CommuteFrom)
+			if !ok || commute.Minutes > opts.MaxTravelMinutes {
+				continue
+			}
+		}
+		if center, radius, ok := opts.GeoFilter(); ok && !listing.WithinRadius(center, radius) {
+			continue
+		}
+		if opts.BBox != nil && !listing.WithinBBox(*opts.BBox) {
+			continue
+		}
 		matches = append(matches, listing)
 	}
 
@@ -140,6 +191,19 @@ func (r *ListingRepository) Search(ctx context.Context, params domainlistings.Se
 				return matches[i].RateRub < matches[j].RateRub
 			}
 			return matches[i].UpdatedAt.After(matches[j].UpdatedAt)
+		case domainlistings.SortByPricePerSqM:
+			pi, iok := matches[i].PricePerSqM()
+			pj, jok := matches[j].PricePerSqM()
+			if iok != jok {
+				return iok
+			}
+			if pi != pj {
+				return pi < pj
+			}
+			if matches[i].RateRub != matches[j].RateRub {
+				return matches[i].RateRub < matches[j].RateRub
+			}
+			return matches[i].ID < matches[j].ID
 		default:
 			if matches[i].RateRub == matches[j].RateRub {
 				return matches[i].Rating > matches[j].Rating
@@ -159,11 +223,41 @@ func (r *ListingRepository) Search(ctx context.Context, params domainlistings.Se
 	}
 
 	return domainlistings.SearchResult{
-		Items: matches[start:end],
+		Items: cloneListings(matches[start:end]),
 		Total: total,
 	}, nil
 }
 
+// PricePerSqMMedians computes the medians over the active listings of the cities.
+func (r *ListingRepository) PricePerSqMMedians(ctx context.Context, cities []string) (map[domainlistings.BenchmarkKey]float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	wanted := make(map[string]struct{}, len(cities))
+	for _, city := range cities {
+		wanted[strings.ToLower(strings.TrimSpace(city))] = struct{}{}
+	}
+	samples := make(map[domainlistings.BenchmarkKey][]float64)
+	for _, listing := range r.items {
+		if listing.State != domainlistings.ListingActive {
+			continue
+		}
+		key := domainlistings.BenchmarkKeyFor(listing)
+		if _, ok := wanted[key.City]; !ok {
+			continue
+		}
+		if price, ok := listing.PricePerSqM(); ok {
+			samples[key] = append(samples[key], price)
+		}
+	}
+	medians := make(map[domainlistings.BenchmarkKey]float64, len(samples))
+	for key, values := range samples {
+		if median := domainlistings.MedianPricePerSqM(values); median > 0 {
+			medians[key] = median
+		}
+	}
+	return medians, nil
+}
+
 func tokensMatch(values []string, required []string) bool {
 	if len(required) == 0 {
 		return true
@@ -233,6 +327,15 @@ func rentalTermMatches(value domainlistings.RentalTermType, allowed []domainlist
 	return false
 }
 
+func cityIncluded(city string, cities []string) bool {
+	for _, candidate := range cities {
+		if strings.EqualFold(strings.TrimSpace(city), candidate) {
+			return true
+		}
+	}
+	return false
+}
+
 func stateIncluded(state domainlistings.ListingState, allowed []domainlistings.ListingState) bool {
 	for _, candidate := range allowed {
 		if state == candidate {
@@ -260,18 +363,39 @@ func (r *AvailabilityRepository) Calendar(ctx context.Context, id domainlistings
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if cal, ok := r.calendars[id]; ok {
-		return cal, nil
+		return cloneCalendar(cal), nil
 	}
 	cal := domainavailability.NewCalendar(id, 1)
 	r.calendars[id] = cal
-	return cal, nil
+	return cloneCalendar(cal), nil
 }
 
-// Save persists a calendar snapshot.
+// CalendarsByListingIDs returns copies of the stored calendars, with empty
+// ones for listings that have none yet.
+func (r *AvailabilityRepository) CalendarsByListingIDs(ctx context.Context, ids []domainlistings.ListingID) (map[domainlistings.ListingID]*domainavailability.AvailabilityCalendar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[domainlistings.ListingID]*domainavailability.AvailabilityCalendar, len(ids))
+	for _, id := range ids {
+		if cal, ok := r.calendars[id]; ok {
+			out[id] = cloneCalendar(cal)
+			continue
+		}
+		out[id] = domainavailability.NewCalendar(id, 1)
+	}
+	return out, nil
+}
+
+// Save persists a calendar snapshot unless another one was saved since it
+// was loaded.
 func (r *AvailabilityRepository) Save(ctx context.Context, calendar *domainavailability.AvailabilityCalendar) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.calendars[calendar.ListingID] = calendar
+	if stored, ok := r.calendars[calendar.ListingID]; ok && stored.Version != calendar.Version {
+		return concurrency.ErrConcurrentUpdate
+	}
+	calendar.Version++
+	r.calendars[calendar.ListingID] = cloneCalendar(calendar)
 	return nil
 }
 
@@ -294,37 +418,55 @@ func (r *BookingRepository) ByID(ctx context.Context, id domainbooking.BookingID
 	if !ok {
 		return nil, ErrBookingNotFound
 	}
-	return booking, nil
+	return cloneBooking(booking), nil
 }
 
-// Save stores the current booking state.
+// Save stores the current booking state unless another save happened since
+// the booking was loaded.
 func (r *BookingRepository) Save(ctx context.Context, booking *domainbooking.Booking) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if stored, ok := r.items[booking.ID]; ok && stored.Version != booking.Version {
+		return concurrency.ErrConcurrentUpdate
+	}
 	booking.Version++
-	r.items[booking.ID] = booking
+	r.items[booking.ID] = cloneBooking(booking)
 	return nil
 }
 
-func (r *BookingRepository) ListByGuest(ctx context.Context, guestID string) ([]*domainbooking.Booking, error) {
+func (r *BookingRepository) ListByGuest(ctx context.Context, guestID string, page domainbooking.GuestPage) ([]*domainbooking.Booking, string, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	id := strings.TrimSpace(guestID)
 	if id == "" {
-		return nil, errors.New("memory: guest id required")
+		return nil, "", errors.New("memory: guest id required")
+	}
+	cursor, err := domainbooking.ParseCursor(page.Cursor)
+	if err != nil {
+		return nil, "", err
 	}
 	matches := make([]*domainbooking.Booking, 0)
 	for _, booking := range r.items {
-		if booking.GuestID == id {
-			matches = append(matches, booking)
+		if booking.GuestID != id {
+			continue
+		}
+		if cursor != nil && !cursor.Before(booking) {
+			continue
 		}
+		matches = append(matches, booking)
 	}
 	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].CreatedAt.Equal(matches[j].CreatedAt) {
+			return matches[i].ID > matches[j].ID
+		}
 		return matches[i].CreatedAt.After(matches[j].CreatedAt)
 	})
-	result := make([]*domainbooking.Booking, len(matches))
-	copy(result, matches)
-	return result, nil
+	next := ""
+	if page.Limit > 0 && len(matches) > page.Limit {
+		matches = matches[:page.Limit]
+		next = domainbooking.CursorFor(matches[len(matches)-1])
+	}
+	return cloneBookings(matches), next, nil
 }
 
 func (r *BookingRepository) ListByListing(ctx context.Context, listingID domainlistings.ListingID) ([]*domainbooking.Booking, error) {
@@ -344,7 +486,113 @@ func (r *BookingRepository) ListByListing(ctx context.Context, listingID domainl
 	})
 	result := make([]*domainbooking.Booking, len(matches))
 	copy(result, matches)
-	return result, nil
+	return cloneBookings(result), nil
+}
+
+// ListByState returns bookings in the given state created before olderThan, oldest first.
+func (r *BookingRepository) ListByState(ctx context.Context, state domainbooking.BookingState, olderThan time.Time) ([]*domainbooking.Booking, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	matches := make([]*domainbooking.Booking, 0)
+	for _, booking := range r.items {
+		if booking.State != state {
+			continue
+		}
+		if !olderThan.IsZero() && !booking.CreatedAt.Before(olderThan) {
+			continue
+		}
+		matches = append(matches, booking)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt.Before(matches[j].CreatedAt)
+	})
+	return cloneBookings(matches), nil
+}
+
+// ListByListingIDs returns a newest-first page of bookings for the given listings.
+func (r *BookingRepository) ListByListingIDs(ctx context.Context, ids []domainlistings.ListingID, state domainbooking.BookingState, limit, offset int) ([]*domainbooking.Booking, int, error) {
+	if len(ids) == 0 {
+		return nil, 0, nil
+	}
+	wanted := make(map[domainlistings.ListingID]struct{}, len(ids))
+	for _, id := range ids {
+		wanted[id] = struct{}{}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	matches := make([]*domainbooking.Booking, 0)
+	for _, booking := range r.items {
+		if _, ok := wanted[booking.ListingID]; !ok {
+			continue
+		}
+		if state != "" && booking.State != state {
+			continue
+		}
+		matches = append(matches, booking)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt.After(matches[j].CreatedAt)
+	})
+	total := len(matches)
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= total {
+		return []*domainbooking.Booking{}, total, nil
+	}
+	end := total
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	result := make([]*domainbooking.Booking, end-offset)
+	copy(result, matches[offset:end])
+	return cloneBookings(result), total, nil
+}
+
+// List scans every booking and returns a newest-first page of the matches.
+func (r *BookingRepository) List(ctx context.Context, filter domainbooking.BookingFilter) ([]*domainbooking.Booking, int, error) {
+	if filter.ListingIDs != nil && len(filter.ListingIDs) == 0 {
+		return []*domainbooking.Booking{}, 0, nil
+	}
+	var wanted map[domainlistings.ListingID]struct{}
+	if len(filter.ListingIDs) > 0 {
+		wanted = make(map[domainlistings.ListingID]struct{}, len(filter.ListingIDs))
+		for _, id := range filter.ListingIDs {
+			wanted[id] = struct{}{}
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	matches := make([]*domainbooking.Booking, 0)
+	for _, booking := range r.items {
+		if filter.GuestID != "" && booking.GuestID != filter.GuestID {
+			continue
+		}
+		if wanted != nil {
+			if _, ok := wanted[booking.ListingID]; !ok {
+				continue
+			}
+		}
+		if filter.State != "" && booking.State != filter.State {
+			continue
+		}
+		matches = append(matches, booking)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt.After(matches[j].CreatedAt)
+	})
+	total := len(matches)
+	offset := max(filter.Offset, 0)
+	if offset >= total {
+		return []*domainbooking.Booking{}, total, nil
+	}
+	end := total
+	if filter.Limit > 0 && offset+filter.Limit < end {
+		end = offset + filter.Limit
+	}
+	result := make([]*domainbooking.Booking, end-offset)
+	copy(result, matches[offset:end])
+	return cloneBookings(result), total, nil
 }
 
 // ReviewsRepository is a lightweight in-memory review store.
@@ -420,6 +668,20 @@ func (r *ReviewsRepository) ListByListing(ctx context.Context, listingID domainl
 	return result, nil
 }
 
+// StatsByListing aggregates the submitted reviews of a listing; moderated
+// reviews do not count.
+func (r *ReviewsRepository) StatsByListing(ctx context.Context, listingID domainlistings.ListingID) (domainreviews.ListingStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var stats domainreviews.ListingStats
+	for _, review := range r.items {
+		if review.ListingID == listingID && review.Submitted && !review.Moderated {
+			stats.Add(review.Rating)
+		}
+	}
+	return stats, nil
+}
+
 // Save writes the review entry.
 func (r *ReviewsRepository) Save(ctx context.Context, review *domainreviews.Review) error {
 	r.mu.Lock()
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainavailability "rentme/internal/domain/availability"
+	domainbooking "rentme/internal/domain/booking"
+	domainlistings "rentme/internal/domain/listings"
+	"rentme/internal/domain/shared/concurrency"
+	domainrange "rentme/internal/domain/shared/daterange"
+)
+
+var raceNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestBookingRepositoryRejectsInterleavedSave(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBookingRepository()
+	if err := repo.Save(ctx, &domainbooking.Booking{ID: "booking-1", ListingID: "listing-1", GuestID: "guest-1", State: domainbooking.StatePending}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	accepted, err := repo.ByID(ctx, "booking-1")
+	if err != nil {
+		t.Fatalf("first load: %v", err)
+	}
+	declined, err := repo.ByID(ctx, "booking-1")
+	if err != nil {
+		t.Fatalf("second load: %v", err)
+	}
+	if err := accepted.Accept(raceNow); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if err := declined.Decline("dates changed", raceNow); err != nil {
+		t.Fatalf("decline: %v", err)
+	}
+
+	if err := repo.Save(ctx, accepted); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+	if err := repo.Save(ctx, declined); !errors.Is(err, concurrency.ErrConcurrentUpdate) {
+		t.Fatalf("second save err = %v, want ErrConcurrentUpdate", err)
+	}
+
+	stored, err := repo.ByID(ctx, "booking-1")
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if stored.State != domainbooking.StateAccepted {
+		t.Fatalf("stored state = %s, want %s", stored.State, domainbooking.StateAccepted)
+	}
+	if stored.Version != accepted.Version {
+		t.Fatalf("stored version = %d, want %d", stored.Version, accepted.Version)
+	}
+
+	// A fresh load sees the winner and may save again.
+	if err := stored.Confirm("hold-1", raceNow); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	if err := repo.Save(ctx, stored); err != nil {
+		t.Fatalf("save after reload: %v", err)
+	}
+}
+
+func TestBookingRepositoryHandsOutCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBookingRepository()
+	if err := repo.Save(ctx, &domainbooking.Booking{ID: "booking-1", State: domainbooking.StatePending}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	loaded, err := repo.ByID(ctx, "booking-1")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	loaded.State = domainbooking.StateCancelled
+	stored, err := repo.ByID(ctx, "booking-1")
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if stored.State != domainbooking.StatePending {
+		t.Fatalf("unsaved change leaked into the store: %s", stored.State)
+	}
+}
+
+func TestListingRepositoryRejectsInterleavedSave(t *testing.T) {
+	ctx := context.Background()
+	repo := NewListingRepository()
+	if err := repo.Save(ctx, &domainlistings.Listing{ID: "listing-1", Title: "Loft"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	first, err := repo.ByID(ctx, "listing-1")
+	if err != nil {
+		t.Fatalf("first load: %v", err)
+	}
+	second, err := repo.ByID(ctx, "listing-1")
+	if err != nil {
+		t.Fatalf("second load: %v", err)
+	}
+	first.UpdateRating(4.5, raceNow)
+	second.UpdateRating(3, raceNow)
+
+	if err := repo.Save(ctx, first); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+	if err := repo.Save(ctx, second); !errors.Is(err, concurrency.ErrConcurrentUpdate) {
+		t.Fatalf("second save err = %v, want ErrConcurrentUpdate", err)
+	}
+	stored, err := repo.ByID(ctx, "listing-1")
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if stored.Rating != 4.5 {
+		t.Fatalf("stored rating = %v, want 4.5", stored.Rating)
+	}
+}
+
+func TestAvailabilityRepositoryRejectsInterleavedSave(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAvailabilityRepository()
+	first, err := repo.Calendar(ctx, "listing-1")
+	if err != nil {
+		t.Fatalf("first load: %v", err)
+	}
+	second, err := repo.Calendar(ctx, "listing-1")
+	if err != nil {
+		t.Fatalf("second load: %v", err)
+	}
+	stay := domainrange.DateRange{CheckIn: raceNow.AddDate(0, 0, 10), CheckOut: raceNow.AddDate(0, 0, 13)}
+	if err := first.Reserve(stay, "booking-1", raceNow); err != nil {
+		t.Fatalf("first reserve: %v", err)
+	}
+	// The second load has not seen booking-1, so the same nights look free.
+	if err := second.Reserve(stay, "booking-2", raceNow); err != nil {
+		t.Fatalf("second reserve: %v", err)
+	}
+
+	if err := repo.Save(ctx, first); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+	if err := repo.Save(ctx, second); !errors.Is(err, concurrency.ErrConcurrentUpdate) {
+		t.Fatalf("second save err = %v, want ErrConcurrentUpdate", err)
+	}
+	stored, err := repo.Calendar(ctx, "listing-1")
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	block, taken := stored.Conflict(stay)
+	if !taken || block.Reference != "booking-1" || block.Reason != domainavailability.ReasonBooking {
+		t.Fatalf("stored block = %+v (taken %v), want booking-1", block, taken)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	domainlistings "rentme/internal/domain/listings"
 	domainpricing "rentme/internal/domain/pricing"
 	domainreviews "rentme/internal/domain/reviews"
+	domainwishlist "rentme/internal/domain/wishlist"
 )
 
 // Factory wires in-memory repositories into a unit-of-work boundary.
@@ -19,6 +20,7 @@ type Factory struct {
 	BookingRepo      domainbooking.Repository
 	PricingSvc       domainpricing.Calculator
 	ReviewsRepo      domainreviews.Repository
+	WishlistsRepo    domainwishlist.Repository
 }
 
 // ErrFactoryMisconfigured indicates missing repositories.
@@ -27,7 +29,7 @@ var ErrFactoryMisconfigured = errors.New("memory: unit of work factory misconfig
 // Begin starts a lightweight transaction boundary. No isolation is provided but
 // the abstraction matches the application ports.
 func (f Factory) Begin(ctx context.Context, opts uow.TxOptions) (uow.UnitOfWork, error) {
-	if f.ListingsRepo == nil || f.AvailabilityRepo == nil || f.BookingRepo == nil || f.ReviewsRepo == nil {
+	if f.ListingsRepo == nil || f.AvailabilityRepo == nil || f.BookingRepo == nil || f.ReviewsRepo == nil || f.WishlistsRepo == nil {
 		return nil, ErrFactoryMisconfigured
 	}
 	return &Unit{
@@ -36,6 +38,7 @@ func (f Factory) Begin(ctx context.Context, opts uow.TxOptions) (uow.UnitOfWork,
 		booking:      f.BookingRepo,
 		pricing:      f.PricingSvc,
 		reviews:      f.ReviewsRepo,
+		wishlists:    f.WishlistsRepo,
 	}, nil
 }
 
@@ -46,6 +49,7 @@ type Unit struct {
 	booking      domainbooking.Repository
 	pricing      domainpricing.Calculator
 	reviews      domainreviews.Repository
+	wishlists    domainwishlist.Repository
 }
 
 func (u *Unit) Listings() domainlistings.ListingRepository {
@@ -68,6 +72,10 @@ func (u *Unit) Reviews() domainreviews.Repository {
 	return u.reviews
 }
 
+func (u *Unit) Wishlists() domainwishlist.Repository {
+	return u.wishlists
+}
+
 func (u *Unit) Commit(ctx context.Context) error {
 	return nil
 }
@@ -70,6 +70,32 @@ func (r *UserRepository) Save(ctx context.Context, user *domainuser.User) error
 	return nil
 }
 
+// Create inserts user only when neither its ID nor its email is registered.
+func (r *UserRepository) Create(ctx context.Context, user *domainuser.User) error {
+	if user == nil {
+		return domainuser.ErrIDRequired
+	}
+	if strings.TrimSpace(string(user.ID)) == "" {
+		return domainuser.ErrIDRequired
+	}
+	emailKey := strings.ToLower(strings.TrimSpace(user.Email))
+	if emailKey == "" {
+		return domainuser.ErrEmailRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byEmail[emailKey]; ok {
+		return domainuser.ErrEmailAlreadyUsed
+	}
+	if _, ok := r.byID[user.ID]; ok {
+		return domainuser.ErrEmailAlreadyUsed
+	}
+	r.byEmail[emailKey] = user.ID
+	r.byID[user.ID] = cloneUser(user)
+	return nil
+}
+
 func (r *UserRepository) List(ctx context.Context, params domainuser.ListParams) ([]*domainuser.User, int, error) {
 	limit := params.Limit
 	if limit <= 0 || limit > 200 {
@@ -86,6 +112,9 @@ func (r *UserRepository) List(ctx context.Context, params domainuser.ListParams)
 
 	matches := make([]*domainuser.User, 0, len(r.byID))
 	for _, user := range r.byID {
+		if params.Role != "" && !user.HasRole(params.Role) {
+			continue
+		}
 		if query != "" {
 			if !strings.Contains(strings.ToLower(user.Email), query) && !strings.Contains(strings.ToLower(user.Name), query) {
 				continue
@@ -126,12 +155,22 @@ type SessionStore struct {
 	mu        sync.RWMutex
 	tokens    map[domainauth.Token]*domainauth.Session
 	userIndex map[domainuser.ID]map[domainauth.Token]struct{}
+	refresh   map[domainauth.Token]domainauth.Token
+	rotated   map[domainauth.Token]rotatedRefresh
+}
+
+// rotatedRefresh remembers a used refresh token until it would have expired.
+type rotatedRefresh struct {
+	userID domainuser.ID
+	until  time.Time
 }
 
 func NewSessionStore() *SessionStore {
 	return &SessionStore{
 		tokens:    make(map[domainauth.Token]*domainauth.Session),
 		userIndex: make(map[domainuser.ID]map[domainauth.Token]struct{}),
+		refresh:   make(map[domainauth.Token]domainauth.Token),
+		rotated:   make(map[domainauth.Token]rotatedRefresh),
 	}
 }
 
@@ -141,14 +180,23 @@ func (s *SessionStore) Save(ctx context.Context, session *domainauth.Session) er
 	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.saveLocked(session)
+	return nil
+}
+
+func (s *SessionStore) saveLocked(session *domainauth.Session) {
 	s.tokens[session.Token] = cloneSession(session)
 	if _, ok := s.userIndex[session.UserID]; !ok {
 		s.userIndex[session.UserID] = make(map[domainauth.Token]struct{})
 	}
 	s.userIndex[session.UserID][session.Token] = struct{}{}
-	return nil
+	if session.RefreshToken != "" {
+		s.refresh[session.RefreshToken] = session.Token
+	}
 }
 
+// Get returns the session while its access token is valid. Sessions with an
+// expired access token are kept until their refresh token expires too.
 func (s *SessionStore) Get(ctx context.Context, token domainauth.Token) (*domainauth.Session, error) {
 	s.mu.RLock()
 	session, ok := s.tokens[token]
@@ -156,8 +204,11 @@ func (s *SessionStore) Get(ctx context.Context, token domainauth.Token) (*domain
 	if !ok {
 		return nil, domainauth.ErrSessionNotFound
 	}
-	if session.ExpiresAt.Before(time.Now().UTC()) {
-		_ = s.Delete(ctx, token)
+	now := time.Now().UTC()
+	if session.Expired(now) {
+		if session.RefreshExpired(now) {
+			_ = s.Delete(ctx, token)
+		}
 		return nil, domainauth.ErrSessionNotFound
 	}
 	return cloneSession(session), nil
@@ -166,32 +217,111 @@ func (s *SessionStore) Get(ctx context.Context, token domainauth.Token) (*domain
 func (s *SessionStore) Delete(ctx context.Context, token domainauth.Token) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.deleteLocked(token)
+	return nil
+}
+
+func (s *SessionStore) DeleteByUser(ctx context.Context, userID domainuser.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token := range s.userIndex[userID] {
+		s.deleteLocked(token)
+	}
+	delete(s.userIndex, userID)
+	return nil
+}
+
+func (s *SessionStore) DeleteByUserExcept(ctx context.Context, userID domainuser.ID, keep domainauth.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token := range s.userIndex[userID] {
+		if token != keep {
+			s.deleteLocked(token)
+		}
+	}
+	return nil
+}
+
+func (s *SessionStore) ConsumeRefresh(ctx context.Context, refresh domainauth.Token, now time.Time) (*domainauth.Session, error) {
+	now = now.UTC()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneRotatedLocked(now)
+	if rotated, ok := s.rotated[refresh]; ok {
+		return &domainauth.Session{UserID: rotated.userID}, domainauth.ErrRefreshTokenReused
+	}
+	token, ok := s.refresh[refresh]
+	if !ok {
+		return nil, domainauth.ErrSessionNotFound
+	}
+	session := s.tokens[token]
+	s.deleteLocked(token)
+	if session == nil || session.RefreshExpired(now) {
+		return nil, domainauth.ErrSessionNotFound
+	}
+	s.rotated[refresh] = rotatedRefresh{userID: session.UserID, until: session.RefreshExpiresAt}
+	return cloneSession(session), nil
+}
+
+func (s *SessionStore) Refresh(ctx context.Context, old, next domainauth.Session) error {
+	if next.Token == "" {
+		return domainauth.ErrTokenRequired
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.tokens[old.Token]
+	if !ok {
+		return domainauth.ErrSessionNotFound
+	}
+	s.deleteLocked(old.Token)
+	if stored.RefreshToken != "" {
+		s.rotated[stored.RefreshToken] = rotatedRefresh{userID: stored.UserID, until: stored.RefreshExpiresAt}
+	}
+	s.saveLocked(&next)
+	return nil
+}
+
+// Cleanup drops sessions that can be neither used nor refreshed at now and
+// forgets rotated refresh tokens past their expiry. It returns how many
+// sessions were removed.
+func (s *SessionStore) Cleanup(ctx context.Context, now time.Time) int {
+	now = now.UTC()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed := 0
+	for token, session := range s.tokens {
+		if session.Expired(now) && session.RefreshExpired(now) {
+			s.deleteLocked(token)
+			removed++
+		}
+	}
+	s.pruneRotatedLocked(now)
+	return removed
+}
+
+func (s *SessionStore) deleteLocked(token domainauth.Token) {
 	session, ok := s.tokens[token]
 	if !ok {
-		return nil
+		return
 	}
 	delete(s.tokens, token)
+	if session.RefreshToken != "" {
+		delete(s.refresh, session.RefreshToken)
+	}
 	if index, ok := s.userIndex[session.UserID]; ok {
 		delete(index, token)
 		if len(index) == 0 {
 			delete(s.userIndex, session.UserID)
 		}
 	}
-	return nil
 }
 
-func (s *SessionStore) DeleteByUser(ctx context.Context, userID domainuser.ID) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	index, ok := s.userIndex[userID]
-	if !ok {
-		return nil
-	}
-	for token := range index {
-		delete(s.tokens, token)
+func (s *SessionStore) pruneRotatedLocked(now time.Time) {
+	for token, rotated := range s.rotated {
+		if !rotated.until.After(now) {
+			delete(s.rotated, token)
+		}
 	}
-	delete(s.userIndex, userID)
-	return nil
 }
 
 func cloneSession(s *domainauth.Session) *domainauth.Session {
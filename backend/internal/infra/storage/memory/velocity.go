@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"rentme/internal/app/services/trust"
+)
+
+const (
+	velocityWindow       = 24 * time.Hour
+	velocityDecisionsCap = 50
+	velocitySweepEvery   = 10 * time.Minute
+)
+
+// VelocityStore keeps attempt timestamps for the last 24 hours and the most
+// recent velocity decisions per user.
+type VelocityStore struct {
+	mu        sync.Mutex
+	hits      map[string][]time.Time
+	decisions map[string][]trust.Verdict
+	swept     time.Time
+}
+
+func NewVelocityStore() *VelocityStore {
+	return &VelocityStore{
+		hits:      make(map[string][]time.Time),
+		decisions: make(map[string][]trust.Verdict),
+	}
+}
+
+func (s *VelocityStore) Hit(ctx context.Context, key string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := at.Add(-velocityWindow)
+	if at.Sub(s.swept) >= velocitySweepEvery {
+		for k, hits := range s.hits {
+			if hits = pruneHits(hits, cutoff); len(hits) == 0 {
+				delete(s.hits, k)
+			} else {
+				s.hits[k] = hits
+			}
+		}
+		s.swept = at
+	}
+	s.hits[key] = append(pruneHits(s.hits[key], cutoff), at)
+	return nil
+}
+
+func (s *VelocityStore) Count(ctx context.Context, key string, since time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, at := range s.hits[key] {
+		if !at.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *VelocityStore) AppendDecision(ctx context.Context, verdict trust.Verdict) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := append(s.decisions[verdict.UserID], verdict)
+	if len(items) > velocityDecisionsCap {
+		items = items[len(items)-velocityDecisionsCap:]
+	}
+	s.decisions[verdict.UserID] = items
+	return nil
+}
+
+// Decisions returns newest-first decisions for userID.
+func (s *VelocityStore) Decisions(ctx context.Context, userID string, limit int) ([]trust.Verdict, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := s.decisions[userID]
+	out := make([]trust.Verdict, 0, len(items))
+	for i := len(items) - 1; i >= 0; i-- {
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+		out = append(out, items[i])
+	}
+	return out, nil
+}
+
+func pruneHits(hits []time.Time, cutoff time.Time) []time.Time {
+	idx := 0
+	for idx < len(hits) && hits[idx].Before(cutoff) {
+		idx++
+	}
+	return hits[idx:]
+}
+
+var _ trust.Store = (*VelocityStore)(nil)
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	domainlistings "rentme/internal/domain/listings"
+	domainwishlist "rentme/internal/domain/wishlist"
+)
+
+// WishlistRepository keeps guest wishlists in memory.
+type WishlistRepository struct {
+	mu    sync.RWMutex
+	items map[string]*domainwishlist.Wishlist
+}
+
+// NewWishlistRepository builds an empty wishlist store.
+func NewWishlistRepository() *WishlistRepository {
+	return &WishlistRepository{items: make(map[string]*domainwishlist.Wishlist)}
+}
+
+// ByGuest returns the guest wishlist, or an empty one when nothing was saved yet.
+func (r *WishlistRepository) ByGuest(ctx context.Context, guestID string) (*domainwishlist.Wishlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id := strings.TrimSpace(guestID)
+	if wishlist, ok := r.items[id]; ok {
+		return cloneWishlist(wishlist), nil
+	}
+	return domainwishlist.New(id)
+}
+
+// Save stores the wishlist snapshot.
+func (r *WishlistRepository) Save(ctx context.Context, wishlist *domainwishlist.Wishlist) error {
+	if wishlist == nil || strings.TrimSpace(wishlist.GuestID) == "" {
+		return domainwishlist.ErrGuestRequired
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items[wishlist.GuestID] = cloneWishlist(wishlist)
+	return nil
+}
+
+func cloneWishlist(wishlist *domainwishlist.Wishlist) *domainwishlist.Wishlist {
+	return &domainwishlist.Wishlist{
+		GuestID:    wishlist.GuestID,
+		ListingIDs: append([]domainlistings.ListingID(nil), wishlist.ListingIDs...),
+	}
+}
@@ -0,0 +1,91 @@
+package s3
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/url"
+	"strings"
+)
+
+// versionParam carries the content hash that busts CDN caches when an
+// object under the same key changes.
+const (
+	versionParam  = "v"
+	versionLength = 12
+)
+
+// MediaURLs moves object URLs between the form they are stored in and the
+// form clients load them from. With BaseURL set (MEDIA_BASE_URL, usually a CDN
+// in front of the bucket) URLs under Origin are served from BaseURL; anything
+// else, such as external photo links, passes through unchanged. Stored URLs
+// are rewritten on read, so existing data needs no migration.
+type MediaURLs struct {
+	BaseURL string
+	// Origin is the base objects are stored under: the public S3 endpoint
+	// followed by the bucket.
+	Origin string
+}
+
+func NewMediaURLs(baseURL, origin string) MediaURLs {
+	return MediaURLs{
+		BaseURL: strings.TrimRight(strings.TrimSpace(baseURL), "/"),
+		Origin:  strings.TrimRight(strings.TrimSpace(origin), "/"),
+	}
+}
+
+// Public returns the URL clients should load raw from.
+func (m MediaURLs) Public(raw string) string {
+	if m.BaseURL == "" || m.Origin == "" {
+		return raw
+	}
+	if rest, ok := cutBase(raw, m.Origin); ok {
+		return m.BaseURL + rest
+	}
+	return raw
+}
+
+// Stored maps a URL a client sent back, possibly a Public one, to the form it
+// is stored in so it can be matched against the listing's photos.
+func (m MediaURLs) Stored(raw string) string {
+	if m.BaseURL == "" || m.Origin == "" {
+		return raw
+	}
+	if rest, ok := cutBase(raw, m.BaseURL); ok {
+		return m.Origin + rest
+	}
+	return raw
+}
+
+func cutBase(raw, base string) (string, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(raw), base)
+	if !ok || (rest != "" && rest[0] != '/' && rest[0] != '?') {
+		return "", false
+	}
+	return rest, true
+}
+
+// withVersion sets the v query parameter to a prefix of the content hash.
+func withVersion(raw string, sum hash.Hash) string {
+	version := hex.EncodeToString(sum.Sum(nil))[:versionLength]
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	query := parsed.Query()
+	query.Set(versionParam, version)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+func newContentHash() hash.Hash {
+	return sha256.New()
+}
+
+// withoutQuery drops the version, leaving the URL of the object itself.
+func withoutQuery(raw string) string {
+	if i := strings.IndexByte(raw, '?'); i >= 0 {
+		return raw[:i]
+	}
+	return raw
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
@@ -17,6 +18,21 @@ import (
 // Uploader stores binary content in an S3-compatible bucket and returns a public URL.
 type Uploader interface {
 	Upload(ctx context.Context, key string, reader io.Reader, contentType string) (publicURL string, err error)
+	// Remove deletes the object behind a URL returned by Upload or VersionedURL.
+	Remove(ctx context.Context, publicURL string) error
+	// Delete deletes the object stored under key. Deleting a missing object
+	// is not an error.
+	Delete(ctx context.Context, key string) error
+	// Open streams the object stored under key.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// GeneratePresignedUploadURL lets a browser PUT the object under key
+	// directly, bypassing the backend, until expiry passes.
+	GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, expiry time.Duration) (string, error)
+	// PublicURL returns the URL an object under key is served from.
+	PublicURL(key string) string
+	// VersionedURL returns PublicURL with the content hash of the stored
+	// object, as Upload does.
+	VersionedURL(ctx context.Context, key string) (string, error)
 }
 
 // Client wraps a MinIO/S3 client.
@@ -62,7 +78,8 @@ func NewClient(endpoint string, useSSL bool, accessKey, secretKey, bucket, publi
 	}, nil
 }
 
-// Upload stores the content and returns a direct URL (bucket is made publicly readable for local demo).
+// Upload stores the content and returns a direct URL (bucket is made publicly
+// readable for local demo) versioned with the content hash.
 func (c *Client) Upload(ctx context.Context, key string, reader io.Reader, contentType string) (string, error) {
 	if reader == nil {
 		return "", errors.New("s3: reader is required")
@@ -79,27 +96,147 @@ func (c *Client) Upload(ctx context.Context, key string, reader io.Reader, conte
 		contentType = "application/octet-stream"
 	}
 
-	_, err := c.client.PutObject(ctx, c.bucket, key, reader, -1, minio.PutObjectOptions{
+	sum := newContentHash()
+	_, err := c.client.PutObject(ctx, c.bucket, key, io.TeeReader(reader, sum), -1, minio.PutObjectOptions{
 		ContentType: contentType,
 	})
 	if err != nil {
 		return "", fmt.Errorf("s3: put object: %w", err)
 	}
 
-	publicURL := c.objectURL(key)
+	publicURL := withVersion(c.objectURL(key), sum)
 	if c.logger != nil {
 		c.logger.Info("s3 upload completed", "bucket", c.bucket, "key", key, "url", publicURL)
 	}
 	return publicURL, nil
 }
 
-// NoopUploader fails fast when S3 is unavailable.
+// Remove deletes the object behind a URL previously returned by Upload.
+func (c *Client) Remove(ctx context.Context, publicURL string) error {
+	key, ok := c.objectKey(publicURL)
+	if !ok {
+		return fmt.Errorf("s3: url %q is not served from bucket %s", publicURL, c.bucket)
+	}
+	return c.Delete(ctx, key)
+}
+
+// Delete removes the object under key.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	key = strings.Trim(strings.TrimSpace(key), "/")
+	if key == "" {
+		return errors.New("s3: object key is required")
+	}
+	if err := c.client.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("s3: remove object: %w", err)
+	}
+	if c.logger != nil {
+		c.logger.Info("s3 object removed", "bucket", c.bucket, "key", key)
+	}
+	return nil
+}
+
+// Open streams the object under key. A missing object surfaces on the first read.
+func (c *Client) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	key = strings.Trim(strings.TrimSpace(key), "/")
+	if key == "" {
+		return nil, errors.New("s3: object key is required")
+	}
+	object, err := c.client.GetObject(ctx, c.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("s3: get object: %w", err)
+	}
+	return object, nil
+}
+
+// GeneratePresignedUploadURL signs a PUT for key. The browser must send the
+// same Content-Type it announced; the signature does not cover it.
+func (c *Client) GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, expiry time.Duration) (string, error) {
+	key = strings.Trim(strings.TrimSpace(key), "/")
+	if key == "" {
+		return "", errors.New("s3: object key is required")
+	}
+	if err := c.ensureBucket(ctx); err != nil {
+		return "", err
+	}
+	signed, err := c.client.PresignedPutObject(ctx, c.bucket, key, expiry)
+	if err != nil {
+		return "", fmt.Errorf("s3: presign put object: %w", err)
+	}
+	if c.logger != nil {
+		c.logger.Info("s3 upload url issued", "bucket", c.bucket, "key", key, "content_type", contentType, "expiry", expiry)
+	}
+	return signed.String(), nil
+}
+
+// PublicURL returns the URL Upload would have returned for key.
+func (c *Client) PublicURL(key string) string {
+	return c.objectURL(strings.Trim(strings.TrimSpace(key), "/"))
+}
+
+// VersionedURL reads the object under key back to hash it; it is meant for
+// objects uploaded directly by browsers, which Upload never saw.
+func (c *Client) VersionedURL(ctx context.Context, key string) (string, error) {
+	object, err := c.Open(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer object.Close()
+	sum := newContentHash()
+	if _, err := io.Copy(sum, object); err != nil {
+		return "", fmt.Errorf("s3: read object: %w", err)
+	}
+	return withVersion(c.PublicURL(key), sum), nil
+}
+
+// NoopUploader fails fast when S3 is unavailable. Removal and deletion succeed because
+// nothing could have been stored, so photo deletion keeps working.
 type NoopUploader struct{}
 
 func (NoopUploader) Upload(_ context.Context, _ string, _ io.Reader, _ string) (string, error) {
 	return "", errors.New("s3 uploader is not configured")
 }
 
+func (NoopUploader) Remove(_ context.Context, _ string) error {
+	return nil
+}
+
+func (NoopUploader) Delete(_ context.Context, _ string) error {
+	return nil
+}
+
+func (NoopUploader) Open(_ context.Context, _ string) (io.ReadCloser, error) {
+	return nil, errors.New("s3 uploader is not configured")
+}
+
+func (NoopUploader) GeneratePresignedUploadURL(_ context.Context, _ string, _ string, _ time.Duration) (string, error) {
+	return "", errors.New("s3 uploader is not configured")
+}
+
+func (NoopUploader) PublicURL(_ string) string {
+	return ""
+}
+
+func (NoopUploader) VersionedURL(_ context.Context, _ string) (string, error) {
+	return "", errors.New("s3 uploader is not configured")
+}
+
+// probePrefix keeps probe objects apart from listing photos.
+const probePrefix = "_probe/"
+
+// Probe writes a tiny object and deletes it again, proving the credentials
+// can both put and delete in the bucket. It does not create the bucket.
+func (c *Client) Probe(ctx context.Context) error {
+	key := fmt.Sprintf("%s%d", probePrefix, time.Now().UnixNano())
+	body := strings.NewReader("ok")
+	if _, err := c.client.PutObject(ctx, c.bucket, key, body, body.Size(), minio.PutObjectOptions{ContentType: "text/plain"}); err != nil {
+		return fmt.Errorf("s3: put probe object: %w", err)
+	}
+	if err := c.client.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("s3: remove probe object: %w", err)
+	}
+	return nil
+}
+
 func (c *Client) ensureBucket(ctx context.Context) error {
 	c.bucketInitOnce.Do(func() {
 		exists, err := c.client.BucketExists(ctx, c.bucket)
@@ -129,9 +266,20 @@ func (c *Client) allowPublicRead(ctx context.Context) error {
 	return nil
 }
 
+// ObjectBase is the URL prefix objects of bucket are served under.
+func ObjectBase(publicBaseURL, bucket string) string {
+	return strings.TrimRight(strings.TrimSpace(publicBaseURL), "/") + "/" + strings.TrimSpace(bucket)
+}
+
 func (c *Client) objectURL(key string) string {
-	base := strings.TrimRight(c.publicBaseURL, "/")
-	return fmt.Sprintf("%s/%s/%s", base, c.bucket, strings.TrimLeft(key, "/"))
+	return ObjectBase(c.publicBaseURL, c.bucket) + "/" + strings.TrimLeft(key, "/")
+}
+
+func (c *Client) objectKey(publicURL string) (string, bool) {
+	prefix := c.objectURL("")
+	key, ok := strings.CutPrefix(withoutQuery(strings.TrimSpace(publicURL)), prefix)
+	key = strings.Trim(key, "/")
+	return key, ok && key != ""
 }
 
 func parseEndpoint(endpoint string) string {
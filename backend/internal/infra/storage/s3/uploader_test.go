@@ -0,0 +1,41 @@
+package s3
+
+import (
+	"context"
+	"testing"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	client, err := NewClient("http://127.0.0.1:1", false, "key", "secret", "photos", "http://cdn.test", nil)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return client
+}
+
+func TestClientDeleteRequiresKey(t *testing.T) {
+	if err := newTestClient(t).Delete(context.Background(), " / "); err == nil {
+		t.Fatal("Delete accepted an empty key")
+	}
+}
+
+func TestClientRemoveRejectsForeignURL(t *testing.T) {
+	if err := newTestClient(t).Remove(context.Background(), "http://elsewhere.test/photos/a.jpg"); err == nil {
+		t.Fatal("Remove accepted a URL outside the bucket")
+	}
+}
+
+func TestObjectKeyOfVersionedURL(t *testing.T) {
+	client := newTestClient(t)
+	key, ok := client.objectKey(client.PublicURL("listings/l-1/a.jpg") + "?v=abc")
+	if !ok || key != "listings/l-1/a.jpg" {
+		t.Fatalf("objectKey = %q, %t", key, ok)
+	}
+}
+
+func TestNoopUploaderDeleteSucceeds(t *testing.T) {
+	if err := (NoopUploader{}).Delete(context.Background(), "listings/l-1/a.jpg"); err != nil {
+		t.Fatalf("Delete = %v, want nil", err)
+	}
+}
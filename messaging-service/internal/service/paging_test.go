@@ -0,0 +1,114 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"messaging-service/internal/storage/scylla"
+)
+
+// newestMessages returns n messages newest first, as the store lists them.
+func newestMessages(n int) []scylla.Message {
+	base := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	out := make([]scylla.Message, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, scylla.Message{ID: gocql.UUIDFromTime(base.Add(-time.Duration(i) * time.Minute))})
+	}
+	return out
+}
+
+func TestPageMessagesCursorOnlyWhenMoreRemain(t *testing.T) {
+	const limit = 3
+	cases := []struct {
+		name       string
+		fetched    int
+		wantPage   int
+		wantCursor bool
+	}{
+		{"fewer than a page", 2, 2, false},
+		{"exactly a page left", 3, 3, false},
+		{"one past the page", 4, 3, true},
+		{"nothing left", 0, 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetched := newestMessages(tc.fetched)
+			page, next := pageMessages(fetched, limit)
+			if len(page) != tc.wantPage || (next != "") != tc.wantCursor {
+				t.Fatalf("page = %d, cursor = %q; want %d messages, cursor %t", len(page), next, tc.wantPage, tc.wantCursor)
+			}
+			if tc.wantCursor && next != page[len(page)-1].ID.String() {
+				t.Fatalf("cursor = %s, want the oldest message of the page", next)
+			}
+		})
+	}
+}
+
+// newestConversations returns n conversations newest activity first; the
+// last two share an instant and are ordered by id descending like the store.
+func newestConversations(n int) []scylla.Conversation {
+	base := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	out := make([]scylla.Conversation, 0, n)
+	for i := 0; i < n; i++ {
+		conv := scylla.Conversation{ID: gocql.TimeUUID(), CreatedAt: base.AddDate(0, 0, -1), LastMessageAt: base.Add(-time.Duration(i) * time.Minute)}
+		out = append(out, conv)
+	}
+	if n >= 2 {
+		a, b := &out[n-2], &out[n-1]
+		b.LastMessageAt = a.LastMessageAt
+		if a.ID.String() < b.ID.String() {
+			a.ID, b.ID = b.ID, a.ID
+		}
+	}
+	return out
+}
+
+func TestPageConversationsWalksEveryConversationOnce(t *testing.T) {
+	for _, total := range []int{5, 6, 7} {
+		conversations := newestConversations(total)
+		var seen []gocql.UUID
+		cursor := ""
+		for pages := 0; ; pages++ {
+			if pages > total {
+				t.Fatalf("%d conversations: paging does not end", total)
+			}
+			page, next := pageConversations(conversations, cursor, 3)
+			if len(page) == 0 && next == "" && pages > 0 {
+				t.Fatalf("%d conversations: empty trailing page after cursor %q", total, cursor)
+			}
+			for _, conv := range page {
+				seen = append(seen, conv.ID)
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		if len(seen) != total {
+			t.Fatalf("%d conversations: seen %d", total, len(seen))
+		}
+		for i, id := range seen {
+			if id != conversations[i].ID {
+				t.Fatalf("%d conversations: position %d out of order", total, i)
+			}
+		}
+	}
+}
+
+func TestPageConversationsExactPageHasNoCursor(t *testing.T) {
+	page, next := pageConversations(newestConversations(3), "", 3)
+	if len(page) != 3 || next != "" {
+		t.Fatalf("page = %d, cursor = %q; want 3 conversations and no cursor", len(page), next)
+	}
+}
+
+func TestConversationCursorUsesCreationWithoutMessages(t *testing.T) {
+	created := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	conv := scylla.Conversation{ID: gocql.TimeUUID(), CreatedAt: created}
+	at, id, err := parseCursor(conversationCursor(conv))
+	if err != nil || !at.Equal(created) || id != conv.ID.String() {
+		t.Fatalf("cursor = %s %s (%v), want the creation time and id", at, id, err)
+	}
+}
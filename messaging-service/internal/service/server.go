@@ -17,6 +17,12 @@ import (
 	pb "messaging-service/proto"
 )
 
+// maxUnreadCount caps per-conversation unread counters; clients render it as "99+".
+const maxUnreadCount = 99
+
+// maxClientMessageIDLength bounds client-generated ids used for send deduplication.
+const maxClientMessageIDLength = 128
+
 // Server implements the MessagingService gRPC contract.
 type Server struct {
 	pb.UnimplementedMessagingServiceServer
@@ -76,6 +82,10 @@ func (s *Server) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*
 	conversationID := strings.TrimSpace(req.GetConversationId())
 	senderID := strings.TrimSpace(req.GetSenderId())
 	text := strings.TrimSpace(req.GetText())
+	clientMessageID := strings.TrimSpace(req.GetClientMessageId())
+	if len(clientMessageID) > maxClientMessageIDLength {
+		return nil, status.Errorf(codes.InvalidArgument, "client_message_id must be at most %d characters", maxClientMessageIDLength)
+	}
 	if conversationID == "" || senderID == "" || text == "" {
 		return nil, status.Error(codes.InvalidArgument, "conversation_id, sender_id and text are required")
 	}
@@ -86,7 +96,7 @@ func (s *Server) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*
 		}
 		return nil, status.Errorf(codes.Internal, "load conversation: %v", err)
 	}
-	msg, err := s.Store.AddMessage(ctx, conversation.ID, senderID, text, time.Now())
+	msg, err := s.Store.AddMessage(ctx, conversation.ID, senderID, text, clientMessageID, time.Now())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "save message: %v", err)
 	}
@@ -120,18 +130,18 @@ func (s *Server) ListMessages(ctx context.Context, req *pb.ListMessagesRequest)
 		}
 		before = &cursor
 	}
-	limit := int(req.GetLimit())
-	messages, err := s.Store.ListMessages(ctx, conversation.ID, limit, before)
+	limit := normalizeLimit(int(req.GetLimit()))
+	// One extra row tells whether another page exists, so a page that ends
+	// exactly at the oldest message carries no cursor.
+	messages, err := s.Store.ListMessages(ctx, conversation.ID, limit+1, before)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "list messages: %v", err)
 	}
-	resp := &pb.ListMessagesResponse{Messages: make([]*pb.Message, 0, len(messages))}
+	messages, next := pageMessages(messages, limit)
+	resp := &pb.ListMessagesResponse{Messages: make([]*pb.Message, 0, len(messages)), NextCursor: next}
 	for _, msg := range messages {
 		resp.Messages = append(resp.Messages, toProtoMessage(&msg, conversation))
 	}
-	if len(messages) == normalizeLimit(limit) {
-		resp.NextCursor = messages[len(messages)-1].ID.String()
-	}
 	return resp, nil
 }
 
@@ -157,10 +167,39 @@ func (s *Server) ListConversations(ctx context.Context, req *pb.ListConversation
 			return nil, status.Errorf(codes.Internal, "list conversation reads: %v", err)
 		}
 	}
-	cursorTime, cursorID, _ := parseCursor(req.GetCursor())
-	limit := normalizeLimit(int(req.GetLimit()))
+	page, next := pageConversations(conversations, req.GetCursor(), normalizeLimit(int(req.GetLimit())))
+	resp := &pb.ListConversationsResponse{Conversations: make([]*pb.Conversation, 0, len(page)), NextCursor: next}
+	for _, conv := range page {
+		hasUnread := userID != "" && !includeAll && calculateHasUnread(conv, readStates, userID)
+		protoConv := toProtoConversation(&conv, hasUnread)
+		if hasUnread {
+			protoConv.UnreadCount, err = countUnread(ctx, s.Store, conv, readStates, userID)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "count unread messages: %v", err)
+			}
+		}
+		resp.Conversations = append(resp.Conversations, protoConv)
+	}
+	return resp, nil
+}
 
-	resp := &pb.ListConversationsResponse{Conversations: make([]*pb.Conversation, 0, limit)}
+// pageMessages trims messages, fetched with one row past limit, to the page
+// and returns the cursor of the next page, empty when no message is left.
+func pageMessages(messages []scylla.Message, limit int) ([]scylla.Message, string) {
+	if len(messages) <= limit {
+		return messages, ""
+	}
+	messages = messages[:limit]
+	return messages, messages[len(messages)-1].ID.String()
+}
+
+// pageConversations returns the conversations after cursor, newest activity
+// first as the store lists them, and the cursor of the next page. The cursor
+// is only set when another conversation matches, so a page that ends exactly
+// at the last conversation carries none.
+func pageConversations(conversations []scylla.Conversation, cursor string, limit int) ([]scylla.Conversation, string) {
+	cursorTime, cursorID, _ := parseCursor(cursor)
+	page := make([]scylla.Conversation, 0, limit)
 	for _, conv := range conversations {
 		if cursorID != "" {
 			activity := lastActivity(conv)
@@ -171,17 +210,12 @@ func (s *Server) ListConversations(ctx context.Context, req *pb.ListConversation
 				continue
 			}
 		}
-		hasUnread := userID != "" && !includeAll && calculateHasUnread(conv, readStates, userID)
-		resp.Conversations = append(resp.Conversations, toProtoConversation(&conv, hasUnread))
-		if len(resp.Conversations) == limit {
-			break
+		if len(page) == limit {
+			return page, conversationCursor(page[len(page)-1])
 		}
+		page = append(page, conv)
 	}
-	if len(resp.Conversations) == limit {
-		lastConv := resp.Conversations[len(resp.Conversations)-1]
-		resp.NextCursor = buildCursor(lastConv)
-	}
-	return resp, nil
+	return page, ""
 }
 
 // MarkConversationRead updates last read pointer for a user inside a conversation.
@@ -246,11 +280,12 @@ func toProtoMessage(msg *scylla.Message, conv *scylla.Conversation) *pb.Message
 		return nil
 	}
 	return &pb.Message{
-		Id:             msg.ID.String(),
-		ConversationId: msg.ConversationID.String(),
-		SenderId:       msg.SenderID,
-		Text:           msg.Text,
-		CreatedAt:      tsOrNil(msg.CreatedAt),
+		Id:              msg.ID.String(),
+		ConversationId:  msg.ConversationID.String(),
+		SenderId:        msg.SenderID,
+		Text:            msg.Text,
+		CreatedAt:       tsOrNil(msg.CreatedAt),
+		ClientMessageId: msg.ClientMessageID,
 	}
 }
 
@@ -268,6 +303,25 @@ func calculateHasUnread(conv scylla.Conversation, reads map[gocql.UUID]scylla.Co
 	return conv.LastMessageSenderID != userID
 }
 
+// unreadCounter is the part of the store unread counters are read from.
+type unreadCounter interface {
+	CountMessagesAfter(ctx context.Context, conversationID gocql.UUID, after gocql.UUID, excludeSender string, max int) (int, error)
+}
+
+// countUnread counts the messages after the user's read marker, up to
+// maxUnreadCount; without a marker every message from the others is unread.
+func countUnread(ctx context.Context, store unreadCounter, conv scylla.Conversation, reads map[gocql.UUID]scylla.ConversationRead, userID string) (int32, error) {
+	var after gocql.UUID
+	if read, ok := reads[conv.ID]; ok {
+		after = read.LastReadMessageID
+	}
+	count, err := store.CountMessagesAfter(ctx, conv.ID, after, userID, maxUnreadCount)
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
 func tsOrNil(t time.Time) *timestamppb.Timestamp {
 	if t.IsZero() {
 		return nil
@@ -303,15 +357,8 @@ func parseCursor(raw string) (time.Time, string, error) {
 	return cursorTime, parts[1], nil
 }
 
-func buildCursor(conv *pb.Conversation) string {
-	if conv == nil || conv.CreatedAt == nil {
-		return ""
-	}
-	activity := conv.CreatedAt.AsTime()
-	if conv.LastMessageAt != nil {
-		activity = conv.LastMessageAt.AsTime()
-	}
-	return fmt.Sprintf("%d|%s", activity.UTC().UnixNano(), conv.GetId())
+func conversationCursor(conv scylla.Conversation) string {
+	return fmt.Sprintf("%d|%s", lastActivity(conv).UTC().UnixNano(), conv.ID.String())
 }
 
 func lastActivity(conv scylla.Conversation) time.Time {
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"messaging-service/internal/storage/scylla"
+)
+
+func TestHasUnreadWithoutReadMarker(t *testing.T) {
+	conv := scylla.Conversation{ID: gocql.TimeUUID(), LastMessageID: gocql.TimeUUID(), LastMessageSenderID: "guest"}
+	reads := map[gocql.UUID]scylla.ConversationRead{}
+
+	if !calculateHasUnread(conv, reads, "host") {
+		t.Fatal("conversation without a read marker is read")
+	}
+	if calculateHasUnread(conv, reads, "guest") {
+		t.Fatal("own last message counts as unread")
+	}
+}
+
+func TestHasUnreadWhenFullyRead(t *testing.T) {
+	last := gocql.UUIDFromTime(time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC))
+	conv := scylla.Conversation{ID: gocql.TimeUUID(), LastMessageID: last, LastMessageSenderID: "guest"}
+	reads := map[gocql.UUID]scylla.ConversationRead{conv.ID: {ConversationID: conv.ID, UserID: "host", LastReadMessageID: last}}
+
+	if calculateHasUnread(conv, reads, "host") {
+		t.Fatal("fully read conversation has unread messages")
+	}
+}
+
+func TestHasUnreadAfterOlderMarker(t *testing.T) {
+	read := gocql.UUIDFromTime(time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC))
+	last := gocql.UUIDFromTime(time.Date(2026, 4, 1, 12, 5, 0, 0, time.UTC))
+	conv := scylla.Conversation{ID: gocql.TimeUUID(), LastMessageID: last, LastMessageSenderID: "guest"}
+	reads := map[gocql.UUID]scylla.ConversationRead{conv.ID: {ConversationID: conv.ID, UserID: "host", LastReadMessageID: read}}
+
+	if !calculateHasUnread(conv, reads, "host") {
+		t.Fatal("message after the read marker is not unread")
+	}
+}
+
+func TestHasUnreadEmptyConversation(t *testing.T) {
+	if calculateHasUnread(scylla.Conversation{ID: gocql.TimeUUID()}, nil, "host") {
+		t.Fatal("empty conversation has unread messages")
+	}
+}
+
+// fakeCounter counts its messages the way the store does: newer than the
+// marker, not sent by excludeSender, at most max.
+type fakeCounter struct {
+	messages []scylla.Message
+	err      error
+}
+
+func (f fakeCounter) CountMessagesAfter(ctx context.Context, conversationID gocql.UUID, after gocql.UUID, excludeSender string, max int) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	count := 0
+	for _, msg := range f.messages {
+		if count == max {
+			break
+		}
+		if msg.SenderID != excludeSender && (after == (gocql.UUID{}) || msg.ID.Time().After(after.Time())) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// guestMessages returns n messages from the guest, one a minute, oldest first.
+func guestMessages(n int) []scylla.Message {
+	base := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	out := make([]scylla.Message, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, scylla.Message{ID: gocql.UUIDFromTime(base.Add(time.Duration(i) * time.Minute)), SenderID: "guest"})
+	}
+	return out
+}
+
+func TestCountUnreadWithoutReadMarkerIsCapped(t *testing.T) {
+	store := fakeCounter{messages: guestMessages(maxUnreadCount + 20)}
+	conv := scylla.Conversation{ID: gocql.TimeUUID()}
+
+	got, err := countUnread(context.Background(), store, conv, map[gocql.UUID]scylla.ConversationRead{}, "host")
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if got != maxUnreadCount {
+		t.Fatalf("unread = %d, want %d", got, maxUnreadCount)
+	}
+}
+
+func TestCountUnreadWhenFullyRead(t *testing.T) {
+	messages := guestMessages(5)
+	store := fakeCounter{messages: messages}
+	conv := scylla.Conversation{ID: gocql.TimeUUID()}
+	reads := map[gocql.UUID]scylla.ConversationRead{conv.ID: {ConversationID: conv.ID, UserID: "host", LastReadMessageID: messages[len(messages)-1].ID}}
+
+	got, err := countUnread(context.Background(), store, conv, reads, "host")
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if got != 0 {
+		t.Fatalf("unread = %d, want 0", got)
+	}
+}
+
+func TestCountUnreadReturnsStoreErrors(t *testing.T) {
+	boom := errors.New("timeout")
+	got, err := countUnread(context.Background(), fakeCounter{err: boom}, scylla.Conversation{ID: gocql.TimeUUID()}, nil, "host")
+	if !errors.Is(err, boom) || got != 0 {
+		t.Fatalf("count = %d, err = %v, want 0 and the store error", got, err)
+	}
+}
@@ -88,6 +88,7 @@ CREATE TABLE IF NOT EXISTS %s.messages (
 	message_id timeuuid,
 	sender_id text,
 	text text,
+	client_message_id text,
 	created_at timestamp,
 	PRIMARY KEY (conversation_id, message_id)
 ) WITH CLUSTERING ORDER BY (message_id DESC);`, cfg.ScyllaKeyspace)
@@ -95,6 +96,17 @@ CREATE TABLE IF NOT EXISTS %s.messages (
 		return fmt.Errorf("create messages table: %w", err)
 	}
 
+	clientIDs := fmt.Sprintf(`
+CREATE TABLE IF NOT EXISTS %s.message_client_ids (
+	conversation_id uuid,
+	client_message_id text,
+	message_id timeuuid,
+	PRIMARY KEY (conversation_id, client_message_id)
+);`, cfg.ScyllaKeyspace)
+	if err := session.Query(clientIDs).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("create message_client_ids table: %w", err)
+	}
+
 	reads := fmt.Sprintf(`
 CREATE TABLE IF NOT EXISTS %s.conversation_reads (
 	user_id text,
@@ -110,6 +122,8 @@ CREATE TABLE IF NOT EXISTS %s.conversation_reads (
 	// Make sure new nullable columns exist for rolling upgrades.
 	alterConversation := fmt.Sprintf(`ALTER TABLE %s.conversations ADD IF NOT EXISTS last_message_text text;`, cfg.ScyllaKeyspace)
 	_ = session.Query(alterConversation).WithContext(ctx).Exec()
+	alterMessages := fmt.Sprintf(`ALTER TABLE %s.messages ADD IF NOT EXISTS client_message_id text;`, cfg.ScyllaKeyspace)
+	_ = session.Query(alterMessages).WithContext(ctx).Exec()
 	return nil
 }
 
@@ -140,11 +154,12 @@ type Conversation struct {
 
 // Message represents a chat message persisted in Scylla.
 type Message struct {
-	ID             gocql.UUID
-	ConversationID gocql.UUID
-	SenderID       string
-	Text           string
-	CreatedAt      time.Time
+	ID              gocql.UUID
+	ConversationID  gocql.UUID
+	SenderID        string
+	Text            string
+	ClientMessageID string
+	CreatedAt       time.Time
 }
 
 // ConversationRead stores last read position per user.
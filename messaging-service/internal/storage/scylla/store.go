@@ -166,7 +166,9 @@ func (s *Store) ListConversations(ctx context.Context, userID string, includeAll
 }
 
 // AddMessage appends a message and updates conversation activity timestamp.
-func (s *Store) AddMessage(ctx context.Context, conversationID gocql.UUID, senderID, text string, at time.Time) (*Message, error) {
+// When clientMessageID is set, a retry with the same id returns the message
+// stored by the first attempt instead of creating a duplicate.
+func (s *Store) AddMessage(ctx context.Context, conversationID gocql.UUID, senderID, text, clientMessageID string, at time.Time) (*Message, error) {
 	if s.session == nil {
 		return nil, errors.New("scylla session not initialized")
 	}
@@ -176,9 +178,26 @@ func (s *Store) AddMessage(ctx context.Context, conversationID gocql.UUID, sende
 	}
 	at = at.UTC()
 	messageID := gocql.TimeUUID()
+	if clientMessageID != "" {
+		claimedID, claimed, err := s.claimClientMessageID(ctx, conversationID, clientMessageID, messageID)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			existing, err := s.getMessage(ctx, conversationID, claimedID)
+			if err == nil {
+				return existing, nil
+			}
+			if !errors.Is(err, gocql.ErrNotFound) {
+				return nil, err
+			}
+			// The first attempt claimed the id but never stored the message; finish it.
+			messageID = claimedID
+		}
+	}
 	if err := s.session.
-		Query(`INSERT INTO messages (conversation_id, message_id, sender_id, text, created_at) VALUES (?, ?, ?, ?, ?)`,
-			conversationID, messageID, senderID, text, at).
+		Query(`INSERT INTO messages (conversation_id, message_id, sender_id, text, client_message_id, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+			conversationID, messageID, senderID, text, clientMessageID, at).
 		WithContext(ctx).
 		Consistency(gocql.Quorum).
 		Exec(); err != nil {
@@ -194,14 +213,51 @@ func (s *Store) AddMessage(ctx context.Context, conversationID gocql.UUID, sende
 		s.logger.Warn("failed to update last message meta", "error", err, "conversation_id", conversationID)
 	}
 	return &Message{
-		ID:             messageID,
-		ConversationID: conversationID,
-		SenderID:       senderID,
-		Text:           text,
-		CreatedAt:      at,
+		ID:              messageID,
+		ConversationID:  conversationID,
+		SenderID:        senderID,
+		Text:            text,
+		ClientMessageID: clientMessageID,
+		CreatedAt:       at,
 	}, nil
 }
 
+// claimClientMessageID reserves the client id for messageID. When the id was
+// already claimed it returns the message id recorded by the earlier attempt.
+func (s *Store) claimClientMessageID(ctx context.Context, conversationID gocql.UUID, clientMessageID string, messageID gocql.UUID) (gocql.UUID, bool, error) {
+	var (
+		existingConversation gocql.UUID
+		existingClientID     string
+		existingMessageID    gocql.UUID
+	)
+	applied, err := s.session.
+		Query(`INSERT INTO message_client_ids (conversation_id, client_message_id, message_id) VALUES (?, ?, ?) IF NOT EXISTS`,
+			conversationID, clientMessageID, messageID).
+		WithContext(ctx).
+		SerialConsistency(gocql.LocalSerial).
+		ScanCAS(&existingConversation, &existingClientID, &existingMessageID)
+	if err != nil {
+		return gocql.UUID{}, false, err
+	}
+	if applied {
+		return messageID, true, nil
+	}
+	return existingMessageID, false, nil
+}
+
+func (s *Store) getMessage(ctx context.Context, conversationID, messageID gocql.UUID) (*Message, error) {
+	var msg Message
+	if err := s.session.
+		Query(`SELECT conversation_id, message_id, sender_id, text, client_message_id, created_at FROM messages WHERE conversation_id = ? AND message_id = ? LIMIT 1`,
+			conversationID, messageID).
+		WithContext(ctx).
+		Consistency(gocql.Quorum).
+		Scan(&msg.ConversationID, &msg.ID, &msg.SenderID, &msg.Text, &msg.ClientMessageID, &msg.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
 func trimSnippet(text string, max int) string {
 	if max <= 0 {
 		return ""
@@ -260,26 +316,88 @@ func (s *Store) ListConversationReads(ctx context.Context, userID string) (map[g
 	return result, nil
 }
 
+// countScanFactor bounds the rows CountMessagesAfter reads to this many
+// times max, so a long run of the user's own messages, or a conversation
+// without a read marker, is never scanned in full.
+const countScanFactor = 3
+
+// CountMessagesAfter counts messages newer than the after marker that were not
+// sent by excludeSender. A zero marker counts from the newest message. Counting
+// stops once max is reached or countScanFactor*max rows were read, so long
+// threads stay cheap; the count is then a lower bound.
+func (s *Store) CountMessagesAfter(ctx context.Context, conversationID gocql.UUID, after gocql.UUID, excludeSender string, max int) (int, error) {
+	if s.session == nil {
+		return 0, errors.New("scylla session not initialized")
+	}
+	if max <= 0 {
+		return 0, nil
+	}
+	maxRows := countScanFactor * max
+	var query *gocql.Query
+	if after == (gocql.UUID{}) {
+		query = s.session.
+			Query(`SELECT sender_id FROM messages WHERE conversation_id = ? ORDER BY message_id DESC LIMIT ?`, conversationID, maxRows)
+	} else {
+		query = s.session.
+			Query(`SELECT sender_id FROM messages WHERE conversation_id = ? AND message_id > ? ORDER BY message_id DESC LIMIT ?`, conversationID, after, maxRows)
+	}
+	iter := query.
+		WithContext(ctx).
+		Consistency(gocql.One).
+		PageSize(maxRows).
+		Iter()
+
+	count := countOthers(func(sender *string) bool { return iter.Scan(sender) }, excludeSender, max, maxRows)
+	if err := iter.Close(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// countOthers counts senders other than excludeSender read from next, up to
+// max, reading at most maxRows.
+func countOthers(next func(sender *string) bool, excludeSender string, max, maxRows int) int {
+	count := 0
+	var sender string
+	for rows := 0; count < max && rows < maxRows && next(&sender); rows++ {
+		if sender != excludeSender {
+			count++
+		}
+	}
+	return count
+}
+
+// maxMessagesPage is the largest page of messages the service hands out.
+const maxMessagesPage = 200
+
+// messagesQueryLimit bounds the rows ListMessages reads. Callers ask for one
+// row past their page size to detect a next page, so a full page of
+// maxMessagesPage still gets its extra row.
+func messagesQueryLimit(limit int) int {
+	if limit <= 0 || limit > maxMessagesPage+1 {
+		return 50
+	}
+	return limit
+}
+
 // ListMessages returns messages ordered from newest to oldest with optional cursor.
 func (s *Store) ListMessages(ctx context.Context, conversationID gocql.UUID, limit int, before *gocql.UUID) ([]Message, error) {
 	if s.session == nil {
 		return nil, errors.New("scylla session not initialized")
 	}
-	if limit <= 0 || limit > 200 {
-		limit = 50
-	}
+	limit = messagesQueryLimit(limit)
 
 	var iter *gocql.Iter
 	if before != nil {
 		iter = s.session.
-			Query(`SELECT conversation_id, message_id, sender_id, text, created_at FROM messages WHERE conversation_id = ? AND message_id < ? ORDER BY message_id DESC LIMIT ?`,
+			Query(`SELECT conversation_id, message_id, sender_id, text, client_message_id, created_at FROM messages WHERE conversation_id = ? AND message_id < ? ORDER BY message_id DESC LIMIT ?`,
 				conversationID, *before, limit).
 			WithContext(ctx).
 			Consistency(gocql.One).
 			Iter()
 	} else {
 		iter = s.session.
-			Query(`SELECT conversation_id, message_id, sender_id, text, created_at FROM messages WHERE conversation_id = ? ORDER BY message_id DESC LIMIT ?`,
+			Query(`SELECT conversation_id, message_id, sender_id, text, client_message_id, created_at FROM messages WHERE conversation_id = ? ORDER BY message_id DESC LIMIT ?`,
 				conversationID, limit).
 			WithContext(ctx).
 			Consistency(gocql.One).
@@ -292,15 +410,17 @@ func (s *Store) ListMessages(ctx context.Context, conversationID gocql.UUID, lim
 		messageID gocql.UUID
 		sender    string
 		text      string
+		clientID  string
 		createdAt time.Time
 	)
-	for iter.Scan(&cID, &messageID, &sender, &text, &createdAt) {
+	for iter.Scan(&cID, &messageID, &sender, &text, &clientID, &createdAt) {
 		messages = append(messages, Message{
-			ID:             messageID,
-			ConversationID: cID,
-			SenderID:       sender,
-			Text:           text,
-			CreatedAt:      createdAt,
+			ID:              messageID,
+			ConversationID:  cID,
+			SenderID:        sender,
+			Text:            text,
+			ClientMessageID: clientID,
+			CreatedAt:       createdAt,
 		})
 	}
 	if err := iter.Close(); err != nil {
@@ -0,0 +1,61 @@
+package scylla
+
+import "testing"
+
+func senders(list ...string) func(*string) bool {
+	return func(sender *string) bool {
+		if len(list) == 0 {
+			return false
+		}
+		*sender, list = list[0], list[1:]
+		return true
+	}
+}
+
+func TestCountOthersSkipsOwnMessages(t *testing.T) {
+	got := countOthers(senders("guest", "host", "guest", "host"), "host", 99, 300)
+	if got != 2 {
+		t.Fatalf("count = %d, want 2", got)
+	}
+}
+
+func TestCountOthersStopsAtMax(t *testing.T) {
+	rows := make([]string, 150)
+	for i := range rows {
+		rows[i] = "guest"
+	}
+	if got := countOthers(senders(rows...), "host", 99, 300); got != 99 {
+		t.Fatalf("count = %d, want 99", got)
+	}
+}
+
+func TestCountOthersBoundsRowsRead(t *testing.T) {
+	read := 0
+	next := func(sender *string) bool {
+		read++
+		*sender = "host"
+		return true
+	}
+	if got := countOthers(next, "host", 99, 297); got != 0 {
+		t.Fatalf("count = %d, want 0", got)
+	}
+	if read != 297 {
+		t.Fatalf("rows read = %d, want 297", read)
+	}
+}
+
+func TestMessagesQueryLimitKeepsTheExtraRow(t *testing.T) {
+	cases := map[int]int{
+		1:                   1,
+		maxMessagesPage:     maxMessagesPage,
+		maxMessagesPage + 1: maxMessagesPage + 1,
+		maxMessagesPage + 2: 50,
+		0:                   50,
+		-1:                  50,
+	}
+	for limit, want := range cases {
+		if got := messagesQueryLimit(limit); got != want {
+			t.Errorf("messagesQueryLimit(%d) = %d, want %d", limit, got, want)
+		}
+	}
+}